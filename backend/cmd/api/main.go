@@ -23,21 +23,44 @@ import (
 	"github.com/devdavidalonso/cecor/backend/internal/auth"
 	"github.com/devdavidalonso/cecor/backend/internal/config"
 	"github.com/devdavidalonso/cecor/backend/internal/database"
+	"github.com/devdavidalonso/cecor/backend/internal/infrastructure/cep"
 	"github.com/devdavidalonso/cecor/backend/internal/infrastructure/googleapis"
+	"github.com/devdavidalonso/cecor/backend/internal/infrastructure/storage"
+	"github.com/devdavidalonso/cecor/backend/internal/migrations"
 	"github.com/devdavidalonso/cecor/backend/internal/models"
 	"github.com/devdavidalonso/cecor/backend/internal/repository/mongodb"
 	"github.com/devdavidalonso/cecor/backend/internal/repository/postgres"
+	"github.com/devdavidalonso/cecor/backend/internal/service/absences"
+	"github.com/devdavidalonso/cecor/backend/internal/service/addresses"
+	"github.com/devdavidalonso/cecor/backend/internal/service/assignments"
 	"github.com/devdavidalonso/cecor/backend/internal/service/attendance" // Adicionar importação de attendance
+	"github.com/devdavidalonso/cecor/backend/internal/service/availability"
+	"github.com/devdavidalonso/cecor/backend/internal/service/calendar"
+	"github.com/devdavidalonso/cecor/backend/internal/service/catalog"
 	"github.com/devdavidalonso/cecor/backend/internal/service/courses"    // Adicionar importação de courses
+	"github.com/devdavidalonso/cecor/backend/internal/service/diary"
+	"github.com/devdavidalonso/cecor/backend/internal/service/documents"
+	"github.com/devdavidalonso/cecor/backend/internal/service/duplicates"
 	"github.com/devdavidalonso/cecor/backend/internal/service/email"
 	"github.com/devdavidalonso/cecor/backend/internal/service/enrollments" // Adicionar importação de enrollments
+	"github.com/devdavidalonso/cecor/backend/internal/service/gradebook"
+	"github.com/devdavidalonso/cecor/backend/internal/service/guardianportal"
 	"github.com/devdavidalonso/cecor/backend/internal/service/interviews"  // Import interviews service
+	"github.com/devdavidalonso/cecor/backend/internal/service/invitations"
 	"github.com/devdavidalonso/cecor/backend/internal/service/keycloak"
+	"github.com/devdavidalonso/cecor/backend/internal/service/notifications"
+	"github.com/devdavidalonso/cecor/backend/internal/service/pickups"
 	"github.com/devdavidalonso/cecor/backend/internal/service/reports"  // Adicionar importação de reports
 	"github.com/devdavidalonso/cecor/backend/internal/service/students" // Adicionar importação de students
+	"github.com/devdavidalonso/cecor/backend/internal/service/studentimport"
+	"github.com/devdavidalonso/cecor/backend/internal/service/studentstatus"
+	"github.com/devdavidalonso/cecor/backend/internal/service/substitutes"
+	"github.com/devdavidalonso/cecor/backend/internal/service/substitutions"
 	"github.com/devdavidalonso/cecor/backend/internal/service/teacherportal"
+	"github.com/devdavidalonso/cecor/backend/internal/service/volunteerhours"
 	"github.com/devdavidalonso/cecor/backend/internal/service/incidents"
 	"github.com/devdavidalonso/cecor/backend/internal/service/teachers" // Adicionar importação de professors
+	"github.com/devdavidalonso/cecor/backend/internal/service/timeline"
 	"github.com/devdavidalonso/cecor/backend/internal/service/users"    // Adicionar esta importação
 	"github.com/devdavidalonso/cecor/backend/pkg/logger"
 )
@@ -92,12 +115,18 @@ func main() {
 		appLogger.Fatal("Failed to connect to database", "error", err)
 	}
 
-	// Perform database migrations
-	err = postgres.MigrateDB(db)
+	// Apply pending versioned migrations (see cmd/migrate for manual runs and rollbacks)
+	migrator, err := migrations.New(db)
 	if err != nil {
-		appLogger.Fatal("Failed to migrate database", "error", err)
+		appLogger.Fatal("Failed to load migrations", "error", err)
+	}
+	if cfg.Database.AutoMigrate {
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			appLogger.Fatal("Failed to migrate database", "error", err)
+		}
+		appLogger.Info("Database migration completed successfully", "applied", len(applied))
 	}
-	appLogger.Info("Database migration completed successfully")
 
 	// Atualizar senha do usuário de teste
 	updateUserPassword(db, "maria.silva@cecor.org", "cecor2024!")
@@ -122,6 +151,7 @@ func main() {
 	enrollmentRepo := postgres.NewEnrollmentRepository(db) // Adicionar repositório de matrículas
 	attendanceRepo := postgres.NewAttendanceRepository(db) // Adicionar repositório de presenças
 	reportRepo := postgres.NewReportRepository(db)         // Adicionar repositório de relatórios
+	teacherRepo := postgres.NewTeacherRepository(db)
 
 	// Initialize Google Classroom Client
 	classroomClient, err := googleapis.NewGoogleClassroomClient("credentials.json")
@@ -130,12 +160,26 @@ func main() {
 		classroomClient = nil // explicitly nil if failed
 	}
 
+	// Initialize CEP lookup (address autofill and validation)
+	cepProvider, err := cep.New(cep.Config{
+		Provider: cfg.CEP.Provider,
+		URL:      cfg.CEP.URL,
+		FilePath: cfg.CEP.FilePath,
+		Timeout:  cfg.CEP.Timeout,
+	})
+	if err != nil {
+		appLogger.Fatal("Failed to initialize CEP provider", "error", err)
+	}
+	addressService := addresses.NewService(db, cepProvider, cfg.CEP.CacheTTL)
+	addressHandler := handlers.NewAddressHandler(addressService)
+
 	// Initialize services
 	keycloakService := keycloak.NewKeycloakService()                                                      // Inicializar keycloak service
 	emailService := email.NewEmailService()                                                               // Inicializar email service
-	studentService := students.NewStudentService(studentRepo, keycloakService, emailService)              // Inicializar student service com Keycloak e Email
+	studentService := students.NewStudentService(studentRepo, keycloakService, emailService, addressService) // Inicializar student service com Keycloak e Email
 	userService := users.NewUserService(userRepo)                                                         // Adicionar o serviço de usuários
-	teacherService := teachers.NewService(userRepo, keycloakService, emailService)                        // Adicionar serviço de teachers/
+	teacherService := teachers.NewService(userRepo, teacherRepo, keycloakService)                         // Adicionar serviço de teachers/
+	invitationService := invitations.NewService(db, teacherService, emailService, cfg.Server.FrontendURL, cfg.Volunteer.InvitationTTL) // Convites de professores (única forma de cadastrar um professor)
 	courseService := courses.NewService(courseRepo, classroomClient)                                      // Adicionar serviço de cursos
 	enrollmentService := enrollments.NewService(enrollmentRepo, studentRepo, courseRepo, classroomClient) // Updated with dependencies
 	attendanceService := attendance.NewService(attendanceRepo)                                            // Adicionar serviço de presenças
@@ -152,7 +196,7 @@ func main() {
 	// Initialize handlers
 	studentHandler := handlers.NewStudentHandler(studentService)
 	authHandler := handlers.NewAuthHandler(userService, cfg, ssoConfig)        // Adicionar o handler de autenticação
-	teacherHandler := handlers.NewTeacherHandler(teacherService)               // Adicionar handler de professores
+	teacherHandler := handlers.NewTeacherHandler(teacherService, invitationService) // Adicionar handler de professores
 	courseHandler := handlers.NewCourseHandler(courseService, keycloakService) // Adicionar handler de cursos
 	enrollmentHandler := handlers.NewEnrollmentHandler(enrollmentService, interviewService) // Adicionar handler de matrículas com entrevista
 	attendanceHandler := handlers.NewAttendanceHandler(attendanceService)      // Adicionar handler de presenças
@@ -168,6 +212,90 @@ func main() {
 	incidentService := incidents.NewService(db)
 	incidentHandler := handlers.NewIncidentHandler(incidentService)
 
+	// Initialize calendar feed service and handler
+	calendarService := calendar.NewService(db)
+	calendarFeedHandler := handlers.NewCalendarFeedHandler(calendarService, cfg.Server.PublicURL)
+
+	// Initialize public course catalog service and handler
+	catalogService := catalog.NewService(db)
+	catalogHandler := handlers.NewCatalogHandler(catalogService)
+
+	// Initialize substitute teacher matching, the substitution workflow, teacher absences and availability
+	substituteService := substitutes.NewService(db)
+	notificationService := notifications.NewService(db, cfg.Telegram.BotToken)
+	notificationTriggers := notifications.NewTriggers(db, notificationService)
+	substitutionService := substitutions.NewService(db, substituteService, notificationTriggers)
+	absenceService := absences.NewService(db, substitutionService, notificationTriggers)
+	absenceHandler := handlers.NewAbsenceHandler(absenceService)
+	availabilityService := availability.NewService(db)
+	availabilityHandler := handlers.NewAvailabilityHandler(availabilityService)
+
+	// Initialize teacher assignments to course classes
+	assignmentService := assignments.NewService(db)
+	assignmentHandler := handlers.NewAssignmentHandler(assignmentService)
+
+	// Initialize lesson diary and class register
+	diaryService := diary.NewService(db)
+	diaryHandler := handlers.NewDiaryHandler(diaryService)
+
+	// Initialize assessments, scores and final results
+	gradebookService := gradebook.NewService(db, gradebook.Rule{
+		PassingGrade:      cfg.Grading.PassingGrade,
+		MinAttendanceRate: cfg.Grading.MinAttendanceRate,
+	})
+	gradebookHandler := handlers.NewGradebookHandler(gradebookService)
+
+	// Initialize volunteer hours ledger and declarations
+	volunteerHoursService := volunteerhours.NewService(db, cfg.Volunteer.OrganizationName, cfg.Volunteer.DeclarationKey, cfg.Server.PublicURL)
+	volunteerHoursHandler := handlers.NewVolunteerHoursHandler(volunteerHoursService)
+
+	// Initialize teacher onboarding invitations
+	invitationHandler := handlers.NewTeacherInvitationHandler(invitationService)
+
+	// Initialize student document files (local filesystem or S3-compatible storage)
+	documentStorage, err := storage.New(storage.Config{
+		Driver:    cfg.Storage.Driver,
+		LocalPath: cfg.Storage.LocalPath,
+		S3: storage.S3Config{
+			Endpoint:  cfg.Storage.S3Endpoint,
+			Region:    cfg.Storage.S3Region,
+			Bucket:    cfg.Storage.S3Bucket,
+			AccessKey: cfg.Storage.S3AccessKey,
+			SecretKey: cfg.Storage.S3SecretKey,
+			PathStyle: cfg.Storage.S3PathStyle,
+		},
+	})
+	if err != nil {
+		appLogger.Fatal("Failed to initialize document storage", "error", err)
+	}
+	documentService := documents.NewService(db, documentStorage, cfg.Storage.MaxUploadBytes, cfg.Storage.LinkKey, cfg.Storage.LinkTTL, cfg.Server.PublicURL)
+	documentHandler := handlers.NewDocumentHandler(documentService)
+	substitutionHandler := handlers.NewSubstitutionHandler(substitutionService, notifications.NewTelegramService(cfg.Telegram.BotToken), cfg.Telegram.WebhookSecret)
+
+	// Initialize duplicate student detection and merge
+	duplicateService := duplicates.NewService(db, formRepo)
+	duplicateHandler := handlers.NewStudentDuplicateHandler(duplicateService)
+
+	// Initialize student timeline
+	timelineService := timeline.NewService(db, formRepo)
+	timelineHandler := handlers.NewStudentTimelineHandler(timelineService)
+
+	// Initialize guardian portal
+	guardianPortalService := guardianportal.NewService(db)
+	guardianPortalHandler := handlers.NewGuardianPortalHandler(guardianPortalService)
+
+	// Initialize supervised pickup check-out
+	pickupService := pickups.NewService(db, notificationTriggers, emailService)
+	pickupHandler := handlers.NewPickupHandler(pickupService)
+
+	// Initialize student status lifecycle
+	studentStatusService := studentstatus.NewService(db, notificationTriggers, cfg.Students.InactiveAfter)
+	studentStatusHandler := handlers.NewStudentStatusHandler(studentStatusService)
+
+	// Initialize bulk student import (accounts are created by the background job)
+	studentImportService := studentimport.NewService(db, addressService, keycloakService, emailService)
+	studentImportHandler := handlers.NewStudentImportHandler(studentImportService)
+
 	// Create router
 	r := chi.NewRouter()
 
@@ -177,7 +305,7 @@ func main() {
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Timeout(60 * time.Second))
-	r.Use(middleware.AllowContentType("application/json"))
+	r.Use(middleware.AllowContentType("application/json", "multipart/form-data"))
 	r.Use(middleware.SetHeader("Content-Type", "application/json"))
 
 	// CORS middleware
@@ -198,9 +326,9 @@ func main() {
 
 	// Initialize new handlers for Phase 2
 	studentPortalHandler := handlers.NewStudentPortalHandler(db)
-	courseClassHandler := handlers.NewCourseClassHandler(db)
-	skillHandler := handlers.NewSkillHandler(db)
-	migrationHandler := handlers.NewMigrationHandler(db)
+	courseClassHandler := handlers.NewCourseClassHandler(db, assignmentService)
+	skillHandler := handlers.NewSkillHandler(db, substituteService)
+	migrationHandler := handlers.NewMigrationHandler(db, migrator)
 
 	// Registrar todas as rotas v1 sob um único prefixo /api/v1
 	appLogger.Info("Registering v1 routes...")
@@ -209,6 +337,24 @@ func main() {
 			// Rotas base (públicas + protegidas)
 			routes.Register(r, cfg, authHandler, courseHandler, enrollmentHandler, attendanceHandler, reportHandler, teacherHandler)
 
+			// Feeds iCalendar públicos (o token na URL é a credencial)
+			calendarFeedHandler.RegisterPublicRoutes(r)
+
+			// Catálogo público de cursos
+			catalogHandler.RegisterPublicRoutes(r)
+
+			// Webhook do Telegram (respostas às substituições)
+			substitutionHandler.RegisterPublicRoutes(r)
+
+			// Verificação pública das declarações de voluntariado
+			volunteerHoursHandler.RegisterPublicRoutes(r)
+
+			// Convites de cadastro de professores (o token na URL é a credencial)
+			invitationHandler.RegisterPublicRoutes(r)
+
+			// Download de documentos por links assinados com validade curta
+			documentHandler.RegisterPublicRoutes(r)
+
 			// Módulos adicionais protegidos
 			r.Group(func(r chi.Router) {
 				r.Use(apiMiddleware.Authenticate(cfg))
@@ -216,14 +362,26 @@ func main() {
 				// Interview endpoints
 				interviewHandler.RegisterRoutes(r)
 
-				// Teacher portal / incidents / student portal
+				// Teacher portal / incidents / student and guardian portals
 				teacherPortalHandler.RegisterRoutes(r)
 				incidentHandler.RegisterRoutes(r)
 				studentPortalHandler.RegisterRoutes(r)
+				guardianPortalHandler.RegisterRoutes(r)
+				calendarFeedHandler.RegisterRoutes(r)
 
 				// Course class / skills
 				courseClassHandler.RegisterRoutes(r)
 				skillHandler.RegisterRoutes(r)
+				substitutionHandler.RegisterRoutes(r)
+				absenceHandler.RegisterRoutes(r)
+				availabilityHandler.RegisterRoutes(r)
+				assignmentHandler.RegisterRoutes(r)
+				diaryHandler.RegisterRoutes(r)
+				gradebookHandler.RegisterRoutes(r)
+				volunteerHoursHandler.RegisterRoutes(r)
+				documentHandler.RegisterRoutes(r)
+				addressHandler.RegisterRoutes(r)
+				pickupHandler.RegisterRoutes(r)
 
 				// Students CRUD
 				r.Route("/students", func(r chi.Router) {
@@ -235,9 +393,10 @@ func main() {
 					r.Get("/{id}/guardians", studentHandler.GetGuardians)
 					r.Post("/{id}/guardians", studentHandler.AddGuardian)
 					r.Get("/{id}/documents", studentHandler.GetDocuments)
-					r.Post("/{id}/documents", studentHandler.AddDocument)
+					r.Post("/{id}/documents", documentHandler.UploadDocument)
 					r.Get("/{id}/notes", studentHandler.GetNotes)
 					r.Post("/{id}/notes", studentHandler.AddNote)
+					r.Get("/{id}/timeline", timelineHandler.GetTimeline)
 				})
 			})
 
@@ -247,10 +406,28 @@ func main() {
 				r.Use(apiMiddleware.RequireAdmin)
 
 				interviewAdminHandler.RegisterAdminRoutes(r)
+				calendarFeedHandler.RegisterAdminRoutes(r)
+				catalogHandler.RegisterAdminRoutes(r)
+				substitutionHandler.RegisterAdminRoutes(r)
+				absenceHandler.RegisterAdminRoutes(r)
+				availabilityHandler.RegisterAdminRoutes(r)
+				assignmentHandler.RegisterAdminRoutes(r)
+				diaryHandler.RegisterAdminRoutes(r)
+				gradebookHandler.RegisterAdminRoutes(r)
+				volunteerHoursHandler.RegisterAdminRoutes(r)
+				invitationHandler.RegisterAdminRoutes(r)
+				duplicateHandler.RegisterAdminRoutes(r)
+				pickupHandler.RegisterAdminRoutes(r)
+				studentStatusHandler.RegisterAdminRoutes(r)
+				studentImportHandler.RegisterAdminRoutes(r)
 				r.Post("/migrations/run", migrationHandler.RunMigrations)
 				r.Post("/migrations/data", migrationHandler.RunDataMigration)
 				r.Post("/migrations/rollback", migrationHandler.RollbackMigrations)
 				r.Get("/migrations/status", migrationHandler.GetMigrationStatus)
+				r.Get("/migrations/course-classes/check", migrationHandler.CheckCourseClassConsistency)
+				r.Get("/migrations/cpf/check", migrationHandler.CheckCPFs)
+				r.Get("/migrations/encryption/check", migrationHandler.CheckFieldEncryption)
+				r.Post("/migrations/encryption/rotate", migrationHandler.RotateFieldKeys)
 			})
 		})
 	})
@@ -283,6 +460,82 @@ func main() {
 		}
 	}()
 
+	// Escalonar periodicamente os pedidos de substituição sem resposta
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go func() {
+		ticker := time.NewTicker(15 * time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-jobsCtx.Done():
+				return
+			case <-ticker.C:
+				if escalated, err := substitutionService.EscalateExpired(jobsCtx); err != nil {
+					appLogger.Error("Failed to escalate substitutions", "error", err)
+				} else if escalated > 0 {
+					appLogger.Info("Substitution requests escalated", "count", escalated)
+				}
+			}
+		}
+	}()
+
+	// Aplicar diariamente as regras de situação dos alunos (suspensões e inatividade)
+	go func() {
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-jobsCtx.Done():
+				return
+			case <-ticker.C:
+				if result, err := studentStatusService.RunRules(jobsCtx); err != nil {
+					appLogger.Error("Failed to apply student status rules", "error", err)
+				} else {
+					appLogger.Info("Student status rules applied",
+						"inactivated", result.Inactivated, "reactivated", result.Reactivated,
+						"suspensionsStarted", result.SuspensionsStarted, "suspensionsEnded", result.SuspensionsEnded)
+				}
+			}
+		}
+	}()
+
+	// Criar as contas de acesso e enviar os emails de boas-vindas dos alunos importados
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-jobsCtx.Done():
+				return
+			case <-ticker.C:
+				if created, err := studentImportService.ProcessAccounts(jobsCtx, 50); err != nil {
+					appLogger.Error("Failed to process queued student accounts", "error", err)
+				} else if created > 0 {
+					appLogger.Info("Queued student accounts created", "count", created)
+				}
+			}
+		}
+	}()
+
+	// Concluir a transferência das respostas de entrevista (MongoDB) das fusões de alunos
+	go func() {
+		ticker := time.NewTicker(10 * time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-jobsCtx.Done():
+				return
+			case <-ticker.C:
+				if completed, err := duplicateService.RetryPendingResponses(jobsCtx); err != nil {
+					appLogger.Error("Failed to move pending interview responses of merges", "error", err)
+				} else if completed > 0 {
+					appLogger.Info("Pending interview responses of merges moved", "merges", completed)
+				}
+			}
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shut down the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -0,0 +1,151 @@
+// Command import_students importa alunos de uma planilha CSV ou XLSX.
+//
+// Por padrão apenas valida a planilha e imprime o relatório (simulação). As contas de acesso e
+// os emails de boas-vindas ficam na fila processada pela API (ou pelo subcomando accounts).
+//
+// Uso:
+//
+//	go run ./cmd/import_students check alunos.xlsx                           # valida sem gravar
+//	go run ./cmd/import_students run [-skip-invalid] [-user ID] alunos.xlsx  # importa
+//	go run ./cmd/import_students accounts [limit]                            # processa a fila de contas agora
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+
+	"github.com/devdavidalonso/cecor/backend/internal/config"
+	"github.com/devdavidalonso/cecor/backend/internal/infrastructure/cep"
+	"github.com/devdavidalonso/cecor/backend/internal/repository/postgres"
+	"github.com/devdavidalonso/cecor/backend/internal/service/addresses"
+	"github.com/devdavidalonso/cecor/backend/internal/service/email"
+	"github.com/devdavidalonso/cecor/backend/internal/service/keycloak"
+	"github.com/devdavidalonso/cecor/backend/internal/service/studentimport"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using system environment variables")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	db, err := postgres.InitDB(cfg)
+	if err != nil {
+		log.Fatalf("failed to connect db: %v", err)
+	}
+
+	cepProvider, err := cep.New(cep.Config{
+		Provider: cfg.CEP.Provider,
+		URL:      cfg.CEP.URL,
+		FilePath: cfg.CEP.FilePath,
+		Timeout:  cfg.CEP.Timeout,
+	})
+	if err != nil {
+		log.Fatalf("failed to initialize CEP provider: %v", err)
+	}
+	service := studentimport.NewService(db, addresses.NewService(db, cepProvider, cfg.CEP.CacheTTL), keycloak.NewKeycloakService(), email.NewEmailService())
+
+	ctx := context.Background()
+	switch os.Args[1] {
+	case "check", "run":
+		flags := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+		skipInvalid := flags.Bool("skip-invalid", false, "import the valid rows even if others are invalid")
+		userID := flags.Uint("user", 0, "ID of the user recorded as author of the import")
+		flags.Parse(os.Args[2:])
+		if flags.NArg() != 1 {
+			usage()
+		}
+		importFile(ctx, service, flags.Arg(0), studentimport.Options{
+			DryRun:      os.Args[1] == "check",
+			SkipInvalid: *skipInvalid,
+		}, *userID)
+
+	case "accounts":
+		limit := 100
+		if len(os.Args) > 2 {
+			limit, err = strconv.Atoi(os.Args[2])
+			if err != nil || limit < 1 {
+				log.Fatalf("invalid limit: %s", os.Args[2])
+			}
+		}
+		created, err := service.ProcessAccounts(ctx, limit)
+		if err != nil {
+			log.Fatalf("failed to process accounts: %v", err)
+		}
+		log.Printf("%d account(s) created", created)
+
+	default:
+		usage()
+	}
+}
+
+// importFile reads the spreadsheet, imports it and prints the report
+func importFile(ctx context.Context, service studentimport.Service, path string, options studentimport.Options, userID uint) {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	rows, err := studentimport.ReadSheet(path, file)
+	if err != nil {
+		log.Fatalf("failed to read %s: %v", path, err)
+	}
+
+	options.FileName = filepath.Base(path)
+	report, err := service.Import(ctx, rows, options, userID)
+	if report != nil {
+		printReport(report)
+	}
+	if err != nil {
+		if errors.Is(err, studentimport.ErrInvalidRows) {
+			log.Fatalf("nothing imported: %v (fix the rows or use -skip-invalid)", err)
+		}
+		log.Fatalf("import failed: %v", err)
+	}
+}
+
+func printReport(report *studentimport.Report) {
+	for _, row := range report.Rows {
+		state := "ok"
+		if !row.Valid {
+			state = "INVALID"
+		}
+		fmt.Printf("line %4d  %-7s %-40s %s\n", row.Line, state, row.Name, row.Email)
+		for _, message := range row.Errors {
+			fmt.Printf("           error:   %s\n", message)
+		}
+		for _, message := range row.Warnings {
+			fmt.Printf("           warning: %s\n", message)
+		}
+	}
+	if len(report.IgnoredColumns) > 0 {
+		fmt.Printf("ignored columns: %s\n", strings.Join(report.IgnoredColumns, ", "))
+	}
+	fmt.Printf("rows: %d  valid: %d  invalid: %d  created: %d\n", report.TotalRows, report.ValidRows, report.InvalidRows, report.Created)
+	if report.DryRun {
+		fmt.Println("dry run: nothing was saved")
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: import_students check <file> | run [-skip-invalid] [-user ID] <file> | accounts [limit]")
+	os.Exit(2)
+}
@@ -0,0 +1,209 @@
+// Command migrate aplica, reverte e lista as migrações versionadas do banco.
+//
+// Uso:
+//
+//	go run ./cmd/migrate up           # aplica as migrações pendentes
+//	go run ./cmd/migrate down [n]     # reverte as n últimas migrações (padrão 1)
+//	go run ./cmd/migrate status       # lista versões aplicadas e pendentes
+//	go run ./cmd/migrate course-classes check                     # inconsistências Course → CourseClass
+//	go run ./cmd/migrate course-classes fix [batchSize] [maxBatches] # corrige em lotes (retomável)
+//	go run ./cmd/migrate encryption check                          # dados sensíveis por chave
+//	go run ./cmd/migrate encryption rotate [-reindex] [batchSize]  # recifra com a chave atual (retomável)
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/config"
+	"github.com/devdavidalonso/cecor/backend/internal/migrations"
+	"github.com/devdavidalonso/cecor/backend/internal/repository/postgres"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using system environment variables")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	db, err := postgres.InitDB(cfg)
+	if err != nil {
+		log.Fatalf("failed to connect db: %v", err)
+	}
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	switch os.Args[1] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatalf("migrate up failed: %v", err)
+		}
+		log.Printf("%d migration(s) applied", len(applied))
+
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			steps, err = strconv.Atoi(os.Args[2])
+			if err != nil || steps < 1 {
+				log.Fatalf("invalid number of steps: %s", os.Args[2])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			log.Fatalf("migrate down failed: %v", err)
+		}
+		log.Printf("%d migration(s) reverted", len(reverted))
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("failed to read migration status: %v", err)
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if status.ChecksumMismatch {
+				state += " (CHECKSUM MISMATCH)"
+			}
+			if status.Unknown {
+				state += " (UNKNOWN TO THIS BUILD)"
+			}
+			fmt.Printf("%04d  %-40s %s\n", status.Version, status.Name, state)
+		}
+
+	case "course-classes":
+		courseClasses(db, os.Args[2:])
+
+	case "encryption":
+		encryption(db, os.Args[2:])
+
+	default:
+		usage()
+	}
+}
+
+// courseClasses runs the Course → CourseClass consistency check or fix
+func courseClasses(db *gorm.DB, args []string) {
+	if len(args) == 0 {
+		usage()
+	}
+
+	switch args[0] {
+	case "check":
+		report, err := migrations.CheckCourseClassConsistency(db)
+		if err != nil {
+			log.Fatalf("consistency check failed: %v", err)
+		}
+		for _, issue := range report.Issues {
+			fmt.Printf("%-28s %8d  %v\n", issue.Issue, issue.Count, issue.SampleIDs)
+		}
+		if !report.Consistent {
+			os.Exit(1)
+		}
+
+	case "fix":
+		var opts migrations.CourseClassFixOptions
+		if len(args) > 1 {
+			opts.BatchSize = positiveArg(args[1])
+		}
+		if len(args) > 2 {
+			opts.MaxBatches = positiveArg(args[2])
+		}
+		result, err := migrations.FixCourseClassConsistency(db, opts)
+		if result != nil {
+			for _, step := range result.Steps {
+				fmt.Printf("%-28s fixed=%d unresolved=%d completed=%t %v\n", step.Issue, step.Fixed, step.Unresolved, step.Completed, step.UnresolvedIDs)
+			}
+		}
+		if err != nil {
+			log.Fatalf("consistency fix failed (run again to resume): %v", err)
+		}
+		if !result.Completed {
+			log.Printf("stopped after %d batch(es); run again to resume", result.Batches)
+		}
+
+	default:
+		usage()
+	}
+}
+
+// encryption checks or rotates the encryption of the sensitive fields
+func encryption(db *gorm.DB, args []string) {
+	if len(args) == 0 {
+		usage()
+	}
+
+	switch args[0] {
+	case "check":
+		report, err := migrations.CheckFieldEncryption(db)
+		if err != nil {
+			log.Fatalf("encryption check failed: %v", err)
+		}
+		fmt.Printf("current key: %s\n", report.KeyID)
+		for _, column := range report.Columns {
+			fmt.Printf("%-32s plaintext=%d missingIndex=%d byKey=%v\n", column.Table+"."+column.Column, column.Plaintext, column.MissingIndex, column.ByKey)
+		}
+		if len(report.UnknownKeys) > 0 {
+			fmt.Printf("keys in use but not configured: %v\n", report.UnknownKeys)
+		}
+		if !report.Clean {
+			os.Exit(1)
+		}
+
+	case "rotate":
+		var opts migrations.FieldKeyOptions
+		for _, arg := range args[1:] {
+			if arg == "-reindex" {
+				opts.Reindex = true
+				continue
+			}
+			opts.BatchSize = positiveArg(arg)
+		}
+		result, err := migrations.RotateFieldKeys(db, opts)
+		if result != nil {
+			for _, column := range result.Columns {
+				fmt.Printf("%-32s encrypted=%d indexed=%d duplicated=%v\n", column.Table+"."+column.Column, column.Encrypted, column.Indexed, column.DuplicateIndex)
+			}
+		}
+		if err != nil {
+			log.Fatalf("rotation failed (run again to resume): %v", err)
+		}
+
+	default:
+		usage()
+	}
+}
+
+func positiveArg(arg string) int {
+	value, err := strconv.Atoi(arg)
+	if err != nil || value < 1 {
+		log.Fatalf("invalid number: %s", arg)
+	}
+	return value
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate up | down [n] | status | course-classes check | course-classes fix [batchSize] [maxBatches] | encryption check | encryption rotate [-reindex] [batchSize]")
+	os.Exit(2)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/devdavidalonso/cecor/backend/internal/config"
 	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/pkg/fieldcrypt"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -17,6 +18,13 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	// CPF is an encrypted field: the keys must be set before creating the users
+	keyring, err := fieldcrypt.NewKeyring(cfg.Encryption.KeyID, cfg.Encryption.Keys, cfg.Encryption.IndexKey)
+	if err != nil {
+		log.Fatalf("Failed to configure field encryption: %v", err)
+	}
+	fieldcrypt.SetDefault(keyring)
+
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s",
 		cfg.Database.PostgresHost,
 		cfg.Database.PostgresUser,
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 
 	"github.com/devdavidalonso/cecor/backend/internal/config"
+	"github.com/devdavidalonso/cecor/backend/internal/migrations"
+	"github.com/devdavidalonso/cecor/backend/internal/models"
 	"github.com/devdavidalonso/cecor/backend/internal/repository/postgres"
+	"github.com/devdavidalonso/cecor/backend/pkg/fieldcrypt"
 	"github.com/joho/godotenv"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -26,7 +30,11 @@ func main() {
 		log.Fatalf("failed to connect db: %v", err)
 	}
 
-	if err := postgres.MigrateDB(db); err != nil {
+	migrator, err := migrations.New(db)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
 		log.Fatalf("failed to run migrations: %v", err)
 	}
 
@@ -144,6 +152,15 @@ func runSeed(db *gorm.DB, passwordHash string) error {
 }
 
 func upsertUser(tx *gorm.DB, name, email, cpf, phone, profileText string, profileID int, passwordHash string) error {
+	// The CPF is stored encrypted and found by its blind index (see models.CPFIndex)
+	cpfHash, err := models.CPFIndex(cpf)
+	if err != nil {
+		return err
+	}
+	if cpf, err = fieldcrypt.Encrypt(cpf); err != nil {
+		return err
+	}
+
 	var count int64
 	if err := tx.Raw(`SELECT COUNT(1) FROM users WHERE LOWER(email)=LOWER(?)`, email).Scan(&count).Error; err != nil {
 		return err
@@ -154,6 +171,7 @@ func upsertUser(tx *gorm.DB, name, email, cpf, phone, profileText string, profil
 			UPDATE users
 			SET name = ?,
 				cpf = ?,
+				cpf_hash = ?,
 				phone = ?,
 				profile = ?,
 				profile_id = ?,
@@ -161,14 +179,14 @@ func upsertUser(tx *gorm.DB, name, email, cpf, phone, profileText string, profil
 				active = true,
 				updated_at = NOW()
 			WHERE LOWER(email)=LOWER(?)
-		`, name, cpf, phone, profileText, profileID, passwordHash, email).Error
+		`, name, cpf, cpfHash, phone, profileText, profileID, passwordHash, email).Error
 	}
 
 	return tx.Exec(`
 		INSERT INTO users (
-			name, email, password, profile, cpf, phone, active, profile_id, birth_date, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, true, ?, NOW() - INTERVAL '20 years', NOW(), NOW())
-	`, name, email, passwordHash, profileText, cpf, phone, profileID).Error
+			name, email, password, profile, cpf, cpf_hash, phone, active, profile_id, birth_date, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, true, ?, NOW() - INTERVAL '20 years', NOW(), NOW())
+	`, name, email, passwordHash, profileText, cpf, cpfHash, phone, profileID).Error
 }
 
 func getUserIDByEmail(tx *gorm.DB, email string) (int64, error) {
@@ -0,0 +1,309 @@
+// backend/internal/api/handlers/absence_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/service/absences"
+)
+
+// AbsenceHandler handles teacher absence endpoints (teacher portal and coordination)
+type AbsenceHandler struct {
+	service absences.Service
+}
+
+// NewAbsenceHandler creates a new handler
+func NewAbsenceHandler(service absences.Service) *AbsenceHandler {
+	return &AbsenceHandler{service: service}
+}
+
+// absenceRequestBody is an absence report; dates are YYYY-MM-DD
+type absenceRequestBody struct {
+	TeacherID uint   `json:"teacherId"` // Apenas coordenação
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+	Reason    string `json:"reason"`
+	Mode      string `json:"mode"` // auto (padrão): pede substitutos; cancel: propõe cancelamento
+}
+
+// ReportMyAbsence registra uma ausência do professor logado
+// POST /api/v1/teacher/absences
+func (h *AbsenceHandler) ReportMyAbsence(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	teacherID, err := h.service.TeacherIDForUser(r.Context(), userID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	h.report(w, r, teacherID, userID)
+}
+
+// ListMyAbsences lista as ausências do professor logado
+// GET /api/v1/teacher/absences
+func (h *AbsenceHandler) ListMyAbsences(w http.ResponseWriter, r *http.Request) {
+	teacherID, err := h.service.TeacherIDForUser(r.Context(), getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	list, err := h.service.List(r.Context(), absences.Filter{Status: r.URL.Query().Get("status"), TeacherID: &teacherID})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// GetMyAbsence obtém uma ausência do professor logado com as aulas afetadas
+// GET /api/v1/teacher/absences/:id
+func (h *AbsenceHandler) GetMyAbsence(w http.ResponseWriter, r *http.Request) {
+	detail, ok := h.ownAbsence(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detail)
+}
+
+// CancelMyAbsence cancela uma ausência do professor logado
+// POST /api/v1/teacher/absences/:id/cancel
+func (h *AbsenceHandler) CancelMyAbsence(w http.ResponseWriter, r *http.Request) {
+	detail, ok := h.ownAbsence(w, r)
+	if !ok {
+		return
+	}
+
+	detail, err := h.service.Cancel(r.Context(), detail.ID, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detail)
+}
+
+// ReportAbsence registra a ausência de um professor (coordenação)
+// POST /api/v1/admin/teacher-absences
+func (h *AbsenceHandler) ReportAbsence(w http.ResponseWriter, r *http.Request) {
+	h.report(w, r, 0, getUserIDFromContext(r))
+}
+
+// ListAbsences lista as ausências
+// GET /api/v1/admin/teacher-absences?status=&teacherId=
+func (h *AbsenceHandler) ListAbsences(w http.ResponseWriter, r *http.Request) {
+	filter := absences.Filter{Status: r.URL.Query().Get("status")}
+	if teacherID := r.URL.Query().Get("teacherId"); teacherID != "" {
+		id, err := strconv.ParseUint(teacherID, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid teacherId", http.StatusBadRequest)
+			return
+		}
+		value := uint(id)
+		filter.TeacherID = &value
+	}
+
+	list, err := h.service.List(r.Context(), filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// GetAbsence obtém uma ausência com as aulas afetadas e a situação de cada substituição
+// GET /api/v1/admin/teacher-absences/:id
+func (h *AbsenceHandler) GetAbsence(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.service.Get(r.Context(), uint(id))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detail)
+}
+
+// ResolveAbsence encerra a ausência (professor retornou), revertendo as substituições pendentes
+// POST /api/v1/admin/teacher-absences/:id/resolve
+func (h *AbsenceHandler) ResolveAbsence(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.service.Resolve(r.Context(), uint(id), getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detail)
+}
+
+// CancelAbsence cancela a ausência, revertendo as substituições pendentes
+// POST /api/v1/admin/teacher-absences/:id/cancel
+func (h *AbsenceHandler) CancelAbsence(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.service.Cancel(r.Context(), uint(id), getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detail)
+}
+
+// CancelAffectedSession confirma o cancelamento de uma aula afetada e avisa os alunos
+// POST /api/v1/admin/teacher-absences/:id/sessions/:sessionId/cancel
+func (h *AbsenceHandler) CancelAffectedSession(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	sessionID, err := strconv.ParseUint(chi.URLParam(r, "sessionId"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid session id", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.service.CancelSession(r.Context(), uint(id), uint(sessionID))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detail)
+}
+
+// report registers an absence; teacherID 0 takes it from the body (coordination)
+func (h *AbsenceHandler) report(w http.ResponseWriter, r *http.Request, teacherID uint, userID uint) {
+	var body absenceRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if teacherID == 0 {
+		teacherID = body.TeacherID
+	}
+
+	startDate, err := parseOptionalDate(body.StartDate)
+	if err != nil || startDate == nil {
+		http.Error(w, "invalid startDate, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	endDate, err := parseOptionalDate(body.EndDate)
+	if err != nil || endDate == nil {
+		http.Error(w, "invalid endDate, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.service.Report(r.Context(), absences.ReportInput{
+		TeacherID:   teacherID,
+		StartDate:   *startDate,
+		EndDate:     *endDate,
+		Reason:      body.Reason,
+		Mode:        body.Mode,
+		CreatedByID: userID,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(detail)
+}
+
+// ownAbsence loads an absence of the logged teacher, writing the error response otherwise
+func (h *AbsenceHandler) ownAbsence(w http.ResponseWriter, r *http.Request) (*absences.AbsenceDetail, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return nil, false
+	}
+
+	teacherID, err := h.service.TeacherIDForUser(r.Context(), getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return nil, false
+	}
+
+	detail, err := h.service.Get(r.Context(), uint(id))
+	if err != nil {
+		h.writeError(w, err)
+		return nil, false
+	}
+	if detail.TeacherID != teacherID {
+		http.Error(w, "teacher absence not found", http.StatusNotFound)
+		return nil, false
+	}
+	return detail, true
+}
+
+func (h *AbsenceHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, absences.ErrNotFound):
+		http.Error(w, "teacher absence not found", http.StatusNotFound)
+	case errors.Is(err, absences.ErrInvalidAbsence):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, absences.ErrNotTeacher):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, absences.ErrNotActive):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, absences.ErrSessionNotAffected):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterRoutes registra as rotas do professor (portal)
+func (h *AbsenceHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/teacher/absences", func(r chi.Router) {
+		r.Get("/", h.ListMyAbsences)
+		r.Post("/", h.ReportMyAbsence)
+		r.Get("/{id}", h.GetMyAbsence)
+		r.Post("/{id}/cancel", h.CancelMyAbsence)
+	})
+}
+
+// RegisterAdminRoutes registra a gestão de ausências (coordenação)
+func (h *AbsenceHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Route("/teacher-absences", func(r chi.Router) {
+		r.Get("/", h.ListAbsences)
+		r.Post("/", h.ReportAbsence)
+		r.Get("/{id}", h.GetAbsence)
+		r.Post("/{id}/resolve", h.ResolveAbsence)
+		r.Post("/{id}/cancel", h.CancelAbsence)
+		r.Post("/{id}/sessions/{sessionId}/cancel", h.CancelAffectedSession)
+	})
+}
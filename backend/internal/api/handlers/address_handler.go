@@ -0,0 +1,49 @@
+// backend/internal/api/handlers/address_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/service/addresses"
+)
+
+// AddressHandler handles the CEP lookup used to fill in addresses
+type AddressHandler struct {
+	service addresses.Service
+}
+
+// NewAddressHandler creates a new handler
+func NewAddressHandler(service addresses.Service) *AddressHandler {
+	return &AddressHandler{service: service}
+}
+
+// LookupCEP retorna rua, bairro, cidade e UF de um CEP (com ou sem hífen)
+// GET /api/v1/addresses/cep/:cep
+func (h *AddressHandler) LookupCEP(w http.ResponseWriter, r *http.Request) {
+	postalCode, err := h.service.Lookup(r.Context(), chi.URLParam(r, "cep"))
+	if err != nil {
+		switch {
+		case errors.Is(err, addresses.ErrInvalidCEP):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, addresses.ErrCEPNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, addresses.ErrUnavailable):
+			http.Error(w, "address lookup is temporarily unavailable", http.StatusServiceUnavailable)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(postalCode)
+}
+
+// RegisterRoutes registra a consulta de CEP
+func (h *AddressHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/addresses/cep/{cep}", h.LookupCEP)
+}
@@ -0,0 +1,201 @@
+// backend/internal/api/handlers/assignment_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/service/assignments"
+)
+
+// AssignmentHandler handles the teacher assignments to course classes (role and period)
+type AssignmentHandler struct {
+	service assignments.Service
+}
+
+// NewAssignmentHandler creates a new handler
+func NewAssignmentHandler(service assignments.Service) *AssignmentHandler {
+	return &AssignmentHandler{service: service}
+}
+
+// assignmentRequestBody assigns a teacher to a class; dates are YYYY-MM-DD
+type assignmentRequestBody struct {
+	TeacherID uint   `json:"teacherId"`
+	Role      string `json:"role"` // primary (padrão), assistant, substitute
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+	Notes     string `json:"notes"`
+}
+
+// ListClassTeachers lista os professores atribuídos à turma (com ?history=true, inclui encerrados)
+// GET /api/v1/course-classes/:id/teachers?history=
+func (h *AssignmentHandler) ListClassTeachers(w http.ResponseWriter, r *http.Request) {
+	classID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid class id", http.StatusBadRequest)
+		return
+	}
+
+	value := uint(classID)
+	h.list(w, r, assignments.Filter{CourseClassID: &value})
+}
+
+// AssignClassTeacher atribui um professor à turma com papel e período
+// POST /api/v1/admin/course-classes/:id/teachers
+func (h *AssignmentHandler) AssignClassTeacher(w http.ResponseWriter, r *http.Request) {
+	classID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid class id", http.StatusBadRequest)
+		return
+	}
+
+	var body assignmentRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	startDate, err := parseOptionalDate(body.StartDate)
+	if err != nil || startDate == nil {
+		http.Error(w, "invalid startDate, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	endDate, err := parseOptionalDate(body.EndDate)
+	if err != nil {
+		http.Error(w, "invalid endDate, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	assignment, err := h.service.Assign(r.Context(), assignments.AssignInput{
+		CourseClassID: uint(classID),
+		TeacherID:     body.TeacherID,
+		Role:          body.Role,
+		StartDate:     *startDate,
+		EndDate:       endDate,
+		Notes:         body.Notes,
+		AssignedByID:  getUserIDFromContext(r),
+	})
+	if err != nil {
+		writeAssignmentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(assignment)
+}
+
+// EndAssignment encerra uma atribuição (padrão: hoje), mantendo-a no histórico
+// POST /api/v1/admin/teacher-assignments/:id/end
+func (h *AssignmentHandler) EndAssignment(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var body struct {
+		EndDate string `json:"endDate"`
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+	endDate, err := parseOptionalDate(body.EndDate)
+	if err != nil {
+		http.Error(w, "invalid endDate, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	assignment, err := h.service.End(r.Context(), uint(id), endDate)
+	if err != nil {
+		writeAssignmentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(assignment)
+}
+
+// RevokeAssignment desativa uma atribuição feita por engano
+// POST /api/v1/admin/teacher-assignments/:id/revoke
+func (h *AssignmentHandler) RevokeAssignment(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	assignment, err := h.service.Revoke(r.Context(), uint(id))
+	if err != nil {
+		writeAssignmentError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(assignment)
+}
+
+// ListTeacherAssignments lista as atribuições de um professor (com ?history=true, inclui encerradas)
+// GET /api/v1/admin/teachers/:id/assignments?history=
+func (h *AssignmentHandler) ListTeacherAssignments(w http.ResponseWriter, r *http.Request) {
+	teacherID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid teacher id", http.StatusBadRequest)
+		return
+	}
+
+	value := uint(teacherID)
+	h.list(w, r, assignments.Filter{TeacherID: &value})
+}
+
+func (h *AssignmentHandler) list(w http.ResponseWriter, r *http.Request, filter assignments.Filter) {
+	filter.History = r.URL.Query().Get("history") == "true"
+
+	list, err := h.service.List(r.Context(), filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// writeAssignmentError maps the assignment errors (also used when a class changes its default teacher)
+func writeAssignmentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, assignments.ErrNotFound):
+		http.Error(w, "teacher assignment not found", http.StatusNotFound)
+	case errors.Is(err, assignments.ErrClassNotFound):
+		http.Error(w, "class not found", http.StatusNotFound)
+	case errors.Is(err, assignments.ErrTeacherNotFound):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, assignments.ErrInvalidAssignment):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, assignments.ErrOverlap), errors.Is(err, assignments.ErrAlreadyEnded):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterRoutes registra a consulta das atribuições da turma
+func (h *AssignmentHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/course-classes/{id}/teachers", h.ListClassTeachers)
+}
+
+// RegisterAdminRoutes registra a gestão das atribuições (coordenação)
+func (h *AssignmentHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Post("/course-classes/{id}/teachers", h.AssignClassTeacher)
+	r.Get("/teachers/{id}/assignments", h.ListTeacherAssignments)
+	r.Route("/teacher-assignments", func(r chi.Router) {
+		r.Post("/{id}/end", h.EndAssignment)
+		r.Post("/{id}/revoke", h.RevokeAssignment)
+	})
+}
@@ -0,0 +1,210 @@
+// backend/internal/api/handlers/availability_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/service/availability"
+)
+
+// AvailabilityHandler handles the weekly availability of teachers (teacher portal and coordination)
+type AvailabilityHandler struct {
+	service availability.Service
+}
+
+// NewAvailabilityHandler creates a new handler
+func NewAvailabilityHandler(service availability.Service) *AvailabilityHandler {
+	return &AvailabilityHandler{service: service}
+}
+
+// ListMyAvailability lista a disponibilidade semanal do professor logado
+// GET /api/v1/teacher/availability
+func (h *AvailabilityHandler) ListMyAvailability(w http.ResponseWriter, r *http.Request) {
+	teacherID, ok := h.currentTeacher(w, r)
+	if !ok {
+		return
+	}
+	h.list(w, r, teacherID)
+}
+
+// CreateMyAvailability adiciona uma janela de disponibilidade
+// POST /api/v1/teacher/availability
+func (h *AvailabilityHandler) CreateMyAvailability(w http.ResponseWriter, r *http.Request) {
+	teacherID, ok := h.currentTeacher(w, r)
+	if !ok {
+		return
+	}
+
+	var input availability.WindowInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	window, err := h.service.Create(r.Context(), teacherID, input)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(window)
+}
+
+// ReplaceMyAvailability substitui toda a disponibilidade semanal do professor logado
+// PUT /api/v1/teacher/availability
+func (h *AvailabilityHandler) ReplaceMyAvailability(w http.ResponseWriter, r *http.Request) {
+	teacherID, ok := h.currentTeacher(w, r)
+	if !ok {
+		return
+	}
+	h.replace(w, r, teacherID)
+}
+
+// UpdateMyAvailability altera uma janela de disponibilidade
+// PUT /api/v1/teacher/availability/:id
+func (h *AvailabilityHandler) UpdateMyAvailability(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	teacherID, ok := h.currentTeacher(w, r)
+	if !ok {
+		return
+	}
+
+	var input availability.WindowInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	window, err := h.service.Update(r.Context(), teacherID, uint(id), input)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(window)
+}
+
+// DeleteMyAvailability remove uma janela de disponibilidade
+// DELETE /api/v1/teacher/availability/:id
+func (h *AvailabilityHandler) DeleteMyAvailability(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	teacherID, ok := h.currentTeacher(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), teacherID, uint(id)); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetTeacherAvailability lista a disponibilidade de um professor (coordenação)
+// GET /api/v1/admin/teachers/:id/availability
+func (h *AvailabilityHandler) GetTeacherAvailability(w http.ResponseWriter, r *http.Request) {
+	teacherID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid teacher id", http.StatusBadRequest)
+		return
+	}
+	h.list(w, r, uint(teacherID))
+}
+
+// ReplaceTeacherAvailability substitui a disponibilidade de um professor (coordenação)
+// PUT /api/v1/admin/teachers/:id/availability
+func (h *AvailabilityHandler) ReplaceTeacherAvailability(w http.ResponseWriter, r *http.Request) {
+	teacherID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid teacher id", http.StatusBadRequest)
+		return
+	}
+	h.replace(w, r, uint(teacherID))
+}
+
+func (h *AvailabilityHandler) list(w http.ResponseWriter, r *http.Request, teacherID uint) {
+	windows, err := h.service.List(r.Context(), teacherID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(windows)
+}
+
+func (h *AvailabilityHandler) replace(w http.ResponseWriter, r *http.Request, teacherID uint) {
+	var inputs []availability.WindowInput
+	if err := json.NewDecoder(r.Body).Decode(&inputs); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	windows, err := h.service.Replace(r.Context(), teacherID, inputs)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(windows)
+}
+
+// currentTeacher resolves the teacher record of the logged user, writing the error response otherwise
+func (h *AvailabilityHandler) currentTeacher(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	teacherID, err := h.service.TeacherIDForUser(r.Context(), getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return 0, false
+	}
+	return teacherID, true
+}
+
+func (h *AvailabilityHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, availability.ErrNotFound):
+		http.Error(w, "availability window not found", http.StatusNotFound)
+	case errors.Is(err, availability.ErrInvalidWindow):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, availability.ErrOverlap):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, availability.ErrNotTeacher):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterRoutes registra as rotas do professor (portal)
+func (h *AvailabilityHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/teacher/availability", func(r chi.Router) {
+		r.Get("/", h.ListMyAvailability)
+		r.Post("/", h.CreateMyAvailability)
+		r.Put("/", h.ReplaceMyAvailability)
+		r.Put("/{id}", h.UpdateMyAvailability)
+		r.Delete("/{id}", h.DeleteMyAvailability)
+	})
+}
+
+// RegisterAdminRoutes registra a consulta e manutenção pela coordenação
+func (h *AvailabilityHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/teachers/{id}/availability", h.GetTeacherAvailability)
+	r.Put("/teachers/{id}/availability", h.ReplaceTeacherAvailability)
+}
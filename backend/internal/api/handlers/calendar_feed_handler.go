@@ -0,0 +1,261 @@
+// backend/internal/api/handlers/calendar_feed_handler.go
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/calendar"
+)
+
+// CalendarFeedHandler handles iCalendar feed endpoints
+type CalendarFeedHandler struct {
+	service   calendar.Service
+	publicURL string
+}
+
+// NewCalendarFeedHandler creates a new handler
+func NewCalendarFeedHandler(service calendar.Service, publicURL string) *CalendarFeedHandler {
+	return &CalendarFeedHandler{
+		service:   service,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}
+}
+
+// CalendarFeedResponse is a feed token with its subscription URLs
+type CalendarFeedResponse struct {
+	models.CalendarFeedToken
+	URL       string `json:"url"`
+	WebcalURL string `json:"webcalUrl"`
+}
+
+// GetFeed serves the .ics document for a token (public, the token is the credential)
+// GET /api/v1/calendar/feeds/:token.ics
+func (h *CalendarFeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+
+	body, err := h.service.RenderFeed(r.Context(), token)
+	if err != nil {
+		if errors.Is(err, calendar.ErrFeedNotFound) {
+			http.Error(w, "Calendar feed not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="cecor.ics"`)
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	w.Write(body)
+}
+
+// ListTeacherFeeds lists the calendar feeds of the logged teacher
+// GET /api/v1/teacher/calendar-feeds
+func (h *CalendarFeedHandler) ListTeacherFeeds(w http.ResponseWriter, r *http.Request) {
+	h.listFeeds(w, r, models.CalendarFeedOwnerTeacher, h.service.TeacherIDForUser)
+}
+
+// CreateTeacherFeed creates a calendar feed for the logged teacher
+// POST /api/v1/teacher/calendar-feeds
+func (h *CalendarFeedHandler) CreateTeacherFeed(w http.ResponseWriter, r *http.Request) {
+	h.createFeed(w, r, models.CalendarFeedOwnerTeacher, h.service.TeacherIDForUser)
+}
+
+// RevokeTeacherFeed revokes a calendar feed of the logged teacher
+// DELETE /api/v1/teacher/calendar-feeds/:feedId
+func (h *CalendarFeedHandler) RevokeTeacherFeed(w http.ResponseWriter, r *http.Request) {
+	h.revokeFeed(w, r, models.CalendarFeedOwnerTeacher, h.service.TeacherIDForUser)
+}
+
+// ListStudentFeeds lists the calendar feeds of the logged student
+// GET /api/v1/student/calendar-feeds
+func (h *CalendarFeedHandler) ListStudentFeeds(w http.ResponseWriter, r *http.Request) {
+	h.listFeeds(w, r, models.CalendarFeedOwnerStudent, h.service.StudentIDForUser)
+}
+
+// CreateStudentFeed creates a calendar feed for the logged student
+// POST /api/v1/student/calendar-feeds
+func (h *CalendarFeedHandler) CreateStudentFeed(w http.ResponseWriter, r *http.Request) {
+	h.createFeed(w, r, models.CalendarFeedOwnerStudent, h.service.StudentIDForUser)
+}
+
+// RevokeStudentFeed revokes a calendar feed of the logged student
+// DELETE /api/v1/student/calendar-feeds/:feedId
+func (h *CalendarFeedHandler) RevokeStudentFeed(w http.ResponseWriter, r *http.Request) {
+	h.revokeFeed(w, r, models.CalendarFeedOwnerStudent, h.service.StudentIDForUser)
+}
+
+// ListLocationFeeds lists the calendar feeds of a room
+// GET /api/v1/admin/locations/:id/calendar-feeds
+func (h *CalendarFeedHandler) ListLocationFeeds(w http.ResponseWriter, r *http.Request) {
+	h.listFeeds(w, r, models.CalendarFeedOwnerLocation, h.locationFromURL(r))
+}
+
+// CreateLocationFeed creates a calendar feed for a room
+// POST /api/v1/admin/locations/:id/calendar-feeds
+func (h *CalendarFeedHandler) CreateLocationFeed(w http.ResponseWriter, r *http.Request) {
+	h.createFeed(w, r, models.CalendarFeedOwnerLocation, h.locationFromURL(r))
+}
+
+// RevokeLocationFeed revokes a calendar feed of a room
+// DELETE /api/v1/admin/locations/:id/calendar-feeds/:feedId
+func (h *CalendarFeedHandler) RevokeLocationFeed(w http.ResponseWriter, r *http.Request) {
+	h.revokeFeed(w, r, models.CalendarFeedOwnerLocation, h.locationFromURL(r))
+}
+
+// ownerResolver maps the logged user to the feed owner ID
+type ownerResolver func(ctx context.Context, userID uint) (uint, error)
+
+// locationFromURL resolves the owner from the {id} URL parameter (admin routes)
+func (h *CalendarFeedHandler) locationFromURL(r *http.Request) ownerResolver {
+	return func(ctx context.Context, userID uint) (uint, error) {
+		id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+		if err != nil {
+			return 0, calendar.ErrInvalidOwner
+		}
+		return uint(id), nil
+	}
+}
+
+// resolveOwner writes the error response itself when the owner cannot be resolved
+func (h *CalendarFeedHandler) resolveOwner(w http.ResponseWriter, r *http.Request, resolve ownerResolver) (uint, bool) {
+	userID := getUserIDFromContext(r)
+	if userID == 0 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return 0, false
+	}
+
+	ownerID, err := resolve(r.Context(), userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return 0, false
+	}
+	return ownerID, true
+}
+
+// toResponse adds the subscription URLs to a token
+func (h *CalendarFeedHandler) toResponse(token models.CalendarFeedToken) CalendarFeedResponse {
+	url := h.publicURL + "/api/v1/calendar/feeds/" + token.Token + ".ics"
+	webcal := url
+	if i := strings.Index(url, "://"); i >= 0 {
+		webcal = "webcal" + url[i:]
+	}
+	return CalendarFeedResponse{
+		CalendarFeedToken: token,
+		URL:               url,
+		WebcalURL:         webcal,
+	}
+}
+
+func (h *CalendarFeedHandler) listFeeds(w http.ResponseWriter, r *http.Request, ownerType models.CalendarFeedOwnerType, resolve ownerResolver) {
+	ownerID, ok := h.resolveOwner(w, r, resolve)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.service.ListTokens(r.Context(), ownerType, ownerID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]CalendarFeedResponse, 0, len(tokens))
+	for _, token := range tokens {
+		response = append(response, h.toResponse(token))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func (h *CalendarFeedHandler) createFeed(w http.ResponseWriter, r *http.Request, ownerType models.CalendarFeedOwnerType, resolve ownerResolver) {
+	ownerID, ok := h.resolveOwner(w, r, resolve)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Label string `json:"label"`
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	token, err := h.service.CreateToken(r.Context(), ownerType, ownerID, req.Label, getUserIDFromContext(r))
+	if err != nil {
+		if errors.Is(err, calendar.ErrInvalidOwner) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(h.toResponse(*token))
+}
+
+func (h *CalendarFeedHandler) revokeFeed(w http.ResponseWriter, r *http.Request, ownerType models.CalendarFeedOwnerType, resolve ownerResolver) {
+	ownerID, ok := h.resolveOwner(w, r, resolve)
+	if !ok {
+		return
+	}
+
+	feedID, err := strconv.ParseUint(chi.URLParam(r, "feedId"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid feed ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.RevokeToken(r.Context(), ownerType, ownerID, uint(feedID)); err != nil {
+		if errors.Is(err, calendar.ErrFeedNotFound) {
+			http.Error(w, "Calendar feed not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterPublicRoutes registers the unauthenticated feed route
+func (h *CalendarFeedHandler) RegisterPublicRoutes(r chi.Router) {
+	r.Get("/calendar/feeds/{token}.ics", h.GetFeed)
+}
+
+// RegisterRoutes registers the portal routes to manage feeds
+func (h *CalendarFeedHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/teacher/calendar-feeds", func(r chi.Router) {
+		r.Get("/", h.ListTeacherFeeds)
+		r.Post("/", h.CreateTeacherFeed)
+		r.Delete("/{feedId}", h.RevokeTeacherFeed)
+	})
+
+	r.Route("/student/calendar-feeds", func(r chi.Router) {
+		r.Get("/", h.ListStudentFeeds)
+		r.Post("/", h.CreateStudentFeed)
+		r.Delete("/{feedId}", h.RevokeStudentFeed)
+	})
+}
+
+// RegisterAdminRoutes registers the room feed routes (coordination)
+func (h *CalendarFeedHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Route("/locations/{id}/calendar-feeds", func(r chi.Router) {
+		r.Get("/", h.ListLocationFeeds)
+		r.Post("/", h.CreateLocationFeed)
+		r.Delete("/{feedId}", h.RevokeLocationFeed)
+	})
+}
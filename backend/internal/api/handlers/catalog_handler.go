@@ -0,0 +1,206 @@
+// backend/internal/api/handlers/catalog_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/catalog"
+)
+
+// CatalogHandler gerencia o catálogo público de cursos
+type CatalogHandler struct {
+	service catalog.Service
+}
+
+// NewCatalogHandler cria um novo handler
+func NewCatalogHandler(service catalog.Service) *CatalogHandler {
+	return &CatalogHandler{service: service}
+}
+
+// ListClasses lista as turmas abertas com filtros e paginação
+// GET /api/v1/catalog/classes?categoryId=&weekDay=&period=&difficulty=&tags=a,b&q=&page=&pageSize=
+func (h *CatalogHandler) ListClasses(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	filter := catalog.Filter{
+		Period:     q.Get("period"),
+		Difficulty: q.Get("difficulty"),
+		Search:     q.Get("q"),
+	}
+	filter.Page, _ = strconv.Atoi(q.Get("page"))
+	filter.PageSize, _ = strconv.Atoi(q.Get("pageSize"))
+
+	if categoryID := q.Get("categoryId"); categoryID != "" {
+		id, err := strconv.ParseUint(categoryID, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid categoryId", http.StatusBadRequest)
+			return
+		}
+		value := uint(id)
+		filter.CategoryID = &value
+	}
+
+	if weekDay := q.Get("weekDay"); weekDay != "" {
+		day, err := strconv.Atoi(weekDay)
+		if err != nil {
+			http.Error(w, "Invalid weekDay", http.StatusBadRequest)
+			return
+		}
+		filter.WeekDay = &day
+	}
+
+	for _, tag := range strings.Split(q.Get("tags"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			filter.Tags = append(filter.Tags, tag)
+		}
+	}
+
+	page, err := h.service.ListClasses(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, catalog.ErrInvalidFilter) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// GetClass obtém os detalhes de uma turma aberta
+// GET /api/v1/catalog/classes/:id
+func (h *CatalogHandler) GetClass(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	class, err := h.service.GetClass(r.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, catalog.ErrClassNotFound) {
+			http.Error(w, "class not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(class)
+}
+
+// ListCategories lista as categorias com o número de turmas abertas
+// GET /api/v1/catalog/categories
+func (h *CatalogHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.service.ListCategories(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(categories)
+}
+
+// CreateCategory cria uma categoria de curso
+// POST /api/v1/admin/course-categories
+func (h *CatalogHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var category models.CourseCategory
+	if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	category.ID = 0
+
+	if err := h.service.CreateCategory(r.Context(), &category); err != nil {
+		h.writeCategoryError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(category)
+}
+
+// UpdateCategory atualiza uma categoria de curso
+// PUT /api/v1/admin/course-categories/:id
+func (h *CatalogHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var category models.CourseCategory
+	if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	category.ID = uint(id)
+
+	if err := h.service.UpdateCategory(r.Context(), &category); err != nil {
+		h.writeCategoryError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(category)
+}
+
+// DeleteCategory remove uma categoria (os cursos ficam sem categoria)
+// DELETE /api/v1/admin/course-categories/:id
+func (h *CatalogHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteCategory(r.Context(), uint(id)); err != nil {
+		h.writeCategoryError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CatalogHandler) writeCategoryError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, catalog.ErrCategoryNotFound):
+		http.Error(w, "category not found", http.StatusNotFound)
+	case errors.Is(err, catalog.ErrInvalidCategory):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case strings.Contains(err.Error(), "duplicate key"):
+		http.Error(w, "category name already exists", http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterPublicRoutes registra as rotas públicas do catálogo (sem autenticação)
+func (h *CatalogHandler) RegisterPublicRoutes(r chi.Router) {
+	r.Route("/catalog", func(r chi.Router) {
+		r.Get("/categories", h.ListCategories)
+		r.Get("/classes", h.ListClasses)
+		r.Get("/classes/{id}", h.GetClass)
+	})
+}
+
+// RegisterAdminRoutes registra a gestão de categorias (coordenação)
+func (h *CatalogHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Route("/course-categories", func(r chi.Router) {
+		r.Post("/", h.CreateCategory)
+		r.Put("/{id}", h.UpdateCategory)
+		r.Delete("/{id}", h.DeleteCategory)
+	})
+}
@@ -8,18 +8,20 @@ import (
 	"time"
 
 	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/assignments"
 	"github.com/go-chi/chi/v5"
 	"gorm.io/gorm"
 )
 
 // CourseClassHandler gerencia turmas (CourseClass)
 type CourseClassHandler struct {
-	db *gorm.DB
+	db          *gorm.DB
+	assignments assignments.Service
 }
 
 // NewCourseClassHandler cria um novo handler
-func NewCourseClassHandler(db *gorm.DB) *CourseClassHandler {
-	return &CourseClassHandler{db: db}
+func NewCourseClassHandler(db *gorm.DB, assignmentService assignments.Service) *CourseClassHandler {
+	return &CourseClassHandler{db: db, assignments: assignmentService}
 }
 
 // ListCourseClasses lista todas as turmas
@@ -128,8 +130,24 @@ func (h *CourseClassHandler) CreateCourseClass(w http.ResponseWriter, r *http.Re
 		Status:             "active",
 	}
 
-	if err := h.db.Create(&class).Error; err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	// O professor padrão é registrado como titular da turma, na mesma transação
+	err := h.db.WithContext(r.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&class).Error; err != nil {
+			return err
+		}
+		if class.DefaultTeacherID == nil {
+			return nil
+		}
+		return h.assignments.AssignTx(tx, assignments.AssignInput{
+			CourseClassID: class.ID,
+			TeacherID:     *class.DefaultTeacherID,
+			Role:          assignments.RolePrimary,
+			StartDate:     class.StartDate,
+			AssignedByID:  getUserIDFromContext(r),
+		})
+	})
+	if err != nil {
+		writeAssignmentError(w, err)
 		return
 	}
 
@@ -194,8 +212,39 @@ func (h *CourseClassHandler) UpdateCourseClass(w http.ResponseWriter, r *http.Re
 		"status":               req.Status,
 	}
 
-	if err := h.db.Model(&class).Updates(updates).Error; err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	// A troca do professor padrão vira uma nova atribuição de titular, a partir de hoje
+	// (ou do início da turma), encerrando a anterior; sem professor, a anterior só é encerrada
+	changedTeacher := req.DefaultTeacherID != nil &&
+		(class.DefaultTeacherID == nil || *class.DefaultTeacherID != *req.DefaultTeacherID)
+	clearedTeacher := req.DefaultTeacherID == nil && class.DefaultTeacherID != nil
+	if changedTeacher {
+		delete(updates, "default_teacher_id")
+	}
+
+	err = h.db.WithContext(r.Context()).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&class).Updates(updates).Error; err != nil {
+			return err
+		}
+		if !changedTeacher && !clearedTeacher {
+			return nil
+		}
+		startDate := time.Now()
+		if class.StartDate.After(startDate) {
+			startDate = class.StartDate
+		}
+		if clearedTeacher {
+			return h.assignments.ClosePrimaryTx(tx, class.ID, startDate)
+		}
+		return h.assignments.AssignTx(tx, assignments.AssignInput{
+			CourseClassID: class.ID,
+			TeacherID:     *req.DefaultTeacherID,
+			Role:          assignments.RolePrimary,
+			StartDate:     startDate,
+			AssignedByID:  getUserIDFromContext(r),
+		})
+	})
+	if err != nil {
+		writeAssignmentError(w, err)
 		return
 	}
 
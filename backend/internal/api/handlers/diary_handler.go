@@ -0,0 +1,297 @@
+// backend/internal/api/handlers/diary_handler.go
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/service/diary"
+)
+
+// DiaryHandler handles the lesson diary of the sessions and the class register
+type DiaryHandler struct {
+	service diary.Service
+}
+
+// NewDiaryHandler creates a new handler
+func NewDiaryHandler(service diary.Service) *DiaryHandler {
+	return &DiaryHandler{service: service}
+}
+
+// diaryRequestBody is the diary of a session filled in by the teacher
+type diaryRequestBody struct {
+	SyllabusTopicID *uint  `json:"syllabusTopicId"` // vazio: tópico planejado
+	TopicStatus     string `json:"topicStatus"`     // covered (padrão), partial, not_covered
+	Content         string `json:"content"`
+	Homework        string `json:"homework"`
+	Observations    string `json:"observations"`
+}
+
+// === Portal do professor ===
+
+// GetMyDiary retorna o diário de uma aula do professor
+// GET /api/v1/teacher/sessions/:id/diary
+func (h *DiaryHandler) GetMyDiary(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	if userID == 0 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	sessionID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid session id", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.GetForTeacher(r.Context(), userID, uint(sessionID))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// SaveMyDiary registra o diário de uma aula (professor efetivo, inclusive o substituto)
+// PUT /api/v1/teacher/sessions/:id/diary
+func (h *DiaryHandler) SaveMyDiary(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	if userID == 0 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	sessionID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid session id", http.StatusBadRequest)
+		return
+	}
+
+	var body diaryRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.SaveForTeacher(r.Context(), userID, uint(sessionID), diary.EntryInput{
+		SyllabusTopicID: body.SyllabusTopicID,
+		TopicStatus:     body.TopicStatus,
+		Content:         body.Content,
+		Homework:        body.Homework,
+		Observations:    body.Observations,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// ListMyClassDiary lista o diário das aulas da turma visíveis ao professor
+// GET /api/v1/teacher/course-classes/:id/diary?startDate=&endDate=
+func (h *DiaryHandler) ListMyClassDiary(w http.ResponseWriter, r *http.Request) {
+	userID := getUserIDFromContext(r)
+	if userID == 0 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	classID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid class id", http.StatusBadRequest)
+		return
+	}
+	period, err := parseDiaryPeriod(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.service.ListForTeacher(r.Context(), userID, uint(classID), period)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// === Coordenação ===
+
+// GetSessionDiary retorna o diário de uma aula
+// GET /api/v1/admin/class-sessions/:id/diary
+func (h *DiaryHandler) GetSessionDiary(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid session id", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.Get(r.Context(), uint(sessionID))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// ListClassDiary lista o diário das aulas da turma
+// GET /api/v1/admin/course-classes/:id/diary?startDate=&endDate=
+func (h *DiaryHandler) ListClassDiary(w http.ResponseWriter, r *http.Request) {
+	classID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid class id", http.StatusBadRequest)
+		return
+	}
+	period, err := parseDiaryPeriod(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.service.ListClass(r.Context(), uint(classID), period)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// ExportClassRegister exporta o diário de classe da turma (aulas, conteúdo e frequência)
+// GET /api/v1/admin/course-classes/:id/register?startDate=&endDate=&format=json|csv
+func (h *DiaryHandler) ExportClassRegister(w http.ResponseWriter, r *http.Request) {
+	classID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid class id", http.StatusBadRequest)
+		return
+	}
+	period, err := parseDiaryPeriod(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		http.Error(w, "format must be json or csv", http.StatusBadRequest)
+		return
+	}
+
+	register, err := h.service.Register(r.Context(), uint(classID), period)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	if format != "csv" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(register)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="diario-turma-%d.csv"`, classID))
+	writeRegisterCSV(w, register)
+}
+
+// writeRegisterCSV writes one line per session of the class register
+func writeRegisterCSV(w http.ResponseWriter, register *diary.ClassRegister) {
+	out := csv.NewWriter(w)
+	out.Write([]string{
+		"Data", "Início", "Fim", "Professor", "Substituição", "Cancelada",
+		"Tópico planejado", "Tópico trabalhado", "Situação do tópico",
+		"Conteúdo", "Tarefa", "Observações", "Registrado por",
+		"Presentes", "Ausentes", "Parciais",
+	})
+	for _, session := range register.Sessions {
+		var topicStatus, content, homework, observations string
+		if session.Entry != nil {
+			topicStatus = session.Entry.TopicStatus
+			content = session.Entry.Content
+			homework = session.Entry.Homework
+			observations = session.Entry.Observations
+		}
+		out.Write([]string{
+			session.Date.Format("2006-01-02"),
+			session.StartTime,
+			session.EndTime,
+			session.TeacherName,
+			yesNo(session.IsSubstituted),
+			yesNo(session.IsCancelled),
+			session.PlannedTopic,
+			session.TopicTitle,
+			topicStatus,
+			content,
+			homework,
+			observations,
+			session.AuthorName,
+			strconv.Itoa(session.Present),
+			strconv.Itoa(session.Absent),
+			strconv.Itoa(session.Partial),
+		})
+	}
+	out.Flush()
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "sim"
+	}
+	return "não"
+}
+
+// parseDiaryPeriod reads the optional startDate and endDate (YYYY-MM-DD) filters
+func parseDiaryPeriod(r *http.Request) (diary.Period, error) {
+	startDate, err := parseOptionalDate(r.URL.Query().Get("startDate"))
+	if err != nil {
+		return diary.Period{}, errors.New("invalid startDate, expected YYYY-MM-DD")
+	}
+	endDate, err := parseOptionalDate(r.URL.Query().Get("endDate"))
+	if err != nil {
+		return diary.Period{}, errors.New("invalid endDate, expected YYYY-MM-DD")
+	}
+	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
+		return diary.Period{}, errors.New("endDate before startDate")
+	}
+	return diary.Period{StartDate: startDate, EndDate: endDate}, nil
+}
+
+func (h *DiaryHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, diary.ErrSessionNotFound):
+		http.Error(w, "class session not found", http.StatusNotFound)
+	case errors.Is(err, diary.ErrClassNotFound):
+		http.Error(w, "class not found", http.StatusNotFound)
+	case errors.Is(err, diary.ErrNotTeacher), errors.Is(err, diary.ErrNotEditable):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, diary.ErrInvalidEntry):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterRoutes registra o diário no portal do professor
+func (h *DiaryHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/teacher/sessions/{id}/diary", h.GetMyDiary)
+	r.Put("/teacher/sessions/{id}/diary", h.SaveMyDiary)
+	r.Get("/teacher/course-classes/{id}/diary", h.ListMyClassDiary)
+}
+
+// RegisterAdminRoutes registra a consulta do diário e a exportação do diário de classe (coordenação)
+func (h *DiaryHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/class-sessions/{id}/diary", h.GetSessionDiary)
+	r.Get("/course-classes/{id}/diary", h.ListClassDiary)
+	r.Get("/course-classes/{id}/register", h.ExportClassRegister)
+}
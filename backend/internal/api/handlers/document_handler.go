@@ -0,0 +1,185 @@
+// backend/internal/api/handlers/document_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/api/middleware"
+	"github.com/devdavidalonso/cecor/backend/internal/service/documents"
+)
+
+// Multipart parsing: form fields and boundaries on top of the file, and how much is kept in memory
+const (
+	multipartOverhead = 1 << 20
+	multipartMemory   = 8 << 20
+)
+
+// DocumentHandler handles the files of the student documents
+type DocumentHandler struct {
+	service documents.Service
+}
+
+// NewDocumentHandler creates a new handler
+func NewDocumentHandler(service documents.Service) *DocumentHandler {
+	return &DocumentHandler{service: service}
+}
+
+// UploadDocument envia um documento do aluno (multipart: file, name, type)
+// O tipo do arquivo é detectado pelo conteúdo: PDF, JPEG, PNG ou WebP.
+// POST /api/v1/students/:id/documents
+func (h *DocumentHandler) UploadDocument(w http.ResponseWriter, r *http.Request) {
+	studentID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid student id", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.service.MaxSize()+multipartOverhead)
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, fmt.Sprintf("document exceeds the size limit of %d MB", h.service.MaxSize()>>20), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "expected a multipart/form-data body", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "the file field is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	document, err := h.service.Upload(r.Context(), documents.UploadInput{
+		StudentID: uint(studentID),
+		Name:      r.FormValue("name"),
+		Type:      r.FormValue("type"),
+		FileName:  header.Filename,
+		File:      file,
+		Size:      header.Size,
+	}, h.viewer(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(document)
+}
+
+// CreateDownloadLink gera um link de download com validade curta
+// POST /api/v1/documents/:id/link
+func (h *DocumentHandler) CreateDownloadLink(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	link, err := h.service.Link(r.Context(), uint(id), h.viewer(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(link)
+}
+
+// DeleteDocument remove o documento e o arquivo (coordenação ou quem enviou)
+// DELETE /api/v1/documents/:id
+func (h *DocumentHandler) DeleteDocument(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), uint(id), h.viewer(r)); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DownloadDocument entrega o arquivo de um link assinado (a assinatura na URL é a credencial)
+// GET /api/v1/documents/:id/content?expires=&signature=
+func (h *DocumentHandler) DownloadDocument(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	content, err := h.service.Open(r.Context(), uint(id), r.URL.Query().Get("expires"), r.URL.Query().Get("signature"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	defer content.Body.Close()
+
+	document := content.Document
+	filename := document.Name + path.Ext(document.Path)
+	w.Header().Set("Content-Type", document.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, no-store")
+	if document.Size > 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(document.Size, 10))
+	}
+	io.Copy(w, content.Body)
+}
+
+// viewer identifies the user for the access checks
+func (h *DocumentHandler) viewer(r *http.Request) documents.Viewer {
+	claims, _ := middleware.GetUserFromContext(r.Context())
+	return documents.Viewer{
+		UserID: getUserIDFromContext(r),
+		Admin:  middleware.IsAdmin(claims),
+	}
+}
+
+func (h *DocumentHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, documents.ErrStudentNotFound):
+		http.Error(w, "student not found", http.StatusNotFound)
+	case errors.Is(err, documents.ErrDocumentNotFound):
+		http.Error(w, "document not found", http.StatusNotFound)
+	case errors.Is(err, documents.ErrForbidden), errors.Is(err, documents.ErrInvalidLink):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, documents.ErrTooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, documents.ErrUnsupportedType):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	case errors.Is(err, documents.ErrInvalidDocument):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterRoutes registra o link de download e a remoção dos documentos
+// O envio fica em /students/{id}/documents, junto às demais rotas do aluno.
+func (h *DocumentHandler) RegisterRoutes(r chi.Router) {
+	r.Post("/documents/{id}/link", h.CreateDownloadLink)
+	r.Delete("/documents/{id}", h.DeleteDocument)
+}
+
+// RegisterPublicRoutes registra o download pelos links assinados
+func (h *DocumentHandler) RegisterPublicRoutes(r chi.Router) {
+	r.Get("/documents/{id}/content", h.DownloadDocument)
+}
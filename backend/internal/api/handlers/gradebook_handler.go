@@ -0,0 +1,285 @@
+// backend/internal/api/handlers/gradebook_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/service/gradebook"
+)
+
+// GradebookHandler handles the assessments, scores and final results of the course classes
+type GradebookHandler struct {
+	service gradebook.Service
+}
+
+// NewGradebookHandler creates a new handler
+func NewGradebookHandler(service gradebook.Service) *GradebookHandler {
+	return &GradebookHandler{service: service}
+}
+
+// assessmentRequestBody creates or updates an assessment; date is YYYY-MM-DD
+type assessmentRequestBody struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Weight      float64 `json:"weight"`   // padrão 1
+	MaxScore    float64 `json:"maxScore"` // padrão 10
+	Date        string  `json:"date"`
+}
+
+func (b assessmentRequestBody) input() (gradebook.AssessmentInput, error) {
+	date, err := parseOptionalDate(b.Date)
+	if err != nil {
+		return gradebook.AssessmentInput{}, errors.New("invalid date, expected YYYY-MM-DD")
+	}
+	return gradebook.AssessmentInput{
+		Name:        b.Name,
+		Description: b.Description,
+		Weight:      b.Weight,
+		MaxScore:    b.MaxScore,
+		Date:        date,
+	}, nil
+}
+
+// classHandlerFunc handles a request on a course class already checked
+type classHandlerFunc func(w http.ResponseWriter, r *http.Request, classID uint)
+
+// forClass parses the class id and, in the teacher portal, checks that the user teaches the class
+func (h *GradebookHandler) forClass(teacherPortal bool, next classHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		classID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+		if err != nil {
+			http.Error(w, "invalid class id", http.StatusBadRequest)
+			return
+		}
+		if teacherPortal {
+			userID := getUserIDFromContext(r)
+			if userID == 0 {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if err := h.service.AuthorizeTeacher(r.Context(), userID, uint(classID)); err != nil {
+				h.writeError(w, err)
+				return
+			}
+		}
+		next(w, r, uint(classID))
+	}
+}
+
+// GetGradebook retorna avaliações, notas, frequência e resultado previsto de cada aluno
+// GET /api/v1/teacher/course-classes/:id/gradebook
+// GET /api/v1/admin/course-classes/:id/gradebook
+func (h *GradebookHandler) GetGradebook(w http.ResponseWriter, r *http.Request, classID uint) {
+	book, err := h.service.Get(r.Context(), classID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(book)
+}
+
+// CreateAssessment cria uma avaliação na turma
+// POST /api/v1/teacher/course-classes/:id/assessments
+// POST /api/v1/admin/course-classes/:id/assessments
+func (h *GradebookHandler) CreateAssessment(w http.ResponseWriter, r *http.Request, classID uint) {
+	var body assessmentRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	input, err := body.input()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	assessment, err := h.service.CreateAssessment(r.Context(), classID, input, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(assessment)
+}
+
+// UpdateAssessment altera uma avaliação da turma
+// PUT /api/v1/teacher/course-classes/:id/assessments/:assessmentId
+// PUT /api/v1/admin/course-classes/:id/assessments/:assessmentId
+func (h *GradebookHandler) UpdateAssessment(w http.ResponseWriter, r *http.Request, classID uint) {
+	assessmentID, err := strconv.ParseUint(chi.URLParam(r, "assessmentId"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid assessment id", http.StatusBadRequest)
+		return
+	}
+	var body assessmentRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	input, err := body.input()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	assessment, err := h.service.UpdateAssessment(r.Context(), classID, uint(assessmentID), input)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(assessment)
+}
+
+// DeleteAssessment remove uma avaliação e suas notas
+// DELETE /api/v1/teacher/course-classes/:id/assessments/:assessmentId
+// DELETE /api/v1/admin/course-classes/:id/assessments/:assessmentId
+func (h *GradebookHandler) DeleteAssessment(w http.ResponseWriter, r *http.Request, classID uint) {
+	assessmentID, err := strconv.ParseUint(chi.URLParam(r, "assessmentId"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid assessment id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteAssessment(r.Context(), classID, uint(assessmentID)); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RecordScores lança as notas de uma avaliação (score nulo remove a nota)
+// PUT /api/v1/teacher/course-classes/:id/assessments/:assessmentId/scores
+// PUT /api/v1/admin/course-classes/:id/assessments/:assessmentId/scores
+func (h *GradebookHandler) RecordScores(w http.ResponseWriter, r *http.Request, classID uint) {
+	assessmentID, err := strconv.ParseUint(chi.URLParam(r, "assessmentId"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid assessment id", http.StatusBadRequest)
+		return
+	}
+	var scores []gradebook.ScoreInput
+	if err := json.NewDecoder(r.Body).Decode(&scores); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	book, err := h.service.RecordScores(r.Context(), classID, uint(assessmentID), scores, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(book)
+}
+
+// GetGradingRule retorna a regra de resultado final da turma (ou a padrão)
+// GET /api/v1/admin/course-classes/:id/grading-rule
+func (h *GradebookHandler) GetGradingRule(w http.ResponseWriter, r *http.Request, classID uint) {
+	rule, err := h.service.GetRule(r.Context(), classID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rule)
+}
+
+// SetGradingRule define a média e a frequência mínimas da turma
+// PUT /api/v1/admin/course-classes/:id/grading-rule
+func (h *GradebookHandler) SetGradingRule(w http.ResponseWriter, r *http.Request, classID uint) {
+	var rule gradebook.Rule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.SetRule(r.Context(), classID, rule, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// FinalizeResults registra o resultado final (aprovado/reprovado) e conclui as matrículas;
+// matrículas com notas em falta exigem allowMissingScores (contam como zero)
+// POST /api/v1/admin/course-classes/:id/final-results
+func (h *GradebookHandler) FinalizeResults(w http.ResponseWriter, r *http.Request, classID uint) {
+	var body struct {
+		EnrollmentIDs      []uint `json:"enrollmentIds"`      // vazio: todas as matrículas em aberto
+		AllowMissingScores bool   `json:"allowMissingScores"` // confirma que as notas em falta contam como zero
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	results, err := h.service.Finalize(r.Context(), classID, body.EnrollmentIDs, body.AllowMissingScores, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+func (h *GradebookHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, gradebook.ErrClassNotFound):
+		http.Error(w, "class not found", http.StatusNotFound)
+	case errors.Is(err, gradebook.ErrAssessmentNotFound):
+		http.Error(w, "assessment not found", http.StatusNotFound)
+	case errors.Is(err, gradebook.ErrNotTeacher):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, gradebook.ErrInvalidAssessment), errors.Is(err, gradebook.ErrInvalidScore), errors.Is(err, gradebook.ErrInvalidRule):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, gradebook.ErrFinalized), errors.Is(err, gradebook.ErrMissingScores):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// registerClassRoutes registra as rotas de avaliação comuns ao portal do professor e à coordenação
+func (h *GradebookHandler) registerClassRoutes(r chi.Router, teacherPortal bool) {
+	r.Get("/gradebook", h.forClass(teacherPortal, h.GetGradebook))
+	r.Post("/assessments", h.forClass(teacherPortal, h.CreateAssessment))
+	r.Put("/assessments/{assessmentId}", h.forClass(teacherPortal, h.UpdateAssessment))
+	r.Delete("/assessments/{assessmentId}", h.forClass(teacherPortal, h.DeleteAssessment))
+	r.Put("/assessments/{assessmentId}/scores", h.forClass(teacherPortal, h.RecordScores))
+}
+
+// RegisterRoutes registra as avaliações e notas no portal do professor
+func (h *GradebookHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/teacher/course-classes/{id}", func(r chi.Router) {
+		h.registerClassRoutes(r, true)
+	})
+}
+
+// RegisterAdminRoutes registra a gestão das avaliações, da regra de resultado e do fechamento (coordenação)
+func (h *GradebookHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Route("/course-classes/{id}", func(r chi.Router) {
+		h.registerClassRoutes(r, false)
+		r.Get("/grading-rule", h.forClass(false, h.GetGradingRule))
+		r.Put("/grading-rule", h.forClass(false, h.SetGradingRule))
+		r.Post("/final-results", h.forClass(false, h.FinalizeResults))
+	})
+}
@@ -0,0 +1,318 @@
+// backend/internal/api/handlers/guardian_portal_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/guardianportal"
+)
+
+// GuardianPortalHandler gerencia o portal dos responsáveis
+type GuardianPortalHandler struct {
+	service guardianportal.Service
+}
+
+// NewGuardianPortalHandler cria um novo handler
+func NewGuardianPortalHandler(service guardianportal.Service) *GuardianPortalHandler {
+	return &GuardianPortalHandler{service: service}
+}
+
+// ListDependents lista os alunos vinculados ao responsável logado
+// GET /api/v1/guardian/dependents
+func (h *GuardianPortalHandler) ListDependents(w http.ResponseWriter, r *http.Request) {
+	dependents, err := h.service.ListDependents(r.Context(), getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dependents)
+}
+
+// GetClasses lista as turmas do dependente com a frequência de cada uma
+// GET /api/v1/guardian/dependents/:studentId/classes
+func (h *GuardianPortalHandler) GetClasses(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := dependentID(w, r)
+	if !ok {
+		return
+	}
+
+	classes, err := h.service.GetClasses(r.Context(), getUserIDFromContext(r), studentID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(classes)
+}
+
+// GetAttendance retorna o histórico de presenças do dependente
+// Filtros opcionais: courseId, from e to (YYYY-MM-DD).
+// GET /api/v1/guardian/dependents/:studentId/attendance
+func (h *GuardianPortalHandler) GetAttendance(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := dependentID(w, r)
+	if !ok {
+		return
+	}
+
+	var filter guardianportal.AttendanceFilter
+	if value := r.URL.Query().Get("courseId"); value != "" {
+		courseID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			http.Error(w, "invalid courseId", http.StatusBadRequest)
+			return
+		}
+		id := uint(courseID)
+		filter.CourseID = &id
+	}
+	from, err := parseOptionalDate(r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, "invalid from, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	to, err := parseOptionalDate(r.URL.Query().Get("to"))
+	if err != nil {
+		http.Error(w, "invalid to, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	filter.From, filter.To = from, to
+
+	entries, err := h.service.GetAttendance(r.Context(), getUserIDFromContext(r), studentID, filter)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// GetAbsenceAlerts lista os alertas de faltas do dependente
+// GET /api/v1/guardian/dependents/:studentId/absence-alerts
+func (h *GuardianPortalHandler) GetAbsenceAlerts(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := dependentID(w, r)
+	if !ok {
+		return
+	}
+
+	alerts, err := h.service.GetAbsenceAlerts(r.Context(), getUserIDFromContext(r), studentID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(alerts)
+}
+
+// GetIncidents lista as ocorrências do dependente visíveis aos responsáveis
+// Apenas disciplinares, de saúde e de segurança que não foram canceladas.
+// GET /api/v1/guardian/dependents/:studentId/incidents
+func (h *GuardianPortalHandler) GetIncidents(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := dependentID(w, r)
+	if !ok {
+		return
+	}
+
+	incidents, err := h.service.GetIncidents(r.Context(), getUserIDFromContext(r), studentID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(incidents)
+}
+
+// GetPickups lista as saídas supervisionadas do dependente (quem retirou e quando)
+// GET /api/v1/guardian/dependents/:studentId/pickups
+func (h *GuardianPortalHandler) GetPickups(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := dependentID(w, r)
+	if !ok {
+		return
+	}
+
+	records, err := h.service.GetPickups(r.Context(), getUserIDFromContext(r), studentID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(records)
+}
+
+// ListConsents lista as autorizações pedidas ao responsável, pendentes primeiro
+// GET /api/v1/guardian/dependents/:studentId/consents
+func (h *GuardianPortalHandler) ListConsents(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := dependentID(w, r)
+	if !ok {
+		return
+	}
+
+	consents, err := h.service.ListConsents(r.Context(), getUserIDFromContext(r), studentID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(consents)
+}
+
+// RespondConsent registra a resposta do responsável a uma autorização
+// Body: {"type": "activities" | "enrollment", "enrollmentId": 1, "accepted": true}
+// POST /api/v1/guardian/dependents/:studentId/consents
+func (h *GuardianPortalHandler) RespondConsent(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := dependentID(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Type         models.ConsentType `json:"type"`
+		EnrollmentID *uint              `json:"enrollmentId"`
+		Accepted     *bool              `json:"accepted"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if body.Accepted == nil {
+		http.Error(w, "accepted is required", http.StatusBadRequest)
+		return
+	}
+
+	consent, err := h.service.RespondConsent(r.Context(), getUserIDFromContext(r), studentID, guardianportal.ConsentAnswer{
+		Type:         body.Type,
+		EnrollmentID: body.EnrollmentID,
+		Accepted:     *body.Accepted,
+		IPAddress:    r.RemoteAddr,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(consent)
+}
+
+// ListJustifications lista as justificativas de faltas do dependente
+// GET /api/v1/guardian/dependents/:studentId/justifications
+func (h *GuardianPortalHandler) ListJustifications(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := dependentID(w, r)
+	if !ok {
+		return
+	}
+
+	justifications, err := h.service.ListJustifications(r.Context(), getUserIDFromContext(r), studentID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(justifications)
+}
+
+// SubmitJustification envia uma justificativa de faltas do dependente para análise da coordenação
+// Body: {"courseId": 1, "startDate": "2026-03-02", "endDate": "2026-03-04", "reason": "...", "documentUrl": "..."}
+// POST /api/v1/guardian/dependents/:studentId/justifications
+func (h *GuardianPortalHandler) SubmitJustification(w http.ResponseWriter, r *http.Request) {
+	studentID, ok := dependentID(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		CourseID    uint   `json:"courseId"`
+		StartDate   string `json:"startDate"`
+		EndDate     string `json:"endDate"`
+		Reason      string `json:"reason"`
+		DocumentURL string `json:"documentUrl"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	startDate, err := parseOptionalDate(body.StartDate)
+	if err != nil || startDate == nil {
+		http.Error(w, "invalid startDate, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	endDate, err := parseOptionalDate(body.EndDate)
+	if err != nil || endDate == nil {
+		http.Error(w, "invalid endDate, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	justification, err := h.service.SubmitJustification(r.Context(), getUserIDFromContext(r), studentID, guardianportal.JustificationInput{
+		CourseID:    body.CourseID,
+		StartDate:   *startDate,
+		EndDate:     *endDate,
+		Reason:      body.Reason,
+		DocumentURL: body.DocumentURL,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(justification)
+}
+
+// dependentID reads the student ID from the URL, writing the error response otherwise
+func dependentID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "studentId"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid student id", http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *GuardianPortalHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, guardianportal.ErrNoDependents):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, guardianportal.ErrDependentNotFound):
+		http.Error(w, "student not found", http.StatusNotFound)
+	case errors.Is(err, guardianportal.ErrConsentNotFound):
+		http.Error(w, "consent not found", http.StatusNotFound)
+	case errors.Is(err, guardianportal.ErrInvalidJustification):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterRoutes registra as rotas do portal dos responsáveis
+// Todas as rotas de dependente conferem o vínculo em guardians.user_id.
+func (h *GuardianPortalHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/guardian", func(r chi.Router) {
+		r.Get("/dependents", h.ListDependents)
+
+		r.Route("/dependents/{studentId}", func(r chi.Router) {
+			r.Get("/classes", h.GetClasses)
+			r.Get("/attendance", h.GetAttendance)
+			r.Get("/absence-alerts", h.GetAbsenceAlerts)
+			r.Get("/incidents", h.GetIncidents)
+			r.Get("/pickups", h.GetPickups)
+			r.Get("/consents", h.ListConsents)
+			r.Post("/consents", h.RespondConsent)
+			r.Get("/justifications", h.ListJustifications)
+			r.Post("/justifications", h.SubmitJustification)
+		})
+	})
+}
@@ -3,7 +3,9 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/devdavidalonso/cecor/backend/internal/migrations"
 	"gorm.io/gorm"
@@ -11,74 +13,222 @@ import (
 
 // MigrationHandler gerencia endpoints de migração
 type MigrationHandler struct {
-	db *gorm.DB
+	db       *gorm.DB
+	migrator *migrations.Migrator
 }
 
 // NewMigrationHandler cria um novo handler
-func NewMigrationHandler(db *gorm.DB) *MigrationHandler {
-	return &MigrationHandler{db: db}
+func NewMigrationHandler(db *gorm.DB, migrator *migrations.Migrator) *MigrationHandler {
+	return &MigrationHandler{db: db, migrator: migrator}
 }
 
-// RunMigrations executa as migrações de schema
+// RunMigrations aplica as migrações de schema pendentes
 // POST /api/v1/admin/migrations/run
 func (h *MigrationHandler) RunMigrations(w http.ResponseWriter, r *http.Request) {
-	if err := migrations.MigrateCourseClassesAndSkills(h.db); err != nil {
+	applied, err := h.migrator.Up(r.Context())
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	versions := make([]int64, 0, len(applied))
+	for _, migration := range applied {
+		versions = append(versions, migration.Version)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
+	json.NewEncoder(w).Encode(map[string]interface{}{
 		"status":  "success",
 		"message": "Migrations executed successfully",
+		"applied": versions,
 	})
 }
 
-// RunDataMigration executa a migração de dados
+// RunDataMigration corrige em lotes os dados da transição Course → CourseClass.
+// Parâmetros opcionais: batchSize e maxBatches (para rodar aos poucos; chamadas seguintes continuam do checkpoint)
 // POST /api/v1/admin/migrations/data
 func (h *MigrationHandler) RunDataMigration(w http.ResponseWriter, r *http.Request) {
-	if err := migrations.MigrateDataToCourseClasses(h.db); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	var opts migrations.CourseClassFixOptions
+	if batchSize := r.URL.Query().Get("batchSize"); batchSize != "" {
+		value, err := strconv.Atoi(batchSize)
+		if err != nil || value < 1 {
+			http.Error(w, "Invalid batchSize", http.StatusBadRequest)
+			return
+		}
+		opts.BatchSize = value
+	}
+	if maxBatches := r.URL.Query().Get("maxBatches"); maxBatches != "" {
+		value, err := strconv.Atoi(maxBatches)
+		if err != nil || value < 0 {
+			http.Error(w, "Invalid maxBatches", http.StatusBadRequest)
+			return
+		}
+		opts.MaxBatches = value
+	}
+
+	result, err := migrations.FixCourseClassConsistency(h.db, opts)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "error",
+			"error":  err.Error(),
+			"result": result,
+		})
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
+	json.NewEncoder(w).Encode(map[string]interface{}{
 		"status":  "success",
 		"message": "Data migration executed successfully",
+		"result":  result,
 	})
 }
 
-// RollbackMigrations executa o rollback (CUIDADO!)
-// POST /api/v1/admin/migrations/rollback
-func (h *MigrationHandler) RollbackMigrations(w http.ResponseWriter, r *http.Request) {
-	// TODO: Adicionar confirmação extra ou backup automático
-	if err := migrations.RollbackCourseClassesMigration(h.db); err != nil {
+// CheckCourseClassConsistency lista os registros ainda vinculados apenas ao curso e as divergências
+// GET /api/v1/admin/migrations/course-classes/check
+func (h *MigrationHandler) CheckCourseClassConsistency(w http.ResponseWriter, r *http.Request) {
+	report, err := migrations.CheckCourseClassConsistency(h.db.WithContext(r.Context()))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// CheckCPFs lista os CPFs inválidos ou ainda com pontuação (usuários, responsáveis e contatos)
+// GET /api/v1/admin/migrations/cpf/check
+func (h *MigrationHandler) CheckCPFs(w http.ResponseWriter, r *http.Request) {
+	report, err := migrations.CheckCPFs(h.db.WithContext(r.Context()))
+	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{
+	json.NewEncoder(w).Encode(report)
+}
+
+// CheckFieldEncryption conta os dados sensíveis em texto claro ou cifrados com chaves antigas
+// GET /api/v1/admin/migrations/encryption/check
+func (h *MigrationHandler) CheckFieldEncryption(w http.ResponseWriter, r *http.Request) {
+	report, err := migrations.CheckFieldEncryption(h.db.WithContext(r.Context()))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// RotateFieldKeys recifra os dados sensíveis com a chave atual (após incluir uma nova chave).
+// Parâmetros opcionais: batchSize e reindex=true (recalcula os índices de CPF após trocar CPF_INDEX_KEY)
+// POST /api/v1/admin/migrations/encryption/rotate
+func (h *MigrationHandler) RotateFieldKeys(w http.ResponseWriter, r *http.Request) {
+	opts := migrations.FieldKeyOptions{Reindex: r.URL.Query().Get("reindex") == "true"}
+	if batchSize := r.URL.Query().Get("batchSize"); batchSize != "" {
+		value, err := strconv.Atoi(batchSize)
+		if err != nil || value < 1 {
+			http.Error(w, "Invalid batchSize", http.StatusBadRequest)
+			return
+		}
+		opts.BatchSize = value
+	}
+
+	result, err := migrations.RotateFieldKeys(h.db.WithContext(r.Context()), opts)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "error",
+			"error":  err.Error(),
+			"result": result,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
 		"status":  "success",
-		"message": "Rollback executed successfully",
+		"message": "Field encryption keys rotated successfully",
+		"result":  result,
+	})
+}
+
+// RollbackMigrations reverte as últimas migrações de schema aplicadas (CUIDADO!)
+// Parâmetro opcional: steps (padrão 1)
+// POST /api/v1/admin/migrations/rollback
+func (h *MigrationHandler) RollbackMigrations(w http.ResponseWriter, r *http.Request) {
+	steps := 1
+	if value := r.URL.Query().Get("steps"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			http.Error(w, "Invalid steps", http.StatusBadRequest)
+			return
+		}
+		steps = parsed
+	}
+
+	reverted, err := h.migrator.Down(r.Context(), steps)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, migrations.ErrIrreversible) {
+			status = http.StatusConflict
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	versions := make([]int64, 0, len(reverted))
+	for _, migration := range reverted {
+		versions = append(versions, migration.Version)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":   "success",
+		"message":  "Rollback executed successfully",
+		"reverted": versions,
 	})
 }
 
-// GetMigrationStatus retorna status das migrações
+// GetMigrationStatus retorna as versões aplicadas e pendentes
 // GET /api/v1/admin/migrations/status
 func (h *MigrationHandler) GetMigrationStatus(w http.ResponseWriter, r *http.Request) {
-	// Verificar se tabelas existem
-	var tables []string
-	h.db.Raw("SELECT tablename FROM pg_tables WHERE schemaname = 'public' AND tablename IN (?, ?, ?, ?, ?, ?, ?)",
-		"course_classes", "course_categories", "enrollment_course_classes",
-		"syllabus_topics", "skills", "teacher_skills", "teacher_availability").
-		Scan(&tables)
+	statuses, err := h.migrator.Status(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var current int64
+	applied := []migrations.Status{}
+	pending := []migrations.Status{}
+	consistent := true
+	for _, status := range statuses {
+		if status.ChecksumMismatch || status.Unknown {
+			consistent = false
+		}
+		if status.Applied {
+			applied = append(applied, status)
+			if status.Version > current {
+				current = status.Version
+			}
+		} else {
+			pending = append(pending, status)
+		}
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"tablesCreated": len(tables),
-		"tables":        tables,
-		"ready":         len(tables) >= 7,
+		"currentVersion": current,
+		"applied":        applied,
+		"pending":        pending,
+		"consistent":     consistent,
+		"ready":          len(pending) == 0 && consistent,
 	})
 }
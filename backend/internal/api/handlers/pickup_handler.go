@@ -0,0 +1,159 @@
+// backend/internal/api/handlers/pickup_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/api/middleware"
+	"github.com/devdavidalonso/cecor/backend/internal/service/pickups"
+)
+
+// PickupHandler gerencia a saída supervisionada dos alunos
+type PickupHandler struct {
+	service pickups.Service
+}
+
+// NewPickupHandler cria um novo handler
+func NewPickupHandler(service pickups.Service) *PickupHandler {
+	return &PickupHandler{service: service}
+}
+
+// GetRoster lista os alunos da aula com as pessoas cadastradas para buscá-los e as saídas já registradas
+// GET /api/v1/pickups/sessions/:sessionId
+func (h *PickupHandler) GetRoster(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.ParseUint(chi.URLParam(r, "sessionId"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid session id", http.StatusBadRequest)
+		return
+	}
+
+	roster, err := h.service.GetRoster(r.Context(), h.staff(r), uint(sessionID))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(roster)
+}
+
+// CheckOut registra quem retirou o aluno ao fim da aula
+// Body: {"studentId": 1, "guardianId": 2} | {"studentId": 1, "contactId": 3} |
+// {"studentId": 1, "personName": "...", "personDocument": "...", "notes": "..."} | {"studentId": 1, "noPickup": true}
+// POST /api/v1/pickups/sessions/:sessionId/checkouts
+func (h *PickupHandler) CheckOut(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := strconv.ParseUint(chi.URLParam(r, "sessionId"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid session id", http.StatusBadRequest)
+		return
+	}
+
+	var body struct {
+		StudentID      uint   `json:"studentId"`
+		GuardianID     *uint  `json:"guardianId"`
+		ContactID      *uint  `json:"contactId"`
+		PersonName     string `json:"personName"`
+		PersonDocument string `json:"personDocument"`
+		NoPickup       bool   `json:"noPickup"`
+		Notes          string `json:"notes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	record, err := h.service.CheckOut(r.Context(), h.staff(r), uint(sessionID), pickups.CheckoutInput{
+		StudentID:      body.StudentID,
+		GuardianID:     body.GuardianID,
+		ContactID:      body.ContactID,
+		PersonName:     body.PersonName,
+		PersonDocument: body.PersonDocument,
+		NoPickup:       body.NoPickup,
+		Notes:          body.Notes,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(record)
+}
+
+// ListPickups lista as saídas registradas para a coordenação
+// Filtros opcionais: studentId, from e to (YYYY-MM-DD) e exceptions=true.
+// GET /api/v1/admin/pickups
+func (h *PickupHandler) ListPickups(w http.ResponseWriter, r *http.Request) {
+	var filter pickups.Filter
+	if value := r.URL.Query().Get("studentId"); value != "" {
+		studentID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			http.Error(w, "invalid studentId", http.StatusBadRequest)
+			return
+		}
+		id := uint(studentID)
+		filter.StudentID = &id
+	}
+	from, err := parseOptionalDate(r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, "invalid from, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	to, err := parseOptionalDate(r.URL.Query().Get("to"))
+	if err != nil {
+		http.Error(w, "invalid to, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	filter.From, filter.To = from, to
+	filter.ExceptionsOnly = r.URL.Query().Get("exceptions") == "true"
+
+	entries, err := h.service.List(r.Context(), filter)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
+
+// staff identifies the user registering the pickups
+func (h *PickupHandler) staff(r *http.Request) pickups.Staff {
+	claims, _ := middleware.GetUserFromContext(r.Context())
+	return pickups.Staff{
+		UserID: getUserIDFromContext(r),
+		Admin:  middleware.IsAdmin(claims),
+	}
+}
+
+func (h *PickupHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, pickups.ErrSessionNotFound):
+		http.Error(w, "class session not found", http.StatusNotFound)
+	case errors.Is(err, pickups.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, pickups.ErrAlreadyCheckedOut):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, pickups.ErrStudentNotInSession), errors.Is(err, pickups.ErrInvalidCheckout):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterRoutes registra a lista de saída e o registro de retirada das aulas
+func (h *PickupHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/pickups/sessions/{sessionId}", h.GetRoster)
+	r.Post("/pickups/sessions/{sessionId}/checkouts", h.CheckOut)
+}
+
+// RegisterAdminRoutes registra o histórico de saídas para a coordenação
+func (h *PickupHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/pickups", h.ListPickups)
+}
@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/devdavidalonso/cecor/backend/internal/repository"
 	"github.com/devdavidalonso/cecor/backend/internal/service/reports"
 	"github.com/go-chi/chi/v5"
 )
@@ -107,3 +109,89 @@ func (h *ReportHandler) GetStudentAttendanceReport(w http.ResponseWriter, r *htt
 
 	json.NewEncoder(w).Encode(stats)
 }
+
+// GetOccupancyDashboard returns enrolled vs seats, waiting list, attendance and dropouts of every active class
+// GET /api/v1/reports/occupancy?categoryId=&startDate=&endDate=
+func (h *ReportHandler) GetOccupancyDashboard(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseOccupancyFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dashboard, err := h.service.GetOccupancyDashboard(r.Context(), filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(dashboard)
+}
+
+// GetClassOccupancy returns the occupancy of one class with its students
+// GET /api/v1/reports/occupancy/classes/{id}?startDate=&endDate=
+func (h *ReportHandler) GetClassOccupancy(w http.ResponseWriter, r *http.Request) {
+	classID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid class ID", http.StatusBadRequest)
+		return
+	}
+
+	filter, err := parseOccupancyFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.service.GetClassOccupancyDetail(r.Context(), uint(classID), filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if detail == nil {
+		http.Error(w, "Class not found or not active in the period", http.StatusNotFound)
+		return
+	}
+
+	json.NewEncoder(w).Encode(detail)
+}
+
+// parseOccupancyFilter reads categoryId, startDate and endDate; the period defaults to the current year
+func parseOccupancyFilter(r *http.Request) (repository.OccupancyFilter, error) {
+	now := time.Now()
+	filter := repository.OccupancyFilter{
+		StartDate: time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.Local),
+		EndDate:   now,
+	}
+
+	if categoryID := r.URL.Query().Get("categoryId"); categoryID != "" {
+		id, err := strconv.Atoi(categoryID)
+		if err != nil {
+			return filter, errors.New("Invalid category ID")
+		}
+		value := uint(id)
+		filter.CategoryID = &value
+	}
+
+	if startDateStr := r.URL.Query().Get("startDate"); startDateStr != "" {
+		startDate, err := time.Parse("2006-01-02", startDateStr)
+		if err != nil {
+			return filter, errors.New("Invalid start date format (YYYY-MM-DD)")
+		}
+		filter.StartDate = startDate
+	}
+
+	if endDateStr := r.URL.Query().Get("endDate"); endDateStr != "" {
+		endDate, err := time.Parse("2006-01-02", endDateStr)
+		if err != nil {
+			return filter, errors.New("Invalid end date format (YYYY-MM-DD)")
+		}
+		filter.EndDate = endDate
+	}
+
+	if filter.EndDate.Before(filter.StartDate) {
+		return filter, errors.New("endDate must not be before startDate")
+	}
+
+	return filter, nil
+}
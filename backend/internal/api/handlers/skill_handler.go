@@ -3,22 +3,26 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/substitutes"
 	"github.com/go-chi/chi/v5"
 	"gorm.io/gorm"
 )
 
 // SkillHandler gerencia skills e habilidades dos professores
 type SkillHandler struct {
-	db *gorm.DB
+	db          *gorm.DB
+	substitutes substitutes.Service
 }
 
 // NewSkillHandler cria um novo handler
-func NewSkillHandler(db *gorm.DB) *SkillHandler {
-	return &SkillHandler{db: db}
+func NewSkillHandler(db *gorm.DB, substitutesService substitutes.Service) *SkillHandler {
+	return &SkillHandler{db: db, substitutes: substitutesService}
 }
 
 // ListSkills lista todas as skills disponíveis
@@ -153,8 +157,9 @@ func (h *SkillHandler) RemoveTeacherSkill(w http.ResponseWriter, r *http.Request
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// FindSubstituteTeachers busca professores substitutos compatíveis
-// GET /api/v1/course-classes/:id/substitutes
+// FindSubstituteTeachers busca professores substitutos compatíveis, ranqueados e com a explicação da pontuação.
+// Sem sessionId/date, considera a próxima aula da turma.
+// GET /api/v1/course-classes/:id/substitutes?sessionId=&date=YYYY-MM-DD
 func (h *SkillHandler) FindSubstituteTeachers(w http.ResponseWriter, r *http.Request) {
 	classID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
 	if err != nil {
@@ -162,70 +167,135 @@ func (h *SkillHandler) FindSubstituteTeachers(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Buscar turma
-	var class models.CourseClass
-	if err := h.db.Preload("Course").First(&class, classID).Error; err != nil {
-		http.Error(w, "class not found", http.StatusNotFound)
-		return
+	var opts substitutes.SlotOptions
+	if sessionID := r.URL.Query().Get("sessionId"); sessionID != "" {
+		id, err := strconv.ParseUint(sessionID, 10, 32)
+		if err != nil {
+			http.Error(w, "invalid sessionId", http.StatusBadRequest)
+			return
+		}
+		value := uint(id)
+		opts.SessionID = &value
+	}
+	if date := r.URL.Query().Get("date"); date != "" {
+		parsed, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			http.Error(w, "invalid date, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		opts.Date = &parsed
 	}
 
-	// Buscar professores ativos
-	var teachers []models.Teacher
-	if err := h.db.Where("active = ?", true).
-		Preload("User").
-		Preload("Skills").
-		Preload("Skills.Skill").
-		Preload("Availability").
-		Find(&teachers).Error; err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	result, err := h.substitutes.FindCandidates(r.Context(), uint(classID), opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, substitutes.ErrClassNotFound):
+			http.Error(w, "class not found", http.StatusNotFound)
+		case errors.Is(err, substitutes.ErrSessionNotFound):
+			http.Error(w, "session not found", http.StatusNotFound)
+		case errors.Is(err, substitutes.ErrNoUpcomingSession):
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
 
-	// Calcular score para cada professor
-	type SubstituteCandidate struct {
-		models.Teacher
-		Score       int    `json:"score"`
-		SkillMatch  string `json:"skillMatch"`
-		HasConflict bool   `json:"hasConflict"`
-		Available   bool   `json:"available"`
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// teacherSuggestionRequest is the planned schedule of a new class; dates are YYYY-MM-DD
+type teacherSuggestionRequest struct {
+	CourseID  uint   `json:"courseId"`
+	WeekDays  string `json:"weekDays"` // "1,3,5"
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+}
+
+// SuggestClassTeachers sugere professores para uma nova turma pelas skills, cobertura da disponibilidade e carga atual
+// POST /api/v1/course-classes/teacher-suggestions
+func (h *SkillHandler) SuggestClassTeachers(w http.ResponseWriter, r *http.Request) {
+	var body teacherSuggestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
 	}
 
-	var candidates []SubstituteCandidate
+	input := substitutes.ScheduleInput{
+		CourseID:  body.CourseID,
+		WeekDays:  body.WeekDays,
+		StartTime: body.StartTime,
+		EndTime:   body.EndTime,
+	}
+	startDate, err := parseOptionalDate(body.StartDate)
+	if err != nil {
+		http.Error(w, "invalid startDate, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if startDate != nil {
+		input.StartDate = *startDate
+	}
+	endDate, err := parseOptionalDate(body.EndDate)
+	if err != nil {
+		http.Error(w, "invalid endDate, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if endDate != nil {
+		input.EndDate = *endDate
+	}
 
-	for _, teacher := range teachers {
-		// Pular professor padrão da turma
-		if class.DefaultTeacherID != nil && teacher.ID == *class.DefaultTeacherID {
-			continue
-		}
+	h.suggestTeachers(w, r, input)
+}
 
-		score := 0
-		skillMatch := "none"
+// SuggestTeachersForClass sugere professores para uma turma existente (ignora a própria turma na carga)
+// GET /api/v1/course-classes/:id/teacher-suggestions
+func (h *SkillHandler) SuggestTeachersForClass(w http.ResponseWriter, r *http.Request) {
+	classID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid class id", http.StatusBadRequest)
+		return
+	}
 
-		// Verificar skills compatíveis (simplificado - na prática verificaria por categoria)
-		for range teacher.Skills {
-			score += 10 // Pontos por cada skill
-			if skillMatch == "none" {
-				skillMatch = "partial"
-			}
+	var class models.CourseClass
+	if err := h.db.WithContext(r.Context()).First(&class, classID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			http.Error(w, "class not found", http.StatusNotFound)
+			return
 		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
-		// Verificar disponibilidade (simplificado)
-		available := len(teacher.Availability) > 0
-		if available {
-			score += 20
-		}
+	h.suggestTeachers(w, r, substitutes.ScheduleInput{
+		CourseID:       class.CourseID,
+		WeekDays:       class.WeekDays,
+		StartTime:      class.StartTime,
+		EndTime:        class.EndTime,
+		StartDate:      class.StartDate,
+		EndDate:        class.EndDate,
+		ExcludeClassID: &class.ID,
+	})
+}
 
-		candidates = append(candidates, SubstituteCandidate{
-			Teacher:     teacher,
-			Score:       score,
-			SkillMatch:  skillMatch,
-			HasConflict: false, // TODO: verificar conflitos reais
-			Available:   available,
-		})
+func (h *SkillHandler) suggestTeachers(w http.ResponseWriter, r *http.Request, input substitutes.ScheduleInput) {
+	suggestions, err := h.substitutes.SuggestTeachers(r.Context(), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, substitutes.ErrCourseNotFound):
+			http.Error(w, "course not found", http.StatusNotFound)
+		case errors.Is(err, substitutes.ErrInvalidSchedule):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(candidates)
+	json.NewEncoder(w).Encode(suggestions)
 }
 
 // RegisterRoutes registra as rotas de skills
@@ -249,4 +319,8 @@ func (h *SkillHandler) RegisterRoutes(r chi.Router) {
 
 	// Substitutos
 	r.Get("/course-classes/{id}/substitutes", h.FindSubstituteTeachers)
+
+	// Sugestão de professor para turmas
+	r.Post("/course-classes/teacher-suggestions", h.SuggestClassTeachers)
+	r.Get("/course-classes/{id}/teacher-suggestions", h.SuggestTeachersForClass)
 }
@@ -0,0 +1,151 @@
+// backend/internal/api/handlers/student_duplicate_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/service/duplicates"
+)
+
+// StudentDuplicateHandler handles the detection and merge of duplicate student registrations
+type StudentDuplicateHandler struct {
+	service duplicates.Service
+}
+
+// NewStudentDuplicateHandler creates a new handler
+func NewStudentDuplicateHandler(service duplicates.Service) *StudentDuplicateHandler {
+	return &StudentDuplicateHandler{service: service}
+}
+
+// dismissBody is the pair marked as not duplicated
+type dismissBody struct {
+	StudentIDs []uint `json:"studentIds"`
+}
+
+// ListDuplicates lista os pares de alunos que podem ser a mesma pessoa
+// Critérios: CPF, email, telefone ou nome normalizado com a mesma data de nascimento.
+// GET /api/v1/admin/students/duplicates
+func (h *StudentDuplicateHandler) ListDuplicates(w http.ResponseWriter, r *http.Request) {
+	candidates, err := h.service.Detect(r.Context())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(candidates)
+}
+
+// DismissDuplicate marca um par como "não é duplicado" (ex: irmãos com o mesmo telefone)
+// POST /api/v1/admin/students/duplicates/dismissals
+func (h *StudentDuplicateHandler) DismissDuplicate(w http.ResponseWriter, r *http.Request) {
+	var body dismissBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if len(body.StudentIDs) != 2 {
+		http.Error(w, "studentIds must have exactly two students", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Dismiss(r.Context(), body.StudentIDs[0], body.StudentIDs[1], getUserIDFromContext(r)); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MergeStudents funde o cadastro duplicado no cadastro mantido
+// Matrículas, presenças, responsáveis, documentos, observações, ocorrências e respostas de
+// entrevista passam para o aluno mantido (estas após a gravação, com nova tentativa periódica em caso
+// de falha); o duplicado é desativado e a fusão fica registrada.
+// Matrículas no mesmo curso em períodos sobrepostos, presenças no mesmo dia ou saídas da mesma aula
+// retornam 409 com a lista.
+// POST /api/v1/admin/students/merges
+func (h *StudentDuplicateHandler) MergeStudents(w http.ResponseWriter, r *http.Request) {
+	var input duplicates.MergeInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	merge, err := h.service.Merge(r.Context(), input, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(merge)
+}
+
+// ListMerges lista as fusões realizadas (?studentId= filtra pelas que envolvem o aluno)
+// GET /api/v1/admin/students/merges
+func (h *StudentDuplicateHandler) ListMerges(w http.ResponseWriter, r *http.Request) {
+	var studentID uint64
+	if value := r.URL.Query().Get("studentId"); value != "" {
+		var err error
+		if studentID, err = strconv.ParseUint(value, 10, 32); err != nil {
+			http.Error(w, "invalid studentId", http.StatusBadRequest)
+			return
+		}
+	}
+
+	merges, err := h.service.ListMerges(r.Context(), uint(studentID))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(merges)
+}
+
+// GetMerge retorna uma fusão com os dados do cadastro removido e os registros transferidos
+// GET /api/v1/admin/students/merges/:id
+func (h *StudentDuplicateHandler) GetMerge(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	merge, err := h.service.GetMerge(r.Context(), uint(id))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(merge)
+}
+
+func (h *StudentDuplicateHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, duplicates.ErrStudentNotFound), errors.Is(err, duplicates.ErrMergeNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, duplicates.ErrInvalidMerge):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, duplicates.ErrMergeConflict):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterAdminRoutes registra a detecção e a fusão de duplicados (sob /admin)
+func (h *StudentDuplicateHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/students/duplicates", h.ListDuplicates)
+	r.Post("/students/duplicates/dismissals", h.DismissDuplicate)
+	r.Get("/students/merges", h.ListMerges)
+	r.Post("/students/merges", h.MergeStudents)
+	r.Get("/students/merges/{id}", h.GetMerge)
+}
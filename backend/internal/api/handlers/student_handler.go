@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/devdavidalonso/cecor/backend/internal/api/middleware"
 	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/addresses"
 	"github.com/devdavidalonso/cecor/backend/internal/service/students"
 	"github.com/devdavidalonso/cecor/backend/pkg/errors"
 )
@@ -185,7 +187,9 @@ func (h *StudentHandler) CreateStudent(w http.ResponseWriter, r *http.Request) {
 			err.Error() == "phone is required" ||
 			err.Error() == "birth date is required" ||
 			err.Error() == "a student with this email already exists" ||
-			err.Error() == "a student with this CPF already exists" {
+			err.Error() == "a student with this CPF already exists" ||
+			err.Error() == "invalid CPF" ||
+			stderrors.Is(err, addresses.ErrInvalidAddress) {
 			errors.RespondWithError(w, http.StatusBadRequest, err.Error())
 		} else {
 			errors.RespondWithError(w, http.StatusInternalServerError, err.Error())
@@ -236,7 +240,10 @@ func (h *StudentHandler) UpdateStudent(w http.ResponseWriter, r *http.Request) {
 		if err.Error() == "student not found" {
 			errors.RespondWithError(w, http.StatusNotFound, err.Error())
 		} else if err.Error() == "another student with this email already exists" ||
-			err.Error() == "another student with this CPF already exists" {
+			err.Error() == "another student with this CPF already exists" ||
+			err.Error() == "invalid CPF" ||
+			err.Error() == "status changes require a reason; use the student status endpoint" ||
+			stderrors.Is(err, addresses.ErrInvalidAddress) {
 			errors.RespondWithError(w, http.StatusBadRequest, err.Error())
 		} else {
 			errors.RespondWithError(w, http.StatusInternalServerError, err.Error())
@@ -365,7 +372,8 @@ func (h *StudentHandler) AddGuardian(w http.ResponseWriter, r *http.Request) {
 			errors.RespondWithError(w, http.StatusNotFound, err.Error())
 		} else if err.Error() == "guardian name is required" ||
 			err.Error() == "relationship is required" ||
-			err.Error() == "maximum of 3 guardians per student reached" {
+			err.Error() == "maximum of 3 guardians per student reached" ||
+			err.Error() == "invalid CPF" {
 			errors.RespondWithError(w, http.StatusBadRequest, err.Error())
 		} else {
 			errors.RespondWithError(w, http.StatusInternalServerError, err.Error())
@@ -414,6 +422,8 @@ func (h *StudentHandler) UpdateGuardian(w http.ResponseWriter, r *http.Request)
 	if err != nil {
 		if err.Error() == "guardian not found" {
 			errors.RespondWithError(w, http.StatusNotFound, err.Error())
+		} else if err.Error() == "invalid CPF" {
+			errors.RespondWithError(w, http.StatusBadRequest, err.Error())
 		} else {
 			errors.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		}
@@ -494,65 +504,6 @@ func (h *StudentHandler) GetDocuments(w http.ResponseWriter, r *http.Request) {
 	errors.RespondWithJSON(w, http.StatusOK, documents)
 }
 
-// AddDocument adds a document to a student
-// @Summary Add document
-// @Description Adds a new document to a student
-// @Tags students,documents
-// @Accept json
-// @Produce json
-// @Param id path int true "Student ID"
-// @Param document body models.Document true "Document data"
-// @Success 201 {object} models.Document
-// @Failure 400 {object} errors.AppError
-// @Failure 404 {object} errors.AppError
-// @Failure 500 {object} errors.AppError
-// @Router /api/v1/students/{id}/documents [post]
-func (h *StudentHandler) AddDocument(w http.ResponseWriter, r *http.Request) {
-	// Get ID from URL
-	idStr := chi.URLParam(r, "id")
-	id, err := strconv.ParseUint(idStr, 10, 64)
-	if err != nil {
-		errors.RespondWithError(w, http.StatusBadRequest, "Invalid ID")
-		return
-	}
-
-	var document models.Document
-
-	// Decode request body
-	if err := json.NewDecoder(r.Body).Decode(&document); err != nil {
-		errors.RespondWithError(w, http.StatusBadRequest, "Invalid data format")
-		return
-	}
-
-	// Ensure student ID is set correctly
-	document.StudentID = uint(id)
-
-	// Get user from context for document.UploadedBy
-	userClaims, ok := middleware.GetUserFromContext(r.Context())
-	if !ok {
-		errors.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
-		return
-	}
-	document.UploadedByID = uint(userClaims.UserID)
-
-	// Call service
-	err = h.studentService.AddDocument(r.Context(), &document)
-	if err != nil {
-		if err.Error() == "student not found" {
-			errors.RespondWithError(w, http.StatusNotFound, err.Error())
-		} else if err.Error() == "document name is required" ||
-			err.Error() == "document type is required" ||
-			err.Error() == "document path is required" {
-			errors.RespondWithError(w, http.StatusBadRequest, err.Error())
-		} else {
-			errors.RespondWithError(w, http.StatusInternalServerError, err.Error())
-		}
-		return
-	}
-
-	errors.RespondWithJSON(w, http.StatusCreated, document)
-}
-
 // DeleteDocument removes a document
 // @Summary Remove document
 // @Description Removes a document from the system
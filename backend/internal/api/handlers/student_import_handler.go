@@ -0,0 +1,111 @@
+// backend/internal/api/handlers/student_import_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/service/studentimport"
+)
+
+// maxImportBytes limits the spreadsheet size
+const maxImportBytes = 10 << 20
+
+// StudentImportHandler handles the bulk student import from spreadsheets
+type StudentImportHandler struct {
+	service studentimport.Service
+}
+
+// NewStudentImportHandler creates a new handler
+func NewStudentImportHandler(service studentimport.Service) *StudentImportHandler {
+	return &StudentImportHandler{service: service}
+}
+
+// ImportStudents importa alunos de uma planilha CSV ou XLSX (multipart: file, dryRun, skipInvalid)
+// Por padrão só valida (dryRun=true) e devolve o relatório linha a linha; com dryRun=false cria
+// os alunos, e as contas de acesso e os emails de boas-vindas entram na fila de envio.
+// POST /api/v1/admin/students/imports
+func (h *StudentImportHandler) ImportStudents(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes+multipartOverhead)
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, fmt.Sprintf("spreadsheet exceeds the size limit of %d MB", maxImportBytes>>20), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "expected a multipart/form-data body", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "the file field is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	rows, err := studentimport.ReadSheet(header.Filename, file)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	options := studentimport.Options{
+		FileName:    header.Filename,
+		DryRun:      r.FormValue("dryRun") != "false",
+		SkipInvalid: r.FormValue("skipInvalid") == "true",
+	}
+	report, err := h.service.Import(r.Context(), rows, options, getUserIDFromContext(r))
+	if err != nil {
+		if errors.Is(err, studentimport.ErrInvalidRows) && report != nil {
+			// O relatório mostra o que corrigir; nada foi criado
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(report)
+			return
+		}
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !report.DryRun {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+// ListImports lista as importações realizadas com o andamento da criação das contas
+// GET /api/v1/admin/students/imports
+func (h *StudentImportHandler) ListImports(w http.ResponseWriter, r *http.Request) {
+	imports, err := h.service.ListImports(r.Context())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(imports)
+}
+
+func (h *StudentImportHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, studentimport.ErrInvalidFile):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, studentimport.ErrInvalidRows):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterAdminRoutes registra a importação de alunos (sob /admin)
+func (h *StudentImportHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/students/imports", h.ListImports)
+	r.Post("/students/imports", h.ImportStudents)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/pkg/cpf"
 	"github.com/go-chi/chi/v5"
 	"gorm.io/gorm"
 )
@@ -485,7 +486,7 @@ func (h *StudentPortalHandler) GetProfile(w http.ResponseWriter, r *http.Request
 		Name:         student.User.Name,
 		Email:        student.User.Email,
 		Phone:        student.User.Phone,
-		CPF:          student.User.CPF,
+		CPF:          cpf.Format(student.User.CPF),
 		BirthDate:    birthDateStr,
 		Address:      student.User.Address,
 		SpecialNeeds: student.SpecialNeeds,
@@ -0,0 +1,230 @@
+// backend/internal/api/handlers/student_status_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/studentstatus"
+)
+
+// StudentStatusHandler gerencia a situação dos alunos (ativo, inativo, suspenso)
+type StudentStatusHandler struct {
+	service studentstatus.Service
+}
+
+// NewStudentStatusHandler cria um novo handler
+func NewStudentStatusHandler(service studentstatus.Service) *StudentStatusHandler {
+	return &StudentStatusHandler{service: service}
+}
+
+// reasonBody carries the mandatory reason of a change
+type reasonBody struct {
+	Reason string `json:"reason"`
+}
+
+// ChangeStatus altera a situação do aluno manualmente; o motivo é obrigatório
+// Body: {"status": "active" | "inactive", "reason": "..."}
+// POST /api/v1/admin/students/:id/status
+func (h *StudentStatusHandler) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var body struct {
+		Status models.StudentStatus `json:"status"`
+		Reason string               `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	change, err := h.service.ChangeStatus(r.Context(), uint(id), body.Status, body.Reason, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(change)
+}
+
+// GetHistory lista as mudanças de situação do aluno, da mais recente para a mais antiga
+// GET /api/v1/admin/students/:id/status-history
+func (h *StudentStatusHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	history, err := h.service.History(r.Context(), uint(id))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+// RevertChange desfaz a última mudança de situação do aluno
+// Body: {"reason": "..."}
+// POST /api/v1/admin/students/status-changes/:id/revert
+func (h *StudentStatusHandler) RevertChange(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var body reasonBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	change, err := h.service.Revert(r.Context(), uint(id), body.Reason, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(change)
+}
+
+// ListSuspensions lista as suspensões do aluno
+// GET /api/v1/admin/students/:id/suspensions
+func (h *StudentStatusHandler) ListSuspensions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	suspensions, err := h.service.ListSuspensions(r.Context(), uint(id))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suspensions)
+}
+
+// CreateSuspension suspende o aluno envolvido em uma ocorrência pelo período informado
+// Body: {"incidentId": 1, "startDate": "2026-03-02", "endDate": "2026-03-06", "reason": "..."}
+// POST /api/v1/admin/students/suspensions
+func (h *StudentStatusHandler) CreateSuspension(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		IncidentID uint   `json:"incidentId"`
+		StartDate  string `json:"startDate"`
+		EndDate    string `json:"endDate"`
+		Reason     string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	startDate, err := parseOptionalDate(body.StartDate)
+	if err != nil || startDate == nil {
+		http.Error(w, "invalid startDate, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	endDate, err := parseOptionalDate(body.EndDate)
+	if err != nil || endDate == nil {
+		http.Error(w, "invalid endDate, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	suspension, err := h.service.Suspend(r.Context(), studentstatus.SuspensionInput{
+		IncidentID: body.IncidentID,
+		StartDate:  *startDate,
+		EndDate:    *endDate,
+		Reason:     body.Reason,
+	}, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(suspension)
+}
+
+// RevokeSuspension revoga uma suspensão agendada ou em andamento
+// Body: {"reason": "..."}
+// POST /api/v1/admin/students/suspensions/:id/revoke
+func (h *StudentStatusHandler) RevokeSuspension(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var body reasonBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	suspension, err := h.service.RevokeSuspension(r.Context(), uint(id), body.Reason, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suspension)
+}
+
+// RunRules aplica agora as regras automáticas (normalmente executadas uma vez por dia)
+// POST /api/v1/admin/students/status-rules/run
+func (h *StudentStatusHandler) RunRules(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.RunRules(r.Context())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (h *StudentStatusHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, studentstatus.ErrStudentNotFound),
+		errors.Is(err, studentstatus.ErrChangeNotFound),
+		errors.Is(err, studentstatus.ErrSuspensionNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, studentstatus.ErrNotReversible):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, studentstatus.ErrInvalidChange), errors.Is(err, studentstatus.ErrInvalidSuspension):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterAdminRoutes registra a situação, o histórico e as suspensões dos alunos (sob /admin)
+func (h *StudentStatusHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Post("/students/{id}/status", h.ChangeStatus)
+	r.Get("/students/{id}/status-history", h.GetHistory)
+	r.Get("/students/{id}/suspensions", h.ListSuspensions)
+	r.Post("/students/status-changes/{id}/revert", h.RevertChange)
+	r.Post("/students/suspensions", h.CreateSuspension)
+	r.Post("/students/suspensions/{id}/revoke", h.RevokeSuspension)
+	r.Post("/students/status-rules/run", h.RunRules)
+}
@@ -0,0 +1,96 @@
+// backend/internal/api/handlers/student_timeline_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/api/middleware"
+	"github.com/devdavidalonso/cecor/backend/internal/service/timeline"
+)
+
+// StudentTimelineHandler handles the unified timeline of a student
+type StudentTimelineHandler struct {
+	service timeline.Service
+}
+
+// NewStudentTimelineHandler creates a new handler
+func NewStudentTimelineHandler(service timeline.Service) *StudentTimelineHandler {
+	return &StudentTimelineHandler{service: service}
+}
+
+// GetTimeline retorna a linha do tempo do aluno, do evento mais recente ao mais antigo
+// Reúne matrículas, faltas e justificativas, alertas de faltas, ocorrências, observações,
+// entrevistas, documentos, notificações e mudanças de situação. types filtra por tipo
+// (separados por vírgula); from e to (YYYY-MM-DD, inclusive) limitam o período.
+// GET /api/v1/students/:id/timeline?types=&from=&to=&page=&pageSize=
+func (h *StudentTimelineHandler) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	studentID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid student id", http.StatusBadRequest)
+		return
+	}
+
+	values := r.URL.Query()
+	var query timeline.Query
+	if types := values.Get("types"); types != "" {
+		query.Types = strings.Split(types, ",")
+	}
+	if query.From, err = parseOptionalDate(values.Get("from")); err != nil {
+		http.Error(w, "invalid from, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if query.To, err = parseOptionalDate(values.Get("to")); err != nil {
+		http.Error(w, "invalid to, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if query.To != nil {
+		// O dia final entra inteiro no período
+		end := query.To.AddDate(0, 0, 1)
+		query.To = &end
+	}
+	if page := values.Get("page"); page != "" {
+		if query.Page, err = strconv.Atoi(page); err != nil {
+			http.Error(w, "invalid page", http.StatusBadRequest)
+			return
+		}
+	}
+	if pageSize := values.Get("pageSize"); pageSize != "" {
+		if query.PageSize, err = strconv.Atoi(pageSize); err != nil {
+			http.Error(w, "invalid pageSize", http.StatusBadRequest)
+			return
+		}
+	}
+
+	claims, _ := middleware.GetUserFromContext(r.Context())
+	viewer := timeline.Viewer{
+		UserID: getUserIDFromContext(r),
+		Admin:  middleware.IsAdmin(claims),
+	}
+	page, err := h.service.Get(r.Context(), viewer, uint(studentID), query)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+func (h *StudentTimelineHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, timeline.ErrStudentNotFound):
+		http.Error(w, "student not found", http.StatusNotFound)
+	case errors.Is(err, timeline.ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, timeline.ErrInvalidQuery):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
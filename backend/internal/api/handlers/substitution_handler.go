@@ -0,0 +1,329 @@
+// backend/internal/api/handlers/substitution_handler.go
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/service/notifications"
+	"github.com/devdavidalonso/cecor/backend/internal/service/substitutions"
+)
+
+// SubstitutionHandler handles substitution requests (coordination, teacher portal and Telegram)
+type SubstitutionHandler struct {
+	service       substitutions.Service
+	telegram      *notifications.TelegramService
+	webhookSecret string
+}
+
+// NewSubstitutionHandler creates a new handler. telegram may be nil when the bot is not configured.
+func NewSubstitutionHandler(service substitutions.Service, telegram *notifications.TelegramService, webhookSecret string) *SubstitutionHandler {
+	return &SubstitutionHandler{service: service, telegram: telegram, webhookSecret: webhookSecret}
+}
+
+// substitutionRequestBody is the coordinator request; dates are YYYY-MM-DD
+type substitutionRequestBody struct {
+	CourseClassID       uint   `json:"courseClassId"`
+	SessionID           *uint  `json:"sessionId"`
+	StartDate           string `json:"startDate"`
+	EndDate             string `json:"endDate"`
+	SubstituteTeacherID *uint  `json:"substituteTeacherId"`
+	Notes               string `json:"notes"`
+}
+
+// substitutionResponseBody is the substitute answer
+type substitutionResponseBody struct {
+	Notes string `json:"notes"`
+}
+
+// RequestSubstitution solicita um substituto para uma aula ou um período
+// POST /api/v1/admin/substitutions
+func (h *SubstitutionHandler) RequestSubstitution(w http.ResponseWriter, r *http.Request) {
+	var body substitutionRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	input := substitutions.RequestInput{
+		CourseClassID:       body.CourseClassID,
+		SessionID:           body.SessionID,
+		SubstituteTeacherID: body.SubstituteTeacherID,
+		Notes:               body.Notes,
+		RequestedByID:       getUserIDFromContext(r),
+	}
+	var err error
+	if input.StartDate, err = parseOptionalDate(body.StartDate); err != nil {
+		http.Error(w, "invalid startDate, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if input.EndDate, err = parseOptionalDate(body.EndDate); err != nil {
+		http.Error(w, "invalid endDate, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	substitution, err := h.service.Request(r.Context(), input)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(substitution)
+}
+
+// ListSubstitutions lista as substituições
+// GET /api/v1/admin/substitutions?status=&courseClassId=&teacherId=
+func (h *SubstitutionHandler) ListSubstitutions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := substitutions.Filter{Status: q.Get("status")}
+
+	if courseClassID := q.Get("courseClassId"); courseClassID != "" {
+		id, err := strconv.ParseUint(courseClassID, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid courseClassId", http.StatusBadRequest)
+			return
+		}
+		value := uint(id)
+		filter.CourseClassID = &value
+	}
+
+	if teacherID := q.Get("teacherId"); teacherID != "" {
+		id, err := strconv.ParseUint(teacherID, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid teacherId", http.StatusBadRequest)
+			return
+		}
+		value := uint(id)
+		filter.TeacherID = &value
+	}
+
+	list, err := h.service.List(r.Context(), filter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// GetSubstitution obtém uma substituição
+// GET /api/v1/admin/substitutions/:id
+func (h *SubstitutionHandler) GetSubstitution(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	substitution, err := h.service.Get(r.Context(), uint(id))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(substitution)
+}
+
+// CancelSubstitution cancela uma substituição pendente ou confirmada
+// POST /api/v1/admin/substitutions/:id/cancel
+func (h *SubstitutionHandler) CancelSubstitution(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	substitution, err := h.service.Cancel(r.Context(), uint(id))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(substitution)
+}
+
+// EscalateExpired passa ao próximo candidato os pedidos sem resposta no prazo
+// POST /api/v1/admin/substitutions/escalate
+func (h *SubstitutionHandler) EscalateExpired(w http.ResponseWriter, r *http.Request) {
+	escalated, err := h.service.EscalateExpired(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int{"escalated": escalated})
+}
+
+// ListMySubstitutions lista os pedidos de substituição do professor logado
+// GET /api/v1/teacher/substitutions?status=pending
+func (h *SubstitutionHandler) ListMySubstitutions(w http.ResponseWriter, r *http.Request) {
+	list, err := h.service.ListForUser(r.Context(), getUserIDFromContext(r), r.URL.Query().Get("status"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// AcceptSubstitution aceita um pedido de substituição
+// POST /api/v1/teacher/substitutions/:id/accept
+func (h *SubstitutionHandler) AcceptSubstitution(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, true)
+}
+
+// DeclineSubstitution recusa um pedido de substituição
+// POST /api/v1/teacher/substitutions/:id/decline
+func (h *SubstitutionHandler) DeclineSubstitution(w http.ResponseWriter, r *http.Request) {
+	h.respond(w, r, false)
+}
+
+func (h *SubstitutionHandler) respond(w http.ResponseWriter, r *http.Request, accept bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var body substitutionResponseBody
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	substitution, err := h.service.Respond(r.Context(), uint(id), getUserIDFromContext(r), accept, body.Notes)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(substitution)
+}
+
+// TelegramWebhook recebe os cliques nos botões Aceitar/Recusar enviados pelo bot
+// POST /api/v1/telegram/webhook
+func (h *SubstitutionHandler) TelegramWebhook(w http.ResponseWriter, r *http.Request) {
+	if !h.telegram.IsConfigured() || h.webhookSecret == "" {
+		http.Error(w, "telegram webhook not configured", http.StatusNotFound)
+		return
+	}
+	secret := r.Header.Get("X-Telegram-Bot-Api-Secret-Token")
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(h.webhookSecret)) != 1 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var update notifications.Update
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// O Telegram só precisa de 200; mensagens e outros botões são ignorados
+	callback := update.CallbackQuery
+	if callback == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	substitutionID, accept, ok := notifications.ParseSubstitutionCallbackData(callback.Data)
+	if !ok {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	chatID := callback.From.ID
+	if callback.Message != nil {
+		chatID = callback.Message.Chat.ID
+	}
+
+	answer := "Substituição confirmada. Obrigado!"
+	if !accept {
+		answer = "Substituição recusada."
+	}
+	if _, err := h.service.RespondByTelegram(r.Context(), strconv.FormatInt(chatID, 10), substitutionID, accept); err != nil {
+		switch {
+		case errors.Is(err, substitutions.ErrNotPending):
+			answer = "Este pedido já foi encerrado."
+		case errors.Is(err, substitutions.ErrNotSubstitute), errors.Is(err, substitutions.ErrNotFound):
+			answer = "Pedido não encontrado para este usuário."
+		default:
+			answer = "Não foi possível registrar a resposta. Tente pelo portal."
+		}
+	}
+	h.telegram.AnswerCallbackQuery(callback.ID, answer)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// parseOptionalDate parses a YYYY-MM-DD value, returning nil when empty
+func parseOptionalDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func (h *SubstitutionHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, substitutions.ErrNotFound):
+		http.Error(w, "substitution not found", http.StatusNotFound)
+	case errors.Is(err, substitutions.ErrInvalidRequest):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, substitutions.ErrNotSubstitute):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, substitutions.ErrNotPending),
+		errors.Is(err, substitutions.ErrAlreadyRequested):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, substitutions.ErrNoSessions),
+		errors.Is(err, substitutions.ErrNoOriginalTeacher),
+		errors.Is(err, substitutions.ErrNoCandidate):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterRoutes registra as rotas do professor (portal)
+func (h *SubstitutionHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/teacher/substitutions", func(r chi.Router) {
+		r.Get("/", h.ListMySubstitutions)
+		r.Post("/{id}/accept", h.AcceptSubstitution)
+		r.Post("/{id}/decline", h.DeclineSubstitution)
+	})
+}
+
+// RegisterPublicRoutes registra o webhook do Telegram (autenticado pelo secret token)
+func (h *SubstitutionHandler) RegisterPublicRoutes(r chi.Router) {
+	r.Post("/telegram/webhook", h.TelegramWebhook)
+}
+
+// RegisterAdminRoutes registra a gestão de substituições (coordenação)
+func (h *SubstitutionHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Route("/substitutions", func(r chi.Router) {
+		r.Get("/", h.ListSubstitutions)
+		r.Post("/", h.RequestSubstitution)
+		r.Post("/escalate", h.EscalateExpired)
+		r.Get("/{id}", h.GetSubstitution)
+		r.Post("/{id}/cancel", h.CancelSubstitution)
+	})
+}
@@ -2,43 +2,35 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
 
-	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/invitations"
 	"github.com/devdavidalonso/cecor/backend/internal/service/teachers"
 )
 
 // TeacherHandler handles HTTP requests for teachers
 type TeacherHandler struct {
-	service teachers.Service
+	service     teachers.Service
+	invitations *TeacherInvitationHandler
 }
 
 // NewTeacherHandler creates a new instance of TeacherHandler
-func NewTeacherHandler(service teachers.Service) *TeacherHandler {
+func NewTeacherHandler(service teachers.Service, invitations invitations.Service) *TeacherHandler {
 	return &TeacherHandler{
-		service: service,
+		service:     service,
+		invitations: NewTeacherInvitationHandler(invitations),
 	}
 }
 
-// CreateProfessor handles the creation of a new professor
+// CreateProfessor invites the professor by email (same as POST /admin/teacher-invitations):
+// the teacher record and the account are created only when the volunteer accepts the invitation
+// with their own password
 func (h *TeacherHandler) CreateProfessor(w http.ResponseWriter, r *http.Request) {
-	var professor models.User
-	if err := json.NewDecoder(r.Body).Decode(&professor); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
-		return
-	}
-
-	if err := h.service.CreateProfessor(r.Context(), &professor); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(professor)
+	h.invitations.Invite(w, r)
 }
 
 // GetProfessors handles the retrieval of all professors
@@ -53,7 +45,7 @@ func (h *TeacherHandler) GetProfessors(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(professors)
 }
 
-// GetProfessorByID handles the retrieval of a professor by ID
+// GetProfessorByID handles the retrieval of a professor by its teacher ID
 func (h *TeacherHandler) GetProfessorByID(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.Atoi(idStr)
@@ -64,7 +56,7 @@ func (h *TeacherHandler) GetProfessorByID(w http.ResponseWriter, r *http.Request
 
 	professor, err := h.service.GetProfessorByID(r.Context(), uint(id))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		h.writeError(w, err)
 		return
 	}
 
@@ -81,16 +73,15 @@ func (h *TeacherHandler) UpdateProfessor(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	var professor models.User
-	if err := json.NewDecoder(r.Body).Decode(&professor); err != nil {
+	var input teachers.ProfessorInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	professor.ID = uint(id)
-
-	if err := h.service.UpdateProfessor(r.Context(), &professor); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	professor, err := h.service.UpdateProfessor(r.Context(), uint(id), input)
+	if err != nil {
+		h.writeError(w, err)
 		return
 	}
 
@@ -108,9 +99,22 @@ func (h *TeacherHandler) DeleteProfessor(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := h.service.DeleteProfessor(r.Context(), uint(id)); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		h.writeError(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+func (h *TeacherHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, teachers.ErrProfessorNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, teachers.ErrInvalidProfessor):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, teachers.ErrEmailInUse):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,221 @@
+// backend/internal/api/handlers/teacher_invitation_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/service/invitations"
+	"github.com/devdavidalonso/cecor/backend/internal/service/teachers"
+)
+
+// TeacherInvitationHandler handles the onboarding invitations of volunteer teachers
+type TeacherInvitationHandler struct {
+	service invitations.Service
+}
+
+// NewTeacherInvitationHandler creates a new handler
+func NewTeacherInvitationHandler(service invitations.Service) *TeacherInvitationHandler {
+	return &TeacherInvitationHandler{service: service}
+}
+
+// acceptInvitationBody is the profile (as in /teachers) plus the password and the accepted term
+type acceptInvitationBody struct {
+	teachers.ProfessorInput
+	Password       string `json:"password"`
+	AcceptTerm     bool   `json:"acceptTerm"`
+	TermTemplateID uint   `json:"termTemplateId"`
+}
+
+// === Coordenação ===
+
+// Invite convida um voluntário por email
+// POST /api/v1/admin/teacher-invitations
+func (h *TeacherInvitationHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	var input invitations.InviteInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	sent, err := h.service.Invite(r.Context(), input, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(sent)
+}
+
+// ListInvitations lista os convites (?status=pending|expired|accepted|revoked)
+// GET /api/v1/admin/teacher-invitations
+func (h *TeacherInvitationHandler) ListInvitations(w http.ResponseWriter, r *http.Request) {
+	list, err := h.service.List(r.Context(), r.URL.Query().Get("status"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// ResendInvitation reenvia o convite com um novo link e renova a validade
+// POST /api/v1/admin/teacher-invitations/:id/resend
+func (h *TeacherInvitationHandler) ResendInvitation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	sent, err := h.service.Resend(r.Context(), uint(id))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sent)
+}
+
+// RevokeInvitation cancela um convite pendente
+// POST /api/v1/admin/teacher-invitations/:id/revoke
+func (h *TeacherInvitationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	invitation, err := h.service.Revoke(r.Context(), uint(id))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(invitation)
+}
+
+// ListTermTemplates lista as versões do termo de voluntariado
+// GET /api/v1/admin/volunteer-term-templates
+func (h *TeacherInvitationHandler) ListTermTemplates(w http.ResponseWriter, r *http.Request) {
+	list, err := h.service.ListTermTemplates(r.Context())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// PublishTermTemplate publica uma nova versão do termo (a anterior deixa de ser apresentada)
+// POST /api/v1/admin/volunteer-term-templates
+func (h *TeacherInvitationHandler) PublishTermTemplate(w http.ResponseWriter, r *http.Request) {
+	var input invitations.TermTemplateInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	template, err := h.service.PublishTermTemplate(r.Context(), input, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(template)
+}
+
+// === Voluntário (o token do link é a credencial) ===
+
+// OpenInvitation retorna os dados do convite e o termo de voluntariado vigente
+// GET /api/v1/teacher-invitations/:token
+func (h *TeacherInvitationHandler) OpenInvitation(w http.ResponseWriter, r *http.Request) {
+	onboarding, err := h.service.Open(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(onboarding)
+}
+
+// AcceptInvitation completa o cadastro, registra o aceite do termo e ativa a conta
+// POST /api/v1/teacher-invitations/:token/accept
+func (h *TeacherInvitationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	var body acceptInvitationBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	professor, err := h.service.Accept(r.Context(), chi.URLParam(r, "token"), invitations.AcceptInput{
+		Profile:        body.ProfessorInput,
+		Password:       body.Password,
+		AcceptTerm:     body.AcceptTerm,
+		TermTemplateID: body.TermTemplateID,
+		IPAddress:      r.RemoteAddr,
+		DeviceInfo:     r.UserAgent(),
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(professor)
+}
+
+func (h *TeacherInvitationHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, invitations.ErrInvitationNotFound):
+		http.Error(w, "invitation not found", http.StatusNotFound)
+	case errors.Is(err, invitations.ErrInvitationExpired):
+		http.Error(w, err.Error(), http.StatusGone)
+	case errors.Is(err, invitations.ErrInvitationClosed),
+		errors.Is(err, invitations.ErrAlreadyInvited),
+		errors.Is(err, invitations.ErrEmailInUse),
+		errors.Is(err, invitations.ErrNoVolunteerTerm),
+		errors.Is(err, invitations.ErrTermOutdated):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, invitations.ErrInvalidInvitation),
+		errors.Is(err, invitations.ErrInvalidAcceptance),
+		errors.Is(err, invitations.ErrInvalidTerm):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterAdminRoutes registra a gestão dos convites e do termo de voluntariado (coordenação)
+func (h *TeacherInvitationHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Route("/teacher-invitations", func(r chi.Router) {
+		r.Get("/", h.ListInvitations)
+		r.Post("/", h.Invite)
+		r.Post("/{id}/resend", h.ResendInvitation)
+		r.Post("/{id}/revoke", h.RevokeInvitation)
+	})
+	r.Route("/volunteer-term-templates", func(r chi.Router) {
+		r.Get("/", h.ListTermTemplates)
+		r.Post("/", h.PublishTermTemplate)
+	})
+}
+
+// RegisterPublicRoutes registra o link de uso único aberto pelo voluntário
+func (h *TeacherInvitationHandler) RegisterPublicRoutes(r chi.Router) {
+	r.Get("/teacher-invitations/{token}", h.OpenInvitation)
+	r.Post("/teacher-invitations/{token}/accept", h.AcceptInvitation)
+}
@@ -0,0 +1,373 @@
+// backend/internal/api/handlers/volunteer_hours_handler.go
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/devdavidalonso/cecor/backend/internal/service/volunteerhours"
+)
+
+// VolunteerHoursHandler handles the volunteer hours ledger and the declarations (Lei 9.608/98)
+type VolunteerHoursHandler struct {
+	service volunteerhours.Service
+}
+
+// NewVolunteerHoursHandler creates a new handler
+func NewVolunteerHoursHandler(service volunteerhours.Service) *VolunteerHoursHandler {
+	return &VolunteerHoursHandler{service: service}
+}
+
+// activityRequestBody logs volunteer work; date is YYYY-MM-DD
+type activityRequestBody struct {
+	Date        string `json:"date"`
+	Minutes     int    `json:"minutes"`
+	Category    string `json:"category"` // planning, event, training, other
+	Description string `json:"description"`
+}
+
+// === Portal do professor ===
+
+// GetMyStatement retorna o extrato de horas do professor no período (padrão: ano corrente)
+// GET /api/v1/teacher/volunteer-hours?startDate=&endDate=
+func (h *VolunteerHoursHandler) GetMyStatement(w http.ResponseWriter, r *http.Request) {
+	teacherID, ok := h.currentTeacher(w, r)
+	if !ok {
+		return
+	}
+	h.statement(w, r, teacherID)
+}
+
+// IssueMyDeclaration emite a declaração assinada (PDF) das horas do professor no período
+// POST /api/v1/teacher/volunteer-hours/declarations?startDate=&endDate=
+func (h *VolunteerHoursHandler) IssueMyDeclaration(w http.ResponseWriter, r *http.Request) {
+	teacherID, ok := h.currentTeacher(w, r)
+	if !ok {
+		return
+	}
+	h.declaration(w, r, teacherID)
+}
+
+// ListMyActivities lista as atividades registradas pelo professor
+// GET /api/v1/teacher/volunteer-activities?status=
+func (h *VolunteerHoursHandler) ListMyActivities(w http.ResponseWriter, r *http.Request) {
+	teacherID, ok := h.currentTeacher(w, r)
+	if !ok {
+		return
+	}
+
+	list, err := h.service.ListActivities(r.Context(), volunteerhours.ActivityFilter{
+		TeacherID: &teacherID,
+		Status:    r.URL.Query().Get("status"),
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// LogMyActivity registra uma atividade (aguarda aprovação da coordenação)
+// POST /api/v1/teacher/volunteer-activities
+func (h *VolunteerHoursHandler) LogMyActivity(w http.ResponseWriter, r *http.Request) {
+	teacherID, ok := h.currentTeacher(w, r)
+	if !ok {
+		return
+	}
+	h.logActivity(w, r, teacherID, false)
+}
+
+// DeleteMyActivity remove uma atividade ainda não avaliada
+// DELETE /api/v1/teacher/volunteer-activities/:id
+func (h *VolunteerHoursHandler) DeleteMyActivity(w http.ResponseWriter, r *http.Request) {
+	teacherID, ok := h.currentTeacher(w, r)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteActivity(r.Context(), teacherID, uint(id)); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// === Coordenação ===
+
+// GetTeacherStatement retorna o extrato de horas de um professor no período
+// GET /api/v1/admin/teachers/:id/volunteer-hours?startDate=&endDate=
+func (h *VolunteerHoursHandler) GetTeacherStatement(w http.ResponseWriter, r *http.Request) {
+	teacherID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid teacher id", http.StatusBadRequest)
+		return
+	}
+	h.statement(w, r, uint(teacherID))
+}
+
+// IssueTeacherDeclaration emite a declaração assinada (PDF) de um professor
+// POST /api/v1/admin/teachers/:id/volunteer-hours/declarations?startDate=&endDate=
+func (h *VolunteerHoursHandler) IssueTeacherDeclaration(w http.ResponseWriter, r *http.Request) {
+	teacherID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid teacher id", http.StatusBadRequest)
+		return
+	}
+	h.declaration(w, r, uint(teacherID))
+}
+
+// LogTeacherActivity registra uma atividade já aprovada para um professor
+// POST /api/v1/admin/teachers/:id/volunteer-activities
+func (h *VolunteerHoursHandler) LogTeacherActivity(w http.ResponseWriter, r *http.Request) {
+	teacherID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid teacher id", http.StatusBadRequest)
+		return
+	}
+	h.logActivity(w, r, uint(teacherID), true)
+}
+
+// ListActivities lista as atividades (ex: ?status=pending para a fila de aprovação)
+// GET /api/v1/admin/volunteer-activities?status=&teacherId=
+func (h *VolunteerHoursHandler) ListActivities(w http.ResponseWriter, r *http.Request) {
+	filter := volunteerhours.ActivityFilter{Status: r.URL.Query().Get("status")}
+	if value := r.URL.Query().Get("teacherId"); value != "" {
+		teacherID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			http.Error(w, "invalid teacherId", http.StatusBadRequest)
+			return
+		}
+		id := uint(teacherID)
+		filter.TeacherID = &id
+	}
+
+	list, err := h.service.ListActivities(r.Context(), filter)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// ApproveActivity aprova uma atividade registrada pelo professor
+// POST /api/v1/admin/volunteer-activities/:id/approve
+func (h *VolunteerHoursHandler) ApproveActivity(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, true)
+}
+
+// RejectActivity rejeita uma atividade registrada pelo professor
+// POST /api/v1/admin/volunteer-activities/:id/reject
+func (h *VolunteerHoursHandler) RejectActivity(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, false)
+}
+
+// === Público ===
+
+// VerifyDeclaration confere a autenticidade de uma declaração pelo código de verificação
+// GET /api/v1/volunteer-declarations/:code
+func (h *VolunteerHoursHandler) VerifyDeclaration(w http.ResponseWriter, r *http.Request) {
+	verification, err := h.service.VerifyDeclaration(r.Context(), chi.URLParam(r, "code"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(verification)
+}
+
+func (h *VolunteerHoursHandler) currentTeacher(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	userID := getUserIDFromContext(r)
+	if userID == 0 {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return 0, false
+	}
+	teacherID, err := h.service.TeacherIDForUser(r.Context(), userID)
+	if err != nil {
+		h.writeError(w, err)
+		return 0, false
+	}
+	return teacherID, true
+}
+
+func (h *VolunteerHoursHandler) statement(w http.ResponseWriter, r *http.Request, teacherID uint) {
+	period, err := parseVolunteerPeriod(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	statement, err := h.service.Statement(r.Context(), teacherID, period)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(statement)
+}
+
+func (h *VolunteerHoursHandler) declaration(w http.ResponseWriter, r *http.Request, teacherID uint) {
+	period, err := parseVolunteerPeriod(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	declaration, pdf, err := h.service.IssueDeclaration(r.Context(), teacherID, period, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="declaracao-voluntariado-%s.pdf"`, declaration.Code))
+	w.Header().Set("X-Declaration-Code", declaration.Code)
+	w.WriteHeader(http.StatusCreated)
+	w.Write(pdf)
+}
+
+func (h *VolunteerHoursHandler) logActivity(w http.ResponseWriter, r *http.Request, teacherID uint, approved bool) {
+	var body activityRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	date, err := parseOptionalDate(body.Date)
+	if err != nil || date == nil {
+		http.Error(w, "invalid date, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	activity, err := h.service.LogActivity(r.Context(), teacherID, volunteerhours.ActivityInput{
+		Date:        *date,
+		Minutes:     body.Minutes,
+		Category:    body.Category,
+		Description: body.Description,
+	}, getUserIDFromContext(r), approved)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(activity)
+}
+
+func (h *VolunteerHoursHandler) review(w http.ResponseWriter, r *http.Request, approve bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	var body struct {
+		Notes string `json:"notes"`
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	activity, err := h.service.ReviewActivity(r.Context(), uint(id), approve, body.Notes, getUserIDFromContext(r))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(activity)
+}
+
+// parseVolunteerPeriod reads startDate and endDate (YYYY-MM-DD); the default is the current year up to today
+func parseVolunteerPeriod(r *http.Request) (volunteerhours.Period, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	period := volunteerhours.Period{
+		StartDate: time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   today,
+	}
+
+	startDate, err := parseOptionalDate(r.URL.Query().Get("startDate"))
+	if err != nil {
+		return period, errors.New("invalid startDate, expected YYYY-MM-DD")
+	}
+	endDate, err := parseOptionalDate(r.URL.Query().Get("endDate"))
+	if err != nil {
+		return period, errors.New("invalid endDate, expected YYYY-MM-DD")
+	}
+	if startDate != nil {
+		period.StartDate = *startDate
+	}
+	if endDate != nil {
+		period.EndDate = *endDate
+	}
+	return period, nil
+}
+
+func (h *VolunteerHoursHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, volunteerhours.ErrNotTeacher):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, volunteerhours.ErrTeacherNotFound):
+		http.Error(w, "teacher not found", http.StatusNotFound)
+	case errors.Is(err, volunteerhours.ErrActivityNotFound):
+		http.Error(w, "volunteer activity not found", http.StatusNotFound)
+	case errors.Is(err, volunteerhours.ErrDeclarationNotFound):
+		http.Error(w, "declaration not found", http.StatusNotFound)
+	case errors.Is(err, volunteerhours.ErrInvalidPeriod), errors.Is(err, volunteerhours.ErrInvalidActivity):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, volunteerhours.ErrNotPending):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RegisterRoutes registra o extrato, as atividades e as declarações no portal do professor
+func (h *VolunteerHoursHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/teacher/volunteer-hours", func(r chi.Router) {
+		r.Get("/", h.GetMyStatement)
+		r.Post("/declarations", h.IssueMyDeclaration)
+	})
+	r.Route("/teacher/volunteer-activities", func(r chi.Router) {
+		r.Get("/", h.ListMyActivities)
+		r.Post("/", h.LogMyActivity)
+		r.Delete("/{id}", h.DeleteMyActivity)
+	})
+}
+
+// RegisterAdminRoutes registra a consulta, a aprovação das atividades e a emissão pela coordenação
+func (h *VolunteerHoursHandler) RegisterAdminRoutes(r chi.Router) {
+	r.Get("/teachers/{id}/volunteer-hours", h.GetTeacherStatement)
+	r.Post("/teachers/{id}/volunteer-hours/declarations", h.IssueTeacherDeclaration)
+	r.Post("/teachers/{id}/volunteer-activities", h.LogTeacherActivity)
+	r.Route("/volunteer-activities", func(r chi.Router) {
+		r.Get("/", h.ListActivities)
+		r.Post("/{id}/approve", h.ApproveActivity)
+		r.Post("/{id}/reject", h.RejectActivity)
+	})
+}
+
+// RegisterPublicRoutes registra a verificação pública das declarações
+func (h *VolunteerHoursHandler) RegisterPublicRoutes(r chi.Router) {
+	r.Get("/volunteer-declarations/{code}", h.VerifyDeclaration)
+}
@@ -125,16 +125,7 @@ func RequireAdmin(next http.Handler) http.Handler {
 		}
 
 		// Verificar se o usuário tem papel de administrador
-		isAdmin := false
-		for _, role := range claims.Roles {
-			normalizedRole := strings.ToLower(strings.TrimSpace(role))
-			if normalizedRole == "admin" || normalizedRole == "administrador" || normalizedRole == "gestor" {
-				isAdmin = true
-				break
-			}
-		}
-
-		if !isAdmin {
+		if !IsAdmin(claims) {
 			errors.RespondWithError(w, http.StatusForbidden, "Acesso negado: privilégios de administrador necessários")
 			return
 		}
@@ -144,6 +135,20 @@ func RequireAdmin(next http.Handler) http.Handler {
 	})
 }
 
+// IsAdmin verifica se o usuário tem papel de administrador (admin, administrador ou gestor)
+func IsAdmin(claims *UserClaims) bool {
+	if claims == nil {
+		return false
+	}
+	for _, role := range claims.Roles {
+		normalizedRole := strings.ToLower(strings.TrimSpace(role))
+		if normalizedRole == "admin" || normalizedRole == "administrador" || normalizedRole == "gestor" {
+			return true
+		}
+	}
+	return false
+}
+
 // GetUserFromContext extrai informações do usuário do contexto
 func GetUserFromContext(ctx context.Context) (*UserClaims, bool) {
 	claims, ok := ctx.Value(userClaimsKey).(*UserClaims)
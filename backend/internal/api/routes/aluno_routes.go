@@ -35,7 +35,6 @@ func SetupStudentRoutes(r chi.Router, cfg *config.Config, handler *handlers.Stud
 			// Sub-routes for documents
 			r.Route("/{id}/documents", func(r chi.Router) {
 				r.Get("/", handler.GetDocuments) // List documents
-				r.Post("/", handler.AddDocument) // Add document
 			})
 
 			// Sub-routes for notes
@@ -62,6 +62,8 @@ func Register(r chi.Router, cfg *config.Config, authHandler *handlers.AuthHandle
 		r.Route("/reports", func(r chi.Router) {
 			r.Get("/attendance/course/{id}", reportHandler.GetCourseAttendanceReport)
 			r.Get("/attendance/student/{id}", reportHandler.GetStudentAttendanceReport)
+			r.Get("/occupancy", reportHandler.GetOccupancyDashboard)
+			r.Get("/occupancy/classes/{id}", reportHandler.GetClassOccupancy)
 			r.Get("/students", http.NotFound)
 			r.Get("/courses", http.NotFound)
 		})
@@ -4,16 +4,24 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Config representa a configuração global da aplicação
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Auth     AuthConfig
-	SSO      SSOConfig
-	Env      string
+	Server     ServerConfig
+	Database   DatabaseConfig
+	Auth       AuthConfig
+	SSO        SSOConfig
+	Telegram   TelegramConfig
+	Grading    GradingConfig
+	Volunteer  VolunteerConfig
+	Storage    StorageConfig
+	CEP        CEPConfig
+	Students   StudentsConfig
+	Encryption EncryptionConfig
+	Env        string
 }
 
 // ServerConfig contém configurações do servidor HTTP
@@ -22,6 +30,8 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+	PublicURL    string // URL pública da API, usada em links externos (ex: feeds de calendário)
+	FrontendURL  string // Endereço do frontend, usado nos links enviados por email (ex: convites)
 }
 
 // DatabaseConfig contém configurações do banco de dados
@@ -33,6 +43,7 @@ type DatabaseConfig struct {
 	PostgresDB       string
 	PostgresSSLMode  string
 	MongoURI         string
+	AutoMigrate      bool // Aplica as migrações pendentes ao iniciar a API
 }
 
 // AuthConfig contém configurações de autenticação
@@ -53,6 +64,61 @@ type SSOConfig struct {
 	UserInfoURL  string
 }
 
+// TelegramConfig contém as configurações do bot de notificações
+type TelegramConfig struct {
+	BotToken      string // Vazio desativa o canal Telegram
+	WebhookSecret string // Conferido no header X-Telegram-Bot-Api-Secret-Token do webhook
+}
+
+// GradingConfig contém a regra padrão de resultado final das turmas
+type GradingConfig struct {
+	PassingGrade      float64 // Média mínima para aprovação (escala 0-10)
+	MinAttendanceRate float64 // Frequência mínima para aprovação (%)
+}
+
+// VolunteerConfig contém os dados das declarações de horas e dos convites de voluntários
+type VolunteerConfig struct {
+	OrganizationName string        // Instituição que emite a declaração
+	DeclarationKey   string        // Chave HMAC que assina as declarações (conferidas pelo código de verificação)
+	InvitationTTL    time.Duration // Validade do link de convite de novos professores
+}
+
+// StorageConfig contém o armazenamento dos arquivos enviados (documentos dos alunos)
+type StorageConfig struct {
+	Driver         string // local ou s3 (qualquer serviço compatível, ex: MinIO)
+	LocalPath      string
+	S3Endpoint     string
+	S3Region       string
+	S3Bucket       string
+	S3AccessKey    string
+	S3SecretKey    string
+	S3PathStyle    bool
+	MaxUploadBytes int64         // Tamanho máximo de cada arquivo
+	LinkTTL        time.Duration // Validade dos links de download
+	LinkKey        string        // Chave HMAC que assina os links de download
+}
+
+// CEPConfig contém o provedor de consulta de CEP usado no preenchimento dos endereços
+type CEPConfig struct {
+	Provider string        // http (API compatível com o ViaCEP) ou file (arquivo JSON local)
+	URL      string        // URL do provedor http, com {cep}
+	FilePath string        // Arquivo do provedor file
+	Timeout  time.Duration // Tempo máximo de cada consulta ao provedor http
+	CacheTTL time.Duration // Validade das consultas guardadas no banco
+}
+
+// StudentsConfig contém as regras automáticas de situação dos alunos
+type StudentsConfig struct {
+	InactiveAfter time.Duration // Tempo sem matrícula ativa até o aluno ser inativado
+}
+
+// EncryptionConfig contém as chaves da criptografia dos dados sensíveis (saúde e CPF)
+type EncryptionConfig struct {
+	KeyID    string            // Chave que cifra os novos valores (a primeira da lista)
+	Keys     map[string]string // Segredos por id; as chaves antigas ficam até a rotação terminar
+	IndexKey string            // Chave HMAC do índice cego de CPF
+}
+
 // Load carrega configurações a partir de variáveis de ambiente
 func Load() (*Config, error) {
 	serverPort, err := strconv.Atoi(getEnv("SERVER_PORT", "8080"))
@@ -75,12 +141,54 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("Refresh token expiry inválido: %w", err)
 	}
 
+	passingGrade, err := strconv.ParseFloat(getEnv("GRADING_PASSING_GRADE", "6"), 64)
+	if err != nil || passingGrade < 0 || passingGrade > 10 {
+		return nil, fmt.Errorf("média mínima de aprovação inválida: %q", os.Getenv("GRADING_PASSING_GRADE"))
+	}
+
+	minAttendanceRate, err := strconv.ParseFloat(getEnv("GRADING_MIN_ATTENDANCE_RATE", "75"), 64)
+	if err != nil || minAttendanceRate < 0 || minAttendanceRate > 100 {
+		return nil, fmt.Errorf("frequência mínima de aprovação inválida: %q", os.Getenv("GRADING_MIN_ATTENDANCE_RATE"))
+	}
+
+	invitationDays, err := strconv.Atoi(getEnv("TEACHER_INVITATION_VALID_DAYS", "7"))
+	if err != nil || invitationDays <= 0 {
+		return nil, fmt.Errorf("validade do convite de professores inválida: %q", os.Getenv("TEACHER_INVITATION_VALID_DAYS"))
+	}
+
+	maxUploadMB, err := strconv.Atoi(getEnv("DOCUMENTS_MAX_UPLOAD_MB", "10"))
+	if err != nil || maxUploadMB <= 0 {
+		return nil, fmt.Errorf("tamanho máximo de documentos inválido: %q", os.Getenv("DOCUMENTS_MAX_UPLOAD_MB"))
+	}
+
+	linkTTLMinutes, err := strconv.Atoi(getEnv("DOCUMENTS_LINK_TTL_MINUTES", "5"))
+	if err != nil || linkTTLMinutes <= 0 {
+		return nil, fmt.Errorf("validade dos links de documentos inválida: %q", os.Getenv("DOCUMENTS_LINK_TTL_MINUTES"))
+	}
+
+	cepCacheDays, err := strconv.Atoi(getEnv("CEP_CACHE_DAYS", "90"))
+	if err != nil || cepCacheDays <= 0 {
+		return nil, fmt.Errorf("validade do cache de CEP inválida: %q", os.Getenv("CEP_CACHE_DAYS"))
+	}
+
+	inactiveDays, err := strconv.Atoi(getEnv("STUDENT_INACTIVE_AFTER_DAYS", "180"))
+	if err != nil || inactiveDays <= 0 {
+		return nil, fmt.Errorf("prazo de inativação de alunos inválido: %q", os.Getenv("STUDENT_INACTIVE_AFTER_DAYS"))
+	}
+
+	keyID, keys, err := parseKeys(getEnv("FIELD_ENCRYPTION_KEYS", "dev:chave_de_criptografia_para_desenvolvimento")) // WARNING: Default value for development only. Do not use in production.
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		Server: ServerConfig{
 			Port:         serverPort,
 			ReadTimeout:  15 * time.Second,
 			WriteTimeout: 15 * time.Second,
 			IdleTimeout:  60 * time.Second,
+			PublicURL:    getEnv("PUBLIC_API_URL", "http://localhost:8080"),
+			FrontendURL:  getEnv("FRONTEND_URL", "http://localhost:4201"),
 		},
 		Database: DatabaseConfig{
 			PostgresHost:     getEnv("POSTGRES_HOST", "localhost"),
@@ -90,6 +198,7 @@ func Load() (*Config, error) {
 			PostgresDB:       getEnv("POSTGRES_DB", "cecor_db"),
 			PostgresSSLMode:  getEnv("POSTGRES_SSLMODE", "disable"),
 			MongoURI:         getEnv("MONGO_URI", "mongodb://mongo:27017"),
+			AutoMigrate:      getEnv("DB_AUTO_MIGRATE", "true") == "true",
 		},
 		Auth: AuthConfig{
 			JwtSecret:          getEnv("JWT_SECRET", "sua_chave_secreta_muito_segura"), // WARNING: Default value for development only. Do not use in production.
@@ -105,10 +214,71 @@ func Load() (*Config, error) {
 			TokenURL:     getEnv("SSO_TOKEN_URL", "http://localhost:8081/realms/cecor/protocol/openid-connect/token"),
 			UserInfoURL:  getEnv("SSO_USER_INFO_URL", "http://localhost:8081/realms/cecor/protocol/openid-connect/userinfo"),
 		},
+		Telegram: TelegramConfig{
+			BotToken:      os.Getenv("TELEGRAM_BOT_TOKEN"),
+			WebhookSecret: os.Getenv("TELEGRAM_WEBHOOK_SECRET"),
+		},
+		Grading: GradingConfig{
+			PassingGrade:      passingGrade,
+			MinAttendanceRate: minAttendanceRate,
+		},
+		Volunteer: VolunteerConfig{
+			OrganizationName: getEnv("ORGANIZATION_NAME", "CECOR - Centro Educacional Comunitário de Referência"),
+			DeclarationKey:   getEnv("VOLUNTEER_DECLARATION_KEY", "chave_de_declaracoes_para_desenvolvimento"), // WARNING: Default value for development only. Do not use in production.
+			InvitationTTL:    time.Duration(invitationDays) * 24 * time.Hour,
+		},
+		Storage: StorageConfig{
+			Driver:         getEnv("STORAGE_DRIVER", "local"),
+			LocalPath:      getEnv("STORAGE_LOCAL_PATH", "./data/uploads"),
+			S3Endpoint:     os.Getenv("STORAGE_S3_ENDPOINT"),
+			S3Region:       getEnv("STORAGE_S3_REGION", "us-east-1"),
+			S3Bucket:       os.Getenv("STORAGE_S3_BUCKET"),
+			S3AccessKey:    os.Getenv("STORAGE_S3_ACCESS_KEY"),
+			S3SecretKey:    os.Getenv("STORAGE_S3_SECRET_KEY"),
+			S3PathStyle:    getEnv("STORAGE_S3_PATH_STYLE", "false") == "true",
+			MaxUploadBytes: int64(maxUploadMB) << 20,
+			LinkTTL:        time.Duration(linkTTLMinutes) * time.Minute,
+			LinkKey:        getEnv("DOCUMENTS_LINK_KEY", "chave_de_links_para_desenvolvimento"), // WARNING: Default value for development only. Do not use in production.
+		},
+		CEP: CEPConfig{
+			Provider: getEnv("CEP_PROVIDER", "http"),
+			URL:      getEnv("CEP_PROVIDER_URL", "https://viacep.com.br/ws/{cep}/json/"),
+			FilePath: getEnv("CEP_FILE_PATH", "./data/ceps.json"),
+			Timeout:  5 * time.Second,
+			CacheTTL: time.Duration(cepCacheDays) * 24 * time.Hour,
+		},
+		Students: StudentsConfig{
+			InactiveAfter: time.Duration(inactiveDays) * 24 * time.Hour,
+		},
+		Encryption: EncryptionConfig{
+			KeyID:    keyID,
+			Keys:     keys,
+			IndexKey: getEnv("CPF_INDEX_KEY", "chave_do_indice_de_cpf_para_desenvolvimento"), // WARNING: Default value for development only. Do not use in production.
+		},
 		Env: getEnv("APP_ENV", "development"),
 	}, nil
 }
 
+// parseKeys lê a lista id:segredo,id:segredo das chaves de criptografia; a primeira é a atual
+func parseKeys(value string) (string, map[string]string, error) {
+	var current string
+	keys := map[string]string{}
+	for _, entry := range strings.Split(value, ",") {
+		id, secret, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || id == "" || secret == "" {
+			return "", nil, fmt.Errorf("chave de criptografia inválida em FIELD_ENCRYPTION_KEYS: esperado id:segredo")
+		}
+		if _, repeated := keys[id]; repeated {
+			return "", nil, fmt.Errorf("chave de criptografia %q repetida em FIELD_ENCRYPTION_KEYS", id)
+		}
+		if current == "" {
+			current = id
+		}
+		keys[id] = secret
+	}
+	return current, keys, nil
+}
+
 // getEnv obtém valor de variável de ambiente ou retorna valor padrão
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
@@ -0,0 +1,91 @@
+// Package cep looks up Brazilian postal codes (CEP) behind a provider interface, with an HTTP
+// implementation for ViaCEP-compatible APIs and a local JSON file / in-memory one for
+// development and tests.
+package cep
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Providers accepted by New
+const (
+	ProviderHTTP = "http"
+	ProviderFile = "file"
+)
+
+var (
+	// ErrInvalid is returned for values that are not 8 digits
+	ErrInvalid = errors.New("invalid CEP")
+	// ErrNotFound is returned when the CEP does not exist
+	ErrNotFound = errors.New("CEP not found")
+	// ErrUnavailable is returned when the provider cannot be reached or fails
+	ErrUnavailable = errors.New("CEP provider unavailable")
+)
+
+// Address is the address of a CEP. Street and Neighborhood are empty for cities with a single CEP.
+type Address struct {
+	CEP          string `json:"cep"` // Só dígitos
+	Street       string `json:"street"`
+	Complement   string `json:"complement"`
+	Neighborhood string `json:"neighborhood"`
+	City         string `json:"city"`
+	State        string `json:"state"`    // UF
+	IBGECode     string `json:"ibgeCode"` // Código IBGE do município
+}
+
+// Provider looks up a CEP given as 8 digits
+type Provider interface {
+	Lookup(ctx context.Context, cep string) (*Address, error)
+}
+
+// Config selects and configures the provider
+type Config struct {
+	Provider string        // http (padrão) ou file
+	URL      string        // Provider http: URL com {cep} (ex: https://viacep.com.br/ws/{cep}/json/)
+	FilePath string        // Provider file: arquivo JSON com uma lista de endereços
+	Timeout  time.Duration // Provider http
+}
+
+// New creates the provider selected by the configuration
+func New(cfg Config) (Provider, error) {
+	switch cfg.Provider {
+	case "", ProviderHTTP:
+		return NewHTTPProvider(cfg.URL, cfg.Timeout)
+	case ProviderFile:
+		return NewFileProvider(cfg.FilePath)
+	default:
+		return nil, fmt.Errorf("unknown CEP provider %q", cfg.Provider)
+	}
+}
+
+// Normalize returns the 8 digits of a CEP typed with or without the hyphen
+func Normalize(value string) (string, error) {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(value) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '-' || r == '.' || r == ' ':
+		default:
+			return "", fmt.Errorf("%w: %q", ErrInvalid, value)
+		}
+	}
+	digits := b.String()
+	if len(digits) != 8 || digits == "00000000" {
+		return "", fmt.Errorf("%w: %q", ErrInvalid, value)
+	}
+	return digits, nil
+}
+
+// Format returns 00000-000 for a valid CEP and the value unchanged otherwise
+func Format(value string) string {
+	digits, err := Normalize(value)
+	if err != nil {
+		return value
+	}
+	return digits[:5] + "-" + digits[5:]
+}
@@ -0,0 +1,104 @@
+package cep
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	for value, want := range map[string]string{"01001-000": "01001000", " 01001000 ": "01001000", "01.001-000": "01001000"} {
+		if got, err := Normalize(value); err != nil || got != want {
+			t.Errorf("Normalize(%q) = %q, %v; expected %q", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "0100100", "010010000", "01001-00a", "00000-000"} {
+		if _, err := Normalize(value); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Expected ErrInvalid for %q, got %v", value, err)
+		}
+	}
+	if got := Format("01001000"); got != "01001-000" {
+		t.Errorf("Unexpected format %q", got)
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ws/01001000/json/":
+			w.Write([]byte(`{"cep":"01001-000","logradouro":"Praça da Sé","complemento":"lado ímpar","bairro":"Sé","localidade":"São Paulo","uf":"SP","ibge":"3550308"}`))
+		case "/ws/99999999/json/":
+			w.Write([]byte(`{"erro": true}`))
+		case "/ws/99999998/json/":
+			w.Write([]byte(`{"erro": "true"}`))
+		case "/ws/12345678/json/":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewHTTPProvider(server.URL+"/ws/{cep}/json/", 0)
+	if err != nil {
+		t.Fatalf("Expected a provider, got %v", err)
+	}
+	ctx := context.Background()
+
+	address, err := provider.Lookup(ctx, "01001000")
+	if err != nil {
+		t.Fatalf("Expected the address, got %v", err)
+	}
+	if address.CEP != "01001000" || address.Street != "Praça da Sé" || address.Neighborhood != "Sé" || address.City != "São Paulo" || address.State != "SP" || address.IBGECode != "3550308" {
+		t.Errorf("Unexpected address %+v", address)
+	}
+
+	for _, cep := range []string{"99999999", "99999998"} {
+		if _, err := provider.Lookup(ctx, cep); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound for %s, got %v", cep, err)
+		}
+	}
+	if _, err := provider.Lookup(ctx, "12345678"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable on a provider failure, got %v", err)
+	}
+	if _, err := provider.Lookup(ctx, "11111111"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected ErrInvalid on a bad request, got %v", err)
+	}
+
+	server.Close()
+	if _, err := provider.Lookup(ctx, "01001000"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable when the provider is down, got %v", err)
+	}
+
+	if _, err := NewHTTPProvider("https://viacep.com.br/ws/json/", 0); err == nil {
+		t.Error("Expected an error for a URL without {cep}")
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ceps.json")
+	content := `[{"cep":"20040-020","street":"Avenida Rio Branco","neighborhood":"Centro","city":"Rio de Janeiro","state":"rj"}]`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := New(Config{Provider: ProviderFile, FilePath: path})
+	if err != nil {
+		t.Fatalf("Expected a file provider, got %v", err)
+	}
+	address, err := provider.Lookup(context.Background(), "20040020")
+	if err != nil || address.City != "Rio de Janeiro" || address.State != "RJ" {
+		t.Errorf("Unexpected lookup %+v, %v", address, err)
+	}
+	if _, err := provider.Lookup(context.Background(), "01001000"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	if _, err := New(Config{Provider: "ftp"}); err == nil {
+		t.Error("Expected an error for an unknown provider")
+	}
+}
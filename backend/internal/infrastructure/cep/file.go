@@ -0,0 +1,54 @@
+package cep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// StaticProvider answers from a fixed list of addresses (local development without network, tests)
+type StaticProvider struct {
+	addresses map[string]Address
+}
+
+// NewStaticProvider indexes the addresses by CEP
+func NewStaticProvider(addresses ...Address) (*StaticProvider, error) {
+	p := &StaticProvider{addresses: make(map[string]Address, len(addresses))}
+	for _, address := range addresses {
+		digits, err := Normalize(address.CEP)
+		if err != nil {
+			return nil, err
+		}
+		address.CEP = digits
+		address.State = strings.ToUpper(address.State)
+		p.addresses[digits] = address
+	}
+	return p, nil
+}
+
+// NewFileProvider loads a JSON file with a list of addresses, in the format of Address
+func NewFileProvider(path string) (*StaticProvider, error) {
+	if path == "" {
+		return nil, fmt.Errorf("CEP file path is required")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CEP file: %w", err)
+	}
+	var addresses []Address
+	if err := json.Unmarshal(data, &addresses); err != nil {
+		return nil, fmt.Errorf("invalid CEP file %s: %w", path, err)
+	}
+	return NewStaticProvider(addresses...)
+}
+
+// Lookup returns the address of the CEP
+func (p *StaticProvider) Lookup(ctx context.Context, cep string) (*Address, error) {
+	address, ok := p.addresses[cep]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &address, nil
+}
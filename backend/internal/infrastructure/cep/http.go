@@ -0,0 +1,91 @@
+package cep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultURL is the public ViaCEP API
+const DefaultURL = "https://viacep.com.br/ws/{cep}/json/"
+
+// HTTPProvider queries a ViaCEP-compatible API (the JSON fields logradouro, bairro, localidade, uf)
+type HTTPProvider struct {
+	url    string
+	client *http.Client
+}
+
+// viaCEPResponse is the ViaCEP payload; unknown CEPs answer 200 with "erro": true (or "true")
+type viaCEPResponse struct {
+	CEP         string          `json:"cep"`
+	Logradouro  string          `json:"logradouro"`
+	Complemento string          `json:"complemento"`
+	Bairro      string          `json:"bairro"`
+	Localidade  string          `json:"localidade"`
+	UF          string          `json:"uf"`
+	IBGE        string          `json:"ibge"`
+	Erro        json.RawMessage `json:"erro"`
+}
+
+// NewHTTPProvider validates the URL template
+func NewHTTPProvider(url string, timeout time.Duration) (*HTTPProvider, error) {
+	if url == "" {
+		url = DefaultURL
+	}
+	if !strings.Contains(url, "{cep}") || (!strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://")) {
+		return nil, fmt.Errorf("invalid CEP provider URL %q (expected an http(s) URL with {cep})", url)
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	return &HTTPProvider{url: url, client: &http.Client{Timeout: timeout}}, nil
+}
+
+// Lookup queries the API
+func (p *HTTPProvider) Lookup(ctx context.Context, cep string) (*Address, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.ReplaceAll(p.url, "{cep}", cep), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode == http.StatusBadRequest:
+		return nil, fmt.Errorf("%w: %q", ErrInvalid, cep)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%w: status %d", ErrUnavailable, resp.StatusCode)
+	}
+
+	var payload viaCEPResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	if flag := strings.Trim(string(payload.Erro), `"`); flag == "true" {
+		return nil, ErrNotFound
+	}
+	if payload.Localidade == "" || payload.UF == "" {
+		return nil, fmt.Errorf("%w: incomplete response", ErrUnavailable)
+	}
+
+	return &Address{
+		CEP:          cep,
+		Street:       strings.TrimSpace(payload.Logradouro),
+		Complement:   strings.TrimSpace(payload.Complemento),
+		Neighborhood: strings.TrimSpace(payload.Bairro),
+		City:         strings.TrimSpace(payload.Localidade),
+		State:        strings.ToUpper(strings.TrimSpace(payload.UF)),
+		IBGECode:     strings.TrimSpace(payload.IBGE),
+	}, nil
+}
@@ -0,0 +1,67 @@
+// Package storage keeps uploaded files behind an interface, with a local filesystem and an
+// S3-compatible implementation (AWS S3, MinIO and similar).
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Drivers accepted by New
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+var (
+	// ErrNotFound is returned when the object does not exist
+	ErrNotFound = errors.New("object not found")
+	// ErrInvalidKey is returned for empty keys or keys escaping the storage root
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Storage stores objects by key; keys are slash-separated relative paths (ex: students/12/abc.pdf)
+type Storage interface {
+	// Put stores size bytes read from body under key, replacing any previous object
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get opens the object; the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// Config selects and configures the storage backend
+type Config struct {
+	Driver    string // local (padrão) ou s3
+	LocalPath string // Diretório raiz do driver local
+	S3        S3Config
+}
+
+// New creates the storage backend selected by the configuration
+func New(cfg Config) (Storage, error) {
+	switch cfg.Driver {
+	case "", DriverLocal:
+		return NewLocalStorage(cfg.LocalPath)
+	case DriverS3:
+		return NewS3Storage(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
+
+// validateKey accepts only clean relative keys, so a key can never point outside the root or the bucket
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+	}
+	return nil
+}
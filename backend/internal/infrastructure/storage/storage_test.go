@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"students/1/a.pdf", "a.png", "students/1/ação.pdf"} {
+		if err := validateKey(key); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", key, err)
+		}
+	}
+	for _, key := range []string{"", "/etc/passwd", "../a", "students/../../a", "students//a", "students/./a", `students\a`, "students/"} {
+		if err := validateKey(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Expected ErrInvalidKey for %q, got %v", key, err)
+		}
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("Expected a local storage, got %v", err)
+	}
+	testStorage(t, store)
+
+	if err := store.Put(context.Background(), "short.txt", strings.NewReader("abc"), 10, "text/plain"); err == nil {
+		t.Error("Expected an error when the body is shorter than the declared size")
+	}
+	if _, err := store.Get(context.Background(), "short.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected no partial object to be left, got %v", err)
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	fake := newFakeS3(t, "cecor-test", "minio", "minio-secret")
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := NewS3Storage(S3Config{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    "cecor-test",
+		AccessKey: "minio",
+		SecretKey: "minio-secret",
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected an s3 storage, got %v", err)
+	}
+	testStorage(t, store)
+
+	if err := store.Put(context.Background(), "students/1/relatório (1).pdf", strings.NewReader("x"), 1, "application/pdf"); err != nil {
+		t.Errorf("Expected keys with reserved characters to be signed correctly, got %v", err)
+	}
+
+	store.secretKey = "wrong"
+	if err := store.Put(context.Background(), "students/1/a.pdf", strings.NewReader("x"), 1, "application/pdf"); err == nil {
+		t.Error("Expected the stand-in to reject a wrong signature")
+	}
+}
+
+func TestSigningKey(t *testing.T) {
+	// Exemplo da documentação da AWS (Signature Version 4, derivação da chave)
+	key := signingKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	if got := hex.EncodeToString(key); got != "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d" {
+		t.Errorf("Unexpected signing key %s", got)
+	}
+}
+
+func TestURIEncode(t *testing.T) {
+	if got := uriEncode("/b/students/1/a b+(ç).pdf", false); got != "/b/students/1/a%20b%2B%28%C3%A7%29.pdf" {
+		t.Errorf("Unexpected encoding %s", got)
+	}
+}
+
+// testStorage runs the common contract on a backend
+func testStorage(t *testing.T, store Storage) {
+	t.Helper()
+	ctx := context.Background()
+	content := "%PDF-1.4 conteúdo"
+
+	if err := store.Put(ctx, "students/7/doc.pdf", strings.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+		t.Fatalf("Expected Put to succeed, got %v", err)
+	}
+	body, err := store.Get(ctx, "students/7/doc.pdf")
+	if err != nil {
+		t.Fatalf("Expected Get to succeed, got %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != content {
+		t.Errorf("Expected the stored content back, got %q", data)
+	}
+
+	if err := store.Delete(ctx, "students/7/doc.pdf"); err != nil {
+		t.Fatalf("Expected Delete to succeed, got %v", err)
+	}
+	if _, err := store.Get(ctx, "students/7/doc.pdf"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after Delete, got %v", err)
+	}
+	if err := store.Delete(ctx, "students/7/doc.pdf"); err != nil {
+		t.Errorf("Expected deleting a missing object to succeed, got %v", err)
+	}
+	if err := store.Put(ctx, "../escape", strings.NewReader(""), 0, ""); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Expected ErrInvalidKey, got %v", err)
+	}
+}
+
+// fakeS3 is an in-memory stand-in for a path-style S3 endpoint that checks the request signatures
+type fakeS3 struct {
+	t         *testing.T
+	bucket    string
+	accessKey string
+	secretKey string
+	mu        sync.Mutex
+	objects   map[string][]byte
+}
+
+func newFakeS3(t *testing.T, bucket, accessKey, secretKey string) *fakeS3 {
+	return &fakeS3{t: t, bucket: bucket, accessKey: accessKey, secretKey: secretKey, objects: map[string][]byte{}}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !f.authorized(r) {
+		http.Error(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>", http.StatusForbidden)
+		return
+	}
+	prefix := "/" + f.bucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		http.Error(w, "<Error><Code>NoSuchBucket</Code></Error>", http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		if int64(len(data)) != r.ContentLength {
+			http.Error(w, "<Error><Code>IncompleteBody</Code></Error>", http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = data
+	case http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// authorized rebuilds the signature from the request as received
+func (f *fakeS3) authorized(r *http.Request) bool {
+	amzDate := r.Header.Get("X-Amz-Date")
+	when, err := time.Parse("20060102T150405Z", amzDate)
+	if err != nil {
+		return false
+	}
+	date := when.Format("20060102")
+	scope := date + "/us-east-1/s3/aws4_request"
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		r.URL.EscapedPath(),
+		"",
+		"host:" + r.Host,
+		"x-amz-content-sha256:" + r.Header.Get("X-Amz-Content-Sha256"),
+		"x-amz-date:" + amzDate,
+		"",
+		"host;x-amz-content-sha256;x-amz-date",
+		r.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+	signature := hex.EncodeToString(hmacSHA256(signingKey(f.secretKey, date, "us-east-1", "s3"), stringToSign))
+
+	expected := fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=%s",
+		f.accessKey, scope, signature)
+	return r.Header.Get("Authorization") == expected
+}
@@ -0,0 +1,439 @@
+// backend/internal/migrations/course_class_consistency.go
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// defaultClassCode é o código da turma padrão criada para cursos legados
+const defaultClassCode = "2026A"
+
+// migrationNotePrefix marca os vínculos criados automaticamente (usado pelo rollback)
+const migrationNotePrefix = "Migração automática"
+
+// sampleSize limita os IDs de exemplo por problema no relatório
+const sampleSize = 20
+
+// CourseClassIssue identifica um tipo de inconsistência entre Course e CourseClass
+type CourseClassIssue string
+
+const (
+	IssueOrphanedPivots          CourseClassIssue = "orphaned_pivots"           // Pivô sem matrícula ou sem turma
+	IssueCoursesWithoutClass     CourseClassIssue = "courses_without_class"     // Curso sem nenhuma turma
+	IssueSessionCourseMismatch   CourseClassIssue = "session_course_mismatch"   // Aula com course_id diferente da turma
+	IssuePivotCourseMismatch     CourseClassIssue = "pivot_course_mismatch"     // Matrícula vinculada a turma de outro curso
+	IssueEnrollmentsWithoutClass CourseClassIssue = "enrollments_without_class" // Matrícula só no nível do curso
+	IssueSessionsWithoutClass    CourseClassIssue = "sessions_without_class"    // Aula só no nível do curso
+	IssueAttendanceWithoutClass  CourseClassIssue = "attendance_without_class"  // Presença de matrícula sem turma
+)
+
+// consistencyCheck is the SQL that finds the records with an issue, selecting their id
+type consistencyCheck struct {
+	Issue       CourseClassIssue
+	Description string
+	Query       string
+}
+
+// consistencyChecks are ordered as the fix steps run
+var consistencyChecks = []consistencyCheck{
+	{
+		Issue:       IssueOrphanedPivots,
+		Description: "enrollment_course_classes rows pointing to a missing enrollment or course class",
+		Query: `SELECT ecc.id FROM enrollment_course_classes ecc
+			LEFT JOIN enrollments e ON e.id = ecc.enrollment_id
+			LEFT JOIN course_classes cc ON cc.id = ecc.course_class_id
+			WHERE e.id IS NULL OR cc.id IS NULL`,
+	},
+	{
+		Issue:       IssueCoursesWithoutClass,
+		Description: "courses without any course class",
+		Query: `SELECT c.id FROM courses c
+			WHERE c.deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM course_classes cc WHERE cc.course_id = c.id)`,
+	},
+	{
+		Issue:       IssueSessionCourseMismatch,
+		Description: "class sessions whose course_id differs from the course of their class",
+		Query: `SELECT cs.id FROM class_sessions cs
+			JOIN course_classes cc ON cc.id = cs.course_class_id
+			WHERE cs.course_id <> cc.course_id`,
+	},
+	{
+		Issue:       IssuePivotCourseMismatch,
+		Description: "enrollments linked to a class of another course",
+		Query: `SELECT ecc.id FROM enrollment_course_classes ecc
+			JOIN enrollments e ON e.id = ecc.enrollment_id
+			JOIN course_classes cc ON cc.id = ecc.course_class_id
+			WHERE e.course_id <> cc.course_id`,
+	},
+	{
+		Issue:       IssueEnrollmentsWithoutClass,
+		Description: "enrollments linked only to a course",
+		Query: `SELECT e.id FROM enrollments e
+			WHERE NOT EXISTS (SELECT 1 FROM enrollment_course_classes ecc WHERE ecc.enrollment_id = e.id)`,
+	},
+	{
+		Issue:       IssueSessionsWithoutClass,
+		Description: "class sessions linked only to a course",
+		Query:       `SELECT cs.id FROM class_sessions cs WHERE cs.course_class_id IS NULL`,
+	},
+	{
+		Issue:       IssueAttendanceWithoutClass,
+		Description: "attendance records whose enrollment is not linked to a class (fixed with the enrollment)",
+		Query: `SELECT a.id FROM attendances a
+			WHERE NOT EXISTS (SELECT 1 FROM enrollment_course_classes ecc WHERE ecc.enrollment_id = a.enrollment_id)`,
+	},
+}
+
+// IssueReport is the result of one consistency check
+type IssueReport struct {
+	Issue       CourseClassIssue `json:"issue"`
+	Description string           `json:"description"`
+	Count       int64            `json:"count"`
+	SampleIDs   []uint           `json:"sampleIds"`
+}
+
+// ConsistencyReport lists every remaining inconsistency of the Course → CourseClass transition
+type ConsistencyReport struct {
+	CheckedAt  time.Time     `json:"checkedAt"`
+	Consistent bool          `json:"consistent"`
+	Issues     []IssueReport `json:"issues"`
+}
+
+// CheckCourseClassConsistency reporta registros ainda vinculados apenas ao curso,
+// pivôs órfãos e divergências de curso entre aulas, matrículas e turmas. Não altera dados.
+func CheckCourseClassConsistency(db *gorm.DB) (*ConsistencyReport, error) {
+	report := &ConsistencyReport{CheckedAt: time.Now(), Consistent: true}
+
+	for _, check := range consistencyChecks {
+		issue := IssueReport{Issue: check.Issue, Description: check.Description, SampleIDs: []uint{}}
+
+		if err := db.Raw("SELECT COUNT(*) FROM (" + check.Query + ") issue").Scan(&issue.Count).Error; err != nil {
+			return nil, fmt.Errorf("check %s failed: %w", check.Issue, err)
+		}
+		if issue.Count > 0 {
+			report.Consistent = false
+			if err := db.Raw("SELECT id FROM ("+check.Query+") issue ORDER BY id LIMIT ?", sampleSize).Scan(&issue.SampleIDs).Error; err != nil {
+				return nil, fmt.Errorf("check %s failed: %w", check.Issue, err)
+			}
+		}
+
+		report.Issues = append(report.Issues, issue)
+	}
+
+	return report, nil
+}
+
+// DataMigrationCheckpoint guarda o último ID processado de cada etapa,
+// para que uma correção interrompida continue de onde parou
+type DataMigrationCheckpoint struct {
+	Step      string    `gorm:"primaryKey;size:100"`
+	LastID    uint      `gorm:"not null;default:0"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+}
+
+// TableName retorna o nome da tabela
+func (DataMigrationCheckpoint) TableName() string {
+	return "data_migration_checkpoints"
+}
+
+// CourseClassFixOptions controla a correção em lotes
+type CourseClassFixOptions struct {
+	BatchSize  int // Registros por transação (padrão 500)
+	MaxBatches int // Limite de lotes nesta execução; 0 = até o fim
+}
+
+// FixStepResult is the outcome of one fix step
+type FixStepResult struct {
+	Issue         CourseClassIssue `json:"issue"`
+	Fixed         int64            `json:"fixed"`
+	Unresolved    int64            `json:"unresolved"`
+	UnresolvedIDs []uint           `json:"unresolvedIds,omitempty"`
+	Completed     bool             `json:"completed"`
+}
+
+// CourseClassFixResult is the outcome of a fix run
+type CourseClassFixResult struct {
+	Batches   int             `json:"batches"`
+	Completed bool            `json:"completed"` // false when MaxBatches stopped the run; run again to resume
+	Steps     []FixStepResult `json:"steps"`
+}
+
+// fixFunc fixes a batch of ids inside a transaction and returns the ids it could not resolve
+type fixFunc func(tx *gorm.DB, ids []uint, resolver *classResolver) (fixed int64, unresolved []uint, err error)
+
+// FixCourseClassConsistency corrige as inconsistências em lotes transacionais.
+// Cada lote grava um checkpoint; se a execução for interrompida (erro ou MaxBatches),
+// a próxima chamada continua do último lote confirmado. Registros que não podem ser
+// resolvidos automaticamente (ex: curso com várias turmas no mesmo período) são pulados e reportados.
+func FixCourseClassConsistency(db *gorm.DB, opts CourseClassFixOptions) (*CourseClassFixResult, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+
+	steps := []struct {
+		issue CourseClassIssue
+		fix   fixFunc
+	}{
+		{IssueOrphanedPivots, fixOrphanedPivots},
+		{IssueCoursesWithoutClass, fixCoursesWithoutClass},
+		{IssueSessionCourseMismatch, fixSessionCourseMismatch},
+		{IssuePivotCourseMismatch, fixPivotCourseMismatch},
+		{IssueEnrollmentsWithoutClass, fixEnrollmentsWithoutClass},
+		{IssueSessionsWithoutClass, fixSessionsWithoutClass},
+	}
+
+	result := &CourseClassFixResult{Completed: true}
+	for _, step := range steps {
+		stepResult, err := runFixStep(db, step.issue, step.fix, opts, &result.Batches)
+		result.Steps = append(result.Steps, stepResult)
+		if err != nil {
+			result.Completed = false
+			return result, err
+		}
+		if !stepResult.Completed {
+			result.Completed = false
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// runFixStep processes the records of one issue in id order, starting after the checkpoint
+func runFixStep(db *gorm.DB, issue CourseClassIssue, fix fixFunc, opts CourseClassFixOptions, batches *int) (FixStepResult, error) {
+	result := FixStepResult{Issue: issue}
+	query := checkQuery(issue)
+	step := "course_class_fix:" + string(issue)
+
+	var checkpoint DataMigrationCheckpoint
+	if err := db.Where("step = ?", step).First(&checkpoint).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return result, fmt.Errorf("failed to read checkpoint of %s: %w", issue, err)
+	}
+	lastID := checkpoint.LastID
+
+	for {
+		if opts.MaxBatches > 0 && *batches >= opts.MaxBatches {
+			return result, nil
+		}
+
+		var ids []uint
+		if err := db.Raw("SELECT id FROM ("+query+") issue WHERE id > ? ORDER BY id LIMIT ?", lastID, opts.BatchSize).Scan(&ids).Error; err != nil {
+			return result, fmt.Errorf("failed to select %s: %w", issue, err)
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			fixed, unresolved, err := fix(tx, ids, newClassResolver(tx))
+			if err != nil {
+				return err
+			}
+			result.Fixed += fixed
+			result.Unresolved += int64(len(unresolved))
+			for _, id := range unresolved {
+				if len(result.UnresolvedIDs) < sampleSize {
+					result.UnresolvedIDs = append(result.UnresolvedIDs, id)
+				}
+			}
+
+			return tx.Save(&DataMigrationCheckpoint{Step: step, LastID: ids[len(ids)-1]}).Error
+		})
+		if err != nil {
+			return result, fmt.Errorf("batch of %s after id %d failed: %w", issue, lastID, err)
+		}
+
+		*batches++
+		lastID = ids[len(ids)-1]
+	}
+
+	// Etapa concluída: a próxima execução recomeça do início para pegar registros novos
+	if err := db.Where("step = ?", step).Delete(&DataMigrationCheckpoint{}).Error; err != nil {
+		return result, fmt.Errorf("failed to clear checkpoint of %s: %w", issue, err)
+	}
+	result.Completed = true
+	return result, nil
+}
+
+func checkQuery(issue CourseClassIssue) string {
+	for _, check := range consistencyChecks {
+		if check.Issue == issue {
+			return check.Query
+		}
+	}
+	panic("unknown course class issue " + string(issue))
+}
+
+func fixOrphanedPivots(tx *gorm.DB, ids []uint, _ *classResolver) (int64, []uint, error) {
+	result := tx.Where("id IN ?", ids).Delete(&models.EnrollmentCourseClass{})
+	return result.RowsAffected, nil, result.Error
+}
+
+func fixCoursesWithoutClass(tx *gorm.DB, ids []uint, _ *classResolver) (int64, []uint, error) {
+	var courses []models.Course
+	if err := tx.Where("id IN ?", ids).Find(&courses).Error; err != nil {
+		return 0, nil, err
+	}
+
+	var fixed int64
+	for _, course := range courses {
+		if err := migrateCourse(tx, course); err != nil {
+			return fixed, nil, fmt.Errorf("course %d: %w", course.ID, err)
+		}
+		fixed++
+	}
+	return fixed, nil, nil
+}
+
+// fixSessionCourseMismatch trusts the class: the session takes the course of its class
+func fixSessionCourseMismatch(tx *gorm.DB, ids []uint, _ *classResolver) (int64, []uint, error) {
+	result := tx.Exec(`UPDATE class_sessions cs SET course_id = cc.course_id, updated_at = NOW()
+		FROM course_classes cc
+		WHERE cc.id = cs.course_class_id AND cs.id IN ?`, ids)
+	return result.RowsAffected, nil, result.Error
+}
+
+// fixPivotCourseMismatch trusts the enrollment: the pivot moves to a class of the enrollment's course
+func fixPivotCourseMismatch(tx *gorm.DB, ids []uint, resolver *classResolver) (int64, []uint, error) {
+	var rows []struct {
+		ID             uint
+		CourseID       uint
+		EnrollmentDate time.Time
+	}
+	err := tx.Table("enrollment_course_classes ecc").
+		Select("ecc.id, e.course_id, e.enrollment_date").
+		Joins("JOIN enrollments e ON e.id = ecc.enrollment_id").
+		Where("ecc.id IN ?", ids).
+		Scan(&rows).Error
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var fixed int64
+	var unresolved []uint
+	for _, row := range rows {
+		classID, err := resolver.resolve(row.CourseID, row.EnrollmentDate)
+		if err != nil {
+			return fixed, unresolved, err
+		}
+		if classID == nil {
+			unresolved = append(unresolved, row.ID)
+			continue
+		}
+		if err := tx.Model(&models.EnrollmentCourseClass{}).Where("id = ?", row.ID).Update("course_class_id", *classID).Error; err != nil {
+			return fixed, unresolved, err
+		}
+		fixed++
+	}
+	return fixed, unresolved, nil
+}
+
+func fixEnrollmentsWithoutClass(tx *gorm.DB, ids []uint, resolver *classResolver) (int64, []uint, error) {
+	var enrollments []models.Enrollment
+	if err := tx.Where("id IN ?", ids).Find(&enrollments).Error; err != nil {
+		return 0, nil, err
+	}
+
+	var fixed int64
+	var unresolved []uint
+	for _, enrollment := range enrollments {
+		classID, err := resolver.resolve(enrollment.CourseID, enrollment.EnrollmentDate)
+		if err != nil {
+			return fixed, unresolved, err
+		}
+		if classID == nil {
+			unresolved = append(unresolved, enrollment.ID)
+			continue
+		}
+
+		ecc := models.EnrollmentCourseClass{
+			EnrollmentID:  enrollment.ID,
+			CourseClassID: *classID,
+			IsPrimary:     true,
+			Notes:         migrationNotePrefix + ": " + time.Now().Format("2006-01-02"),
+		}
+		if err := tx.Create(&ecc).Error; err != nil {
+			return fixed, unresolved, err
+		}
+		fixed++
+	}
+	return fixed, unresolved, nil
+}
+
+func fixSessionsWithoutClass(tx *gorm.DB, ids []uint, resolver *classResolver) (int64, []uint, error) {
+	var sessions []models.ClassSession
+	if err := tx.Where("id IN ?", ids).Find(&sessions).Error; err != nil {
+		return 0, nil, err
+	}
+
+	var fixed int64
+	var unresolved []uint
+	for _, session := range sessions {
+		classID, err := resolver.resolve(session.CourseID, session.Date)
+		if err != nil {
+			return fixed, unresolved, err
+		}
+		if classID == nil {
+			unresolved = append(unresolved, session.ID)
+			continue
+		}
+		if err := tx.Model(&models.ClassSession{}).Where("id = ?", session.ID).Update("course_class_id", *classID).Error; err != nil {
+			return fixed, unresolved, err
+		}
+		fixed++
+	}
+	return fixed, unresolved, nil
+}
+
+// classResolver chooses the class of a course for a legacy record, caching the classes per course
+type classResolver struct {
+	tx      *gorm.DB
+	classes map[uint][]models.CourseClass
+}
+
+func newClassResolver(tx *gorm.DB) *classResolver {
+	return &classResolver{tx: tx, classes: make(map[uint][]models.CourseClass)}
+}
+
+// resolve returns nil when the course has no class or the choice is ambiguous:
+// a single class wins; otherwise the only class whose period contains the date;
+// otherwise the default class created by the migration.
+func (r *classResolver) resolve(courseID uint, date time.Time) (*uint, error) {
+	classes, ok := r.classes[courseID]
+	if !ok {
+		if err := r.tx.Where("course_id = ?", courseID).Order("id").Find(&classes).Error; err != nil {
+			return nil, err
+		}
+		r.classes[courseID] = classes
+	}
+
+	if len(classes) == 0 {
+		return nil, nil
+	}
+	if len(classes) == 1 {
+		return &classes[0].ID, nil
+	}
+
+	var covering []uint
+	for _, class := range classes {
+		if !date.Before(class.StartDate) && date.Before(class.EndDate.AddDate(0, 0, 1)) {
+			covering = append(covering, class.ID)
+		}
+	}
+	if len(covering) == 1 {
+		return &covering[0], nil
+	}
+
+	for _, class := range classes {
+		if class.Code == defaultClassCode {
+			return &class.ID, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+func TestClassResolver(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+
+	resolver := &classResolver{classes: map[uint][]models.CourseClass{
+		1: {{ID: 10, Code: "2026B", StartDate: date("2026-02-01"), EndDate: date("2026-06-30")}},
+		2: {
+			{ID: 20, Code: defaultClassCode, StartDate: date("2026-02-01"), EndDate: date("2026-06-30")},
+			{ID: 21, Code: "2026B", StartDate: date("2026-08-01"), EndDate: date("2026-12-15")},
+		},
+		3: {
+			{ID: 30, Code: "MANHA", StartDate: date("2026-02-01"), EndDate: date("2026-06-30")},
+			{ID: 31, Code: "TARDE", StartDate: date("2026-02-01"), EndDate: date("2026-06-30")},
+		},
+		4: {},
+	}}
+
+	tests := []struct {
+		name     string
+		courseID uint
+		date     time.Time
+		expected *uint
+	}{
+		{"SingleClass", 1, date("2027-01-10"), uintPtr(10)},
+		{"ClassCoveringDate", 2, date("2026-09-10"), uintPtr(21)},
+		{"LastDayOfClassIsCovered", 2, date("2026-12-15").Add(18 * time.Hour), uintPtr(21)},
+		{"FallsBackToDefaultClass", 2, date("2027-03-01"), uintPtr(20)},
+		{"AmbiguousClasses", 3, date("2026-03-01"), nil},
+		{"CourseWithoutClass", 4, date("2026-03-01"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolver.resolve(tt.courseID, tt.date)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if (got == nil) != (tt.expected == nil) || (got != nil && *got != *tt.expected) {
+				t.Errorf("Expected %v, got %v", deref(tt.expected), deref(got))
+			}
+		})
+	}
+}
+
+func uintPtr(v uint) *uint { return &v }
+
+func deref(v *uint) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -1,4 +1,4 @@
-// backend/internal/migrations/003_migrate_data_to_course_classes.go
+// backend/internal/migrations/course_class_data.go
 package migrations
 
 import (
@@ -11,7 +11,10 @@ import (
 )
 
 // MigrateDataToCourseClasses converte dados do modelo antigo para o novo
-// Cria uma turma padrão para cada curso existente
+// Cria uma turma padrão para cada curso existente e vincula matrículas e aulas às turmas.
+// Ao final, FixCourseClassConsistency corrige em lotes o que as etapas originais não cobrem
+// (pivôs órfãos, divergências de curso, matrículas e aulas fora da turma padrão).
+// Pode ser repetida com segurança.
 func MigrateDataToCourseClasses(db *gorm.DB) error {
 	log.Println("🚀 Iniciando migração de dados: Course → CourseClass...")
 
@@ -23,13 +26,23 @@ func MigrateDataToCourseClasses(db *gorm.DB) error {
 
 	log.Printf("📊 Encontrados %d cursos para migrar", len(courses))
 
-	// 2. Para cada curso, criar uma turma padrão
+	// 2. Para cada curso, criar uma turma padrão e migrar as matrículas
 	for _, course := range courses {
 		if err := migrateCourse(db, course); err != nil {
 			log.Printf("❌ Erro ao migrar curso %d (%s): %v", course.ID, course.Name, err)
 			// Continua com o próximo, não interrompe
 			continue
 		}
+
+		var class models.CourseClass
+		if err := db.Where("course_id = ? AND code = ?", course.ID, defaultClassCode).First(&class).Error; err != nil {
+			log.Printf("❌ Erro ao buscar turma padrão do curso %d (%s): %v", course.ID, course.Name, err)
+			continue
+		}
+		if err := migrateEnrollments(db, course.ID, class.ID); err != nil {
+			log.Printf("❌ Erro ao migrar matrículas do curso %d (%s): %v", course.ID, course.Name, err)
+			continue
+		}
 	}
 
 	// 3. Migrar class_sessions para apontar para course_classes
@@ -37,14 +50,24 @@ func MigrateDataToCourseClasses(db *gorm.DB) error {
 		return fmt.Errorf("erro ao migrar class_sessions: %w", err)
 	}
 
+	// 4. Corrigir em lotes as inconsistências restantes
+	result, err := FixCourseClassConsistency(db, CourseClassFixOptions{})
+	if err != nil {
+		return fmt.Errorf("erro ao corrigir inconsistências: %w", err)
+	}
+	for _, step := range result.Steps {
+		log.Printf("📋 %s: %d corrigidos, %d sem resolução", step.Issue, step.Fixed, step.Unresolved)
+	}
+
 	log.Println("✅ Migração de dados concluída!")
 	return nil
 }
 
+// migrateCourse cria a turma padrão de um curso sem turmas
 func migrateCourse(db *gorm.DB, course models.Course) error {
 	// Verificar se já existe turma para este curso
 	var existingClass models.CourseClass
-	if err := db.Where("course_id = ? AND code = ?", course.ID, "2026A").First(&existingClass).Error; err == nil {
+	if err := db.Where("course_id = ? AND code = ?", course.ID, defaultClassCode).First(&existingClass).Error; err == nil {
 		log.Printf("⚠️  Curso %d (%s) já tem turma, pulando...", course.ID, course.Name)
 		return nil
 	}
@@ -52,7 +75,7 @@ func migrateCourse(db *gorm.DB, course models.Course) error {
 	// Criar turma padrão com dados do curso
 	class := models.CourseClass{
 		CourseID:           course.ID,
-		Code:               "2026A",
+		Code:               defaultClassCode,
 		Name:               course.Name + " - Turma A",
 		WeekDays:           course.WeekDays,
 		StartTime:          course.StartTime,
@@ -68,7 +91,7 @@ func migrateCourse(db *gorm.DB, course models.Course) error {
 
 	// Buscar teacher padrão do curso (se houver)
 	var teacherCourse models.TeacherCourse
-	if err := db.Where("course_id = ? AND role = ? AND active = ?", 
+	if err := db.Where("course_id = ? AND role = ? AND active = ?",
 		course.ID, "primary", true).
 		First(&teacherCourse).Error; err == nil {
 		class.DefaultTeacherID = &teacherCourse.TeacherID
@@ -79,12 +102,6 @@ func migrateCourse(db *gorm.DB, course models.Course) error {
 	}
 
 	log.Printf("✅ Turma criada para curso %d (%s): ID=%d", course.ID, course.Name, class.ID)
-
-	// Migrar matrículas para a nova tabela pivô
-	if err := migrateEnrollments(db, course.ID, class.ID); err != nil {
-		return fmt.Errorf("erro ao migrar matrículas: %w", err)
-	}
-
 	return nil
 }
 
@@ -97,7 +114,7 @@ func migrateEnrollments(db *gorm.DB, courseID uint, classID uint) error {
 	for _, enrollment := range enrollments {
 		// Verificar se já existe na tabela pivô
 		var existing models.EnrollmentCourseClass
-		if err := db.Where("enrollment_id = ? AND course_class_id = ?", 
+		if err := db.Where("enrollment_id = ? AND course_class_id = ?",
 			enrollment.ID, classID).First(&existing).Error; err == nil {
 			continue // Já migrado
 		}
@@ -107,7 +124,7 @@ func migrateEnrollments(db *gorm.DB, courseID uint, classID uint) error {
 			EnrollmentID:  enrollment.ID,
 			CourseClassID: classID,
 			IsPrimary:     true,
-			Notes:         "Migração automática: " + time.Now().Format("2006-01-02"),
+			Notes:         migrationNotePrefix + ": " + time.Now().Format("2006-01-02"),
 		}
 
 		if err := db.Create(&ecc).Error; err != nil {
@@ -132,7 +149,7 @@ func migrateClassSessions(db *gorm.DB) error {
 	for _, session := range sessions {
 		// Buscar a turma padrão do curso
 		var class models.CourseClass
-		if err := db.Where("course_id = ? AND code = ?", session.CourseID, "2026A").
+		if err := db.Where("course_id = ? AND code = ?", session.CourseID, defaultClassCode).
 			First(&class).Error; err != nil {
 			log.Printf("⚠️  Não encontrou turma para curso %d, aula %d", session.CourseID, session.ID)
 			continue
@@ -154,7 +171,7 @@ func RollbackCourseClassesMigration(db *gorm.DB) error {
 	log.Println("⚠️  Iniciando ROLLBACK da migração de CourseClasses...")
 
 	// 1. Remover registros da tabela pivô
-	if err := db.Exec("DELETE FROM enrollment_course_classes WHERE notes LIKE 'Migração automática%'").Error; err != nil {
+	if err := db.Exec("DELETE FROM enrollment_course_classes WHERE notes LIKE ?", migrationNotePrefix+"%").Error; err != nil {
 		return err
 	}
 	log.Println("🗑️  Registros da tabela pivô removidos")
@@ -166,7 +183,7 @@ func RollbackCourseClassesMigration(db *gorm.DB) error {
 	log.Println("🗑️  course_class_id zerado nas aulas")
 
 	// 3. Remover turmas criadas na migração
-	if err := db.Exec("DELETE FROM course_classes WHERE code = '2026A'").Error; err != nil {
+	if err := db.Exec("DELETE FROM course_classes WHERE code = ?", defaultClassCode).Error; err != nil {
 		return err
 	}
 	log.Println("🗑️  Turmas de migração removidas")
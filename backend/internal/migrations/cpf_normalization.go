@@ -0,0 +1,138 @@
+// backend/internal/migrations/cpf_normalization.go
+package migrations
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/pkg/cpf"
+)
+
+// CPFIssue identifica um problema de um CPF gravado
+type CPFIssue string
+
+const (
+	IssueCPFInvalid       CPFIssue = "invalid"        // Dígitos verificadores ou tamanho inválidos (corrigir manualmente)
+	IssueCPFNotNormalized CPFIssue = "not_normalized" // Válido, mas gravado com pontuação
+	IssueCPFDuplicate     CPFIssue = "duplicate"      // Válido, mas outro usuário já tem o mesmo CPF só com dígitos
+)
+
+// cpfTables are the tables with a cpf column; users.cpf is unique
+var cpfTables = []string{"users", "guardians", "user_contacts"}
+
+// CPFRecordIssue is a stored CPF that is not valid and canonical
+type CPFRecordIssue struct {
+	Table string   `json:"table"`
+	ID    uint     `json:"id"`
+	Value string   `json:"value"`
+	Issue CPFIssue `json:"issue"`
+}
+
+// CPFReport lists the stored CPFs that are invalid or not normalized
+type CPFReport struct {
+	CheckedAt time.Time        `json:"checkedAt"`
+	Clean     bool             `json:"clean"`
+	Issues    []CPFRecordIssue `json:"issues"`
+}
+
+// cpfRow is a stored CPF (decrypted when read)
+type cpfRow struct {
+	ID  uint
+	CPF string `gorm:"serializer:encrypted"`
+}
+
+// classifyCPF returns the canonical value of a stored CPF and its issue ("" when already canonical)
+func classifyCPF(value string) (string, CPFIssue) {
+	digits, err := cpf.Parse(value)
+	if err != nil {
+		return value, IssueCPFInvalid
+	}
+	if digits != value {
+		return digits, IssueCPFNotNormalized
+	}
+	return digits, ""
+}
+
+// CheckCPFs reporta os CPFs de usuários, responsáveis e contatos que são inválidos ou
+// ainda não estão só com dígitos. Não altera dados.
+func CheckCPFs(db *gorm.DB) (*CPFReport, error) {
+	report := &CPFReport{CheckedAt: time.Now(), Clean: true, Issues: []CPFRecordIssue{}}
+
+	for _, table := range cpfTables {
+		rows, err := loadCPFs(db, table)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if _, issue := classifyCPF(row.CPF); issue != "" {
+				report.Issues = append(report.Issues, CPFRecordIssue{Table: table, ID: row.ID, Value: row.CPF, Issue: issue})
+			}
+		}
+	}
+	report.Clean = len(report.Issues) == 0
+	return report, nil
+}
+
+// normalizeCPFsUp grava só com dígitos os CPFs válidos com pontuação. Os inválidos e os que
+// colidiriam com outro usuário ficam como estão e são registrados no log (apenas tabela e id);
+// a lista completa sai em GET /api/v1/admin/migrations/cpf/check.
+func normalizeCPFsUp(tx *gorm.DB) error {
+	for _, table := range cpfTables {
+		rows, err := loadCPFs(tx, table)
+		if err != nil {
+			return err
+		}
+
+		var normalized int
+		var invalid, duplicates []uint
+		for _, row := range rows {
+			digits, issue := classifyCPF(row.CPF)
+			switch issue {
+			case IssueCPFInvalid:
+				invalid = append(invalid, row.ID)
+				continue
+			case "":
+				continue
+			}
+
+			if table == "users" {
+				var taken int64
+				if err := tx.Table("users").Where("cpf = ? AND id <> ?", digits, row.ID).Count(&taken).Error; err != nil {
+					return err
+				}
+				if taken > 0 {
+					duplicates = append(duplicates, row.ID)
+					continue
+				}
+			}
+			if err := tx.Table(table).Where("id = ?", row.ID).Update("cpf", digits).Error; err != nil {
+				return fmt.Errorf("failed to normalize %s %d: %w", table, row.ID, err)
+			}
+			normalized++
+		}
+
+		log.Printf("CPF normalization: %s: %d normalized, %d invalid %v, %d duplicated %v",
+			table, normalized, len(invalid), invalid, len(duplicates), duplicates)
+	}
+	return nil
+}
+
+// normalizeCPFsDown não desfaz nada: CPFs só com dígitos continuam aceitos pela versão anterior
+func normalizeCPFsDown(tx *gorm.DB) error {
+	return nil
+}
+
+func loadCPFs(db *gorm.DB, table string) ([]cpfRow, error) {
+	var rows []cpfRow
+	if err := db.Table(table).
+		Select("id, cpf").
+		Where("cpf IS NOT NULL AND cpf <> ''").
+		Order("id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read %s.cpf: %w", table, err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import "testing"
+
+func TestClassifyCPF(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		issue    CPFIssue
+	}{
+		{"52998224725", "52998224725", ""},
+		{"529.982.247-25", "52998224725", IssueCPFNotNormalized},
+		{" 529 982 247 25", "52998224725", IssueCPFNotNormalized},
+		{"529.982.247-24", "529.982.247-24", IssueCPFInvalid},
+		{"111.111.111-11", "111.111.111-11", IssueCPFInvalid},
+		{"não informado", "não informado", IssueCPFInvalid},
+	}
+	for _, tt := range tests {
+		value, issue := classifyCPF(tt.value)
+		if value != tt.expected || issue != tt.issue {
+			t.Errorf("classifyCPF(%q) = %q, %q; expected %q, %q", tt.value, value, issue, tt.expected, tt.issue)
+		}
+	}
+}
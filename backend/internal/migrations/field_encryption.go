@@ -0,0 +1,418 @@
+// backend/internal/migrations/field_encryption.go
+package migrations
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/pkg/fieldcrypt"
+)
+
+// encryptedColumn is a column with gorm:"serializer:encrypted"; Index is its blind index column.
+// With JSONKey it is a key of a jsonb column instead, in the rows matching Filter.
+type encryptedColumn struct {
+	Table   string
+	Column  string
+	Index   string
+	JSONKey string
+	Filter  string
+}
+
+// mergeAudit selects the audit entries of student merges, which hold the student snapshots
+const mergeAudit = "entity_type = 'student' AND action = 'Merge'"
+
+// encryptedColumns are the sensitive columns: health data of the students and CPFs, also inside
+// the snapshots kept by the merges of duplicate students
+var encryptedColumns = []encryptedColumn{
+	{Table: "students", Column: "medical_info"},
+	{Table: "students", Column: "special_needs"},
+	{Table: "users", Column: "cpf", Index: "cpf_hash"},
+	{Table: "guardians", Column: "cpf", Index: "cpf_hash"},
+	{Table: "user_contacts", Column: "cpf", Index: "cpf_hash"},
+	{Table: "pickup_records", Column: "person_document"},
+	{Table: "student_merges", Column: "duplicate_snapshot", JSONKey: "medicalInfo"},
+	{Table: "student_merges", Column: "duplicate_snapshot", JSONKey: "specialNeeds"},
+	{Table: "student_merges", Column: "duplicate_snapshot", JSONKey: "cpf"},
+	{Table: "audit_logs", Column: "old_data", JSONKey: "medicalInfo", Filter: mergeAudit},
+	{Table: "audit_logs", Column: "old_data", JSONKey: "specialNeeds", Filter: mergeAudit},
+	{Table: "audit_logs", Column: "old_data", JSONKey: "cpf", Filter: mergeAudit},
+	{Table: "audit_logs", Column: "new_data", JSONKey: "medicalInfo", Filter: mergeAudit},
+	{Table: "audit_logs", Column: "new_data", JSONKey: "specialNeeds", Filter: mergeAudit},
+	{Table: "audit_logs", Column: "new_data", JSONKey: "cpf", Filter: mergeAudit},
+}
+
+// columnsOf returns the encrypted table columns (json false) or the keys of jsonb columns (json true)
+func columnsOf(json bool) []encryptedColumn {
+	var columns []encryptedColumn
+	for _, column := range encryptedColumns {
+		if (column.JSONKey != "") == json {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// name identifies the column in the reports
+func (c encryptedColumn) name() string {
+	if c.JSONKey == "" {
+		return c.Column
+	}
+	return c.Column + "->" + c.JSONKey
+}
+
+// value is the SQL expression of the stored value
+func (c encryptedColumn) value() string {
+	if c.JSONKey == "" {
+		return c.Column
+	}
+	return fmt.Sprintf("(%s ->> '%s')", c.Column, c.JSONKey)
+}
+
+// where restricts a condition on the value to the rows of the column
+func (c encryptedColumn) where(condition string) string {
+	value := c.value()
+	where := fmt.Sprintf("%s IS NOT NULL AND %s <> '' AND %s", value, value, condition)
+	if c.Filter != "" {
+		where = c.Filter + " AND " + where
+	}
+	return where
+}
+
+// set is the update that stores value in the column
+func (c encryptedColumn) set(value string) map[string]interface{} {
+	if c.JSONKey == "" {
+		return map[string]interface{}{c.Column: value}
+	}
+	return map[string]interface{}{
+		c.Column: gorm.Expr(fmt.Sprintf("jsonb_set(%s, '{%s}', to_jsonb(?::text))", c.Column, c.JSONKey), value),
+	}
+}
+
+const defaultEncryptionBatchSize = 500
+
+// FieldKeyOptions controls a rotation of the field encryption keys
+type FieldKeyOptions struct {
+	BatchSize int  // Linhas por transação (padrão 500)
+	Reindex   bool // Recalcula todos os índices de CPF (após trocar CPF_INDEX_KEY)
+}
+
+// FieldEncryptionColumn counts what was rewritten in a column
+type FieldEncryptionColumn struct {
+	Table     string `json:"table"`
+	Column    string `json:"column"`
+	Encrypted int    `json:"encrypted"` // Valores cifrados ou recifrados com a chave atual
+	Indexed   int    `json:"indexed"`   // Índices de CPF gravados
+	// Usuários cujo CPF já está no índice de outro usuário (ficam sem índice; veja migrations.CheckCPFs)
+	DuplicateIndex []uint `json:"duplicateIndex,omitempty"`
+}
+
+// FieldEncryptionResult is the outcome of an encryption or rotation run
+type FieldEncryptionResult struct {
+	KeyID   string                  `json:"keyId"`
+	Columns []FieldEncryptionColumn `json:"columns"`
+}
+
+// FieldEncryptionStatus counts the values of a column by key
+type FieldEncryptionStatus struct {
+	Table        string           `json:"table"`
+	Column       string           `json:"column"`
+	Plaintext    int64            `json:"plaintext"`
+	ByKey        map[string]int64 `json:"byKey"`
+	MissingIndex int64            `json:"missingIndex,omitempty"`
+}
+
+// FieldEncryptionReport shows whether every sensitive value is encrypted with the current key
+type FieldEncryptionReport struct {
+	CheckedAt   time.Time               `json:"checkedAt"`
+	KeyID       string                  `json:"keyId"`
+	Clean       bool                    `json:"clean"`
+	UnknownKeys []string                `json:"unknownKeys"` // Chaves em uso que não estão configuradas
+	Columns     []FieldEncryptionStatus `json:"columns"`
+}
+
+// encryptFieldsUp cria os índices cegos de CPF e cifra os dados sensíveis ainda em texto claro.
+// O CPF deixa de ser único na coluna (o valor cifrado muda a cada gravação): a unicidade passa
+// para users.cpf_hash. CPFs que colidiriam com outro usuário ficam sem índice e são registrados no log.
+func encryptFieldsUp(tx *gorm.DB) error {
+	if err := tx.Exec(`
+		ALTER TABLE users ADD COLUMN IF NOT EXISTS cpf_hash VARCHAR(64);
+		ALTER TABLE guardians ADD COLUMN IF NOT EXISTS cpf_hash VARCHAR(64);
+		ALTER TABLE user_contacts ADD COLUMN IF NOT EXISTS cpf_hash VARCHAR(64);
+		ALTER TABLE users ALTER COLUMN cpf TYPE TEXT;
+		ALTER TABLE guardians ALTER COLUMN cpf TYPE TEXT;
+		ALTER TABLE user_contacts ALTER COLUMN cpf TYPE TEXT;
+		ALTER TABLE students ALTER COLUMN medical_info TYPE TEXT;
+		ALTER TABLE students ALTER COLUMN special_needs TYPE TEXT;
+		ALTER TABLE users DROP CONSTRAINT IF EXISTS uni_users_cpf;
+		ALTER TABLE users DROP CONSTRAINT IF EXISTS users_cpf_key;
+		DROP INDEX IF EXISTS idx_users_cpf;
+		CREATE INDEX IF NOT EXISTS idx_guardians_cpf_hash ON guardians (cpf_hash);
+		CREATE INDEX IF NOT EXISTS idx_user_contacts_cpf_hash ON user_contacts (cpf_hash);
+	`).Error; err != nil {
+		return err
+	}
+
+	result, err := sealColumns(tx, columnsOf(false), FieldKeyOptions{}, func(fn func(*gorm.DB) error) error { return fn(tx) })
+	if err != nil {
+		return err
+	}
+	for _, column := range result.Columns {
+		log.Printf("Field encryption: %s.%s: %d encrypted, %d indexed, %d duplicated %v",
+			column.Table, column.Column, column.Encrypted, column.Indexed, len(column.DuplicateIndex), column.DuplicateIndex)
+	}
+
+	return tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_users_cpf_hash ON users (cpf_hash)`).Error
+}
+
+// encryptFieldsDown volta os dados para texto claro (com as chaves configuradas) e restaura a
+// unicidade de users.cpf
+func encryptFieldsDown(tx *gorm.DB) error {
+	if err := openColumns(tx, columnsOf(false)); err != nil {
+		return err
+	}
+
+	return tx.Exec(`
+		DROP INDEX IF EXISTS idx_users_cpf_hash;
+		DROP INDEX IF EXISTS idx_guardians_cpf_hash;
+		DROP INDEX IF EXISTS idx_user_contacts_cpf_hash;
+		ALTER TABLE users DROP COLUMN IF EXISTS cpf_hash;
+		ALTER TABLE guardians DROP COLUMN IF EXISTS cpf_hash;
+		ALTER TABLE user_contacts DROP COLUMN IF EXISTS cpf_hash;
+		ALTER TABLE users ADD CONSTRAINT uni_users_cpf UNIQUE (cpf);
+	`).Error
+}
+
+// encryptSnapshotsUp cifra os dados sensíveis das fotografias de alunos gravadas pelas fusões
+// (student_merges.duplicate_snapshot e a trilha de auditoria) antes de serem cifradas na gravação
+func encryptSnapshotsUp(tx *gorm.DB) error {
+	result, err := sealColumns(tx, columnsOf(true), FieldKeyOptions{}, func(fn func(*gorm.DB) error) error { return fn(tx) })
+	if err != nil {
+		return err
+	}
+	for _, column := range result.Columns {
+		log.Printf("Field encryption: %s.%s: %d encrypted", column.Table, column.Column, column.Encrypted)
+	}
+	return nil
+}
+
+// encryptSnapshotsDown volta as fotografias das fusões para texto claro
+func encryptSnapshotsDown(tx *gorm.DB) error {
+	return openColumns(tx, columnsOf(true))
+}
+
+// openColumns decrypts the columns back to plain text with the configured keys
+func openColumns(tx *gorm.DB, columns []encryptedColumn) error {
+	keyring, err := fieldcrypt.Default()
+	if err != nil {
+		return err
+	}
+	for _, column := range columns {
+		for {
+			rows, err := loadEncrypted(tx, column, "left("+column.value()+", 4) = 'enc:'", 0, defaultEncryptionBatchSize)
+			if err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				break
+			}
+			for _, row := range rows {
+				plaintext, err := keyring.Decrypt(row.Value)
+				if err != nil {
+					return fmt.Errorf("failed to decrypt %s.%s %d: %w", column.Table, column.name(), row.ID, err)
+				}
+				if err := tx.Table(column.Table).Where("id = ?", row.ID).Updates(column.set(plaintext)).Error; err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// RotateFieldKeys recifra com a chave atual (a primeira de FIELD_ENCRYPTION_KEYS) os valores
+// ainda em texto claro ou cifrados com chaves antigas e preenche os índices de CPF que faltam.
+// Cada lote é uma transação: se for interrompida, basta rodar de novo. Quando CheckFieldEncryption
+// não mostrar mais valores de uma chave antiga, ela pode sair da configuração.
+func RotateFieldKeys(db *gorm.DB, opts FieldKeyOptions) (*FieldEncryptionResult, error) {
+	return sealColumns(db, encryptedColumns, opts, func(fn func(*gorm.DB) error) error { return db.Transaction(fn) })
+}
+
+// CheckFieldEncryption conta, por coluna, os valores em texto claro, por chave e sem índice.
+// Não altera dados.
+func CheckFieldEncryption(db *gorm.DB) (*FieldEncryptionReport, error) {
+	keyring, err := fieldcrypt.Default()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &FieldEncryptionReport{CheckedAt: time.Now(), KeyID: keyring.Current(), Clean: true, UnknownKeys: []string{}}
+	unknown := map[string]bool{}
+	for _, column := range encryptedColumns {
+		var counts []struct {
+			KeyID string
+			Count int64
+		}
+		if err := db.Raw(fmt.Sprintf(`
+			SELECT CASE WHEN left(%[2]s, 4) = 'enc:' THEN split_part(%[2]s, ':', 2) ELSE '' END AS key_id, COUNT(*) AS count
+			FROM %[1]s
+			WHERE %[3]s
+			GROUP BY 1
+		`, column.Table, column.value(), column.where("TRUE"))).Scan(&counts).Error; err != nil {
+			return nil, fmt.Errorf("failed to check %s.%s: %w", column.Table, column.name(), err)
+		}
+
+		status := FieldEncryptionStatus{Table: column.Table, Column: column.name(), ByKey: map[string]int64{}}
+		for _, count := range counts {
+			if count.KeyID == "" {
+				status.Plaintext = count.Count
+				continue
+			}
+			status.ByKey[count.KeyID] = count.Count
+			if count.KeyID != keyring.Current() {
+				report.Clean = false
+			}
+			if !keyring.Has(count.KeyID) && !unknown[count.KeyID] {
+				unknown[count.KeyID] = true
+				report.UnknownKeys = append(report.UnknownKeys, count.KeyID)
+			}
+		}
+		if column.Index != "" {
+			if err := db.Table(column.Table).
+				Where(fmt.Sprintf("%s IS NOT NULL AND %s <> '' AND %s IS NULL", column.Column, column.Column, column.Index)).
+				Count(&status.MissingIndex).Error; err != nil {
+				return nil, err
+			}
+		}
+		if status.Plaintext > 0 || status.MissingIndex > 0 {
+			report.Clean = false
+		}
+		report.Columns = append(report.Columns, status)
+	}
+	sort.Strings(report.UnknownKeys)
+	return report, nil
+}
+
+// sealColumns encrypts the columns in batches; inBatch runs each batch (in a transaction or in
+// the migration transaction)
+func sealColumns(db *gorm.DB, columns []encryptedColumn, opts FieldKeyOptions, inBatch func(func(*gorm.DB) error) error) (*FieldEncryptionResult, error) {
+	keyring, err := fieldcrypt.Default()
+	if err != nil {
+		return nil, err
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultEncryptionBatchSize
+	}
+
+	result := &FieldEncryptionResult{KeyID: keyring.Current()}
+	for _, column := range columns {
+		current := "enc:" + keyring.Current() + ":"
+		condition := fmt.Sprintf("left(%s, %d) <> '%s'", column.value(), len(current), current)
+		if column.Index != "" {
+			if opts.Reindex {
+				condition = "TRUE"
+			} else {
+				condition = fmt.Sprintf("(%s OR %s IS NULL)", condition, column.Index)
+			}
+		}
+
+		summary := FieldEncryptionColumn{Table: column.Table, Column: column.name()}
+		var afterID uint
+		for {
+			var rows []encryptedRow
+			err := inBatch(func(tx *gorm.DB) error {
+				var err error
+				if rows, err = loadEncrypted(tx, column, condition, afterID, opts.BatchSize); err != nil {
+					return err
+				}
+				for _, row := range rows {
+					if err := sealRow(tx, keyring, column, row, &summary); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return result, err
+			}
+			if len(rows) == 0 {
+				break
+			}
+			afterID = rows[len(rows)-1].ID
+		}
+		result.Columns = append(result.Columns, summary)
+	}
+	return result, nil
+}
+
+// sealRow encrypts a value with the current key and writes its blind index
+func sealRow(tx *gorm.DB, keyring *fieldcrypt.Keyring, column encryptedColumn, row encryptedRow, summary *FieldEncryptionColumn) error {
+	plaintext, err := keyring.Decrypt(row.Value)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt %s.%s %d: %w", column.Table, column.name(), row.ID, err)
+	}
+
+	updates := map[string]interface{}{}
+	if fieldcrypt.KeyID(row.Value) != keyring.Current() {
+		encrypted, err := keyring.Encrypt(plaintext)
+		if err != nil {
+			return err
+		}
+		updates = column.set(encrypted)
+		summary.Encrypted++
+	}
+	if column.Index != "" {
+		index, err := models.CPFIndex(plaintext)
+		if err != nil {
+			return err
+		}
+		taken := int64(0)
+		if column.Table == "users" {
+			if err := tx.Table("users").Where("cpf_hash = ? AND id <> ?", index, row.ID).Count(&taken).Error; err != nil {
+				return err
+			}
+		}
+		if taken > 0 {
+			summary.DuplicateIndex = append(summary.DuplicateIndex, row.ID)
+		} else if row.BlindIndex == nil || *row.BlindIndex != index {
+			updates[column.Index] = index
+			summary.Indexed++
+		}
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	if err := tx.Table(column.Table).Where("id = ?", row.ID).Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to encrypt %s.%s %d: %w", column.Table, column.name(), row.ID, err)
+	}
+	return nil
+}
+
+// encryptedRow is a stored value as it is in the database
+type encryptedRow struct {
+	ID         uint
+	Value      string
+	BlindIndex *string
+}
+
+// loadEncrypted reads a batch of non-empty values matching condition, after afterID
+func loadEncrypted(db *gorm.DB, column encryptedColumn, condition string, afterID uint, limit int) ([]encryptedRow, error) {
+	index := "NULL"
+	if column.Index != "" {
+		index = column.Index
+	}
+	var rows []encryptedRow
+	if err := db.Table(column.Table).
+		Select(fmt.Sprintf("id, %s AS value, %s AS blind_index", column.value(), index)).
+		Where(column.where("id > ? AND "+condition), afterID).
+		Order("id").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read %s.%s: %w", column.Table, column.name(), err)
+	}
+	return rows, nil
+}
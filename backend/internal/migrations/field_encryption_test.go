@@ -0,0 +1,99 @@
+package migrations
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/gorm/schema"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/pkg/fieldcrypt"
+)
+
+func setKeyring(t *testing.T) {
+	t.Helper()
+	keyring, err := fieldcrypt.NewKeyring("test", map[string]string{"test": "test secret"}, "test index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fieldcrypt.SetDefault(keyring)
+}
+
+// TestEncryptedColumns keeps encryptedColumns in sync with the serializer:encrypted model fields
+func TestEncryptedColumns(t *testing.T) {
+	listed := map[string]bool{}
+	for _, column := range encryptedColumns {
+		listed[column.Table+"."+column.Column] = true
+	}
+
+	for _, model := range []interface{}{&models.Student{}, &models.User{}, &models.Guardian{}, &models.UserContact{}, &models.PickupRecord{}} {
+		sch, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, field := range sch.Fields {
+			if field.TagSettings["SERIALIZER"] == "encrypted" && !listed[sch.Table+"."+field.DBName] {
+				t.Errorf("%s.%s is encrypted but not in encryptedColumns (not covered by the migration and the rotation)", sch.Table, field.DBName)
+			}
+		}
+	}
+}
+
+func TestEncryptedSerializer(t *testing.T) {
+	setKeyring(t)
+	ctx := context.Background()
+	sch, err := schema.Parse(&models.Student{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	field := sch.LookUpField("medical_info")
+
+	student := models.Student{MedicalInfo: "Alergia a dipirona"}
+	value, _ := field.ValueOf(ctx, reflect.ValueOf(&student).Elem())
+	stored, err := value.(driver.Valuer).Value()
+	if err != nil {
+		t.Fatalf("Expected value to be encrypted, got %v", err)
+	}
+	if encrypted, _ := stored.(string); !strings.HasPrefix(encrypted, "enc:test:") || strings.Contains(encrypted, "dipirona") {
+		t.Fatalf("Expected encrypted value, got %v", stored)
+	}
+
+	for _, dbValue := range []interface{}{stored, "Texto antigo", nil} {
+		var read models.Student
+		scanner := field.NewValuePool.Get()
+		if err := scanner.(interface{ Scan(interface{}) error }).Scan(dbValue); err != nil {
+			t.Fatal(err)
+		}
+		if err := field.Set(ctx, reflect.ValueOf(&read).Elem(), scanner); err != nil {
+			t.Fatalf("Expected %v to be read, got %v", dbValue, err)
+		}
+		expected := map[interface{}]string{stored: "Alergia a dipirona", "Texto antigo": "Texto antigo", nil: ""}[dbValue]
+		if read.MedicalInfo != expected {
+			t.Errorf("Read %v as %q, expected %q", dbValue, read.MedicalInfo, expected)
+		}
+	}
+}
+
+func TestCPFIndex(t *testing.T) {
+	setKeyring(t)
+	digits, _ := models.CPFIndex("52998224725")
+	formatted, _ := models.CPFIndex(" 529.982.247-25 ")
+	if digits == "" || digits != formatted {
+		t.Errorf("Expected the same index for any formatting, got %q and %q", digits, formatted)
+	}
+	if empty, _ := models.CPFIndex(""); empty != "" {
+		t.Errorf("Expected no index for an empty CPF, got %q", empty)
+	}
+
+	user := models.User{CPF: "529.982.247-25"}
+	if err := user.BeforeSave(nil); err != nil {
+		t.Fatal(err)
+	}
+	if user.CPF != "52998224725" || user.CPFHash == nil || *user.CPFHash != digits {
+		t.Errorf("Expected canonical CPF and index, got %q %v", user.CPF, user.CPFHash)
+	}
+}
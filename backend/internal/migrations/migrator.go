@@ -0,0 +1,316 @@
+// backend/internal/migrations/migrator.go
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// advisoryLockKey serializes migration runs between app instances and the CLI
+const advisoryLockKey = 7_202_602_900
+
+var (
+	// ErrChecksumMismatch is returned when an applied migration was changed afterwards
+	ErrChecksumMismatch = errors.New("applied migration checksum mismatch")
+	// ErrIrreversible is returned when rolling back a migration without a down step
+	ErrIrreversible = errors.New("migration has no down step")
+	// ErrUnknownVersion is returned when the database has a version this build does not know
+	ErrUnknownVersion = errors.New("database has an unknown migration version")
+)
+
+// Migration is one versioned schema change.
+// SQL migrations set UpSQL/DownSQL; Go migrations set Up/Down.
+type Migration struct {
+	Version int64
+	Name    string
+
+	UpSQL   string
+	DownSQL string
+
+	Up   func(tx *gorm.DB) error
+	Down func(tx *gorm.DB) error
+
+	// DisableTransaction runs the step outside a transaction (e.g. CREATE INDEX CONCURRENTLY)
+	DisableTransaction bool
+}
+
+// Checksum identifies the migration content. SQL migrations hash their up script;
+// Go migrations hash their name, so renaming one is detected as a change.
+func (m Migration) Checksum() string {
+	content := m.UpSQL
+	if m.Up != nil {
+		content = "go:" + m.Name
+	}
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func (m Migration) reversible() bool {
+	return m.Down != nil || m.DownSQL != ""
+}
+
+// SchemaMigration is a row of the schema_migrations table
+type SchemaMigration struct {
+	Version     int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name        string    `gorm:"not null"`
+	Checksum    string    `gorm:"size:64;not null"`
+	AppliedAt   time.Time `gorm:"not null"`
+	ExecutionMs int64     `gorm:"not null;default:0"`
+}
+
+// TableName retorna o nome da tabela
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Status is the state of one migration
+type Status struct {
+	Version          int64      `json:"version"`
+	Name             string     `json:"name"`
+	Applied          bool       `json:"applied"`
+	AppliedAt        *time.Time `json:"appliedAt,omitempty"`
+	ExecutionMs      int64      `json:"executionMs,omitempty"`
+	Reversible       bool       `json:"reversible"`
+	ChecksumMismatch bool       `json:"checksumMismatch,omitempty"`
+	Unknown          bool       `json:"unknown,omitempty"` // applied in the database but missing from this build
+}
+
+// Migrator applies and rolls back the registered migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator for the given migrations, which must have unique positive versions
+func NewMigrator(db *gorm.DB, migrations []Migration) (*Migrator, error) {
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	for i, m := range sorted {
+		if m.Version <= 0 {
+			return nil, fmt.Errorf("migration %q: version must be positive", m.Name)
+		}
+		if i > 0 && sorted[i-1].Version == m.Version {
+			return nil, fmt.Errorf("duplicate migration version %d (%s, %s)", m.Version, sorted[i-1].Name, m.Name)
+		}
+		if (m.Up == nil) == (m.UpSQL == "") {
+			return nil, fmt.Errorf("migration %d_%s: exactly one of Up or UpSQL must be set", m.Version, m.Name)
+		}
+	}
+
+	return &Migrator{db: db, migrations: sorted}, nil
+}
+
+// Status lists every known migration and any applied version missing from this build
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(m.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{
+			Version:    migration.Version,
+			Name:       migration.Name,
+			Reversible: migration.reversible(),
+		}
+		if row, ok := applied[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.ExecutionMs = row.ExecutionMs
+			status.ChecksumMismatch = row.Checksum != migration.Checksum()
+			delete(applied, migration.Version)
+		}
+		result = append(result, status)
+	}
+
+	for _, row := range applied {
+		appliedAt := row.AppliedAt
+		result = append(result, Status{
+			Version:   row.Version,
+			Name:      row.Name,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+			Unknown:   true,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+
+	return result, nil
+}
+
+// Up applies every pending migration in order and returns the applied ones
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(conn, migration); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down rolls back the last `steps` applied migrations and returns the reverted ones
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err := m.revert(conn, migration); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", advisoryLockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", advisoryLockKey)
+
+		if err := conn.AutoMigrate(&SchemaMigration{}); err != nil {
+			return fmt.Errorf("failed to create schema_migrations: %w", err)
+		}
+		return fn(conn)
+	})
+}
+
+func (m *Migrator) applied(conn *gorm.DB) (map[int64]SchemaMigration, error) {
+	applied := make(map[int64]SchemaMigration)
+	if !conn.Migrator().HasTable(&SchemaMigration{}) {
+		return applied, nil
+	}
+
+	var rows []SchemaMigration
+	if err := conn.Order("version").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// verify refuses to run when applied migrations were edited or are unknown to this build
+func (m *Migrator) verify(applied map[int64]SchemaMigration) error {
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	for version, row := range applied {
+		migration, ok := known[version]
+		if !ok {
+			return fmt.Errorf("%w: %d_%s", ErrUnknownVersion, version, row.Name)
+		}
+		if row.Checksum != migration.Checksum() {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, version, migration.Name)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) apply(conn *gorm.DB, migration Migration) error {
+	log.Printf("⬆️  Aplicando migração %d_%s...", migration.Version, migration.Name)
+	start := time.Now()
+
+	run := func(tx *gorm.DB) error {
+		if migration.Up != nil {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+		} else if err := tx.Exec(migration.UpSQL).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&SchemaMigration{
+			Version:     migration.Version,
+			Name:        migration.Name,
+			Checksum:    migration.Checksum(),
+			AppliedAt:   time.Now(),
+			ExecutionMs: time.Since(start).Milliseconds(),
+		}).Error
+	}
+
+	if err := m.run(conn, migration, run); err != nil {
+		return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+
+	log.Printf("✅ Migração %d_%s aplicada em %s", migration.Version, migration.Name, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+func (m *Migrator) revert(conn *gorm.DB, migration Migration) error {
+	if !migration.reversible() {
+		return fmt.Errorf("%w: %d_%s", ErrIrreversible, migration.Version, migration.Name)
+	}
+	log.Printf("⬇️  Revertendo migração %d_%s...", migration.Version, migration.Name)
+
+	run := func(tx *gorm.DB) error {
+		if migration.Down != nil {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+		} else if err := tx.Exec(migration.DownSQL).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&SchemaMigration{}, "version = ?", migration.Version).Error
+	}
+
+	if err := m.run(conn, migration, run); err != nil {
+		return fmt.Errorf("rollback of %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+
+	log.Printf("✅ Migração %d_%s revertida", migration.Version, migration.Name)
+	return nil
+}
+
+func (m *Migrator) run(conn *gorm.DB, migration Migration, fn func(tx *gorm.DB) error) error {
+	if migration.DisableTransaction {
+		return fn(conn)
+	}
+	return conn.Transaction(fn)
+}
@@ -0,0 +1,116 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"gorm.io/gorm"
+)
+
+func noop(tx *gorm.DB) error { return nil }
+
+func TestNewMigrator(t *testing.T) {
+	t.Run("SortsByVersion", func(t *testing.T) {
+		m, err := NewMigrator(nil, []Migration{
+			{Version: 3, Name: "c", UpSQL: "SELECT 3"},
+			{Version: 1, Name: "a", Up: noop},
+			{Version: 2, Name: "b", UpSQL: "SELECT 2"},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for i, migration := range m.migrations {
+			if migration.Version != int64(i+1) {
+				t.Errorf("Expected version %d at position %d, got %d", i+1, i, migration.Version)
+			}
+		}
+	})
+
+	t.Run("RejectsDuplicateVersions", func(t *testing.T) {
+		_, err := NewMigrator(nil, []Migration{
+			{Version: 1, Name: "a", UpSQL: "SELECT 1"},
+			{Version: 1, Name: "b", UpSQL: "SELECT 1"},
+		})
+		if err == nil {
+			t.Error("Expected an error for duplicate versions")
+		}
+	})
+
+	t.Run("RequiresExactlyOneUpStep", func(t *testing.T) {
+		if _, err := NewMigrator(nil, []Migration{{Version: 1, Name: "empty"}}); err == nil {
+			t.Error("Expected an error for a migration without up step")
+		}
+		if _, err := NewMigrator(nil, []Migration{{Version: 1, Name: "both", Up: noop, UpSQL: "SELECT 1"}}); err == nil {
+			t.Error("Expected an error for a migration with both up steps")
+		}
+	})
+}
+
+func TestChecksum(t *testing.T) {
+	a := Migration{Version: 2, Name: "x", UpSQL: "CREATE TABLE a (id int);"}
+	b := Migration{Version: 2, Name: "x", UpSQL: "CREATE TABLE a (id bigint);"}
+
+	if a.Checksum() == b.Checksum() {
+		t.Error("Expected different checksums for different SQL")
+	}
+	if a.Checksum() != (Migration{Version: 2, Name: "x", UpSQL: a.UpSQL, DownSQL: "DROP TABLE a;"}).Checksum() {
+		t.Error("Expected the down script not to affect the checksum")
+	}
+	if (Migration{Name: "baseline", Up: noop}).Checksum() == (Migration{Name: "baseline_v2", Up: noop}).Checksum() {
+		t.Error("Expected Go migration checksums to depend on the name")
+	}
+}
+
+func TestLoadSQLMigrations(t *testing.T) {
+	t.Run("PairsUpAndDownFiles", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/0002_add_index.up.sql":   {Data: []byte("CREATE INDEX i ON t (c);")},
+			"sql/0002_add_index.down.sql": {Data: []byte("DROP INDEX i;")},
+			"sql/0003_add_column.up.sql":  {Data: []byte("ALTER TABLE t ADD COLUMN d int;")},
+		}
+
+		migrations, err := loadSQLMigrations(fsys, "sql")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(migrations) != 2 {
+			t.Fatalf("Expected 2 migrations, got %d", len(migrations))
+		}
+		if migrations[0].Name != "add_index" || migrations[0].DownSQL != "DROP INDEX i;" {
+			t.Errorf("Unexpected first migration: %+v", migrations[0])
+		}
+		if migrations[1].reversible() {
+			t.Error("Expected migration without down file to be irreversible")
+		}
+	})
+
+	t.Run("RejectsInvalidNames", func(t *testing.T) {
+		fsys := fstest.MapFS{"sql/add_index.sql": {Data: []byte("SELECT 1")}}
+		if _, err := loadSQLMigrations(fsys, "sql"); err == nil {
+			t.Error("Expected an error for an invalid file name")
+		}
+	})
+
+	t.Run("RejectsDownWithoutUp", func(t *testing.T) {
+		fsys := fstest.MapFS{"sql/0004_orphan.down.sql": {Data: []byte("SELECT 1")}}
+		if _, err := loadSQLMigrations(fsys, "sql"); err == nil {
+			t.Error("Expected an error for a migration without up file")
+		}
+	})
+}
+
+func TestRegisteredMigrations(t *testing.T) {
+	all, err := All()
+	if err != nil {
+		t.Fatalf("Expected embedded migrations to load, got %v", err)
+	}
+	m, err := NewMigrator(nil, all)
+	if err != nil {
+		t.Fatalf("Expected registered migrations to be valid, got %v", err)
+	}
+
+	// The baseline is frozen SQL, so editing it is caught by the checksum
+	if baseline := m.migrations[0]; baseline.Version != 1 || baseline.UpSQL == "" {
+		t.Errorf("Expected version 1 to be the frozen SQL baseline, got %d_%s", baseline.Version, baseline.Name)
+	}
+}
@@ -0,0 +1,103 @@
+// backend/internal/migrations/registry.go
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// sqlFiles holds the SQL migrations, named <version>_<name>.up.sql / <version>_<name>.down.sql
+//
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+var sqlFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// goMigrations are the migrations that need Go code (data fixes, encryption).
+// New schema changes should preferably be SQL files in sql/.
+var goMigrations = []Migration{
+	{Version: 14, Name: "normalize_cpf", Up: normalizeCPFsUp, Down: normalizeCPFsDown},
+	{Version: 20, Name: "encrypt_sensitive_fields", Up: encryptFieldsUp, Down: encryptFieldsDown},
+	{Version: 22, Name: "encrypt_merge_snapshots", Up: encryptSnapshotsUp, Down: encryptSnapshotsDown},
+}
+
+// All returns every registered migration (Go and SQL), ordered by version
+func All() ([]Migration, error) {
+	sqlMigrations, err := loadSQLMigrations(sqlFiles, "sql")
+	if err != nil {
+		return nil, err
+	}
+
+	all := append([]Migration{}, goMigrations...)
+	all = append(all, sqlMigrations...)
+	return all, nil
+}
+
+// New creates a migrator with every registered migration
+func New(db *gorm.DB) (*Migrator, error) {
+	all, err := All()
+	if err != nil {
+		return nil, err
+	}
+	return NewMigrator(db, all)
+}
+
+// loadSQLMigrations pairs the up/down files of each version in dir
+func loadSQLMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SQL migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	var order []int64
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := sqlFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid SQL migration file name %q", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SQL migration version in %q: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+			order = append(order, version)
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("SQL migration %d has files with different names (%s, %s)", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.UpSQL = string(content)
+		} else {
+			migration.DownSQL = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(order))
+	for _, version := range order {
+		migration := byVersion[version]
+		if migration.UpSQL == "" {
+			return nil, fmt.Errorf("SQL migration %d_%s has no up file", version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	return migrations, nil
+}
@@ -0,0 +1,1299 @@
+-- Schema da aplicação no momento em que o versionamento foi adotado (antigo postgres.MigrateDB,
+-- migração 002 de turmas/skills/substituição e scripts avulsos de scripts/migrations).
+-- Gerado a partir dos modelos GORM daquela versão e congelado: mudanças de schema
+-- posteriores são novas migrações, nunca edições deste arquivo (o checksum acusa a alteração).
+-- Bases anteriores ao versionamento já estão neste estado, por isso tudo é IF NOT EXISTS.
+
+-- Compatibilidade com bases antigas/incompletas: enum de status, colunas de Student
+-- e default da coluna legada users.profile
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'student_status') THEN
+        CREATE TYPE student_status AS ENUM ('active', 'inactive', 'suspended');
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM information_schema.tables
+        WHERE table_schema = 'public' AND table_name = 'students'
+    ) THEN
+        ALTER TABLE students ADD COLUMN IF NOT EXISTS special_needs text;
+        ALTER TABLE students ADD COLUMN IF NOT EXISTS medical_info text;
+        ALTER TABLE students ADD COLUMN IF NOT EXISTS social_media jsonb;
+        ALTER TABLE students ADD COLUMN IF NOT EXISTS notes text;
+    END IF;
+END
+$$;
+
+-- Em algumas bases antigas a coluna users.profile (texto) é NOT NULL; o sistema atual usa profile_id
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM information_schema.columns
+        WHERE table_schema = 'public'
+          AND table_name = 'users'
+          AND column_name = 'profile'
+    ) THEN
+        ALTER TABLE users ALTER COLUMN profile SET DEFAULT 'student';
+        UPDATE users
+        SET profile = 'student'
+        WHERE profile IS NULL OR profile = '';
+    END IF;
+END
+$$;
+
+-- Tabelas e índices
+CREATE TABLE IF NOT EXISTS "user_profiles" (
+    "id" bigserial,
+    "name" varchar(50) NOT NULL,
+    "description" varchar(255),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_user_profiles_name" ON "user_profiles" ("name");
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" bigserial,
+    "keycloak_user_id" text,
+    "name" text NOT NULL,
+    "email" text NOT NULL,
+    "password" text NOT NULL,
+    "profile_id" bigint NOT NULL DEFAULT 3,
+    "cpf" text,
+    "birth_date" timestamptz,
+    "phone" text,
+    "photo_url" text,
+    "active" boolean DEFAULT true,
+    "last_login" timestamptz,
+    "reset_token" text,
+    "token_expiration" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_users_keycloak_user_id" UNIQUE ("keycloak_user_id"),
+    CONSTRAINT "uni_users_email" UNIQUE ("email"),
+    CONSTRAINT "uni_users_cpf" UNIQUE ("cpf")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_users_profile_id" ON "users" ("profile_id");
+CREATE INDEX IF NOT EXISTS "idx_users_keycloak_user_id" ON "users" ("keycloak_user_id");
+
+CREATE TABLE IF NOT EXISTS "addresses" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "cep" varchar(10),
+    "street" varchar(255),
+    "number" varchar(20),
+    "complement" varchar(100),
+    "neighborhood" varchar(100),
+    "city" varchar(100),
+    "state" varchar(2),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_addresses_user_id" UNIQUE ("user_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_addresses_user_id" ON "addresses" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_addresses_deleted_at" ON "addresses" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "user_contacts" (
+    "id" bigserial,
+    "user_id" bigint,
+    "student_id" bigint,
+    "name" text NOT NULL,
+    "email" text,
+    "phone" text,
+    "cpf" text,
+    "relationship" text NOT NULL,
+    "can_pickup" boolean DEFAULT false,
+    "receive_notifications" boolean DEFAULT false,
+    "authorize_activities" boolean DEFAULT false,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_user_contacts_deleted_at" ON "user_contacts" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_user_contacts_student_id" ON "user_contacts" ("student_id");
+CREATE INDEX IF NOT EXISTS "idx_user_contacts_user_id" ON "user_contacts" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "students" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "registration_number" varchar(10) NOT NULL,
+    "status" student_status NOT NULL DEFAULT 'active',
+    "special_needs" text,
+    "medical_info" text,
+    "social_media" json,
+    "notes" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_students_user_id" UNIQUE ("user_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_students_deleted_at" ON "students" ("deleted_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_students_registration_number" ON "students" ("registration_number");
+CREATE INDEX IF NOT EXISTS "idx_students_user_id" ON "students" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "guardians" (
+    "id" bigserial,
+    "student_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    "email" text,
+    "phone" text,
+    "cpf" text,
+    "relationship" text NOT NULL,
+    "can_pickup" boolean DEFAULT false,
+    "receive_notifications" boolean DEFAULT true,
+    "authorize_activities" boolean DEFAULT false,
+    "user_id" bigint,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_guardians_deleted_at" ON "guardians" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_guardians_student_id" ON "guardians" ("student_id");
+
+CREATE TABLE IF NOT EXISTS "guardian_permissions" (
+    "id" bigserial,
+    "guardian_id" bigint NOT NULL,
+    "pickup_student" boolean DEFAULT false,
+    "receive_notifications" boolean DEFAULT true,
+    "authorize_activities" boolean DEFAULT false,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_guardian_permissions_guardian_id" ON "guardian_permissions" ("guardian_id");
+
+CREATE TABLE IF NOT EXISTS "student_notes" (
+    "id" bigserial,
+    "student_id" bigint NOT NULL,
+    "author_id" bigint NOT NULL,
+    "content" text NOT NULL,
+    "is_confidential" boolean DEFAULT false,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_student_notes_student_id" ON "student_notes" ("student_id");
+
+CREATE TABLE IF NOT EXISTS "documents" (
+    "id" bigserial,
+    "student_id" bigint NOT NULL,
+    "name" text NOT NULL,
+    "type" text NOT NULL,
+    "path" text NOT NULL,
+    "uploaded_by_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_documents_student_id" ON "documents" ("student_id");
+
+CREATE TABLE IF NOT EXISTS "teachers" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "specialization" text,
+    "bio" text,
+    "phone" text,
+    "active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_teachers_user_id" ON "teachers" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "course_categories" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "description" text,
+    "color" text,
+    "icon" text,
+    "order" bigint,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_course_categories_name" UNIQUE ("name")
+);
+
+CREATE TABLE IF NOT EXISTS "courses" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "short_description" text,
+    "cover_image" text,
+    "detailed_description" text,
+    "workload" bigint NOT NULL,
+    "google_classroom_url" text,
+    "google_classroom_id" text,
+    "max_students" bigint NOT NULL,
+    "prerequisites" text,
+    "difficulty_level" text,
+    "target_audience" text,
+    "tags" json,
+    "category_id" bigint,
+    "week_days" text NOT NULL,
+    "start_time" text NOT NULL,
+    "end_time" text NOT NULL,
+    "schedule" text,
+    "duration" bigint NOT NULL,
+    "start_date" timestamptz,
+    "end_date" timestamptz,
+    "status" text NOT NULL DEFAULT 'active',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_courses_deleted_at" ON "courses" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_courses_category_id" ON "courses" ("category_id");
+
+CREATE TABLE IF NOT EXISTS "teacher_courses" (
+    "id" bigserial,
+    "teacher_id" bigint NOT NULL,
+    "course_id" bigint NOT NULL,
+    "role" text NOT NULL,
+    "start_date" timestamptz NOT NULL,
+    "end_date" timestamptz,
+    "active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_teacher_courses_teacher_id" ON "teacher_courses" ("teacher_id");
+CREATE INDEX IF NOT EXISTS "idx_teacher_courses_course_id" ON "teacher_courses" ("course_id");
+
+CREATE TABLE IF NOT EXISTS "skills" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "domain" text,
+    "description" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_skills_name" UNIQUE ("name")
+);
+
+CREATE TABLE IF NOT EXISTS "teacher_skills" (
+    "id" bigserial,
+    "teacher_id" bigint NOT NULL,
+    "skill_id" bigint NOT NULL,
+    "level" text DEFAULT 'intermediate',
+    "notes" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_teacher_skills_skill_id" ON "teacher_skills" ("skill_id");
+CREATE INDEX IF NOT EXISTS "idx_teacher_skills_teacher_id" ON "teacher_skills" ("teacher_id");
+
+CREATE TABLE IF NOT EXISTS "teacher_availability" (
+    "id" bigserial,
+    "teacher_id" bigint NOT NULL,
+    "day_of_week" bigint NOT NULL,
+    "start_time" text NOT NULL,
+    "end_time" text NOT NULL,
+    "is_active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_teacher_availability_teacher_id" ON "teacher_availability" ("teacher_id");
+
+CREATE TABLE IF NOT EXISTS "teacher_absences" (
+    "id" bigserial,
+    "teacher_id" bigint NOT NULL,
+    "start_date" timestamptz NOT NULL,
+    "end_date" timestamptz NOT NULL,
+    "reason" text,
+    "status" text NOT NULL DEFAULT 'active',
+    "created_by_id" bigint NOT NULL,
+    "resolved_at" timestamptz,
+    "resolved_by_id" bigint,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_teacher_absences_teacher_id" ON "teacher_absences" ("teacher_id");
+
+CREATE TABLE IF NOT EXISTS "locations" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "capacity" bigint,
+    "resources" text,
+    "is_active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "course_classes" (
+    "id" bigserial,
+    "course_id" bigint NOT NULL,
+    "code" text NOT NULL,
+    "name" text,
+    "week_days" text NOT NULL,
+    "start_time" text NOT NULL,
+    "end_time" text NOT NULL,
+    "start_date" timestamptz NOT NULL,
+    "end_date" timestamptz NOT NULL,
+    "default_location_id" bigint,
+    "default_teacher_id" bigint,
+    "capacity" bigint NOT NULL DEFAULT 30,
+    "max_students" bigint NOT NULL,
+    "google_classroom_url" text,
+    "google_classroom_id" text,
+    "status" text NOT NULL DEFAULT 'active',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_course_classes_default_teacher_id" ON "course_classes" ("default_teacher_id");
+CREATE INDEX IF NOT EXISTS "idx_course_classes_default_location_id" ON "course_classes" ("default_location_id");
+CREATE INDEX IF NOT EXISTS "idx_course_classes_course_id" ON "course_classes" ("course_id");
+
+CREATE TABLE IF NOT EXISTS "substitutions" (
+    "id" bigserial,
+    "original_teacher_id" bigint NOT NULL,
+    "substitute_teacher_id" bigint NOT NULL,
+    "course_class_id" bigint NOT NULL,
+    "class_session_id" bigint,
+    "date" timestamptz NOT NULL,
+    "status" text NOT NULL DEFAULT 'pending',
+    "requested_by_id" bigint NOT NULL,
+    "notes" text,
+    "response_notes" text,
+    "responded_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_substitutions_substitute_teacher_id" ON "substitutions" ("substitute_teacher_id");
+CREATE INDEX IF NOT EXISTS "idx_substitutions_original_teacher_id" ON "substitutions" ("original_teacher_id");
+CREATE INDEX IF NOT EXISTS "idx_substitutions_class_session_id" ON "substitutions" ("class_session_id");
+CREATE INDEX IF NOT EXISTS "idx_substitutions_course_class_id" ON "substitutions" ("course_class_id");
+
+CREATE TABLE IF NOT EXISTS "class_sessions" (
+    "id" bigserial,
+    "course_id" bigint NOT NULL,
+    "course_class_id" bigint,
+    "location_id" bigint,
+    "teacher_id" bigint,
+    "date" timestamptz NOT NULL,
+    "start_time" text,
+    "end_time" text,
+    "topic" text,
+    "syllabus_topic_id" bigint,
+    "topic_override" text,
+    "is_cancelled" boolean DEFAULT false,
+    "cancellation_reason" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_class_sessions_syllabus_topic_id" ON "class_sessions" ("syllabus_topic_id");
+CREATE INDEX IF NOT EXISTS "idx_class_sessions_teacher_id" ON "class_sessions" ("teacher_id");
+CREATE INDEX IF NOT EXISTS "idx_class_sessions_location_id" ON "class_sessions" ("location_id");
+CREATE INDEX IF NOT EXISTS "idx_class_sessions_course_class_id" ON "class_sessions" ("course_class_id");
+CREATE INDEX IF NOT EXISTS "idx_class_sessions_course_id" ON "class_sessions" ("course_id");
+
+CREATE TABLE IF NOT EXISTS "enrollments" (
+    "id" bigserial,
+    "student_id" bigint NOT NULL,
+    "course_id" bigint NOT NULL,
+    "enrollment_number" text NOT NULL,
+    "status" text NOT NULL DEFAULT 'active',
+    "start_date" timestamptz NOT NULL,
+    "end_date" timestamptz,
+    "enrollment_date" timestamptz NOT NULL,
+    "cancellation_reason" text,
+    "agreement_url" text,
+    "google_invitation_status" text DEFAULT 'not_sent',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_enrollments_enrollment_number" UNIQUE ("enrollment_number")
+);
+CREATE INDEX IF NOT EXISTS "idx_enrollments_student_id" ON "enrollments" ("student_id");
+CREATE INDEX IF NOT EXISTS "idx_enrollments_deleted_at" ON "enrollments" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_enrollments_course_id" ON "enrollments" ("course_id");
+
+CREATE TABLE IF NOT EXISTS "enrollment_course_classes" (
+    "id" bigserial,
+    "enrollment_id" bigint NOT NULL,
+    "course_class_id" bigint NOT NULL,
+    "is_primary" boolean DEFAULT true,
+    "transferred_from" bigint,
+    "notes" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_enrollment_course_classes_course_class_id" ON "enrollment_course_classes" ("course_class_id");
+CREATE INDEX IF NOT EXISTS "idx_enrollment_course_classes_enrollment_id" ON "enrollment_course_classes" ("enrollment_id");
+
+CREATE TABLE IF NOT EXISTS "syllabus_topics" (
+    "id" bigserial,
+    "course_id" bigint NOT NULL,
+    "title" text NOT NULL,
+    "description" text,
+    "content" text,
+    "objectives" text,
+    "resources" json,
+    "estimated_sessions" bigint DEFAULT 1,
+    "order" bigint NOT NULL,
+    "is_active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_syllabus_topics_course_id" ON "syllabus_topics" ("course_id");
+
+CREATE TABLE IF NOT EXISTS "calendar_feed_tokens" (
+    "id" bigserial,
+    "token" varchar(64) NOT NULL,
+    "owner_type" varchar(20) NOT NULL,
+    "owner_id" bigint NOT NULL,
+    "label" text,
+    "created_by_id" bigint NOT NULL,
+    "last_accessed_at" timestamptz,
+    "revoked_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_calendar_feed_owner" ON "calendar_feed_tokens" ("owner_type","owner_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_calendar_feed_tokens_token" ON "calendar_feed_tokens" ("token");
+
+CREATE TABLE IF NOT EXISTS "waiting_list" (
+    "id" bigserial,
+    "student_id" bigint NOT NULL,
+    "course_id" bigint NOT NULL,
+    "register_date" timestamptz NOT NULL,
+    "priority" bigint DEFAULT 0,
+    "notes" text,
+    "status" text NOT NULL DEFAULT 'waiting',
+    "call_date" timestamptz,
+    "response_date" timestamptz,
+    "response" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_waiting_list_course_id" ON "waiting_list" ("course_id");
+CREATE INDEX IF NOT EXISTS "idx_waiting_list_student_id" ON "waiting_list" ("student_id");
+
+CREATE TABLE IF NOT EXISTS "registrations" (
+    "id" bigserial,
+    "student_id" bigint NOT NULL,
+    "course_id" bigint NOT NULL,
+    "status" text NOT NULL,
+    "start_date" timestamptz,
+    "end_date" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_registrations_deleted_at" ON "registrations" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_registrations_course_id" ON "registrations" ("course_id");
+CREATE INDEX IF NOT EXISTS "idx_registrations_student_id" ON "registrations" ("student_id");
+
+CREATE TABLE IF NOT EXISTS "attendances" (
+    "id" bigserial,
+    "student_id" bigint NOT NULL,
+    "enrollment_id" bigint NOT NULL,
+    "course_id" bigint NOT NULL,
+    "date" timestamptz NOT NULL,
+    "status" text NOT NULL,
+    "module" text,
+    "justification" text,
+    "has_attachment" boolean DEFAULT false,
+    "attachment_url" text,
+    "notes" text,
+    "registered_by_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_attendances_date" ON "attendances" ("date");
+CREATE INDEX IF NOT EXISTS "idx_attendances_course_id" ON "attendances" ("course_id");
+CREATE INDEX IF NOT EXISTS "idx_attendances_enrollment_id" ON "attendances" ("enrollment_id");
+CREATE INDEX IF NOT EXISTS "idx_attendances_student_id" ON "attendances" ("student_id");
+
+CREATE TABLE IF NOT EXISTS "absence_justifications" (
+    "id" bigserial,
+    "student_id" bigint NOT NULL,
+    "course_id" bigint NOT NULL,
+    "start_date" timestamptz NOT NULL,
+    "end_date" timestamptz NOT NULL,
+    "reason" text NOT NULL,
+    "document_url" text,
+    "status" text NOT NULL DEFAULT 'pending',
+    "notes" text,
+    "submitted_by_id" bigint NOT NULL,
+    "reviewed_by_id" bigint,
+    "review_date" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_absence_justifications_course_id" ON "absence_justifications" ("course_id");
+CREATE INDEX IF NOT EXISTS "idx_absence_justifications_student_id" ON "absence_justifications" ("student_id");
+
+CREATE TABLE IF NOT EXISTS "absence_alerts" (
+    "id" bigserial,
+    "student_id" bigint NOT NULL,
+    "course_id" bigint NOT NULL,
+    "level" bigint NOT NULL,
+    "absence_count" bigint NOT NULL,
+    "first_absence_date" timestamptz,
+    "last_absence_date" timestamptz,
+    "status" text NOT NULL DEFAULT 'open',
+    "notification_sent" boolean DEFAULT false,
+    "notification_date" timestamptz,
+    "resolved_by_id" bigint,
+    "resolution_date" timestamptz,
+    "resolution_notes" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_absence_alerts_course_id" ON "absence_alerts" ("course_id");
+CREATE INDEX IF NOT EXISTS "idx_absence_alerts_student_id" ON "absence_alerts" ("student_id");
+
+CREATE TABLE IF NOT EXISTS "certificates" (
+    "id" bigserial,
+    "enrollment_id" bigint NOT NULL,
+    "student_id" bigint NOT NULL,
+    "course_id" bigint NOT NULL,
+    "type" text NOT NULL,
+    "issue_date" timestamptz NOT NULL,
+    "expiry_date" timestamptz,
+    "certificate_url" text,
+    "verification_code" text,
+    "qr_code_url" text,
+    "status" text NOT NULL DEFAULT 'active',
+    "revocation_reason" text,
+    "created_by_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_certificates_verification_code" UNIQUE ("verification_code")
+);
+CREATE INDEX IF NOT EXISTS "idx_certificates_course_id" ON "certificates" ("course_id");
+CREATE INDEX IF NOT EXISTS "idx_certificates_student_id" ON "certificates" ("student_id");
+CREATE INDEX IF NOT EXISTS "idx_certificates_enrollment_id" ON "certificates" ("enrollment_id");
+
+CREATE TABLE IF NOT EXISTS "certificate_templates" (
+    "id" bigserial,
+    "name" text NOT NULL,
+    "description" text,
+    "type" text NOT NULL,
+    "html_content" text NOT NULL,
+    "css_styles" text,
+    "is_default" boolean DEFAULT false,
+    "is_active" boolean DEFAULT true,
+    "created_by_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "forms" (
+    "id" bigserial,
+    "title" text NOT NULL,
+    "description" text,
+    "type" text NOT NULL,
+    "is_required" boolean DEFAULT false,
+    "target_audience" text NOT NULL,
+    "status" text NOT NULL DEFAULT 'draft',
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "created_by_id" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_forms_deleted_at" ON "forms" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "form_questions" (
+    "id" bigserial,
+    "form_id" bigint NOT NULL,
+    "question_text" text NOT NULL,
+    "help_text" text,
+    "question_type" text NOT NULL,
+    "options" text,
+    "is_required" boolean DEFAULT true,
+    "display_order" bigint NOT NULL,
+    "conditional_parent_id" bigint,
+    "conditional_value" text,
+    "validation_rules" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_form_questions_form_id" ON "form_questions" ("form_id");
+
+CREATE TABLE IF NOT EXISTS "interviews" (
+    "id" bigserial,
+    "form_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "scheduled_date" timestamptz NOT NULL,
+    "interviewer_id" bigint,
+    "status" text NOT NULL DEFAULT 'scheduled',
+    "completion_date" timestamptz,
+    "notes" text,
+    "trigger_type" text,
+    "related_entity" text,
+    "related_id" bigint,
+    "reminder_sent" boolean DEFAULT false,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_interviews_user_id" ON "interviews" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "form_responses" (
+    "id" bigserial,
+    "interview_id" bigint,
+    "form_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "submission_date" timestamptz NOT NULL,
+    "completion_status" text NOT NULL DEFAULT 'complete',
+    "ip_address" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "form_answer_details" (
+    "id" bigserial,
+    "response_id" bigint NOT NULL,
+    "question_id" bigint NOT NULL,
+    "answer_text" text,
+    "answer_options" text,
+    "file_url" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_form_answer_details_response_id" ON "form_answer_details" ("response_id");
+
+CREATE TABLE IF NOT EXISTS "volunteer_term_templates" (
+    "id" bigserial,
+    "title" text NOT NULL,
+    "content" text NOT NULL,
+    "version" text NOT NULL,
+    "is_active" boolean DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "created_by_id" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "volunteer_terms" (
+    "id" bigserial,
+    "teacher_id" bigint NOT NULL,
+    "template_id" bigint NOT NULL,
+    "signed_at" timestamptz NOT NULL,
+    "expiration_date" timestamptz NOT NULL,
+    "ip_address" text,
+    "device_info" text,
+    "signature_type" text NOT NULL DEFAULT 'digital',
+    "status" text NOT NULL DEFAULT 'active',
+    "document_url" text,
+    "reminder_sent" boolean DEFAULT false,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "created_by_id" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_volunteer_terms_teacher_id" ON "volunteer_terms" ("teacher_id");
+
+CREATE TABLE IF NOT EXISTS "volunteer_term_history" (
+    "id" bigserial,
+    "term_id" bigint NOT NULL,
+    "action_type" text NOT NULL,
+    "action_date" timestamptz NOT NULL,
+    "action_by_id" bigint,
+    "details" text,
+    "created_by_id" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_volunteer_term_history_term_id" ON "volunteer_term_history" ("term_id");
+
+CREATE TABLE IF NOT EXISTS "incidents" (
+    "id" bigserial,
+    "type" text NOT NULL,
+    "severity" text NOT NULL,
+    "title" text NOT NULL,
+    "description" text NOT NULL,
+    "status" text NOT NULL DEFAULT 'open',
+    "course_id" bigint,
+    "class_session_id" bigint,
+    "student_id" bigint,
+    "reported_by_id" bigint NOT NULL,
+    "resolution_notes" text,
+    "resolved_by_id" bigint,
+    "resolved_at" timestamptz,
+    "notification_sent" boolean DEFAULT false,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_incidents_student_id" ON "incidents" ("student_id");
+CREATE INDEX IF NOT EXISTS "idx_incidents_class_session_id" ON "incidents" ("class_session_id");
+CREATE INDEX IF NOT EXISTS "idx_incidents_course_id" ON "incidents" ("course_id");
+CREATE INDEX IF NOT EXISTS "idx_incidents_deleted_at" ON "incidents" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_incidents_reported_by_id" ON "incidents" ("reported_by_id");
+
+CREATE TABLE IF NOT EXISTS "incident_comments" (
+    "id" bigserial,
+    "incident_id" bigint NOT NULL,
+    "user_id" bigint NOT NULL,
+    "comment" text NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_incident_comments_incident_id" ON "incident_comments" ("incident_id");
+
+CREATE TABLE IF NOT EXISTS "incident_attachments" (
+    "id" bigserial,
+    "incident_id" bigint NOT NULL,
+    "file_name" text NOT NULL,
+    "file_url" text NOT NULL,
+    "file_type" text,
+    "file_size" bigint,
+    "uploaded_by_id" bigint NOT NULL,
+    "uploaded_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_incident_attachments_incident_id" ON "incident_attachments" ("incident_id");
+
+CREATE TABLE IF NOT EXISTS "notifications" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "title" text NOT NULL,
+    "message" text NOT NULL,
+    "type" text NOT NULL,
+    "entity_type" text,
+    "entity_id" bigint,
+    "delivery_status" text NOT NULL DEFAULT 'pending',
+    "delivery_attempts" bigint NOT NULL DEFAULT 0,
+    "last_attempt_date" timestamptz,
+    "delivery_date" timestamptz,
+    "read_date" timestamptz,
+    "error_message" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_notifications_user_id" ON "notifications" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "audit_logs" (
+    "id" bigserial,
+    "entity_type" text NOT NULL,
+    "entity_id" bigint NOT NULL,
+    "action" text NOT NULL,
+    "user_id" bigint NOT NULL,
+    "old_data" jsonb,
+    "new_data" jsonb,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+-- Chaves estrangeiras (depois de todas as tabelas, pois há referências cruzadas)
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_users_profile') THEN
+        ALTER TABLE "users" ADD CONSTRAINT "fk_users_profile" FOREIGN KEY ("profile_id") REFERENCES "user_profiles"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_users_address') THEN
+        ALTER TABLE "addresses" ADD CONSTRAINT "fk_users_address" FOREIGN KEY ("user_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_students_user_contacts') THEN
+        ALTER TABLE "user_contacts" ADD CONSTRAINT "fk_students_user_contacts" FOREIGN KEY ("student_id") REFERENCES "students"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_users_user_contacts') THEN
+        ALTER TABLE "user_contacts" ADD CONSTRAINT "fk_users_user_contacts" FOREIGN KEY ("user_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_students_user') THEN
+        ALTER TABLE "students" ADD CONSTRAINT "fk_students_user" FOREIGN KEY ("user_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_students_guardians') THEN
+        ALTER TABLE "guardians" ADD CONSTRAINT "fk_students_guardians" FOREIGN KEY ("student_id") REFERENCES "students"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_guardian_permissions_guardian') THEN
+        ALTER TABLE "guardian_permissions" ADD CONSTRAINT "fk_guardian_permissions_guardian" FOREIGN KEY ("guardian_id") REFERENCES "guardians"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_student_notes_author') THEN
+        ALTER TABLE "student_notes" ADD CONSTRAINT "fk_student_notes_author" FOREIGN KEY ("author_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_students_student_notes') THEN
+        ALTER TABLE "student_notes" ADD CONSTRAINT "fk_students_student_notes" FOREIGN KEY ("student_id") REFERENCES "students"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_students_documents') THEN
+        ALTER TABLE "documents" ADD CONSTRAINT "fk_students_documents" FOREIGN KEY ("student_id") REFERENCES "students"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_teachers_user') THEN
+        ALTER TABLE "teachers" ADD CONSTRAINT "fk_teachers_user" FOREIGN KEY ("user_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_courses_category') THEN
+        ALTER TABLE "courses" ADD CONSTRAINT "fk_courses_category" FOREIGN KEY ("category_id") REFERENCES "course_categories"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_teacher_courses_teacher') THEN
+        ALTER TABLE "teacher_courses" ADD CONSTRAINT "fk_teacher_courses_teacher" FOREIGN KEY ("teacher_id") REFERENCES "teachers"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_courses_teacher_courses') THEN
+        ALTER TABLE "teacher_courses" ADD CONSTRAINT "fk_courses_teacher_courses" FOREIGN KEY ("course_id") REFERENCES "courses"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_teacher_skills_skill') THEN
+        ALTER TABLE "teacher_skills" ADD CONSTRAINT "fk_teacher_skills_skill" FOREIGN KEY ("skill_id") REFERENCES "skills"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_teachers_skills') THEN
+        ALTER TABLE "teacher_skills" ADD CONSTRAINT "fk_teachers_skills" FOREIGN KEY ("teacher_id") REFERENCES "teachers"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_teachers_availability') THEN
+        ALTER TABLE "teacher_availability" ADD CONSTRAINT "fk_teachers_availability" FOREIGN KEY ("teacher_id") REFERENCES "teachers"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_teacher_absences_teacher') THEN
+        ALTER TABLE "teacher_absences" ADD CONSTRAINT "fk_teacher_absences_teacher" FOREIGN KEY ("teacher_id") REFERENCES "teachers"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_course_classes_course') THEN
+        ALTER TABLE "course_classes" ADD CONSTRAINT "fk_course_classes_course" FOREIGN KEY ("course_id") REFERENCES "courses"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_course_classes_default_location') THEN
+        ALTER TABLE "course_classes" ADD CONSTRAINT "fk_course_classes_default_location" FOREIGN KEY ("default_location_id") REFERENCES "locations"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_course_classes_default_teacher') THEN
+        ALTER TABLE "course_classes" ADD CONSTRAINT "fk_course_classes_default_teacher" FOREIGN KEY ("default_teacher_id") REFERENCES "teachers"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_substitutions_original_teacher') THEN
+        ALTER TABLE "substitutions" ADD CONSTRAINT "fk_substitutions_original_teacher" FOREIGN KEY ("original_teacher_id") REFERENCES "teachers"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_substitutions_substitute_teacher') THEN
+        ALTER TABLE "substitutions" ADD CONSTRAINT "fk_substitutions_substitute_teacher" FOREIGN KEY ("substitute_teacher_id") REFERENCES "teachers"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_substitutions_course_class') THEN
+        ALTER TABLE "substitutions" ADD CONSTRAINT "fk_substitutions_course_class" FOREIGN KEY ("course_class_id") REFERENCES "course_classes"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_class_sessions_location') THEN
+        ALTER TABLE "class_sessions" ADD CONSTRAINT "fk_class_sessions_location" FOREIGN KEY ("location_id") REFERENCES "locations"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_class_sessions_teacher') THEN
+        ALTER TABLE "class_sessions" ADD CONSTRAINT "fk_class_sessions_teacher" FOREIGN KEY ("teacher_id") REFERENCES "teachers"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_courses_class_sessions') THEN
+        ALTER TABLE "class_sessions" ADD CONSTRAINT "fk_courses_class_sessions" FOREIGN KEY ("course_id") REFERENCES "courses"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_course_classes_class_sessions') THEN
+        ALTER TABLE "class_sessions" ADD CONSTRAINT "fk_course_classes_class_sessions" FOREIGN KEY ("course_class_id") REFERENCES "course_classes"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_students_enrollments') THEN
+        ALTER TABLE "enrollments" ADD CONSTRAINT "fk_students_enrollments" FOREIGN KEY ("student_id") REFERENCES "students"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_enrollment_course_classes_enrollment') THEN
+        ALTER TABLE "enrollment_course_classes" ADD CONSTRAINT "fk_enrollment_course_classes_enrollment" FOREIGN KEY ("enrollment_id") REFERENCES "enrollments"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_enrollment_course_classes_course_class') THEN
+        ALTER TABLE "enrollment_course_classes" ADD CONSTRAINT "fk_enrollment_course_classes_course_class" FOREIGN KEY ("course_class_id") REFERENCES "course_classes"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_syllabus_topics_course') THEN
+        ALTER TABLE "syllabus_topics" ADD CONSTRAINT "fk_syllabus_topics_course" FOREIGN KEY ("course_id") REFERENCES "courses"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_registrations_student') THEN
+        ALTER TABLE "registrations" ADD CONSTRAINT "fk_registrations_student" FOREIGN KEY ("student_id") REFERENCES "students"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_registrations_course') THEN
+        ALTER TABLE "registrations" ADD CONSTRAINT "fk_registrations_course" FOREIGN KEY ("course_id") REFERENCES "courses"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_forms_created_by') THEN
+        ALTER TABLE "forms" ADD CONSTRAINT "fk_forms_created_by" FOREIGN KEY ("created_by_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_form_questions_conditional_parent') THEN
+        ALTER TABLE "form_questions" ADD CONSTRAINT "fk_form_questions_conditional_parent" FOREIGN KEY ("conditional_parent_id") REFERENCES "form_questions"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_forms_questions') THEN
+        ALTER TABLE "form_questions" ADD CONSTRAINT "fk_forms_questions" FOREIGN KEY ("form_id") REFERENCES "forms"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_interviews_form') THEN
+        ALTER TABLE "interviews" ADD CONSTRAINT "fk_interviews_form" FOREIGN KEY ("form_id") REFERENCES "forms"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_interviews_user') THEN
+        ALTER TABLE "interviews" ADD CONSTRAINT "fk_interviews_user" FOREIGN KEY ("user_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_interviews_interviewer') THEN
+        ALTER TABLE "interviews" ADD CONSTRAINT "fk_interviews_interviewer" FOREIGN KEY ("interviewer_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_form_responses_interview') THEN
+        ALTER TABLE "form_responses" ADD CONSTRAINT "fk_form_responses_interview" FOREIGN KEY ("interview_id") REFERENCES "interviews"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_form_responses_form') THEN
+        ALTER TABLE "form_responses" ADD CONSTRAINT "fk_form_responses_form" FOREIGN KEY ("form_id") REFERENCES "forms"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_form_responses_user') THEN
+        ALTER TABLE "form_responses" ADD CONSTRAINT "fk_form_responses_user" FOREIGN KEY ("user_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_form_responses_answer_details') THEN
+        ALTER TABLE "form_answer_details" ADD CONSTRAINT "fk_form_responses_answer_details" FOREIGN KEY ("response_id") REFERENCES "form_responses"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_form_answer_details_question') THEN
+        ALTER TABLE "form_answer_details" ADD CONSTRAINT "fk_form_answer_details_question" FOREIGN KEY ("question_id") REFERENCES "form_questions"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_volunteer_term_templates_created_by') THEN
+        ALTER TABLE "volunteer_term_templates" ADD CONSTRAINT "fk_volunteer_term_templates_created_by" FOREIGN KEY ("created_by_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_volunteer_terms_teacher') THEN
+        ALTER TABLE "volunteer_terms" ADD CONSTRAINT "fk_volunteer_terms_teacher" FOREIGN KEY ("teacher_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_volunteer_terms_template') THEN
+        ALTER TABLE "volunteer_terms" ADD CONSTRAINT "fk_volunteer_terms_template" FOREIGN KEY ("template_id") REFERENCES "volunteer_term_templates"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_volunteer_terms_created_by') THEN
+        ALTER TABLE "volunteer_terms" ADD CONSTRAINT "fk_volunteer_terms_created_by" FOREIGN KEY ("created_by_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_volunteer_term_history_action_by') THEN
+        ALTER TABLE "volunteer_term_history" ADD CONSTRAINT "fk_volunteer_term_history_action_by" FOREIGN KEY ("action_by_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_volunteer_term_history_created_by') THEN
+        ALTER TABLE "volunteer_term_history" ADD CONSTRAINT "fk_volunteer_term_history_created_by" FOREIGN KEY ("created_by_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_volunteer_terms_history') THEN
+        ALTER TABLE "volunteer_term_history" ADD CONSTRAINT "fk_volunteer_terms_history" FOREIGN KEY ("term_id") REFERENCES "volunteer_terms"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_incidents_course') THEN
+        ALTER TABLE "incidents" ADD CONSTRAINT "fk_incidents_course" FOREIGN KEY ("course_id") REFERENCES "courses"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_incidents_class_session') THEN
+        ALTER TABLE "incidents" ADD CONSTRAINT "fk_incidents_class_session" FOREIGN KEY ("class_session_id") REFERENCES "class_sessions"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_incidents_student') THEN
+        ALTER TABLE "incidents" ADD CONSTRAINT "fk_incidents_student" FOREIGN KEY ("student_id") REFERENCES "students"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_incidents_reported_by') THEN
+        ALTER TABLE "incidents" ADD CONSTRAINT "fk_incidents_reported_by" FOREIGN KEY ("reported_by_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_incidents_resolved_by') THEN
+        ALTER TABLE "incidents" ADD CONSTRAINT "fk_incidents_resolved_by" FOREIGN KEY ("resolved_by_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_incident_comments_incident') THEN
+        ALTER TABLE "incident_comments" ADD CONSTRAINT "fk_incident_comments_incident" FOREIGN KEY ("incident_id") REFERENCES "incidents"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_incident_comments_user') THEN
+        ALTER TABLE "incident_comments" ADD CONSTRAINT "fk_incident_comments_user" FOREIGN KEY ("user_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_incident_attachments_incident') THEN
+        ALTER TABLE "incident_attachments" ADD CONSTRAINT "fk_incident_attachments_incident" FOREIGN KEY ("incident_id") REFERENCES "incidents"("id");
+    END IF;
+END
+$$;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_audit_logs_user') THEN
+        ALTER TABLE "audit_logs" ADD CONSTRAINT "fk_audit_logs_user" FOREIGN KEY ("user_id") REFERENCES "users"("id");
+    END IF;
+END
+$$;
@@ -0,0 +1,8 @@
+-- Índice GIN da busca textual do catálogo público.
+-- A expressão precisa ser idêntica à usada em catalog.courseSearchVector.
+CREATE INDEX IF NOT EXISTS idx_courses_search ON courses USING GIN (
+    to_tsvector('portuguese',
+        coalesce(name, '') || ' ' ||
+        coalesce(short_description, '') || ' ' ||
+        coalesce(detailed_description, ''))
+);
@@ -0,0 +1,7 @@
+-- Checkpoints das correções de dados em lotes (ex: Course → CourseClass),
+-- permitindo retomar uma execução interrompida.
+CREATE TABLE IF NOT EXISTS data_migration_checkpoints (
+    step VARCHAR(100) PRIMARY KEY,
+    last_id BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_substitutions_pending_respond_by;
+DROP INDEX IF EXISTS idx_substitutions_origin_id;
+
+ALTER TABLE substitutions
+    DROP COLUMN IF EXISTS respond_by,
+    DROP COLUMN IF EXISTS attempt,
+    DROP COLUMN IF EXISTS origin_id,
+    DROP COLUMN IF EXISTS end_date;
@@ -0,0 +1,9 @@
+-- Pedidos de substituição por período e escalonamento para o próximo candidato
+ALTER TABLE substitutions
+    ADD COLUMN IF NOT EXISTS end_date TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS origin_id BIGINT,
+    ADD COLUMN IF NOT EXISTS attempt BIGINT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS respond_by TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_substitutions_origin_id ON substitutions (origin_id);
+CREATE INDEX IF NOT EXISTS idx_substitutions_pending_respond_by ON substitutions (respond_by) WHERE status = 'pending';
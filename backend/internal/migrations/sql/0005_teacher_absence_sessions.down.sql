@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_teacher_absences_teacher_period;
+DROP TABLE IF EXISTS teacher_absence_sessions;
@@ -0,0 +1,17 @@
+-- Aulas afetadas por uma ausência de professor e a providência tomada para cada uma
+CREATE TABLE IF NOT EXISTS teacher_absence_sessions (
+    id BIGSERIAL PRIMARY KEY,
+    absence_id BIGINT NOT NULL REFERENCES teacher_absences (id) ON DELETE CASCADE,
+    class_session_id BIGINT NOT NULL REFERENCES class_sessions (id) ON DELETE CASCADE,
+    course_class_id BIGINT NOT NULL,
+    date TIMESTAMP WITH TIME ZONE NOT NULL,
+    action TEXT NOT NULL,
+    substitution_id BIGINT REFERENCES substitutions (id) ON DELETE SET NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_teacher_absence_sessions_absence_id ON teacher_absence_sessions (absence_id);
+CREATE INDEX IF NOT EXISTS idx_teacher_absence_sessions_class_session_id ON teacher_absence_sessions (class_session_id);
+CREATE INDEX IF NOT EXISTS idx_teacher_absences_teacher_period ON teacher_absences (teacher_id, start_date, end_date) WHERE status = 'active';
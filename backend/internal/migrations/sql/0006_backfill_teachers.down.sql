@@ -0,0 +1,3 @@
+-- Os registros criados pelo backfill não se distinguem dos demais e podem já
+-- estar referenciados (skills, disponibilidade, turmas); nada a desfazer.
+SELECT 1;
@@ -0,0 +1,8 @@
+-- Cria o registro em teachers para usuários professores (profile_id = 2)
+-- cadastrados antes de o CRUD de professores manter essa tabela.
+INSERT INTO teachers (user_id, specialization, bio, phone, active, created_at, updated_at)
+SELECT u.id, '', '', COALESCE(u.phone, ''), u.active, NOW(), NOW()
+FROM users u
+WHERE u.profile_id = 2
+  AND u.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM teachers t WHERE t.user_id = u.id);
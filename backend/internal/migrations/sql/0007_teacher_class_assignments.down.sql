@@ -0,0 +1,6 @@
+DELETE FROM teacher_courses WHERE course_class_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_teacher_courses_teacher_period;
+DROP INDEX IF EXISTS idx_teacher_courses_course_class_id;
+ALTER TABLE teacher_courses DROP COLUMN IF EXISTS assigned_by_id;
+ALTER TABLE teacher_courses DROP COLUMN IF EXISTS notes;
+ALTER TABLE teacher_courses DROP COLUMN IF EXISTS course_class_id;
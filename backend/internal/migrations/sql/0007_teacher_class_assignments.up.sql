@@ -0,0 +1,36 @@
+-- Atribuição de professores por turma (papel e período), com histórico
+
+-- Bancos criados pelo schema legado referenciam users (user_id) e não permitem
+-- repetir professor/curso/papel; o histórico de atribuições usa teachers.id.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'teacher_courses' AND column_name = 'user_id'
+    ) THEN
+        UPDATE teacher_courses tc SET teacher_id = t.id
+        FROM teachers t
+        WHERE tc.teacher_id IS NULL AND t.user_id = tc.user_id;
+
+        ALTER TABLE teacher_courses ALTER COLUMN user_id DROP NOT NULL;
+    END IF;
+END $$;
+
+ALTER TABLE teacher_courses DROP CONSTRAINT IF EXISTS unique_teacher_course_role;
+
+ALTER TABLE teacher_courses ADD COLUMN IF NOT EXISTS course_class_id BIGINT REFERENCES course_classes (id) ON DELETE CASCADE;
+ALTER TABLE teacher_courses ADD COLUMN IF NOT EXISTS notes TEXT NOT NULL DEFAULT '';
+ALTER TABLE teacher_courses ADD COLUMN IF NOT EXISTS assigned_by_id BIGINT;
+
+CREATE INDEX IF NOT EXISTS idx_teacher_courses_course_class_id ON teacher_courses (course_class_id);
+CREATE INDEX IF NOT EXISTS idx_teacher_courses_teacher_period ON teacher_courses (teacher_id, start_date, end_date) WHERE active;
+
+-- O professor padrão de cada turma passa a ser a atribuição principal
+INSERT INTO teacher_courses (teacher_id, course_id, course_class_id, role, start_date, active, notes, created_at, updated_at)
+SELECT cc.default_teacher_id, cc.course_id, cc.id, 'primary', cc.start_date, true, '', NOW(), NOW()
+FROM course_classes cc
+WHERE cc.default_teacher_id IS NOT NULL
+  AND NOT EXISTS (
+      SELECT 1 FROM teacher_courses tc
+      WHERE tc.course_class_id = cc.id AND tc.teacher_id = cc.default_teacher_id AND tc.role = 'primary'
+  );
@@ -0,0 +1,18 @@
+-- Diário de classe: registro do que foi ensinado em cada aula, ligado ao tópico da ementa
+CREATE TABLE IF NOT EXISTS lesson_diary_entries (
+    id BIGSERIAL PRIMARY KEY,
+    class_session_id BIGINT NOT NULL REFERENCES class_sessions (id) ON DELETE CASCADE,
+    syllabus_topic_id BIGINT REFERENCES syllabus_topics (id) ON DELETE SET NULL,
+    topic_status TEXT NOT NULL DEFAULT 'covered',
+    content TEXT NOT NULL,
+    homework TEXT NOT NULL DEFAULT '',
+    observations TEXT NOT NULL DEFAULT '',
+    teacher_id BIGINT NOT NULL REFERENCES teachers (id),
+    updated_by_id BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lesson_diary_entries_class_session_id ON lesson_diary_entries (class_session_id);
+CREATE INDEX IF NOT EXISTS idx_lesson_diary_entries_syllabus_topic_id ON lesson_diary_entries (syllabus_topic_id);
+CREATE INDEX IF NOT EXISTS idx_lesson_diary_entries_teacher_id ON lesson_diary_entries (teacher_id);
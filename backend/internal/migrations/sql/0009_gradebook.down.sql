@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS enrollment_results;
+DROP TABLE IF EXISTS grading_rules;
+DROP TABLE IF EXISTS assessment_scores;
+DROP TABLE IF EXISTS assessments;
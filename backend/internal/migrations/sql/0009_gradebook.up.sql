@@ -0,0 +1,56 @@
+-- Avaliações por turma, notas por matrícula e resultado final (média + frequência)
+CREATE TABLE IF NOT EXISTS assessments (
+    id BIGSERIAL PRIMARY KEY,
+    course_class_id BIGINT NOT NULL REFERENCES course_classes (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    weight NUMERIC NOT NULL DEFAULT 1,
+    max_score NUMERIC NOT NULL DEFAULT 10,
+    date TIMESTAMP WITH TIME ZONE,
+    created_by_id BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_assessments_course_class_id ON assessments (course_class_id);
+
+CREATE TABLE IF NOT EXISTS assessment_scores (
+    id BIGSERIAL PRIMARY KEY,
+    assessment_id BIGINT NOT NULL REFERENCES assessments (id) ON DELETE CASCADE,
+    enrollment_id BIGINT NOT NULL REFERENCES enrollments (id) ON DELETE CASCADE,
+    score NUMERIC NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    recorded_by_id BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_assessment_scores_assessment_enrollment ON assessment_scores (assessment_id, enrollment_id);
+CREATE INDEX IF NOT EXISTS idx_assessment_scores_enrollment_id ON assessment_scores (enrollment_id);
+
+CREATE TABLE IF NOT EXISTS grading_rules (
+    course_class_id BIGINT PRIMARY KEY REFERENCES course_classes (id) ON DELETE CASCADE,
+    passing_grade NUMERIC NOT NULL,
+    min_attendance_rate NUMERIC NOT NULL,
+    updated_by_id BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS enrollment_results (
+    id BIGSERIAL PRIMARY KEY,
+    enrollment_id BIGINT NOT NULL REFERENCES enrollments (id) ON DELETE CASCADE,
+    course_class_id BIGINT NOT NULL REFERENCES course_classes (id) ON DELETE CASCADE,
+    final_grade NUMERIC NOT NULL,
+    attendance_rate NUMERIC NOT NULL,
+    result TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    passing_grade NUMERIC NOT NULL,
+    min_attendance_rate NUMERIC NOT NULL,
+    decided_by_id BIGINT NOT NULL,
+    decided_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_enrollment_results_enrollment_class ON enrollment_results (enrollment_id, course_class_id);
+CREATE INDEX IF NOT EXISTS idx_enrollment_results_course_class_id ON enrollment_results (course_class_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS volunteer_declarations;
+DROP TABLE IF EXISTS volunteer_activities;
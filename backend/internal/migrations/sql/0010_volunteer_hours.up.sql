@@ -0,0 +1,36 @@
+-- Horas de voluntariado: atividades registradas manualmente e declarações emitidas (Lei 9.608/98)
+CREATE TABLE IF NOT EXISTS volunteer_activities (
+    id BIGSERIAL PRIMARY KEY,
+    teacher_id BIGINT NOT NULL REFERENCES teachers (id) ON DELETE CASCADE,
+    date TIMESTAMP WITH TIME ZONE NOT NULL,
+    minutes BIGINT NOT NULL,
+    category TEXT NOT NULL,
+    description TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    review_notes TEXT NOT NULL DEFAULT '',
+    reviewed_by_id BIGINT,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_by_id BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_volunteer_activities_teacher_date ON volunteer_activities (teacher_id, date);
+CREATE INDEX IF NOT EXISTS idx_volunteer_activities_pending ON volunteer_activities (created_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS volunteer_declarations (
+    id BIGSERIAL PRIMARY KEY,
+    code TEXT NOT NULL,
+    teacher_id BIGINT NOT NULL REFERENCES teachers (id) ON DELETE CASCADE,
+    start_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    session_minutes BIGINT NOT NULL,
+    activity_minutes BIGINT NOT NULL,
+    total_minutes BIGINT NOT NULL,
+    signature TEXT NOT NULL,
+    issued_by_id BIGINT NOT NULL,
+    issued_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_volunteer_declarations_code ON volunteer_declarations (code);
+CREATE INDEX IF NOT EXISTS idx_volunteer_declarations_teacher_id ON volunteer_declarations (teacher_id);
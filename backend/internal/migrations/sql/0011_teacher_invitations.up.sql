@@ -0,0 +1,22 @@
+-- Convites de cadastro de professores voluntários (link de uso único com validade)
+CREATE TABLE IF NOT EXISTS teacher_invitations (
+    id BIGSERIAL PRIMARY KEY,
+    email TEXT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    token_hash VARCHAR(64) NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    sent_count BIGINT NOT NULL DEFAULT 1,
+    last_sent_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    teacher_id BIGINT REFERENCES teachers (id) ON DELETE SET NULL,
+    invited_by_id BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_teacher_invitations_token_hash ON teacher_invitations (token_hash);
+CREATE INDEX IF NOT EXISTS idx_teacher_invitations_email ON teacher_invitations (email);
+-- No máximo um convite pendente por email (o reenvio reaproveita o convite)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_teacher_invitations_pending_email ON teacher_invitations (LOWER(email)) WHERE status = 'pending';
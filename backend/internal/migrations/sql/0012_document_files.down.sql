@@ -0,0 +1,3 @@
+ALTER TABLE documents DROP COLUMN IF EXISTS checksum;
+ALTER TABLE documents DROP COLUMN IF EXISTS size;
+ALTER TABLE documents DROP COLUMN IF EXISTS content_type;
@@ -0,0 +1,4 @@
+-- Documentos dos alunos: metadados do arquivo enviado (o path passa a ser a chave no storage)
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS content_type TEXT NOT NULL DEFAULT '';
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS size BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS checksum VARCHAR(64) NOT NULL DEFAULT '';
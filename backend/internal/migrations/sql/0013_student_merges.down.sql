@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS student_duplicate_dismissals;
+DROP TABLE IF EXISTS student_merges;
@@ -0,0 +1,29 @@
+-- Fusões de cadastros duplicados de alunos (trilha de auditoria da fusão)
+CREATE TABLE IF NOT EXISTS student_merges (
+    id BIGSERIAL PRIMARY KEY,
+    survivor_student_id BIGINT NOT NULL REFERENCES students (id),
+    duplicate_student_id BIGINT NOT NULL REFERENCES students (id),
+    survivor_user_id BIGINT NOT NULL,
+    duplicate_user_id BIGINT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    duplicate_snapshot JSONB NOT NULL DEFAULT '{}',
+    moved JSONB NOT NULL DEFAULT '{}',
+    interview_responses BIGINT NOT NULL DEFAULT 0,
+    merged_by_id BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_student_merges_survivor ON student_merges (survivor_student_id);
+CREATE INDEX IF NOT EXISTS idx_student_merges_duplicate ON student_merges (duplicate_student_id);
+
+-- Pares marcados como "não é duplicado" (ex: irmãos com o mesmo telefone)
+CREATE TABLE IF NOT EXISTS student_duplicate_dismissals (
+    id BIGSERIAL PRIMARY KEY,
+    student_a_id BIGINT NOT NULL REFERENCES students (id),
+    student_b_id BIGINT NOT NULL REFERENCES students (id),
+    dismissed_by_id BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (student_a_id < student_b_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_student_duplicate_dismissals_pair ON student_duplicate_dismissals (student_a_id, student_b_id);
@@ -0,0 +1,13 @@
+-- Cache das consultas de CEP (preenchimento e validação de endereços)
+CREATE TABLE IF NOT EXISTS postal_codes (
+    cep VARCHAR(8) PRIMARY KEY,
+    street TEXT NOT NULL DEFAULT '',
+    complement TEXT NOT NULL DEFAULT '',
+    neighborhood TEXT NOT NULL DEFAULT '',
+    city TEXT NOT NULL,
+    state VARCHAR(2) NOT NULL,
+    ibge_code TEXT NOT NULL DEFAULT '',
+    fetched_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,18 @@
+-- Autorizações respondidas pelos responsáveis no portal (atividades e termos de matrícula)
+CREATE TABLE IF NOT EXISTS guardian_consents (
+    id BIGSERIAL PRIMARY KEY,
+    guardian_id BIGINT NOT NULL REFERENCES guardians (id),
+    student_id BIGINT NOT NULL REFERENCES students (id),
+    type TEXT NOT NULL,
+    enrollment_id BIGINT REFERENCES enrollments (id),
+    accepted BOOLEAN NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_by BIGINT NOT NULL,
+    ip_address TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_guardian_consents_student ON guardian_consents (student_id);
+
+-- Uma resposta por responsável e autorização (a matrícula entra como 0 quando não se aplica)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_guardian_consents_answer ON guardian_consents (guardian_id, type, COALESCE(enrollment_id, 0));
@@ -0,0 +1,20 @@
+-- Registro de saída supervisionada dos alunos ao fim das aulas
+CREATE TABLE IF NOT EXISTS pickup_records (
+    id BIGSERIAL PRIMARY KEY,
+    class_session_id BIGINT NOT NULL REFERENCES class_sessions (id),
+    student_id BIGINT NOT NULL REFERENCES students (id),
+    status TEXT NOT NULL,
+    guardian_id BIGINT REFERENCES guardians (id),
+    contact_id BIGINT REFERENCES user_contacts (id),
+    person_name TEXT NOT NULL DEFAULT '',
+    person_document TEXT NOT NULL DEFAULT '',
+    authorized BOOLEAN NOT NULL DEFAULT FALSE,
+    notes TEXT NOT NULL DEFAULT '',
+    checked_out_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    recorded_by_id BIGINT NOT NULL,
+    guardians_notified INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pickup_records_session_student ON pickup_records (class_session_id, student_id);
+CREATE INDEX IF NOT EXISTS idx_pickup_records_student ON pickup_records (student_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS student_status_changes;
+DROP TABLE IF EXISTS student_suspensions;
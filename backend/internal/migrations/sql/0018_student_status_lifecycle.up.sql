@@ -0,0 +1,37 @@
+-- Suspensões de alunos ligadas a ocorrências, com início e fim
+CREATE TABLE IF NOT EXISTS student_suspensions (
+    id BIGSERIAL PRIMARY KEY,
+    student_id BIGINT NOT NULL REFERENCES students (id),
+    incident_id BIGINT NOT NULL REFERENCES incidents (id),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'scheduled',
+    created_by_id BIGINT NOT NULL,
+    revoked_by_id BIGINT,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    revoke_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_student_suspensions_student ON student_suspensions (student_id);
+CREATE INDEX IF NOT EXISTS idx_student_suspensions_incident ON student_suspensions (incident_id);
+
+-- Histórico das mudanças de situação dos alunos (manuais e automáticas)
+CREATE TABLE IF NOT EXISTS student_status_changes (
+    id BIGSERIAL PRIMARY KEY,
+    student_id BIGINT NOT NULL REFERENCES students (id),
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    source TEXT NOT NULL,
+    suspension_id BIGINT REFERENCES student_suspensions (id),
+    reverts_change_id BIGINT REFERENCES student_status_changes (id),
+    reverted_at TIMESTAMP WITH TIME ZONE,
+    changed_by_id BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_student_status_changes_student ON student_status_changes (student_id, created_at);
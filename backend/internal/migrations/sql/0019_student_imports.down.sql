@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS account_provisioning_jobs;
+DROP TABLE IF EXISTS student_imports;
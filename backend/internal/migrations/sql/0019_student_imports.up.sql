@@ -0,0 +1,26 @@
+-- Importação de alunos por planilha e fila de criação de contas de acesso
+CREATE TABLE IF NOT EXISTS student_imports (
+    id BIGSERIAL PRIMARY KEY,
+    file_name TEXT NOT NULL,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    created_count INTEGER NOT NULL DEFAULT 0,
+    skipped_count INTEGER NOT NULL DEFAULT 0,
+    created_by_id BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS account_provisioning_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    import_id BIGINT REFERENCES student_imports (id),
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    processed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_account_provisioning_jobs_user ON account_provisioning_jobs (user_id);
+CREATE INDEX IF NOT EXISTS idx_account_provisioning_jobs_pending ON account_provisioning_jobs (status, id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_account_provisioning_jobs_import ON account_provisioning_jobs (import_id);
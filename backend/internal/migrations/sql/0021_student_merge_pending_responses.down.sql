@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_student_merges_responses_pending;
+ALTER TABLE student_merges DROP COLUMN IF EXISTS responses_pending;
@@ -0,0 +1,4 @@
+-- Respostas de entrevista (MongoDB) ainda por transferir após a fusão; reprocessadas periodicamente
+ALTER TABLE student_merges ADD COLUMN IF NOT EXISTS responses_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_student_merges_responses_pending ON student_merges (id) WHERE responses_pending;
@@ -0,0 +1,78 @@
+// backend/internal/models/assessment.go
+package models
+
+import (
+	"time"
+)
+
+// Assessment - Avaliação de uma turma (prova, trabalho, apresentação)
+type Assessment struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	CourseClassID uint       `json:"courseClassId" gorm:"not null;index"`
+	Name          string     `json:"name" gorm:"not null"`
+	Description   string     `json:"description" gorm:"type:text"`
+	Weight        float64    `json:"weight" gorm:"not null;default:1"`    // Peso na média final
+	MaxScore      float64    `json:"maxScore" gorm:"not null;default:10"` // Nota máxima
+	Date          *time.Time `json:"date"`
+	CreatedByID   uint       `json:"createdById" gorm:"not null"`
+	CreatedAt     time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName defines the table name in the database
+func (Assessment) TableName() string {
+	return "assessments"
+}
+
+// AssessmentScore - Nota de uma matrícula em uma avaliação
+type AssessmentScore struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	AssessmentID uint      `json:"assessmentId" gorm:"not null;uniqueIndex:idx_assessment_scores_assessment_enrollment"`
+	EnrollmentID uint      `json:"enrollmentId" gorm:"not null;uniqueIndex:idx_assessment_scores_assessment_enrollment;index"`
+	Score        float64   `json:"score" gorm:"not null"`
+	Notes        string    `json:"notes"`
+	RecordedByID uint      `json:"recordedById" gorm:"not null"`
+	CreatedAt    time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName defines the table name in the database
+func (AssessmentScore) TableName() string {
+	return "assessment_scores"
+}
+
+// GradingRule - Regra de resultado final da turma (sem regra, valem os padrões da configuração)
+type GradingRule struct {
+	CourseClassID     uint      `json:"courseClassId" gorm:"primaryKey;autoIncrement:false"`
+	PassingGrade      float64   `json:"passingGrade" gorm:"not null"`      // Média mínima (escala 0-10)
+	MinAttendanceRate float64   `json:"minAttendanceRate" gorm:"not null"` // Frequência mínima (%)
+	UpdatedByID       uint      `json:"updatedById" gorm:"not null"`
+	CreatedAt         time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName defines the table name in the database
+func (GradingRule) TableName() string {
+	return "grading_rules"
+}
+
+// EnrollmentResult - Resultado final de uma matrícula na turma, com a regra aplicada
+type EnrollmentResult struct {
+	ID                uint      `json:"id" gorm:"primaryKey"`
+	EnrollmentID      uint      `json:"enrollmentId" gorm:"not null;uniqueIndex:idx_enrollment_results_enrollment_class"`
+	CourseClassID     uint      `json:"courseClassId" gorm:"not null;uniqueIndex:idx_enrollment_results_enrollment_class;index"`
+	FinalGrade        float64   `json:"finalGrade" gorm:"not null"`
+	AttendanceRate    float64   `json:"attendanceRate" gorm:"not null"`
+	Result            string    `json:"result" gorm:"not null"` // passed, failed
+	Reason            string    `json:"reason"`                 // Motivo da reprovação
+	PassingGrade      float64   `json:"passingGrade" gorm:"not null"`
+	MinAttendanceRate float64   `json:"minAttendanceRate" gorm:"not null"`
+	DecidedByID       uint      `json:"decidedById" gorm:"not null"`
+	DecidedAt         time.Time `json:"decidedAt" gorm:"not null"`
+	CreatedAt         time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName defines the table name in the database
+func (EnrollmentResult) TableName() string {
+	return "enrollment_results"
+}
@@ -0,0 +1,40 @@
+// backend/internal/models/calendar_feed.go
+package models
+
+import (
+	"time"
+)
+
+// CalendarFeedOwnerType identifica o dono de um feed iCalendar
+type CalendarFeedOwnerType string
+
+const (
+	CalendarFeedOwnerTeacher  CalendarFeedOwnerType = "teacher"  // Aulas ministradas pelo professor
+	CalendarFeedOwnerStudent  CalendarFeedOwnerType = "student"  // Aulas das turmas do aluno
+	CalendarFeedOwnerLocation CalendarFeedOwnerType = "location" // Ocupação de uma sala
+)
+
+// CalendarFeedToken - Token de acesso somente leitura a um feed .ics
+// O token vai na URL para que apps de calendário possam assinar o feed sem login.
+type CalendarFeedToken struct {
+	ID             uint                  `json:"id" gorm:"primaryKey"`
+	Token          string                `json:"token" gorm:"size:64;not null;uniqueIndex"`
+	OwnerType      CalendarFeedOwnerType `json:"ownerType" gorm:"size:20;not null;index:idx_calendar_feed_owner"`
+	OwnerID        uint                  `json:"ownerId" gorm:"not null;index:idx_calendar_feed_owner"`
+	Label          string                `json:"label"` // Ex: "Celular", "Google Agenda"
+	CreatedByID    uint                  `json:"createdById" gorm:"not null"`
+	LastAccessedAt *time.Time            `json:"lastAccessedAt"`
+	RevokedAt      *time.Time            `json:"revokedAt"`
+	CreatedAt      time.Time             `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time             `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName retorna o nome da tabela
+func (CalendarFeedToken) TableName() string {
+	return "calendar_feed_tokens"
+}
+
+// IsRevoked retorna true se o token foi revogado
+func (t CalendarFeedToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
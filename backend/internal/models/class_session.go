@@ -56,3 +56,23 @@ func (s ClassSession) GetEffectiveTeacherID(defaultTeacherID uint) uint {
 	}
 	return defaultTeacherID
 }
+
+// LessonDiaryEntry - Diário de classe de uma aula: o que foi ensinado, tarefa e observações
+type LessonDiaryEntry struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	ClassSessionID  uint      `json:"classSessionId" gorm:"not null;uniqueIndex"`
+	SyllabusTopicID *uint     `json:"syllabusTopicId,omitempty" gorm:"index"` // Tópico da ementa trabalhado (padrão: o planejado)
+	TopicStatus     string    `json:"topicStatus" gorm:"not null;default:'covered'"` // covered, partial, not_covered
+	Content         string    `json:"content" gorm:"type:text;not null"`             // O que foi efetivamente ensinado
+	Homework        string    `json:"homework" gorm:"type:text"`
+	Observations    string    `json:"observations" gorm:"type:text"`
+	TeacherID       uint      `json:"teacherId" gorm:"not null;index"` // Professor que registrou (pode ser o substituto)
+	UpdatedByID     uint      `json:"updatedById" gorm:"not null"`     // Usuário da última alteração
+	CreatedAt       time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName defines the table name in the database
+func (LessonDiaryEntry) TableName() string {
+	return "lesson_diary_entries"
+}
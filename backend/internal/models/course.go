@@ -19,6 +19,7 @@ type Course struct {
 	DifficultyLevel     string     `json:"difficultyLevel"`
 	TargetAudience      string     `json:"targetAudience"`
 	Tags                string     `json:"tags" gorm:"type:json"`
+	CategoryID          *uint      `json:"categoryId" gorm:"index"`
 	WeekDays            string     `json:"weekDays" gorm:"not null"` // E.g., "1,3,5" for Monday, Wednesday, Friday
 	StartTime           string     `json:"startTime" gorm:"not null"`
 	EndTime             string     `json:"endTime" gorm:"not null"`
@@ -32,6 +33,7 @@ type Course struct {
 	DeletedAt           *time.Time `json:"deletedAt" gorm:"index"`
 
 	// Associations
+	Category       *CourseCategory `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
 	ClassSessions  []ClassSession  `json:"classSessions,omitempty" gorm:"foreignKey:CourseID"`
 	TeacherCourses []TeacherCourse `json:"teacherCourses,omitempty" gorm:"foreignKey:CourseID"`
 }
@@ -41,21 +43,28 @@ func (Course) TableName() string {
 	return "courses"
 }
 
-// TeacherCourse represents the association between a teacher and a course
+// TeacherCourse represents the association between a teacher and a course.
+// With CourseClassID it assigns the teacher to one class of the course; without it
+// (legacy rows) the assignment covers every class of the course. Ended assignments
+// keep their EndDate as history; Active=false marks assignments revoked by mistake.
 type TeacherCourse struct {
-	ID        uint       `json:"id" gorm:"primaryKey"`
-	TeacherID uint       `json:"teacherId" gorm:"not null;index"` // References teachers.id
-	CourseID  uint       `json:"courseId" gorm:"not null;index"`
-	Role      string     `json:"role" gorm:"not null"` // primary, assistant, substitute
-	StartDate time.Time  `json:"startDate" gorm:"not null"`
-	EndDate   *time.Time `json:"endDate"`
-	Active    bool       `json:"active" gorm:"default:true"`
-	CreatedAt time.Time  `json:"createdAt" gorm:"autoCreateTime"`
-	UpdatedAt time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	TeacherID     uint       `json:"teacherId" gorm:"not null;index"` // References teachers.id
+	CourseID      uint       `json:"courseId" gorm:"not null;index"`
+	CourseClassID *uint      `json:"courseClassId" gorm:"index"`
+	Role          string     `json:"role" gorm:"not null"` // primary, assistant, substitute
+	StartDate     time.Time  `json:"startDate" gorm:"not null"`
+	EndDate       *time.Time `json:"endDate"`
+	Active        bool       `json:"active" gorm:"default:true"`
+	Notes         string     `json:"notes"`
+	AssignedByID  *uint      `json:"assignedById"`
+	CreatedAt     time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
 
 	// Associations
-	Teacher Teacher `json:"teacher,omitempty" gorm:"foreignKey:TeacherID"`
-	Course  Course  `json:"course,omitempty" gorm:"foreignKey:CourseID"`
+	Teacher     Teacher      `json:"teacher,omitempty" gorm:"foreignKey:TeacherID"`
+	Course      Course       `json:"course,omitempty" gorm:"foreignKey:CourseID"`
+	CourseClass *CourseClass `json:"courseClass,omitempty" gorm:"foreignKey:CourseClassID"`
 }
 
 // TableName defines the table name in the database
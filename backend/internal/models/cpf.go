@@ -0,0 +1,89 @@
+// backend/internal/models/cpf.go
+package models
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/pkg/cpf"
+	"github.com/devdavidalonso/cecor/backend/pkg/fieldcrypt"
+)
+
+// CPFs são gravados só com dígitos e devolvidos na API como 000.000.000-00.
+// A validação dos dígitos verificadores é feita nos serviços (cadastro e edição); os hooks apenas
+// garantem o formato canônico. Valores inválidos antigos ficam como estão até serem corrigidos
+// (veja migrations.CheckCPFs).
+// O CPF é cifrado (serializer:encrypted); as buscas usam o índice cego em cpf_hash, mantido pelos
+// hooks a partir do valor canônico.
+
+// canonicalCPF returns the digits of a valid CPF and the value unchanged otherwise
+func canonicalCPF(value string) string {
+	if digits, err := cpf.Parse(value); err == nil {
+		return digits
+	}
+	return value
+}
+
+// CPFIndex returns the blind index used to find a CPF (cpf_hash); "" when there is no CPF
+func CPFIndex(value string) (string, error) {
+	return fieldcrypt.BlindIndex(canonicalCPF(strings.TrimSpace(value)))
+}
+
+// cpfHash returns the cpf_hash column of a CPF (NULL when there is no CPF)
+func cpfHash(value string) (*string, error) {
+	index, err := CPFIndex(value)
+	if err != nil || index == "" {
+		return nil, err
+	}
+	return &index, nil
+}
+
+// BeforeSave stores the CPF as digits only and updates its index
+func (u *User) BeforeSave(tx *gorm.DB) (err error) {
+	u.CPF = canonicalCPF(u.CPF)
+	u.CPFHash, err = cpfHash(u.CPF)
+	return err
+}
+
+// MarshalJSON formats the CPF
+func (u User) MarshalJSON() ([]byte, error) {
+	type plain User
+	return json.Marshal(struct {
+		plain
+		CPF string `json:"cpf"`
+	}{plain(u), cpf.Format(u.CPF)})
+}
+
+// BeforeSave stores the CPF as digits only and updates its index
+func (g *Guardian) BeforeSave(tx *gorm.DB) (err error) {
+	g.CPF = canonicalCPF(g.CPF)
+	g.CPFHash, err = cpfHash(g.CPF)
+	return err
+}
+
+// MarshalJSON formats the CPF
+func (g Guardian) MarshalJSON() ([]byte, error) {
+	type plain Guardian
+	return json.Marshal(struct {
+		plain
+		CPF string `json:"cpf"`
+	}{plain(g), cpf.Format(g.CPF)})
+}
+
+// BeforeSave stores the CPF as digits only and updates its index
+func (c *UserContact) BeforeSave(tx *gorm.DB) (err error) {
+	c.CPF = canonicalCPF(c.CPF)
+	c.CPFHash, err = cpfHash(c.CPF)
+	return err
+}
+
+// MarshalJSON formats the CPF
+func (c UserContact) MarshalJSON() ([]byte, error) {
+	type plain UserContact
+	return json.Marshal(struct {
+		plain
+		CPF string `json:"cpf"`
+	}{plain(c), cpf.Format(c.CPF)})
+}
@@ -10,7 +10,10 @@ type Document struct {
 	StudentID    uint      `json:"studentId" gorm:"not null;index"`
 	Name         string    `json:"name" gorm:"not null"`
 	Type         string    `json:"type" gorm:"not null"`
-	Path         string    `json:"path" gorm:"not null"`
+	Path         string    `json:"path" gorm:"not null"`                   // Chave do arquivo no storage
+	ContentType  string    `json:"contentType" gorm:"not null;default:''"` // Detectado a partir do conteúdo
+	Size         int64     `json:"size" gorm:"not null;default:0"`
+	Checksum     string    `json:"checksum" gorm:"size:64;not null;default:''"` // SHA-256 do arquivo
 	UploadedByID uint      `json:"uploadedById" gorm:"not null"`
 	CreatedAt    time.Time `json:"createdAt" gorm:"autoCreateTime"`
 	UpdatedAt    time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
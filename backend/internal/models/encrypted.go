@@ -0,0 +1,52 @@
+// backend/internal/models/encrypted.go
+package models
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm/schema"
+
+	"github.com/devdavidalonso/cecor/backend/pkg/fieldcrypt"
+)
+
+// Campos com a tag gorm:"serializer:encrypted" são cifrados ao gravar e decifrados ao ler, com as
+// chaves configuradas em postgres.InitDB (FIELD_ENCRYPTION_KEYS). Valores ainda em texto claro
+// (anteriores à migração 20) são lidos normalmente e cifrados na próxima gravação ou rotação.
+// Atualizações por mapa (Updates(map[string]interface{})) e SQL manual não passam pelo
+// serializer: nesses casos use fieldcrypt.Encrypt. Buscas por CPF usam a coluna cpf_hash (CPFIndex).
+
+func init() {
+	schema.RegisterSerializer("encrypted", EncryptedSerializer{})
+}
+
+// EncryptedSerializer encrypts string fields with the default keyring
+type EncryptedSerializer struct{}
+
+// Scan decrypts the stored value
+func (EncryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var stored string
+	switch value := dbValue.(type) {
+	case nil:
+	case string:
+		stored = value
+	case []byte:
+		stored = string(value)
+	default:
+		return fmt.Errorf("unsupported type %T for encrypted field %s", dbValue, field.Name)
+	}
+
+	plaintext, err := fieldcrypt.Decrypt(stored)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt %s: %w", field.Name, err)
+	}
+	field.ReflectValueOf(ctx, dst).SetString(plaintext)
+	return nil
+}
+
+// Value encrypts the field with the current key
+func (EncryptedSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value, _ := fieldValue.(string)
+	return fieldcrypt.Encrypt(value)
+}
@@ -11,7 +11,8 @@ type Guardian struct {
 	Name                 string     `json:"name" gorm:"not null"`
 	Email                string     `json:"email"`
 	Phone                string     `json:"phone"`
-	CPF                  string     `json:"cpf"`
+	CPF                  string     `json:"cpf" gorm:"serializer:encrypted"`
+	CPFHash              *string    `json:"-" gorm:"size:64;index"` // Índice cego do CPF
 	Relationship         string     `json:"relationship" gorm:"not null"`
 	CanPickup            bool       `json:"canPickup" gorm:"default:false"`
 	ReceiveNotifications bool       `json:"receiveNotifications" gorm:"default:true"`
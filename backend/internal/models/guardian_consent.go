@@ -0,0 +1,34 @@
+// backend/internal/models/guardian_consent.go
+package models
+
+import (
+	"time"
+)
+
+// ConsentType identifica o que o responsável autoriza
+type ConsentType string
+
+const (
+	ConsentTypeActivities ConsentType = "activities" // Atividades externas e eventos (Guardian.AuthorizeActivities)
+	ConsentTypeEnrollment ConsentType = "enrollment" // Termo de matrícula de um curso (Enrollment.AgreementURL)
+)
+
+// GuardianConsent - Resposta de um responsável a uma autorização pedida pela instituição
+// A autorização fica pendente enquanto não houver resposta para o par (responsável, tipo, matrícula).
+type GuardianConsent struct {
+	ID           uint        `json:"id" gorm:"primaryKey"`
+	GuardianID   uint        `json:"guardianId" gorm:"not null;index"`
+	StudentID    uint        `json:"studentId" gorm:"not null;index"`
+	Type         ConsentType `json:"type" gorm:"not null"`
+	EnrollmentID *uint       `json:"enrollmentId,omitempty"` // Só no termo de matrícula
+	Accepted     bool        `json:"accepted"`
+	RespondedAt  time.Time   `json:"respondedAt" gorm:"not null"`
+	RespondedBy  uint        `json:"respondedBy" gorm:"not null"` // Usuário do responsável
+	IPAddress    string      `json:"ipAddress"`
+	CreatedAt    time.Time   `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela
+func (GuardianConsent) TableName() string {
+	return "guardian_consents"
+}
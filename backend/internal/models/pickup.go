@@ -0,0 +1,45 @@
+// backend/internal/models/pickup.go
+package models
+
+import (
+	"time"
+)
+
+// PickupStatus identifica como terminou a saída do aluno
+type PickupStatus string
+
+const (
+	PickupStatusPickedUp     PickupStatus = "picked_up"    // Retirado por pessoa autorizada
+	PickupStatusUnauthorized PickupStatus = "unauthorized" // Exceção: retirado por pessoa sem autorização
+	PickupStatusNoPickup     PickupStatus = "no_pickup"    // Exceção: ninguém veio buscar
+)
+
+// PickupRecord - Registro de saída supervisionada de um aluno ao fim de uma aula
+// Quem retirou é um responsável (GuardianID), um contato do aluno (ContactID) ou outra pessoa
+// identificada só por nome e documento. Há um registro por aluno e aula.
+type PickupRecord struct {
+	ID                uint         `json:"id" gorm:"primaryKey"`
+	ClassSessionID    uint         `json:"classSessionId" gorm:"not null;uniqueIndex:idx_pickup_records_session_student"`
+	StudentID         uint         `json:"studentId" gorm:"not null;uniqueIndex:idx_pickup_records_session_student;index"`
+	Status            PickupStatus `json:"status" gorm:"not null"`
+	GuardianID        *uint        `json:"guardianId,omitempty"`
+	ContactID         *uint        `json:"contactId,omitempty"`
+	PersonName        string       `json:"personName"`
+	PersonDocument    string       `json:"personDocument" gorm:"serializer:encrypted"`
+	Authorized        bool         `json:"authorized"`
+	Notes             string       `json:"notes" gorm:"type:text"`
+	CheckedOutAt      time.Time    `json:"checkedOutAt" gorm:"not null"`
+	RecordedByID      uint         `json:"recordedById" gorm:"not null"`
+	GuardiansNotified int          `json:"guardiansNotified"`
+	CreatedAt         time.Time    `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela
+func (PickupRecord) TableName() string {
+	return "pickup_records"
+}
+
+// IsException indica se a saída precisa de atenção da coordenação
+func (p *PickupRecord) IsException() bool {
+	return p.Status != PickupStatusPickedUp
+}
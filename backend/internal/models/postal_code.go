@@ -0,0 +1,26 @@
+// backend/internal/models/postal_code.go
+package models
+
+import (
+	"time"
+)
+
+// PostalCode - Cache local das consultas de CEP ao provedor de endereços
+// Evita consultar o provedor a cada cadastro e mantém a consulta funcionando se ele estiver fora do ar.
+type PostalCode struct {
+	CEP          string    `json:"cep" gorm:"primaryKey;size:8"` // Só dígitos
+	Street       string    `json:"street"`
+	Complement   string    `json:"complement"`
+	Neighborhood string    `json:"neighborhood"`
+	City         string    `json:"city" gorm:"not null"`
+	State        string    `json:"state" gorm:"size:2;not null"`
+	IBGECode     string    `json:"ibgeCode"`
+	FetchedAt    time.Time `json:"fetchedAt" gorm:"not null"` // Última consulta ao provedor
+	CreatedAt    time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName retorna o nome da tabela
+func (PostalCode) TableName() string {
+	return "postal_codes"
+}
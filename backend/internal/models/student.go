@@ -22,8 +22,8 @@ type Student struct {
 	UserID             uint          `json:"userId" gorm:"not null;unique;index"`                         // Reference to User entity
 	RegistrationNumber string        `json:"registrationNumber" gorm:"size:10;uniqueIndex;not null"`      // Auto-generated
 	Status             StudentStatus `json:"status" gorm:"type:student_status;not null;default:'active'"` // ENUM: active, inactive, suspended
-	SpecialNeeds       string        `json:"specialNeeds"`
-	MedicalInfo        string        `json:"medicalInfo"`
+	SpecialNeeds       string        `json:"specialNeeds" gorm:"type:text;serializer:encrypted"`          // Cifrado (dado de saúde)
+	MedicalInfo        string        `json:"medicalInfo" gorm:"type:text;serializer:encrypted"`           // Cifrado (dado de saúde)
 	SocialMedia        *string       `json:"socialMedia" gorm:"type:json"`
 	Notes              string        `json:"notes"`
 	CreatedAt          time.Time     `json:"createdAt" gorm:"autoCreateTime"`
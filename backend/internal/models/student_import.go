@@ -0,0 +1,52 @@
+// backend/internal/models/student_import.go
+package models
+
+import (
+	"time"
+)
+
+// StudentImport - Importação de alunos a partir de planilha (CSV ou XLSX)
+// Só as importações efetivadas são registradas; as simulações (dry run) apenas geram o relatório.
+type StudentImport struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	FileName     string    `json:"fileName" gorm:"not null"`
+	TotalRows    int       `json:"totalRows"`
+	CreatedCount int       `json:"createdCount"`
+	SkippedCount int       `json:"skippedCount"`
+	CreatedByID  uint      `json:"createdById" gorm:"not null"`
+	CreatedAt    time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela
+func (StudentImport) TableName() string {
+	return "student_imports"
+}
+
+// AccountJobStatus identifica o andamento da criação da conta de acesso
+type AccountJobStatus string
+
+const (
+	AccountJobPending    AccountJobStatus = "pending"    // Aguardando (ou nova tentativa após erro)
+	AccountJobProcessing AccountJobStatus = "processing" // Reservado por um processamento em andamento
+	AccountJobDone       AccountJobStatus = "done"       // Conta criada e email de boas-vindas enviado
+	AccountJobFailed     AccountJobStatus = "failed"     // Tentativas esgotadas
+)
+
+// AccountProvisioningJob - Criação da conta no Keycloak e envio do email de boas-vindas em segundo plano
+// Usado pela importação em lote para não depender do Keycloak e do SMTP durante a importação.
+type AccountProvisioningJob struct {
+	ID          uint             `json:"id" gorm:"primaryKey"`
+	UserID      uint             `json:"userId" gorm:"not null;uniqueIndex"`
+	ImportID    *uint            `json:"importId,omitempty" gorm:"index"`
+	Status      AccountJobStatus `json:"status" gorm:"not null;default:'pending'"`
+	Attempts    int              `json:"attempts"`
+	LastError   string           `json:"lastError,omitempty" gorm:"type:text"`
+	ProcessedAt *time.Time       `json:"processedAt,omitempty"`
+	CreatedAt   time.Time        `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time        `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName retorna o nome da tabela
+func (AccountProvisioningJob) TableName() string {
+	return "account_provisioning_jobs"
+}
@@ -0,0 +1,44 @@
+// backend/internal/models/student_merge.go
+package models
+
+import (
+	"time"
+)
+
+// StudentMerge - Registro da fusão de um cadastro duplicado no cadastro mantido
+// Guarda os dados do cadastro removido e os IDs dos registros transferidos de cada tabela,
+// o suficiente para conferir (ou desfazer manualmente) a fusão.
+type StudentMerge struct {
+	ID                 uint      `json:"id" gorm:"primaryKey"`
+	SurvivorStudentID  uint      `json:"survivorStudentId" gorm:"not null;index"`
+	DuplicateStudentID uint      `json:"duplicateStudentId" gorm:"not null;index"`
+	SurvivorUserID     uint      `json:"survivorUserId" gorm:"not null"`
+	DuplicateUserID    uint      `json:"duplicateUserId" gorm:"not null"`
+	Reason             string    `json:"reason" gorm:"type:text"`
+	DuplicateSnapshot  string    `json:"duplicateSnapshot" gorm:"type:jsonb"`            // Aluno e usuário removidos, antes da fusão
+	Moved              string    `json:"moved" gorm:"type:jsonb"`                        // Tabela -> IDs transferidos
+	InterviewResponses int64     `json:"interviewResponses"`                             // Respostas de entrevista (MongoDB) transferidas
+	ResponsesPending   bool      `json:"responsesPending" gorm:"not null;default:false"` // Transferência das respostas ainda por concluir
+	MergedByID         uint      `json:"mergedById" gorm:"not null"`
+	CreatedAt          time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela
+func (StudentMerge) TableName() string {
+	return "student_merges"
+}
+
+// StudentDuplicateDismissal - Par de alunos marcado como "não é duplicado" pela coordenação
+// O par é guardado em ordem (StudentAID < StudentBID) e deixa de aparecer na detecção.
+type StudentDuplicateDismissal struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	StudentAID    uint      `json:"studentAId" gorm:"not null;uniqueIndex:idx_student_duplicate_dismissals_pair"`
+	StudentBID    uint      `json:"studentBId" gorm:"not null;uniqueIndex:idx_student_duplicate_dismissals_pair"`
+	DismissedByID uint      `json:"dismissedById" gorm:"not null"`
+	CreatedAt     time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela
+func (StudentDuplicateDismissal) TableName() string {
+	return "student_duplicate_dismissals"
+}
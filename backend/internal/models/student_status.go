@@ -0,0 +1,72 @@
+// backend/internal/models/student_status.go
+package models
+
+import (
+	"time"
+)
+
+// StatusChangeSource identifica o que mudou a situação do aluno
+type StatusChangeSource string
+
+const (
+	StatusSourceManual        StatusChangeSource = "manual"         // Alteração feita pela coordenação
+	StatusSourceInactivity    StatusChangeSource = "inactivity"     // Regra: sem matrícula ativa pelo prazo configurado
+	StatusSourceReactivation  StatusChangeSource = "reactivation"   // Regra: aluno inativado voltou a ter matrícula ativa
+	StatusSourceSuspension    StatusChangeSource = "suspension"     // Início de uma suspensão ligada a ocorrência
+	StatusSourceSuspensionEnd StatusChangeSource = "suspension_end" // Fim do prazo ou revogação da suspensão
+	StatusSourceReversal      StatusChangeSource = "reversal"       // Desfaz a alteração anterior
+)
+
+// StudentStatusChange - Histórico das mudanças de situação do aluno
+// Toda mudança tem motivo; as automáticas não têm ChangedByID.
+type StudentStatusChange struct {
+	ID              uint               `json:"id" gorm:"primaryKey"`
+	StudentID       uint               `json:"studentId" gorm:"not null;index"`
+	FromStatus      StudentStatus      `json:"fromStatus" gorm:"not null"`
+	ToStatus        StudentStatus      `json:"toStatus" gorm:"not null"`
+	Reason          string             `json:"reason" gorm:"type:text;not null"`
+	Source          StatusChangeSource `json:"source" gorm:"not null"`
+	SuspensionID    *uint              `json:"suspensionId,omitempty"`
+	RevertsChangeID *uint              `json:"revertsChangeId,omitempty"` // Alteração desfeita por esta
+	RevertedAt      *time.Time         `json:"revertedAt,omitempty"`
+	ChangedByID     *uint              `json:"changedById,omitempty"`
+	CreatedAt       time.Time          `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+// TableName retorna o nome da tabela
+func (StudentStatusChange) TableName() string {
+	return "student_status_changes"
+}
+
+// SuspensionStatus representa a situação de uma suspensão
+type SuspensionStatus string
+
+const (
+	SuspensionStatusScheduled SuspensionStatus = "scheduled" // Começa em StartDate
+	SuspensionStatusActive    SuspensionStatus = "active"
+	SuspensionStatusEnded     SuspensionStatus = "ended"   // Terminou em EndDate
+	SuspensionStatusRevoked   SuspensionStatus = "revoked" // Cancelada pela coordenação
+)
+
+// StudentSuspension - Suspensão de um aluno decorrente de uma ocorrência
+// O aluno fica suspenso de StartDate a EndDate (inclusive) e volta à situação anterior depois.
+type StudentSuspension struct {
+	ID           uint             `json:"id" gorm:"primaryKey"`
+	StudentID    uint             `json:"studentId" gorm:"not null;index"`
+	IncidentID   uint             `json:"incidentId" gorm:"not null;index"`
+	StartDate    time.Time        `json:"startDate" gorm:"type:date;not null"`
+	EndDate      time.Time        `json:"endDate" gorm:"type:date;not null"`
+	Reason       string           `json:"reason" gorm:"type:text;not null"`
+	Status       SuspensionStatus `json:"status" gorm:"not null;default:'scheduled'"`
+	CreatedByID  uint             `json:"createdById" gorm:"not null"`
+	RevokedByID  *uint            `json:"revokedById,omitempty"`
+	RevokedAt    *time.Time       `json:"revokedAt,omitempty"`
+	RevokeReason string           `json:"revokeReason,omitempty"`
+	CreatedAt    time.Time        `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time        `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName retorna o nome da tabela
+func (StudentSuspension) TableName() string {
+	return "student_suspensions"
+}
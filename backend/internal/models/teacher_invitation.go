@@ -0,0 +1,30 @@
+// backend/internal/models/teacher_invitation.go
+package models
+
+import (
+	"time"
+)
+
+// TeacherInvitation - Convite de um voluntário para se cadastrar como professor
+// O link enviado por email é de uso único; somente o hash do token é armazenado.
+type TeacherInvitation struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	Email       string     `json:"email" gorm:"not null;index"`
+	Name        string     `json:"name"`
+	Message     string     `json:"message" gorm:"type:text"` // Recado da coordenação incluído no email
+	TokenHash   string     `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	Status      string     `json:"status" gorm:"not null;default:'pending'"` // pending, accepting, accepted, revoked (expirado: pendente após ExpiresAt)
+	ExpiresAt   time.Time  `json:"expiresAt" gorm:"not null"`
+	SentCount   int        `json:"sentCount" gorm:"not null;default:1"`
+	LastSentAt  time.Time  `json:"lastSentAt" gorm:"not null"`
+	AcceptedAt  *time.Time `json:"acceptedAt"`
+	TeacherID   *uint      `json:"teacherId"` // Professor criado no aceite
+	InvitedByID uint       `json:"invitedById" gorm:"not null"`
+	CreatedAt   time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName retorna o nome da tabela
+func (TeacherInvitation) TableName() string {
+	return "teacher_invitations"
+}
@@ -78,6 +78,25 @@ func (TeacherAbsence) TableName() string {
 	return "teacher_absences"
 }
 
+// TeacherAbsenceSession - Aula afetada por uma ausência e a providência tomada
+type TeacherAbsenceSession struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	AbsenceID      uint      `json:"absenceId" gorm:"not null;index"`
+	ClassSessionID uint      `json:"classSessionId" gorm:"not null;index"`
+	CourseClassID  uint      `json:"courseClassId" gorm:"not null"`
+	Date           time.Time `json:"date" gorm:"not null"`
+	Action         string    `json:"action" gorm:"not null"` // substitution_requested, cancellation_proposed, session_cancelled, rolled_back
+	SubstitutionID *uint     `json:"substitutionId,omitempty"` // Primeira solicitação da cadeia de substituição
+	Note           string    `json:"note"`
+	CreatedAt      time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName retorna o nome da tabela
+func (TeacherAbsenceSession) TableName() string {
+	return "teacher_absence_sessions"
+}
+
 // Substitution - Registro de substituição de professor
 type Substitution struct {
 	ID                 uint         `json:"id" gorm:"primaryKey"`
@@ -89,11 +108,17 @@ type Substitution struct {
 	CourseClass        CourseClass  `json:"courseClass,omitempty" gorm:"foreignKey:CourseClassID"`
 	ClassSessionID     *uint        `json:"classSessionId,omitempty" gorm:"index"` // Específico ou NULL para período
 	Date               time.Time    `json:"date" gorm:"not null"`
-	Status             string       `json:"status" gorm:"not null;default:'pending'"` // pending, confirmed, declined, completed
+	EndDate            *time.Time   `json:"endDate,omitempty"`                        // Fim do período (NULL = uma aula)
+	Status             string       `json:"status" gorm:"not null;default:'pending'"` // pending, confirmed, declined, expired, cancelled, completed
 	RequestedByID      uint         `json:"requestedById" gorm:"not null"`
 	Notes              string       `json:"notes"`
 	ResponseNotes      string       `json:"responseNotes"`
 	RespondedAt        *time.Time   `json:"respondedAt"`
+
+	// Escalonamento: sem resposta até RespondBy, o pedido passa ao próximo candidato
+	OriginID  *uint      `json:"originId,omitempty" gorm:"index"` // Primeira solicitação da cadeia
+	Attempt   int        `json:"attempt" gorm:"not null;default:1"`
+	RespondBy *time.Time `json:"respondBy,omitempty"`
 	CreatedAt          time.Time    `json:"createdAt" gorm:"autoCreateTime"`
 	UpdatedAt          time.Time    `json:"updatedAt" gorm:"autoUpdateTime"`
 }
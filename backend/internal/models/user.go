@@ -12,7 +12,8 @@ type User struct {
 	Email           string     `json:"email" gorm:"not null;unique"`
 	Password        string     `json:"password,omitempty" gorm:"not null"`        // Exposed for creation, handled carefully in responses
 	ProfileID       uint       `json:"profileId" gorm:"not null;default:3;index"` // FK to user_profiles (1=admin, 2=professor, 3=student)
-	CPF             string     `json:"cpf" gorm:"unique"`
+	CPF             string     `json:"cpf" gorm:"serializer:encrypted"`
+	CPFHash         *string    `json:"-" gorm:"size:64;uniqueIndex"` // Índice cego do CPF (busca e unicidade)
 	BirthDate       time.Time  `json:"birthDate"`
 	Phone           string     `json:"phone"`
 	Address         *Address   `json:"address,omitempty" gorm:"foreignKey:UserID"` // One-to-one relationship
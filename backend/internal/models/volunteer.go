@@ -67,3 +67,45 @@ type VolunteerTermHistory struct {
 func (VolunteerTermHistory) TableName() string {
 	return "volunteer_term_history"
 }
+
+// VolunteerActivity represents volunteer work logged manually (planning, events, training)
+type VolunteerActivity struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	TeacherID    uint       `json:"teacherId" gorm:"not null;index"`
+	Date         time.Time  `json:"date" gorm:"not null"`
+	Minutes      int        `json:"minutes" gorm:"not null"`
+	Category     string     `json:"category" gorm:"not null"` // planning, event, training, other
+	Description  string     `json:"description" gorm:"type:text;not null"`
+	Status       string     `json:"status" gorm:"not null;default:'pending'"` // pending, approved, rejected
+	ReviewNotes  string     `json:"reviewNotes"`
+	ReviewedByID *uint      `json:"reviewedById"`
+	ReviewedAt   *time.Time `json:"reviewedAt"`
+	CreatedByID  uint       `json:"createdById" gorm:"not null"`
+	CreatedAt    time.Time  `json:"createdAt" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updatedAt" gorm:"autoUpdateTime"`
+}
+
+// TableName specifies the database table name
+func (VolunteerActivity) TableName() string {
+	return "volunteer_activities"
+}
+
+// VolunteerDeclaration represents an issued declaration of volunteer hours (Lei 9.608/98)
+type VolunteerDeclaration struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	Code            string    `json:"code" gorm:"not null;uniqueIndex"` // Verification code printed on the PDF
+	TeacherID       uint      `json:"teacherId" gorm:"not null;index"`
+	StartDate       time.Time `json:"startDate" gorm:"not null"`
+	EndDate         time.Time `json:"endDate" gorm:"not null"`
+	SessionMinutes  int       `json:"sessionMinutes" gorm:"not null"`
+	ActivityMinutes int       `json:"activityMinutes" gorm:"not null"`
+	TotalMinutes    int       `json:"totalMinutes" gorm:"not null"`
+	Signature       string    `json:"-" gorm:"not null"` // HMAC-SHA256 of the declared data
+	IssuedByID      uint      `json:"issuedById" gorm:"not null"`
+	IssuedAt        time.Time `json:"issuedAt" gorm:"not null"`
+}
+
+// TableName specifies the database table name
+func (VolunteerDeclaration) TableName() string {
+	return "volunteer_declarations"
+}
@@ -28,6 +28,8 @@ type FormRepository interface {
 	GetResponseByStudent(ctx context.Context, studentID uint) (*models.InterviewResponse, error)
 	GetResponseByID(ctx context.Context, id string) (*models.InterviewResponse, error)
 	ListResponsesByForm(ctx context.Context, formVersion string) ([]models.InterviewResponse, error)
+	ListResponsesByStudent(ctx context.Context, studentID uint) ([]models.InterviewResponse, error)
+	ReassignResponses(ctx context.Context, fromStudentID, toStudentID uint) (int64, error)
 }
 
 type formRepository struct {
@@ -198,3 +200,31 @@ func (r *formRepository) ListResponsesByForm(ctx context.Context, formVersion st
 	}
 	return responses, nil
 }
+
+// ListResponsesByStudent returns every response of a student, most recent first
+func (r *formRepository) ListResponsesByStudent(ctx context.Context, studentID uint) ([]models.InterviewResponse, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	cursor, err := r.responseCollection.Find(ctx, bson.M{"studentId": studentID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var responses []models.InterviewResponse
+	if err = cursor.All(ctx, &responses); err != nil {
+		return nil, err
+	}
+	return responses, nil
+}
+
+// ReassignResponses moves the responses of a student to another (merge of duplicate registrations)
+func (r *formRepository) ReassignResponses(ctx context.Context, fromStudentID, toStudentID uint) (int64, error) {
+	result, err := r.responseCollection.UpdateMany(ctx,
+		bson.M{"studentId": fromStudentID},
+		bson.M{"$set": bson.M{"studentId": toStudentID}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
@@ -10,11 +10,16 @@ import (
 	"gorm.io/gorm/logger"
 
 	"github.com/devdavidalonso/cecor/backend/internal/config"
-	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/pkg/fieldcrypt"
 )
 
-// InitDB initializes the PostgreSQL database connection and performs migrations
+// InitDB initializes the PostgreSQL database connection (schema changes are applied by the migrations package)
 func InitDB(cfg *config.Config) (*gorm.DB, error) {
+	// The encrypted model fields need the keys before the first query
+	if err := ConfigureEncryption(cfg); err != nil {
+		return nil, err
+	}
+
 	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Database.PostgresHost,
 		cfg.Database.PostgresPort,
@@ -60,136 +65,12 @@ func InitDB(cfg *config.Config) (*gorm.DB, error) {
 	return db, nil
 }
 
-// MigrateDB performs database migrations
-func MigrateDB(db *gorm.DB) error {
-	// Compatibilidade com bases antigas/incompletas.
-	// Garante enum e colunas de Student antes do AutoMigrate.
-	if err := ensureStudentSchemaCompatibility(db); err != nil {
-		log.Printf("Warning: error ensuring student schema compatibility: %v", err)
-	}
-
-	// Lista de todos os modelos para migração
-	models := []interface{}{
-		// User related models
-		&models.User{},
-		&models.UserProfile{},
-		&models.Address{},
-		&models.UserContact{},
-
-		// Student related models
-		&models.Student{},
-		&models.Guardian{},
-		&models.GuardianPermissions{},
-		&models.StudentNote{},
-		&models.Document{},
-
-		// Course related models
-		&models.Course{},
-		&models.TeacherCourse{},
-		&models.CourseClass{},
-		&models.ClassSession{},
-		&models.EnrollmentCourseClass{},
-		&models.SyllabusTopic{},
-
-		// Enrollment related models
-		&models.Enrollment{},
-		&models.Registration{}, // Legacy
-
-		// Attendance related models
-		&models.Attendance{},
-		// &models.AttendanceLegacy{}, // Legacy
-		&models.AbsenceJustification{},
-		&models.AbsenceAlert{},
-
-		// Certificate related models
-		&models.Certificate{},
-		&models.CertificateTemplate{},
-
-		// Form and Interview related models
-		&models.Form{},
-		&models.FormQuestion{},
-		&models.Interview{},
-		&models.FormResponse{},
-		&models.FormAnswerDetail{},
-
-		// Volunteer related models
-		&models.VolunteerTermTemplate{},
-		&models.VolunteerTerm{},
-		&models.VolunteerTermHistory{},
-
-		// System models
-		&models.Notification{},
-		&models.AuditLog{},
-	}
-
-	// Migrar cada modelo individualmente
-	for _, model := range models {
-		if err := db.AutoMigrate(model); err != nil {
-			log.Printf("Warning: error migrating %T: %v", model, err)
-			// Continua com o próximo modelo, não interrompe a migração
-		}
-	}
-
-	return nil
-}
-
-func ensureStudentSchemaCompatibility(db *gorm.DB) error {
-	// Ensure enum exists for student status
-	if err := db.Exec(`
-		DO $$
-		BEGIN
-			IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'student_status') THEN
-				CREATE TYPE student_status AS ENUM ('active', 'inactive', 'suspended');
-			END IF;
-		END
-		$$;
-	`).Error; err != nil {
-		return err
-	}
-
-	// If students table already exists from legacy schema, add missing columns safely.
-	if err := db.Exec(`
-		DO $$
-		BEGIN
-			IF EXISTS (
-				SELECT 1
-				FROM information_schema.tables
-				WHERE table_schema = 'public' AND table_name = 'students'
-			) THEN
-				ALTER TABLE students ADD COLUMN IF NOT EXISTS special_needs text;
-				ALTER TABLE students ADD COLUMN IF NOT EXISTS medical_info text;
-				ALTER TABLE students ADD COLUMN IF NOT EXISTS social_media jsonb;
-				ALTER TABLE students ADD COLUMN IF NOT EXISTS notes text;
-			END IF;
-		END
-		$$;
-	`).Error; err != nil {
-		return err
-	}
-
-	// Compatibilidade com schema legado de users:
-	// em algumas bases antigas, a coluna `profile` (texto) é NOT NULL.
-	// Como o sistema atual usa `profile_id`, garantimos default para inserts sem essa coluna.
-	if err := db.Exec(`
-		DO $$
-		BEGIN
-			IF EXISTS (
-				SELECT 1
-				FROM information_schema.columns
-				WHERE table_schema = 'public'
-				  AND table_name = 'users'
-				  AND column_name = 'profile'
-			) THEN
-				ALTER TABLE users ALTER COLUMN profile SET DEFAULT 'student';
-				UPDATE users
-				SET profile = 'student'
-				WHERE profile IS NULL OR profile = '';
-			END IF;
-		END
-		$$;
-	`).Error; err != nil {
-		return err
+// ConfigureEncryption sets the keys of the encrypted model fields (gorm:"serializer:encrypted")
+func ConfigureEncryption(cfg *config.Config) error {
+	keyring, err := fieldcrypt.NewKeyring(cfg.Encryption.KeyID, cfg.Encryption.Keys, cfg.Encryption.IndexKey)
+	if err != nil {
+		return fmt.Errorf("failed to configure field encryption: %w", err)
 	}
-
+	fieldcrypt.SetDefault(keyring)
 	return nil
 }
@@ -79,3 +79,144 @@ func (r *reportRepository) GetStudentAttendanceStats(ctx context.Context, studen
 
 	return stats, nil
 }
+
+// classOccupancyQuery aggregates enrollments, dropouts, attendance and waiting list
+// per class in a single statement; filters are appended by GetClassOccupancy.
+const classOccupancyQuery = `
+	WITH enrolled AS (
+		SELECT ecc.course_class_id,
+			COUNT(*) FILTER (WHERE e.status IN ('active', 'in_progress')) AS enrolled_count,
+			COUNT(*) FILTER (
+				WHERE e.status = 'cancelled'
+				AND COALESCE(e.end_date, e.updated_at) >= @start
+				AND COALESCE(e.end_date, e.updated_at) < @end
+			) AS dropout_count
+		FROM enrollment_course_classes ecc
+		JOIN enrollments e ON e.id = ecc.enrollment_id
+		GROUP BY ecc.course_class_id
+	), attendance AS (
+		-- An enrollment may be linked to several classes: only the attendance taken
+		-- on a session of this class counts towards it
+		SELECT ecc.course_class_id,
+			COUNT(a.id) AS attendance_records,
+			COUNT(a.id) FILTER (WHERE a.status = 'present') AS present_count
+		FROM enrollment_course_classes ecc
+		JOIN attendances a ON a.enrollment_id = ecc.enrollment_id
+		WHERE a.date >= @start AND a.date < @end
+			AND EXISTS (
+				SELECT 1 FROM class_sessions cs
+				WHERE cs.course_class_id = ecc.course_class_id
+					AND cs.date::date = a.date::date
+			)
+		GROUP BY ecc.course_class_id
+	), waiting AS (
+		SELECT course_id, COUNT(*) AS waiting_list_count
+		FROM waiting_list
+		WHERE status = 'waiting'
+		GROUP BY course_id
+	)
+	SELECT cc.id AS course_class_id, cc.code, cc.name,
+		c.id AS course_id, c.name AS course_name,
+		cat.id AS category_id, cat.name AS category_name,
+		cc.capacity, cc.max_students,
+		COALESCE(en.enrolled_count, 0) AS enrolled_count,
+		COALESCE(w.waiting_list_count, 0) AS waiting_list_count,
+		COALESCE(att.attendance_records, 0) AS attendance_records,
+		COALESCE(att.present_count, 0) AS present_count,
+		COALESCE(en.dropout_count, 0) AS dropout_count
+	FROM course_classes cc
+	JOIN courses c ON c.id = cc.course_id AND c.deleted_at IS NULL
+	LEFT JOIN course_categories cat ON cat.id = c.category_id
+	LEFT JOIN enrolled en ON en.course_class_id = cc.id
+	LEFT JOIN attendance att ON att.course_class_id = cc.id
+	LEFT JOIN waiting w ON w.course_id = c.id
+	WHERE cc.status = 'active'
+		AND cc.start_date < @end
+		AND cc.end_date >= @start`
+
+// GetClassOccupancy returns the occupancy of the active classes in the period.
+// When courseClassID is set only that class is returned (drill-down).
+func (r *reportRepository) GetClassOccupancy(ctx context.Context, filter repository.OccupancyFilter, courseClassID *uint) ([]repository.ClassOccupancy, error) {
+	start, end := periodBounds(filter.StartDate, filter.EndDate)
+	args := map[string]interface{}{
+		"start": start,
+		"end":   end,
+	}
+
+	query := classOccupancyQuery
+	if filter.CategoryID != nil {
+		query += " AND c.category_id = @category"
+		args["category"] = *filter.CategoryID
+	}
+	if courseClassID != nil {
+		query += " AND cc.id = @class"
+		args["class"] = *courseClassID
+	}
+	query += " ORDER BY c.name, cc.code"
+
+	var stats []repository.ClassOccupancy
+	if err := r.db.WithContext(ctx).Raw(query, args).Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range stats {
+		seats := stats[i].MaxStudents
+		if seats <= 0 {
+			seats = stats[i].Capacity
+		}
+		if seats > 0 {
+			stats[i].OccupancyRate = float64(stats[i].EnrolledCount) / float64(seats) * 100
+		}
+		if stats[i].AttendanceRecords > 0 {
+			stats[i].AttendanceRate = float64(stats[i].PresentCount) / float64(stats[i].AttendanceRecords) * 100
+		}
+	}
+
+	return stats, nil
+}
+
+// GetClassStudentsOccupancy lists the enrollments of a class with their attendance in the period
+func (r *reportRepository) GetClassStudentsOccupancy(ctx context.Context, courseClassID uint, startDate, endDate time.Time) ([]repository.ClassStudentOccupancy, error) {
+	start, end := periodBounds(startDate, endDate)
+
+	var stats []repository.ClassStudentOccupancy
+	err := r.db.WithContext(ctx).Table("enrollment_course_classes AS ecc").
+		Select(`
+			e.id AS enrollment_id,
+			e.student_id,
+			users.name AS student_name,
+			e.status AS enrollment_status,
+			e.enrollment_date,
+			e.end_date,
+			COUNT(a.id) AS total_classes,
+			COUNT(a.id) FILTER (WHERE a.status = 'present') AS present_count
+		`).
+		Joins("JOIN enrollments e ON e.id = ecc.enrollment_id").
+		Joins("JOIN students ON students.id = e.student_id").
+		Joins("JOIN users ON users.id = students.user_id").
+		Joins(`LEFT JOIN attendances a ON a.enrollment_id = e.id AND a.date >= ? AND a.date < ?
+			AND EXISTS (SELECT 1 FROM class_sessions cs WHERE cs.course_class_id = ecc.course_class_id AND cs.date::date = a.date::date)`, start, end).
+		Where("ecc.course_class_id = ?", courseClassID).
+		Group("e.id, e.student_id, users.name, e.status, e.enrollment_date, e.end_date").
+		Order("users.name").
+		Scan(&stats).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range stats {
+		if stats[i].TotalClasses > 0 {
+			stats[i].AttendanceRate = float64(stats[i].PresentCount) / float64(stats[i].TotalClasses) * 100
+		}
+	}
+
+	return stats, nil
+}
+
+// periodBounds turns an inclusive date range into [start, end) day boundaries
+func periodBounds(startDate, endDate time.Time) (time.Time, time.Time) {
+	start := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
+	end := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, endDate.Location()).AddDate(0, 0, 1)
+	return start, end
+}
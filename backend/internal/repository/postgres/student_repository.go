@@ -11,6 +11,7 @@ import (
 
 	"github.com/devdavidalonso/cecor/backend/internal/models"
 	"github.com/devdavidalonso/cecor/backend/internal/repository"
+	"github.com/devdavidalonso/cecor/backend/pkg/cpf"
 )
 
 // studentRepository implements the StudentRepository interface using PostgreSQL/GORM
@@ -53,9 +54,13 @@ func (r *studentRepository) FindAll(ctx context.Context, page int, pageSize int,
 			query = query.Joins("JOIN users ON users.id = students.user_id").
 				Where("users.email ILIKE ?", fmt.Sprintf("%%%s%%", value))
 		case "cpf":
-			// CPF is also in the users table
+			// CPF is encrypted in the users table: the search uses its blind index
+			index, err := models.CPFIndex(fmt.Sprint(value))
+			if err != nil {
+				return nil, 0, err
+			}
 			query = query.Joins("JOIN users ON users.id = students.user_id").
-				Where("users.cpf = ?", value)
+				Where("users.cpf_hash = ?", index)
 		case "status":
 			query = query.Where("students.status = ?", value)
 		case "min_age":
@@ -162,19 +167,22 @@ func (r *studentRepository) FindByEmail(ctx context.Context, email string) (*mod
 // FindByCPF finds a student by CPF (Brazilian tax ID)
 // Parameters:
 // - ctx: context for database operations
-// - cpf: CPF number (with or without formatting)
+// - number: CPF number (with or without formatting)
 // Returns:
 // - *models.Student: pointer to student record (nil if not found)
 // - error: any error encountered during the operation
-func (r *studentRepository) FindByCPF(ctx context.Context, cpf string) (*models.Student, error) {
+func (r *studentRepository) FindByCPF(ctx context.Context, number string) (*models.Student, error) {
 	var student models.Student
 
-	// Remove CPF formatting
-	cleanCPF := strings.ReplaceAll(strings.ReplaceAll(cpf, ".", ""), "-", "")
+	// CPF is encrypted: find it by the blind index of the digits
+	index, err := models.CPFIndex(cpf.Normalize(number))
+	if err != nil {
+		return nil, err
+	}
 
 	result := r.db.WithContext(ctx).
 		Joins("JOIN users ON users.id = students.user_id").
-		Where("users.cpf = ? AND students.deleted_at IS NULL", cleanCPF).
+		Where("users.cpf_hash = ? AND students.deleted_at IS NULL", index).
 		Preload("User").
 		First(&student)
 
@@ -210,7 +218,7 @@ func (r *studentRepository) Create(ctx context.Context, student *models.Student)
 
 	// Clean CPF if provided
 	if student.User.CPF != "" {
-		student.User.CPF = strings.ReplaceAll(strings.ReplaceAll(student.User.CPF, ".", ""), "-", "")
+		student.User.CPF = cpf.Normalize(student.User.CPF)
 	}
 
 	// Start transaction
@@ -279,9 +287,14 @@ func (r *studentRepository) Update(ctx context.Context, student *models.Student)
 			// Ensure user ID is correct
 			student.User.ID = existing.UserID
 
-			// Clean CPF if provided
+			// Clean CPF if provided; the hooks run on the empty model, so the index is set here
 			if student.User.CPF != "" {
-				student.User.CPF = strings.ReplaceAll(strings.ReplaceAll(student.User.CPF, ".", ""), "-", "")
+				student.User.CPF = cpf.Normalize(student.User.CPF)
+				index, err := models.CPFIndex(student.User.CPF)
+				if err != nil {
+					return err
+				}
+				student.User.CPFHash = &index
 			}
 
 			// Update user
@@ -384,7 +397,7 @@ func (r *studentRepository) AddGuardian(ctx context.Context, guardian *models.Gu
 
 	// Clean CPF if provided
 	if guardian.CPF != "" {
-		guardian.CPF = strings.ReplaceAll(strings.ReplaceAll(guardian.CPF, ".", ""), "-", "")
+		guardian.CPF = cpf.Normalize(guardian.CPF)
 	}
 
 	// Insert guardian
@@ -417,7 +430,7 @@ func (r *studentRepository) UpdateGuardian(ctx context.Context, guardian *models
 
 	// Clean CPF if provided
 	if guardian.CPF != "" {
-		guardian.CPF = strings.ReplaceAll(strings.ReplaceAll(guardian.CPF, ".", ""), "-", "")
+		guardian.CPF = cpf.Normalize(guardian.CPF)
 	}
 
 	// Update guardian
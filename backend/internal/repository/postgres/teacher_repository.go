@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/repository"
+)
+
+// teacherRepository implements the repository.TeacherRepository interface for PostgreSQL
+type teacherRepository struct {
+	db *gorm.DB
+}
+
+// NewTeacherRepository creates a new instance of repository.TeacherRepository
+func NewTeacherRepository(db *gorm.DB) repository.TeacherRepository {
+	return &teacherRepository{
+		db: db,
+	}
+}
+
+// FindByID finds a teacher by ID with its user (address and contacts), skills and availability
+func (r *teacherRepository) FindByID(ctx context.Context, id uint) (*models.Teacher, error) {
+	var teacher models.Teacher
+
+	result := r.db.WithContext(ctx).
+		Joins("JOIN users ON users.id = teachers.user_id AND users.deleted_at IS NULL").
+		Preload("User").
+		Preload("User.Address").
+		Preload("User.UserContacts").
+		Preload("Skills").
+		Preload("Skills.Skill").
+		Preload("Availability", func(db *gorm.DB) *gorm.DB {
+			return db.Order("day_of_week ASC, start_time ASC")
+		}).
+		Where("teachers.id = ?", id).
+		First(&teacher)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Returns nil without error when not found
+		}
+		return nil, fmt.Errorf("error finding teacher by ID: %w", result.Error)
+	}
+
+	return &teacher, nil
+}
+
+// FindByUserID finds the teacher record of a user
+func (r *teacherRepository) FindByUserID(ctx context.Context, userID uint) (*models.Teacher, error) {
+	var teacher models.Teacher
+
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&teacher)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error finding teacher by user ID: %w", result.Error)
+	}
+
+	return &teacher, nil
+}
+
+// FindAll lists the teachers whose user was not deleted
+func (r *teacherRepository) FindAll(ctx context.Context) ([]models.Teacher, error) {
+	var teachers []models.Teacher
+
+	result := r.db.WithContext(ctx).
+		Joins("JOIN users ON users.id = teachers.user_id AND users.deleted_at IS NULL").
+		Preload("User").
+		Order("users.name ASC").
+		Find(&teachers)
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("error listing teachers: %w", result.Error)
+	}
+
+	return teachers, nil
+}
+
+// Create creates a new teacher record
+func (r *teacherRepository) Create(ctx context.Context, teacher *models.Teacher) error {
+	result := r.db.WithContext(ctx).Omit("User", "Courses", "Skills", "Availability").Create(teacher)
+	if result.Error != nil {
+		return fmt.Errorf("error creating teacher: %w", result.Error)
+	}
+	return nil
+}
+
+// Update updates the teacher profile fields (specialization, bio, phone, active)
+func (r *teacherRepository) Update(ctx context.Context, teacher *models.Teacher) error {
+	return updateTeacher(r.db.WithContext(ctx), teacher)
+}
+
+func updateTeacher(db *gorm.DB, teacher *models.Teacher) error {
+	result := db.
+		Model(&models.Teacher{}).
+		Where("id = ?", teacher.ID).
+		Updates(map[string]interface{}{
+			"specialization": teacher.Specialization,
+			"bio":            teacher.Bio,
+			"phone":          teacher.Phone,
+			"active":         teacher.Active,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("error updating teacher: %w", result.Error)
+	}
+	return nil
+}
+
+// CreateWithUser creates the user, the teacher record, its skills and availability in one transaction
+func (r *teacherRepository) CreateWithUser(ctx context.Context, user *models.User, teacher *models.Teacher, skills []models.TeacherSkill, windows []models.TeacherAvailability) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createUser(tx, user); err != nil {
+			return err
+		}
+		teacher.UserID = user.ID
+		if err := tx.Omit("User", "Courses", "Skills", "Availability").Create(teacher).Error; err != nil {
+			return fmt.Errorf("error creating teacher: %w", err)
+		}
+		if err := replaceSkills(tx, teacher.ID, skills); err != nil {
+			return err
+		}
+		return replaceAvailability(tx, teacher.ID, windows)
+	})
+}
+
+// UpdateWithUser updates the user (with address and contacts), the teacher profile fields and,
+// when not nil, replaces the skills and availability in one transaction
+func (r *teacherRepository) UpdateWithUser(ctx context.Context, user *models.User, teacher *models.Teacher, skills []models.TeacherSkill, windows []models.TeacherAvailability) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := updateUserWithAssociations(tx, user); err != nil {
+			return err
+		}
+		if err := updateTeacher(tx, teacher); err != nil {
+			return err
+		}
+		if skills != nil {
+			if err := replaceSkills(tx, teacher.ID, skills); err != nil {
+				return err
+			}
+		}
+		if windows != nil {
+			return replaceAvailability(tx, teacher.ID, windows)
+		}
+		return nil
+	})
+}
+
+// ReplaceSkills replaces the skills of a teacher
+func (r *teacherRepository) ReplaceSkills(ctx context.Context, teacherID uint, skills []models.TeacherSkill) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceSkills(tx, teacherID, skills)
+	})
+}
+
+// ReplaceAvailability replaces the weekly availability of a teacher
+func (r *teacherRepository) ReplaceAvailability(ctx context.Context, teacherID uint, windows []models.TeacherAvailability) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replaceAvailability(tx, teacherID, windows)
+	})
+}
+
+func replaceSkills(tx *gorm.DB, teacherID uint, skills []models.TeacherSkill) error {
+	if err := tx.Where("teacher_id = ?", teacherID).Delete(&models.TeacherSkill{}).Error; err != nil {
+		return fmt.Errorf("error removing teacher skills: %w", err)
+	}
+	if len(skills) == 0 {
+		return nil
+	}
+	for i := range skills {
+		skills[i].ID = 0
+		skills[i].TeacherID = teacherID
+	}
+	if err := tx.Omit("Teacher", "Skill").Create(&skills).Error; err != nil {
+		return fmt.Errorf("error creating teacher skills: %w", err)
+	}
+	return nil
+}
+
+func replaceAvailability(tx *gorm.DB, teacherID uint, windows []models.TeacherAvailability) error {
+	if err := tx.Where("teacher_id = ?", teacherID).Delete(&models.TeacherAvailability{}).Error; err != nil {
+		return fmt.Errorf("error removing teacher availability: %w", err)
+	}
+	if len(windows) == 0 {
+		return nil
+	}
+	for i := range windows {
+		windows[i].ID = 0
+		windows[i].TeacherID = teacherID
+	}
+	if err := tx.Omit("Teacher").Create(&windows).Error; err != nil {
+		return fmt.Errorf("error creating teacher availability: %w", err)
+	}
+	return nil
+}
@@ -87,7 +87,13 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models
 
 // Create creates a new user
 func (r *userRepository) Create(ctx context.Context, user *models.User) error {
-	result := r.db.WithContext(ctx).Create(user)
+	return createUser(r.db.WithContext(ctx), user)
+}
+
+// createUser inserts the user with its associations; shared with the repositories that create
+// a user together with other records in one transaction
+func createUser(db *gorm.DB, user *models.User) error {
+	result := db.Create(user)
 	if result.Error != nil {
 		if strings.Contains(result.Error.Error(), "unique constraint") {
 			if strings.Contains(result.Error.Error(), "email") {
@@ -104,7 +110,7 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	// Compatibilidade com schema legado: coluna users.profile (texto) obrigatória.
 	// Mantemos profile_id como fonte principal e sincronizamos profile textual.
 	profileText := profileTextFromID(user.ProfileID)
-	if err := r.db.WithContext(ctx).
+	if err := db.
 		Model(&models.User{}).
 		Where("id = ?", user.ID).
 		Update("profile", profileText).Error; err != nil {
@@ -145,33 +151,39 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 // UpdateWithAssociations updates an existing user and replaces Address and UserContacts
 func (r *userRepository) UpdateWithAssociations(ctx context.Context, user *models.User) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 1. Atualiza campos normais do usuario
-		if err := tx.Model(user).Updates(user).Error; err != nil {
-			if strings.Contains(err.Error(), "unique constraint") {
-				return fmt.Errorf("uniqueness violation: %w", err)
-			}
-			return fmt.Errorf("error updating user: %w", err)
+		return updateUserWithAssociations(tx, user)
+	})
+}
+
+// updateUserWithAssociations runs UpdateWithAssociations in tx; shared with the repositories that
+// update a user together with other records
+func updateUserWithAssociations(tx *gorm.DB, user *models.User) error {
+	// 1. Atualiza campos normais do usuario
+	if err := tx.Model(user).Updates(user).Error; err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			return fmt.Errorf("uniqueness violation: %w", err)
 		}
+		return fmt.Errorf("error updating user: %w", err)
+	}
 
-		// 2. Substitui ou atualiza o Endereço
-		if user.Address != nil {
-			if err := tx.Model(user).Association("Address").Replace(user.Address); err != nil {
-				return fmt.Errorf("error replacing address: %w", err)
-			}
-		} else {
-			// Opcional: remover endereco se nil
-			tx.Model(user).Association("Address").Clear()
+	// 2. Substitui ou atualiza o Endereço
+	if user.Address != nil {
+		if err := tx.Model(user).Association("Address").Replace(user.Address); err != nil {
+			return fmt.Errorf("error replacing address: %w", err)
 		}
+	} else {
+		// Opcional: remover endereco se nil
+		tx.Model(user).Association("Address").Clear()
+	}
 
-		// 3. Substitui os Contatos de Emergência
-		if user.UserContacts != nil {
-			if err := tx.Model(user).Association("UserContacts").Replace(user.UserContacts); err != nil {
-				return fmt.Errorf("error replacing contacts: %w", err)
-			}
+	// 3. Substitui os Contatos de Emergência
+	if user.UserContacts != nil {
+		if err := tx.Model(user).Association("UserContacts").Replace(user.UserContacts); err != nil {
+			return fmt.Errorf("error replacing contacts: %w", err)
 		}
+	}
 
-		return nil
-	})
+	return nil
 }
 
 // Delete removes a user (soft delete)
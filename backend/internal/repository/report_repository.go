@@ -26,4 +26,46 @@ type StudentAttendanceStats struct {
 type ReportRepository interface {
 	GetCourseAttendanceStats(ctx context.Context, courseID uint, startDate, endDate time.Time) ([]CourseAttendanceStats, error)
 	GetStudentAttendanceStats(ctx context.Context, studentID uint, startDate, endDate time.Time) ([]StudentAttendanceStats, error)
+	GetClassOccupancy(ctx context.Context, filter OccupancyFilter, courseClassID *uint) ([]ClassOccupancy, error)
+	GetClassStudentsOccupancy(ctx context.Context, courseClassID uint, startDate, endDate time.Time) ([]ClassStudentOccupancy, error)
+}
+
+// OccupancyFilter restricts the occupancy dashboard
+type OccupancyFilter struct {
+	CategoryID *uint
+	StartDate  time.Time
+	EndDate    time.Time // inclusive
+}
+
+// ClassOccupancy aggregates the occupancy indicators of one course class
+type ClassOccupancy struct {
+	CourseClassID     uint    `json:"courseClassId"`
+	Code              string  `json:"code"`
+	Name              string  `json:"name"`
+	CourseID          uint    `json:"courseId"`
+	CourseName        string  `json:"courseName"`
+	CategoryID        *uint   `json:"categoryId"`
+	CategoryName      *string `json:"categoryName"`
+	Capacity          int     `json:"capacity"`
+	MaxStudents       int     `json:"maxStudents"`
+	EnrolledCount     int64   `json:"enrolledCount"`
+	OccupancyRate     float64 `json:"occupancyRate"`
+	WaitingListCount  int64   `json:"waitingListCount"` // Fila de espera do curso
+	AttendanceRecords int64   `json:"attendanceRecords"`
+	PresentCount      int64   `json:"presentCount"`
+	AttendanceRate    float64 `json:"attendanceRate"`
+	DropoutCount      int64   `json:"dropoutCount"`
+}
+
+// ClassStudentOccupancy is one enrollment in the class drill-down
+type ClassStudentOccupancy struct {
+	EnrollmentID     uint       `json:"enrollmentId"`
+	StudentID        uint       `json:"studentId"`
+	StudentName      string     `json:"studentName"`
+	EnrollmentStatus string     `json:"enrollmentStatus"`
+	EnrollmentDate   time.Time  `json:"enrollmentDate"`
+	EndDate          *time.Time `json:"endDate"`
+	TotalClasses     int64      `json:"totalClasses"`
+	PresentCount     int64      `json:"presentCount"`
+	AttendanceRate   float64    `json:"attendanceRate"`
 }
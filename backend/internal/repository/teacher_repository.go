@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+// TeacherRepository defines the interface for teacher record data access
+type TeacherRepository interface {
+	// FindByID finds a teacher by ID with its user (address and contacts), skills and availability
+	FindByID(ctx context.Context, id uint) (*models.Teacher, error)
+
+	// FindByUserID finds the teacher record of a user
+	FindByUserID(ctx context.Context, userID uint) (*models.Teacher, error)
+
+	// FindAll lists the teachers whose user was not deleted
+	FindAll(ctx context.Context) ([]models.Teacher, error)
+
+	// Create creates a new teacher record
+	Create(ctx context.Context, teacher *models.Teacher) error
+
+	// CreateWithUser creates the user, the teacher record, its skills and availability in one transaction
+	CreateWithUser(ctx context.Context, user *models.User, teacher *models.Teacher, skills []models.TeacherSkill, windows []models.TeacherAvailability) error
+
+	// Update updates the teacher profile fields (specialization, bio, phone, active)
+	Update(ctx context.Context, teacher *models.Teacher) error
+
+	// UpdateWithUser updates the user, the teacher profile fields and, when not nil, the skills and
+	// availability in one transaction
+	UpdateWithUser(ctx context.Context, user *models.User, teacher *models.Teacher, skills []models.TeacherSkill, windows []models.TeacherAvailability) error
+
+	// ReplaceSkills replaces the skills of a teacher
+	ReplaceSkills(ctx context.Context, teacherID uint, skills []models.TeacherSkill) error
+
+	// ReplaceAvailability replaces the weekly availability of a teacher
+	ReplaceAvailability(ctx context.Context, teacherID uint, windows []models.TeacherAvailability) error
+}
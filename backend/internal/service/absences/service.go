@@ -0,0 +1,549 @@
+// backend/internal/service/absences/service.go
+package absences
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/substitutions"
+)
+
+// Status values of models.TeacherAbsence
+const (
+	StatusActive    = "active"
+	StatusResolved  = "resolved"
+	StatusCancelled = "cancelled"
+)
+
+// Action values of models.TeacherAbsenceSession
+const (
+	ActionSubstitutionRequested = "substitution_requested"
+	ActionCancellationProposed  = "cancellation_proposed"
+	ActionSessionCancelled      = "session_cancelled"
+	ActionRolledBack            = "rolled_back"
+)
+
+// Handling modes for the affected sessions
+const (
+	ModeAuto   = "auto"   // pede substituto; sem candidato, propõe cancelamento
+	ModeCancel = "cancel" // propõe o cancelamento de todas as aulas
+)
+
+// coordinatorProfileID is the user profile notified about absences (1=admin)
+const coordinatorProfileID = 1
+
+var (
+	// ErrNotFound is returned when the absence does not exist
+	ErrNotFound = errors.New("teacher absence not found")
+	// ErrInvalidAbsence is returned for malformed absence reports
+	ErrInvalidAbsence = errors.New("invalid teacher absence")
+	// ErrNotTeacher is returned when the user has no teacher record or does not own the absence
+	ErrNotTeacher = errors.New("user is not the absent teacher")
+	// ErrNotActive is returned when resolving or cancelling a closed absence
+	ErrNotActive = errors.New("teacher absence is no longer active")
+	// ErrSessionNotAffected is returned when cancelling a session that is not part of the absence
+	ErrSessionNotAffected = errors.New("session is not affected by this absence")
+)
+
+// Notifier delivers the absence notifications (implemented by notifications.NotificationTriggers)
+type Notifier interface {
+	OnTeacherAbsenceReported(coordinatorUserIDs []uint, teacherName string, period string, affected int, substitutionsRequested int, cancellationsProposed int)
+	OnClassCancelled(studentIDs []uint, courseName string, date string, reason string)
+}
+
+// ReportInput registers an absence period
+type ReportInput struct {
+	TeacherID   uint
+	StartDate   time.Time
+	EndDate     time.Time
+	Reason      string
+	Mode        string // auto (padrão) ou cancel
+	CreatedByID uint
+}
+
+// Filter narrows the coordinator listing
+type Filter struct {
+	Status    string
+	TeacherID *uint
+}
+
+// AffectedSession is a session hit by the absence with the action taken
+type AffectedSession struct {
+	models.TeacherAbsenceSession
+	CourseName         string  `json:"courseName"`
+	ClassName          string  `json:"className"`
+	StartTime          string  `json:"startTime"`
+	EndTime            string  `json:"endTime"`
+	IsCancelled        bool    `json:"isCancelled"`
+	SubstitutionStatus *string `json:"substitutionStatus,omitempty"` // Status da última solicitação da cadeia
+	SubstituteName     *string `json:"substituteName,omitempty"`
+}
+
+// AbsenceDetail is an absence with its affected sessions
+type AbsenceDetail struct {
+	models.TeacherAbsence
+	TeacherName string            `json:"teacherName"`
+	Sessions    []AffectedSession `json:"sessions"`
+}
+
+// Service defines the interface for teacher absence operations
+type Service interface {
+	Report(ctx context.Context, input ReportInput) (*AbsenceDetail, error)
+	List(ctx context.Context, filter Filter) ([]models.TeacherAbsence, error)
+	Get(ctx context.Context, id uint) (*AbsenceDetail, error)
+	Resolve(ctx context.Context, id uint, userID uint) (*AbsenceDetail, error)
+	Cancel(ctx context.Context, id uint, userID uint) (*AbsenceDetail, error)
+	CancelSession(ctx context.Context, id uint, sessionID uint) (*AbsenceDetail, error)
+
+	// Teacher portal
+	TeacherIDForUser(ctx context.Context, userID uint) (uint, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db            *gorm.DB
+	substitutions substitutions.Service
+	notifier      Notifier
+}
+
+// NewService creates a new teacher absence service. notifier may be nil.
+func NewService(db *gorm.DB, substitutionService substitutions.Service, notifier Notifier) Service {
+	return &service{db: db, substitutions: substitutionService, notifier: notifier}
+}
+
+// affectedRow is a non-cancelled session taught by the absent teacher
+type affectedRow struct {
+	SessionID     uint
+	CourseClassID uint
+	Date          time.Time
+	UsesDefault   bool // o professor é o padrão da turma (sem override na aula)
+}
+
+// Report registers the absence, flags every affected session and opens substitutions or proposes cancellations
+func (s *service) Report(ctx context.Context, input ReportInput) (*AbsenceDetail, error) {
+	if input.TeacherID == 0 || input.StartDate.IsZero() || input.EndDate.IsZero() {
+		return nil, fmt.Errorf("%w: teacher, startDate and endDate are required", ErrInvalidAbsence)
+	}
+	if input.EndDate.Before(input.StartDate) {
+		return nil, fmt.Errorf("%w: endDate before startDate", ErrInvalidAbsence)
+	}
+	if input.Mode == "" {
+		input.Mode = ModeAuto
+	}
+	if input.Mode != ModeAuto && input.Mode != ModeCancel {
+		return nil, fmt.Errorf("%w: mode must be %s or %s", ErrInvalidAbsence, ModeAuto, ModeCancel)
+	}
+
+	db := s.db.WithContext(ctx)
+
+	var teacher models.Teacher
+	if err := db.Preload("User").First(&teacher, input.TeacherID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: teacher not found", ErrInvalidAbsence)
+		}
+		return nil, err
+	}
+
+	var rows []affectedRow
+	if err := db.Table("class_sessions cs").
+		Select("cs.id AS session_id, cs.course_class_id, cs.date, cs.teacher_id IS NULL AS uses_default").
+		Joins("JOIN course_classes cc ON cc.id = cs.course_class_id").
+		Where("cs.is_cancelled = ? AND DATE(cs.date) BETWEEN ? AND ?", false, dateOnly(input.StartDate), dateOnly(input.EndDate)).
+		Where("COALESCE(cs.teacher_id, cc.default_teacher_id) = ?", teacher.ID).
+		Order("cs.date, cs.id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load affected sessions: %w", err)
+	}
+
+	// The substitution requests are opened first (by the substitutions service, outside this
+	// transaction); the absence and its sessions are then written together, and the opened
+	// requests are cancelled if that fails, so no partial absence is left behind
+	var affected []models.TeacherAbsenceSession
+	var opened []uint
+	requested, proposed := 0, 0
+	for _, group := range s.groupRequests(db, rows) {
+		action, substitutionID, note := ActionCancellationProposed, (*uint)(nil), "Cancelamento proposto"
+		if input.Mode == ModeAuto {
+			substitution, err := s.substitutions.Request(ctx, group.input(input))
+			switch {
+			case err == nil:
+				action, substitutionID, note = ActionSubstitutionRequested, &substitution.ID, "Substituição solicitada"
+				opened = append(opened, substitution.ID)
+				requested += len(group.sessions)
+			case errors.Is(err, substitutions.ErrNoCandidate):
+				note = "Nenhum substituto disponível; cancelamento proposto"
+			case errors.Is(err, substitutions.ErrAlreadyRequested):
+				note = "Já existe substituição em aberto para estas aulas; verificar antes de cancelar"
+			default:
+				log.Printf("⚠️  Erro ao solicitar substituição para a turma %d: %v", group.courseClassID, err)
+				note = "Falha ao solicitar substituição; cancelamento proposto"
+			}
+		}
+		if action == ActionCancellationProposed {
+			proposed += len(group.sessions)
+		}
+
+		for _, row := range group.sessions {
+			affected = append(affected, models.TeacherAbsenceSession{
+				ClassSessionID: row.SessionID,
+				CourseClassID:  row.CourseClassID,
+				Date:           row.Date,
+				Action:         action,
+				SubstitutionID: substitutionID,
+				Note:           note,
+			})
+		}
+	}
+
+	absence := models.TeacherAbsence{
+		TeacherID:   teacher.ID,
+		StartDate:   input.StartDate,
+		EndDate:     input.EndDate,
+		Reason:      input.Reason,
+		Status:      StatusActive,
+		CreatedByID: input.CreatedByID,
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&absence).Error; err != nil {
+			return err
+		}
+		for i := range affected {
+			affected[i].AbsenceID = absence.ID
+		}
+		if len(affected) == 0 {
+			return nil
+		}
+		return tx.Create(&affected).Error
+	})
+	if err != nil {
+		if _, cancelErr := s.substitutions.CancelPending(ctx, opened); cancelErr != nil {
+			log.Printf("⚠️  Erro ao cancelar substituições %v da ausência não registrada: %v", opened, cancelErr)
+		}
+		return nil, err
+	}
+
+	s.notifyReported(ctx, &teacher, &absence, len(rows), requested, proposed)
+	return s.Get(ctx, absence.ID)
+}
+
+// requestGroup is a set of sessions covered by one substitution request
+type requestGroup struct {
+	courseClassID uint
+	sessions      []affectedRow
+	period        bool
+}
+
+func (g requestGroup) input(report ReportInput) substitutions.RequestInput {
+	input := substitutions.RequestInput{
+		CourseClassID: g.courseClassID,
+		Notes:         "Ausência do professor: " + report.Reason,
+		RequestedByID: report.CreatedByID,
+	}
+	if g.period {
+		start, end := g.sessions[0].Date, g.sessions[len(g.sessions)-1].Date
+		input.StartDate, input.EndDate = &start, &end
+	} else {
+		sessionID := g.sessions[0].SessionID
+		input.SessionID = &sessionID
+	}
+	return input
+}
+
+// groupRequests asks one period substitution per class when the teacher gives every session in the range;
+// otherwise (overrides in the middle) each session gets its own request
+func (s *service) groupRequests(db *gorm.DB, rows []affectedRow) []requestGroup {
+	byClass := make(map[uint][]affectedRow)
+	var order []uint
+	for _, row := range rows {
+		if _, ok := byClass[row.CourseClassID]; !ok {
+			order = append(order, row.CourseClassID)
+		}
+		byClass[row.CourseClassID] = append(byClass[row.CourseClassID], row)
+	}
+
+	var groups []requestGroup
+	for _, classID := range order {
+		sessions := byClass[classID]
+		if len(sessions) > 1 && allUseDefault(sessions) {
+			var total int64
+			err := db.Model(&models.ClassSession{}).
+				Where("course_class_id = ? AND is_cancelled = ? AND DATE(date) BETWEEN ? AND ?",
+					classID, false, dateOnly(sessions[0].Date), dateOnly(sessions[len(sessions)-1].Date)).
+				Count(&total).Error
+			if err == nil && int(total) == len(sessions) {
+				groups = append(groups, requestGroup{courseClassID: classID, sessions: sessions, period: true})
+				continue
+			}
+		}
+		for _, session := range sessions {
+			groups = append(groups, requestGroup{courseClassID: classID, sessions: []affectedRow{session}})
+		}
+	}
+	return groups
+}
+
+// List returns absences, most recent first
+func (s *service) List(ctx context.Context, filter Filter) ([]models.TeacherAbsence, error) {
+	query := s.db.WithContext(ctx).Preload("Teacher.User")
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.TeacherID != nil {
+		query = query.Where("teacher_id = ?", *filter.TeacherID)
+	}
+
+	var absences []models.TeacherAbsence
+	err := query.Order("start_date DESC, id DESC").Find(&absences).Error
+	return absences, err
+}
+
+// Get returns the absence with its affected sessions and the current state of each substitution
+func (s *service) Get(ctx context.Context, id uint) (*AbsenceDetail, error) {
+	db := s.db.WithContext(ctx)
+
+	var absence models.TeacherAbsence
+	if err := db.Preload("Teacher.User").First(&absence, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	detail := &AbsenceDetail{TeacherAbsence: absence, TeacherName: absence.Teacher.User.Name, Sessions: []AffectedSession{}}
+	if err := db.Table("teacher_absence_sessions tas").
+		Select(`tas.*, c.name AS course_name, cc.name AS class_name,
+			COALESCE(NULLIF(cs.start_time, ''), cc.start_time) AS start_time,
+			COALESCE(NULLIF(cs.end_time, ''), cc.end_time) AS end_time,
+			cs.is_cancelled,
+			latest.status AS substitution_status, latest.substitute_name`).
+		Joins("JOIN class_sessions cs ON cs.id = tas.class_session_id").
+		Joins("JOIN course_classes cc ON cc.id = tas.course_class_id").
+		Joins("JOIN courses c ON c.id = cc.course_id").
+		Joins(`LEFT JOIN LATERAL (
+			SELECT sub.status, u.name AS substitute_name
+			FROM substitutions sub
+			JOIN teachers t ON t.id = sub.substitute_teacher_id
+			JOIN users u ON u.id = t.user_id
+			WHERE sub.id = tas.substitution_id OR sub.origin_id = tas.substitution_id
+			ORDER BY sub.attempt DESC, sub.id DESC
+			LIMIT 1
+		) latest ON TRUE`).
+		Where("tas.absence_id = ?", id).
+		Order("tas.date, tas.id").
+		Scan(&detail.Sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+// Resolve closes the absence (teacher is back) and rolls back what is still open
+func (s *service) Resolve(ctx context.Context, id uint, userID uint) (*AbsenceDetail, error) {
+	return s.close(ctx, id, userID, StatusResolved)
+}
+
+// Cancel withdraws the absence and rolls back what is still open
+func (s *service) Cancel(ctx context.Context, id uint, userID uint) (*AbsenceDetail, error) {
+	return s.close(ctx, id, userID, StatusCancelled)
+}
+
+// close ends the absence, cancels the pending substitutions and withdraws the cancellation proposals.
+// Confirmed substitutions and sessions already cancelled are kept.
+func (s *service) close(ctx context.Context, id uint, userID uint, status string) (*AbsenceDetail, error) {
+	db := s.db.WithContext(ctx)
+
+	var absence models.TeacherAbsence
+	if err := db.First(&absence, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if absence.Status != StatusActive {
+		return nil, ErrNotActive
+	}
+
+	var originIDs []uint
+	if err := db.Model(&models.TeacherAbsenceSession{}).
+		Where("absence_id = ? AND action = ? AND substitution_id IS NOT NULL", id, ActionSubstitutionRequested).
+		Distinct().Pluck("substitution_id", &originIDs).Error; err != nil {
+		return nil, err
+	}
+	if _, err := s.substitutions.CancelPending(ctx, originIDs); err != nil {
+		return nil, err
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Propostas de cancelamento e cadeias que ficaram sem substituto confirmado
+		if err := tx.Model(&models.TeacherAbsenceSession{}).
+			Where("absence_id = ? AND action IN ?", id, []string{ActionSubstitutionRequested, ActionCancellationProposed}).
+			Where(`(substitution_id IS NULL OR NOT EXISTS (
+				SELECT 1 FROM substitutions sub
+				WHERE (sub.id = teacher_absence_sessions.substitution_id OR sub.origin_id = teacher_absence_sessions.substitution_id)
+				AND sub.status = ?))`, substitutions.StatusConfirmed).
+			Updates(map[string]interface{}{"action": ActionRolledBack, "note": "Revertido: ausência " + statusLabel(status)}).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		return tx.Model(&absence).Updates(map[string]interface{}{
+			"status":         status,
+			"resolved_at":    now,
+			"resolved_by_id": userID,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id)
+}
+
+// CancelSession accepts the cancellation of an affected session, closing any open substitution for it
+func (s *service) CancelSession(ctx context.Context, id uint, sessionID uint) (*AbsenceDetail, error) {
+	db := s.db.WithContext(ctx)
+
+	var absence models.TeacherAbsence
+	if err := db.First(&absence, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if absence.Status != StatusActive {
+		return nil, ErrNotActive
+	}
+
+	var affected models.TeacherAbsenceSession
+	if err := db.Where("absence_id = ? AND class_session_id = ? AND action IN ?", id, sessionID,
+		[]string{ActionSubstitutionRequested, ActionCancellationProposed}).First(&affected).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSessionNotAffected
+		}
+		return nil, err
+	}
+
+	reason := "Ausência do professor"
+	if absence.Reason != "" {
+		reason += ": " + absence.Reason
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ClassSession{}).Where("id = ?", sessionID).Updates(map[string]interface{}{
+			"is_cancelled":        true,
+			"cancellation_reason": reason,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&affected).Updates(map[string]interface{}{
+			"action": ActionSessionCancelled,
+			"note":   "Aula cancelada pela coordenação",
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Uma substituição só desta aula não faz mais sentido
+	if affected.SubstitutionID != nil {
+		var shared int64
+		db.Model(&models.TeacherAbsenceSession{}).
+			Where("substitution_id = ? AND id <> ? AND action = ?", *affected.SubstitutionID, affected.ID, ActionSubstitutionRequested).
+			Count(&shared)
+		if shared == 0 {
+			if _, err := s.substitutions.CancelPending(ctx, []uint{*affected.SubstitutionID}); err != nil {
+				log.Printf("⚠️  Erro ao cancelar substituição %d: %v", *affected.SubstitutionID, err)
+			}
+		}
+	}
+
+	s.notifyCancelled(ctx, sessionID, reason)
+	return s.Get(ctx, id)
+}
+
+// TeacherIDForUser returns the teacher record linked to a user
+func (s *service) TeacherIDForUser(ctx context.Context, userID uint) (uint, error) {
+	var teacher models.Teacher
+	if err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&teacher).Error; err != nil {
+		return 0, ErrNotTeacher
+	}
+	return teacher.ID, nil
+}
+
+// === Notificações ===
+
+func (s *service) notifyReported(ctx context.Context, teacher *models.Teacher, absence *models.TeacherAbsence, affected, requested, proposed int) {
+	if s.notifier == nil {
+		return
+	}
+	var coordinators []uint
+	if err := s.db.WithContext(ctx).Model(&models.User{}).
+		Where("profile_id = ? AND active = ? AND deleted_at IS NULL", coordinatorProfileID, true).
+		Pluck("id", &coordinators).Error; err != nil || len(coordinators) == 0 {
+		return
+	}
+
+	period := absence.StartDate.Format("02/01/2006")
+	if dateOnly(absence.EndDate) != dateOnly(absence.StartDate) {
+		period += " a " + absence.EndDate.Format("02/01/2006")
+	}
+	s.notifier.OnTeacherAbsenceReported(coordinators, teacher.User.Name, period, affected, requested, proposed)
+}
+
+func (s *service) notifyCancelled(ctx context.Context, sessionID uint, reason string) {
+	if s.notifier == nil {
+		return
+	}
+	db := s.db.WithContext(ctx)
+
+	var session struct {
+		Date          time.Time
+		CourseClassID uint
+		CourseName    string
+	}
+	if err := db.Table("class_sessions cs").
+		Select("cs.date, cs.course_class_id, c.name AS course_name").
+		Joins("JOIN course_classes cc ON cc.id = cs.course_class_id").
+		Joins("JOIN courses c ON c.id = cc.course_id").
+		Where("cs.id = ?", sessionID).
+		Scan(&session).Error; err != nil {
+		return
+	}
+
+	var studentUserIDs []uint
+	if err := db.Table("enrollment_course_classes ecc").
+		Joins("JOIN enrollments e ON e.id = ecc.enrollment_id AND e.deleted_at IS NULL").
+		Joins("JOIN students s ON s.id = e.student_id").
+		Where("ecc.course_class_id = ? AND e.status IN ?", session.CourseClassID, []string{"active", "in_progress"}).
+		Distinct().Pluck("s.user_id", &studentUserIDs).Error; err != nil || len(studentUserIDs) == 0 {
+		return
+	}
+
+	s.notifier.OnClassCancelled(studentUserIDs, session.CourseName, session.Date.Format("02/01/2006"), reason)
+}
+
+func allUseDefault(rows []affectedRow) bool {
+	for _, row := range rows {
+		if !row.UsesDefault {
+			return false
+		}
+	}
+	return true
+}
+
+func dateOnly(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+func statusLabel(status string) string {
+	if status == StatusResolved {
+		return "encerrada"
+	}
+	return "cancelada"
+}
@@ -0,0 +1,37 @@
+package absences
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRequestGroupInput(t *testing.T) {
+	report := ReportInput{Reason: "Cirurgia", CreatedByID: 7}
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	sessions := []affectedRow{
+		{SessionID: 10, CourseClassID: 3, Date: monday, UsesDefault: true},
+		{SessionID: 11, CourseClassID: 3, Date: monday.AddDate(0, 0, 2), UsesDefault: true},
+	}
+
+	t.Run("period group covers first to last session", func(t *testing.T) {
+		input := requestGroup{courseClassID: 3, sessions: sessions, period: true}.input(report)
+
+		if input.SessionID != nil || input.StartDate == nil || input.EndDate == nil {
+			t.Fatalf("Expected a period request, got %+v", input)
+		}
+		if !input.StartDate.Equal(monday) || !input.EndDate.Equal(monday.AddDate(0, 0, 2)) {
+			t.Errorf("Expected period %v to %v, got %v to %v", monday, monday.AddDate(0, 0, 2), *input.StartDate, *input.EndDate)
+		}
+		if input.RequestedByID != 7 || input.Notes != "Ausência do professor: Cirurgia" {
+			t.Errorf("Expected requester and notes from the report, got %d %q", input.RequestedByID, input.Notes)
+		}
+	})
+
+	t.Run("single group targets the session", func(t *testing.T) {
+		input := requestGroup{courseClassID: 3, sessions: sessions[1:]}.input(report)
+
+		if input.SessionID == nil || *input.SessionID != 11 || input.StartDate != nil {
+			t.Errorf("Expected a request for session 11, got %+v", input)
+		}
+	})
+}
@@ -0,0 +1,164 @@
+// backend/internal/service/addresses/service.go
+package addresses
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/devdavidalonso/cecor/backend/internal/infrastructure/cep"
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+var (
+	// ErrInvalidCEP is returned for values that are not a CEP
+	ErrInvalidCEP = errors.New("invalid CEP")
+	// ErrCEPNotFound is returned when the CEP does not exist
+	ErrCEPNotFound = errors.New("CEP not found")
+	// ErrUnavailable is returned when the provider is down and the CEP is not cached
+	ErrUnavailable = errors.New("address lookup unavailable")
+	// ErrInvalidAddress is returned when the address does not match its CEP
+	ErrInvalidAddress = errors.New("invalid address")
+)
+
+// Service defines the interface for the CEP lookup and the address validation
+type Service interface {
+	Lookup(ctx context.Context, value string) (*models.PostalCode, error)
+	ValidateAddress(ctx context.Context, address *models.Address) error
+}
+
+// service implements the Service interface
+type service struct {
+	db       *gorm.DB
+	provider cep.Provider
+	cacheTTL time.Duration
+	now      func() time.Time
+}
+
+// NewService creates a new address service; lookups are cached in postal_codes for cacheTTL
+func NewService(db *gorm.DB, provider cep.Provider, cacheTTL time.Duration) Service {
+	return &service{db: db, provider: provider, cacheTTL: cacheTTL, now: time.Now}
+}
+
+// Lookup returns the address of a CEP typed with or without the hyphen. Fresh cache entries are
+// answered locally; when the provider is down an expired entry is still used.
+func (s *service) Lookup(ctx context.Context, value string) (*models.PostalCode, error) {
+	digits, err := cep.Normalize(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCEP, value)
+	}
+
+	var cached models.PostalCode
+	found := true
+	if err := s.db.WithContext(ctx).First(&cached, "cep = ?", digits).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		found = false
+	}
+	if found && s.now().Sub(cached.FetchedAt) < s.cacheTTL {
+		return &cached, nil
+	}
+
+	address, err := s.provider.Lookup(ctx, digits)
+	switch {
+	case err == nil:
+	case errors.Is(err, cep.ErrUnavailable):
+		if found {
+			return &cached, nil
+		}
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	case errors.Is(err, cep.ErrNotFound):
+		return nil, fmt.Errorf("%w: %s", ErrCEPNotFound, cep.Format(digits))
+	case errors.Is(err, cep.ErrInvalid):
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCEP, value)
+	default:
+		return nil, err
+	}
+
+	postalCode := models.PostalCode{
+		CEP:          digits,
+		Street:       address.Street,
+		Complement:   address.Complement,
+		Neighborhood: address.Neighborhood,
+		City:         address.City,
+		State:        address.State,
+		IBGECode:     address.IBGECode,
+		FetchedAt:    s.now(),
+	}
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cep"}},
+		DoUpdates: clause.AssignmentColumns([]string{"street", "complement", "neighborhood", "city", "state", "ibge_code", "fetched_at", "updated_at"}),
+	}).Create(&postalCode).Error; err != nil {
+		// O endereço foi encontrado; só o cache falhou
+		fmt.Printf("Warning: failed to cache CEP %s: %v\n", digits, err)
+	}
+	return &postalCode, nil
+}
+
+// ValidateAddress checks the address against its CEP and writes the official city, state,
+// neighborhood and street. Addresses without CEP are left as typed, and so are those saved
+// while the provider is down (nothing cached), so a lookup outage does not block registrations.
+func (s *service) ValidateAddress(ctx context.Context, address *models.Address) error {
+	if address == nil || strings.TrimSpace(address.CEP) == "" {
+		return nil
+	}
+
+	postalCode, err := s.Lookup(ctx, address.CEP)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUnavailable):
+			fmt.Printf("Warning: address saved without CEP validation: %v\n", err)
+			address.CEP = cep.Format(address.CEP)
+			return nil
+		case errors.Is(err, ErrInvalidCEP), errors.Is(err, ErrCEPNotFound):
+			return fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+		default:
+			return err
+		}
+	}
+	return reconcile(address, postalCode)
+}
+
+// reconcile rejects a typed city or state that differs from the CEP and fills in the official
+// names. Street and neighborhood are replaced only when the CEP has them (cities with a single
+// CEP do not), which fixes the misspelled neighborhoods.
+func reconcile(address *models.Address, postalCode *models.PostalCode) error {
+	if state := strings.TrimSpace(address.State); state != "" && !strings.EqualFold(state, postalCode.State) {
+		return fmt.Errorf("%w: CEP %s is in %s/%s, not in %s", ErrInvalidAddress, cep.Format(postalCode.CEP), postalCode.City, postalCode.State, state)
+	}
+	if city := strings.TrimSpace(address.City); city != "" && fold(city) != fold(postalCode.City) {
+		return fmt.Errorf("%w: CEP %s is in %s/%s, not in %s", ErrInvalidAddress, cep.Format(postalCode.CEP), postalCode.City, postalCode.State, city)
+	}
+
+	address.CEP = cep.Format(postalCode.CEP)
+	address.City = postalCode.City
+	address.State = postalCode.State
+	if postalCode.Neighborhood != "" {
+		address.Neighborhood = postalCode.Neighborhood
+	}
+	if postalCode.Street != "" {
+		address.Street = postalCode.Street
+	}
+	return nil
+}
+
+// fold compares names ignoring case, accents, punctuation and repeated spaces
+func fold(value string) string {
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), value)
+	if err != nil {
+		stripped = value
+	}
+	return strings.Join(strings.FieldsFunc(strings.ToLower(stripped), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
@@ -0,0 +1,39 @@
+package addresses
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+func TestReconcile(t *testing.T) {
+	se := &models.PostalCode{CEP: "01001000", Street: "Praça da Sé", Neighborhood: "Sé", City: "São Paulo", State: "SP"}
+
+	address := &models.Address{CEP: "01001000", Street: "Praca da Se", Number: "100", Neighborhood: "Centro (Sé)", City: "sao paulo", State: "sp"}
+	if err := reconcile(address, se); err != nil {
+		t.Fatalf("Expected the address to match its CEP, got %v", err)
+	}
+	if address.CEP != "01001-000" || address.Street != "Praça da Sé" || address.Neighborhood != "Sé" || address.City != "São Paulo" || address.State != "SP" || address.Number != "100" {
+		t.Errorf("Expected the official names, got %+v", address)
+	}
+
+	blank := &models.Address{CEP: "01001-000"}
+	if err := reconcile(blank, se); err != nil || blank.City != "São Paulo" || blank.Neighborhood != "Sé" {
+		t.Errorf("Expected the blank fields to be filled, got %+v (%v)", blank, err)
+	}
+
+	if err := reconcile(&models.Address{CEP: "01001000", City: "Campinas", State: "SP"}, se); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("Expected ErrInvalidAddress for another city, got %v", err)
+	}
+	if err := reconcile(&models.Address{CEP: "01001000", State: "RJ"}, se); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("Expected ErrInvalidAddress for another state, got %v", err)
+	}
+
+	// Cidade com CEP único: rua e bairro digitados são mantidos
+	single := &models.PostalCode{CEP: "13480000", City: "Limeira", State: "SP"}
+	typed := &models.Address{CEP: "13480-000", Street: "Rua Boa Morte", Neighborhood: "Centro"}
+	if err := reconcile(typed, single); err != nil || typed.Street != "Rua Boa Morte" || typed.Neighborhood != "Centro" || typed.City != "Limeira" {
+		t.Errorf("Expected the typed street and neighborhood to be kept, got %+v (%v)", typed, err)
+	}
+}
@@ -0,0 +1,403 @@
+// backend/internal/service/assignments/service.go
+package assignments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+// Role values of models.TeacherCourse
+const (
+	RolePrimary    = "primary"
+	RoleAssistant  = "assistant"
+	RoleSubstitute = "substitute"
+)
+
+var validRoles = map[string]bool{RolePrimary: true, RoleAssistant: true, RoleSubstitute: true}
+
+var (
+	// ErrNotFound is returned when the assignment does not exist
+	ErrNotFound = errors.New("teacher assignment not found")
+	// ErrClassNotFound is returned when the course class does not exist
+	ErrClassNotFound = errors.New("course class not found")
+	// ErrTeacherNotFound is returned when the teacher does not exist or is inactive
+	ErrTeacherNotFound = errors.New("teacher not found")
+	// ErrInvalidAssignment is returned for malformed assignments
+	ErrInvalidAssignment = errors.New("invalid teacher assignment")
+	// ErrOverlap is returned when the period clashes with another assignment
+	ErrOverlap = errors.New("teacher assignment overlaps an existing assignment")
+	// ErrAlreadyEnded is returned when ending an assignment that is no longer open
+	ErrAlreadyEnded = errors.New("teacher assignment already ended")
+)
+
+// AssignInput assigns a teacher to a course class for a period
+type AssignInput struct {
+	CourseClassID uint
+	TeacherID     uint
+	Role          string
+	StartDate     time.Time
+	EndDate       *time.Time // nil: sem data de término
+	Notes         string
+	AssignedByID  uint
+}
+
+// Filter narrows the assignment listings
+type Filter struct {
+	CourseClassID *uint
+	TeacherID     *uint
+	// History includes ended and revoked assignments
+	History bool
+}
+
+// Assignment is a teacher assignment (models.TeacherCourse) with display data
+type Assignment struct {
+	ID            uint       `json:"id"`
+	TeacherID     uint       `json:"teacherId"`
+	TeacherName   string     `json:"teacherName"`
+	CourseID      uint       `json:"courseId"`
+	CourseName    string     `json:"courseName"`
+	CourseClassID *uint      `json:"courseClassId"`
+	ClassName     string     `json:"className"`
+	Role          string     `json:"role"`
+	StartDate     time.Time  `json:"startDate"`
+	EndDate       *time.Time `json:"endDate"`
+	Active        bool       `json:"active"`
+	IsCurrent     bool       `json:"current"` // vigente hoje
+	Notes         string     `json:"notes"`
+	AssignedByID  *uint      `json:"assignedById"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+// Service defines the interface for teacher class assignments
+type Service interface {
+	Assign(ctx context.Context, input AssignInput) (*Assignment, error)
+	// AssignTx is Assign within the caller's transaction, for writes that must be saved together
+	AssignTx(tx *gorm.DB, input AssignInput) error
+	// ClosePrimaryTx leaves the class without a primary teacher from the given day on, within tx
+	ClosePrimaryTx(tx *gorm.DB, classID uint, from time.Time) error
+	End(ctx context.Context, id uint, endDate *time.Time) (*Assignment, error)
+	Revoke(ctx context.Context, id uint) (*Assignment, error)
+	Get(ctx context.Context, id uint) (*Assignment, error)
+	List(ctx context.Context, filter Filter) ([]Assignment, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewService creates a new teacher assignment service
+func NewService(db *gorm.DB) Service {
+	return &service{db: db, now: time.Now}
+}
+
+// Assign creates an assignment. A new primary teacher closes the open primary assignment of the
+// class on the day before its start, and replaces the ones that had not started yet; other
+// overlaps of the same teacher in the class are rejected.
+func (s *service) Assign(ctx context.Context, input AssignInput) (*Assignment, error) {
+	assignmentID, err := s.assign(ctx, nil, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, assignmentID)
+}
+
+// AssignTx creates the assignment in tx (see Assign)
+func (s *service) AssignTx(tx *gorm.DB, input AssignInput) error {
+	_, err := s.assign(tx.Statement.Context, tx, input)
+	return err
+}
+
+// assign validates and creates the assignment in its own transaction, or in a savepoint of tx
+func (s *service) assign(ctx context.Context, tx *gorm.DB, input AssignInput) (uint, error) {
+	if input.Role == "" {
+		input.Role = RolePrimary
+	}
+	if !validRoles[input.Role] {
+		return 0, fmt.Errorf("%w: role must be primary, assistant or substitute", ErrInvalidAssignment)
+	}
+	if input.StartDate.IsZero() {
+		return 0, fmt.Errorf("%w: startDate is required", ErrInvalidAssignment)
+	}
+	startDate := truncateDay(input.StartDate)
+	var endDate *time.Time
+	if input.EndDate != nil {
+		end := truncateDay(*input.EndDate)
+		if end.Before(startDate) {
+			return 0, fmt.Errorf("%w: endDate must not be before startDate", ErrInvalidAssignment)
+		}
+		endDate = &end
+	}
+
+	db := tx
+	if db == nil {
+		db = s.db.WithContext(ctx)
+	}
+
+	var assignmentID uint
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var class models.CourseClass
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&class, input.CourseClassID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrClassNotFound
+			}
+			return err
+		}
+
+		var teacher models.Teacher
+		if err := tx.Where("id = ? AND active = ?", input.TeacherID, true).First(&teacher).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTeacherNotFound
+			}
+			return err
+		}
+
+		// O mesmo professor não pode ter dois papéis na turma ao mesmo tempo
+		var clashes int64
+		if err := overlapping(tx, class.ID, startDate, endDate).
+			Where("teacher_id = ?", teacher.ID).
+			Count(&clashes).Error; err != nil {
+			return err
+		}
+		if clashes > 0 {
+			return fmt.Errorf("%w: teacher is already assigned to this class in the period", ErrOverlap)
+		}
+
+		if input.Role == RolePrimary {
+			if err := closePrimary(tx, class.ID, startDate, endDate); err != nil {
+				return err
+			}
+		}
+
+		var assignedBy *uint
+		if input.AssignedByID != 0 {
+			assignedBy = &input.AssignedByID
+		}
+		assignment := models.TeacherCourse{
+			TeacherID:     teacher.ID,
+			CourseID:      class.CourseID,
+			CourseClassID: &class.ID,
+			Role:          input.Role,
+			StartDate:     startDate,
+			EndDate:       endDate,
+			Active:        true,
+			Notes:         input.Notes,
+			AssignedByID:  assignedBy,
+		}
+		if err := tx.Omit(clause.Associations).Create(&assignment).Error; err != nil {
+			return err
+		}
+		assignmentID = assignment.ID
+
+		return s.syncDefaultTeacher(tx, class.ID)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return assignmentID, nil
+}
+
+// ClosePrimaryTx ends, on the day before from, the primary assignment in charge of the class and
+// revokes the ones scheduled to start on or after it
+func (s *service) ClosePrimaryTx(tx *gorm.DB, classID uint, from time.Time) error {
+	from = truncateDay(from)
+	return tx.Transaction(func(tx *gorm.DB) error {
+		if err := closePrimary(tx, classID, from, nil); err != nil {
+			return err
+		}
+		return s.syncDefaultTeacher(tx, classID)
+	})
+}
+
+// End closes an open assignment on endDate (default: today), keeping it as history
+func (s *service) End(ctx context.Context, id uint, endDate *time.Time) (*Assignment, error) {
+	end := truncateDay(s.now())
+	if endDate != nil {
+		end = truncateDay(*endDate)
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		assignment, err := lockAssignment(tx, id)
+		if err != nil {
+			return err
+		}
+		if !assignment.Active || (assignment.EndDate != nil && !assignment.EndDate.After(end)) {
+			return ErrAlreadyEnded
+		}
+		if end.Before(truncateDay(assignment.StartDate)) {
+			return fmt.Errorf("%w: endDate must not be before startDate", ErrInvalidAssignment)
+		}
+
+		if err := tx.Model(&models.TeacherCourse{}).Where("id = ?", id).Update("end_date", end).Error; err != nil {
+			return err
+		}
+		if assignment.CourseClassID == nil {
+			return nil
+		}
+		return s.syncDefaultTeacher(tx, *assignment.CourseClassID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id)
+}
+
+// Revoke deactivates an assignment made by mistake; it stays listed in the history
+func (s *service) Revoke(ctx context.Context, id uint) (*Assignment, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		assignment, err := lockAssignment(tx, id)
+		if err != nil {
+			return err
+		}
+		if !assignment.Active {
+			return ErrAlreadyEnded
+		}
+
+		if err := tx.Model(&models.TeacherCourse{}).Where("id = ?", id).Update("active", false).Error; err != nil {
+			return err
+		}
+		if assignment.CourseClassID == nil {
+			return nil
+		}
+		return s.syncDefaultTeacher(tx, *assignment.CourseClassID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id)
+}
+
+// Get returns an assignment
+func (s *service) Get(ctx context.Context, id uint) (*Assignment, error) {
+	var list []Assignment
+	if err := s.query(ctx).Where("tc.id = ?", id).Scan(&list).Error; err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, ErrNotFound
+	}
+	return &list[0], nil
+}
+
+// List returns the assignments of a class or teacher, current ones first
+func (s *service) List(ctx context.Context, filter Filter) ([]Assignment, error) {
+	query := s.query(ctx)
+	if filter.CourseClassID != nil {
+		query = query.Where("tc.course_class_id = ?", *filter.CourseClassID)
+	}
+	if filter.TeacherID != nil {
+		query = query.Where("tc.teacher_id = ?", *filter.TeacherID)
+	}
+	if !filter.History {
+		query = query.Where("tc.active = ? AND (tc.end_date IS NULL OR tc.end_date >= ?)", true, truncateDay(s.now()))
+	}
+
+	list := []Assignment{}
+	if err := query.Order("is_current DESC, tc.start_date DESC, tc.id DESC").Scan(&list).Error; err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *service) query(ctx context.Context) *gorm.DB {
+	today := truncateDay(s.now())
+	return s.db.WithContext(ctx).Table("teacher_courses tc").
+		Select(`tc.*, u.name AS teacher_name, c.name AS course_name, COALESCE(cc.name, '') AS class_name,
+			(tc.active AND tc.start_date <= ? AND (tc.end_date IS NULL OR tc.end_date >= ?)) AS is_current`, today, today).
+		Joins("JOIN teachers t ON t.id = tc.teacher_id").
+		Joins("JOIN users u ON u.id = t.user_id").
+		Joins("JOIN courses c ON c.id = tc.course_id").
+		Joins("LEFT JOIN course_classes cc ON cc.id = tc.course_class_id")
+}
+
+// syncDefaultTeacher points CourseClass.DefaultTeacherID at the primary teacher in charge today,
+// or at the next scheduled one when nobody is in charge yet
+func (s *service) syncDefaultTeacher(tx *gorm.DB, classID uint) error {
+	today := truncateDay(s.now())
+
+	var primary models.TeacherCourse
+	err := tx.Where("course_class_id = ? AND role = ? AND active = ?", classID, RolePrimary, true).
+		Where("(end_date IS NULL OR end_date >= ?)", today).
+		Order("start_date ASC").
+		First(&primary).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if err == nil {
+		return tx.Model(&models.CourseClass{}).Where("id = ?", classID).Update("default_teacher_id", primary.TeacherID).Error
+	}
+
+	// Sem titular vigente: limpa apenas o professor padrão que veio de uma atribuição encerrada
+	return tx.Model(&models.CourseClass{}).
+		Where("id = ? AND default_teacher_id IN (?)", classID,
+			tx.Model(&models.TeacherCourse{}).Select("teacher_id").Where("course_class_id = ? AND role = ?", classID, RolePrimary)).
+		Update("default_teacher_id", nil).Error
+}
+
+// overlapping selects the active assignments of a class that intersect the period
+func overlapping(tx *gorm.DB, classID uint, start time.Time, end *time.Time) *gorm.DB {
+	query := tx.Model(&models.TeacherCourse{}).
+		Where("course_class_id = ? AND active = ?", classID, true).
+		Where("(end_date IS NULL OR end_date >= ?)", start)
+	if end != nil {
+		query = query.Where("start_date <= ?", *end)
+	}
+	return query
+}
+
+// closePrimary makes way for a new primary assignment running from start to end (nil: open)
+func closePrimary(tx *gorm.DB, classID uint, start time.Time, end *time.Time) error {
+	var primaries []models.TeacherCourse
+	if err := overlapping(tx, classID, start, end).Where("role = ?", RolePrimary).Find(&primaries).Error; err != nil {
+		return err
+	}
+	for _, primary := range primaries {
+		if err := tx.Model(&models.TeacherCourse{}).Where("id = ?", primary.ID).
+			Updates(supersede(primary, start, end)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// supersede returns the changes to a primary assignment overlapping a new one from start to end:
+// the one already running ends the day before start; one that has not started by then is revoked,
+// or postponed to the day after end when it runs past the new one
+func supersede(primary models.TeacherCourse, start time.Time, end *time.Time) map[string]interface{} {
+	switch {
+	case truncateDay(primary.StartDate).Before(start):
+		return map[string]interface{}{"end_date": start.AddDate(0, 0, -1)}
+	case end != nil && (primary.EndDate == nil || truncateDay(*primary.EndDate).After(*end)):
+		return map[string]interface{}{"start_date": end.AddDate(0, 0, 1)}
+	default:
+		return map[string]interface{}{"active": false}
+	}
+}
+
+func lockAssignment(tx *gorm.DB, id uint) (*models.TeacherCourse, error) {
+	var assignment models.TeacherCourse
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&assignment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
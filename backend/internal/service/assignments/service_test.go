@@ -0,0 +1,162 @@
+package assignments
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devdavidalonso/cecor/backend/internal/database/testdb"
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+func TestAssignValidation(t *testing.T) {
+	s := &service{now: time.Now}
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	before := start.AddDate(0, 0, -1)
+
+	cases := map[string]AssignInput{
+		"unknown role":         {CourseClassID: 1, TeacherID: 1, Role: "coordinator", StartDate: start},
+		"missing start date":   {CourseClassID: 1, TeacherID: 1, Role: RoleAssistant},
+		"end before the start": {CourseClassID: 1, TeacherID: 1, Role: RoleAssistant, StartDate: start, EndDate: &before},
+	}
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := s.Assign(context.Background(), input); !errors.Is(err, ErrInvalidAssignment) {
+				t.Errorf("Expected ErrInvalidAssignment, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSupersede(t *testing.T) {
+	start := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 13)
+	later := end.AddDate(0, 1, 0)
+
+	cases := []struct {
+		name    string
+		primary models.TeacherCourse
+		end     *time.Time
+		want    map[string]interface{}
+	}{
+		{"already running", models.TeacherCourse{StartDate: start.AddDate(0, -1, 0)}, nil,
+			map[string]interface{}{"end_date": start.AddDate(0, 0, -1)}},
+		{"starting on the same day", models.TeacherCourse{StartDate: start}, nil,
+			map[string]interface{}{"active": false}},
+		{"starting later", models.TeacherCourse{StartDate: start.AddDate(0, 0, 7)}, nil,
+			map[string]interface{}{"active": false}},
+		{"covered by a bounded assignment", models.TeacherCourse{StartDate: start, EndDate: &end}, &end,
+			map[string]interface{}{"active": false}},
+		{"running past a bounded assignment", models.TeacherCourse{StartDate: start, EndDate: &later}, &end,
+			map[string]interface{}{"start_date": end.AddDate(0, 0, 1)}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := supersede(c.primary, start, c.end)
+			if len(got) != 1 {
+				t.Fatalf("Expected one change, got %v", got)
+			}
+			for key, value := range c.want {
+				if got[key] != value {
+					t.Errorf("Expected %s = %v, got %v", key, value, got)
+				}
+			}
+		})
+	}
+}
+
+// TestReassignPrimary reproduces the default teacher change of the class form, which assigns the
+// new primary from today or from the class start, whichever is later
+func TestReassignPrimary(t *testing.T) {
+	today := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name       string
+		classStart time.Time
+		revoked    bool
+	}{
+		{"before the class starts", today.AddDate(0, 0, 7), true},
+		{"on the first day", today, true},
+		{"after the class started", today.AddDate(0, -1, 0), false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			db := testdb.Open(t, &models.CourseClass{}, &models.Teacher{}, &models.TeacherCourse{})
+			s := &service{db: db, now: func() time.Time { return today }}
+
+			first, second := models.Teacher{UserID: 1, Active: true}, models.Teacher{UserID: 2, Active: true}
+			class := models.CourseClass{CourseID: 1, Code: "2026A", StartDate: c.classStart, EndDate: c.classStart.AddDate(0, 6, 0)}
+			for _, row := range []interface{}{&first, &second, &class} {
+				if err := db.Create(row).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := s.AssignTx(db, AssignInput{CourseClassID: class.ID, TeacherID: first.ID, StartDate: c.classStart}); err != nil {
+				t.Fatal(err)
+			}
+
+			from := today
+			if c.classStart.After(from) {
+				from = c.classStart
+			}
+			if err := s.AssignTx(db, AssignInput{CourseClassID: class.ID, TeacherID: second.ID, StartDate: from}); err != nil {
+				t.Fatalf("Expected the reassignment to succeed, got %v", err)
+			}
+
+			var previous models.TeacherCourse
+			if err := db.Where("teacher_id = ?", first.ID).First(&previous).Error; err != nil {
+				t.Fatal(err)
+			}
+			if c.revoked {
+				if previous.Active {
+					t.Error("Expected the assignment that had not started to be revoked")
+				}
+			} else if !previous.Active || previous.EndDate == nil || !previous.EndDate.Equal(today.AddDate(0, 0, -1)) {
+				t.Errorf("Expected the running assignment to end yesterday, got active=%v end=%v", previous.Active, previous.EndDate)
+			}
+
+			if err := db.First(&class, class.ID).Error; err != nil {
+				t.Fatal(err)
+			}
+			if class.DefaultTeacherID == nil || *class.DefaultTeacherID != second.ID {
+				t.Errorf("Expected default teacher %d, got %v", second.ID, class.DefaultTeacherID)
+			}
+		})
+	}
+}
+
+func TestClosePrimary(t *testing.T) {
+	today := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	db := testdb.Open(t, &models.CourseClass{}, &models.Teacher{}, &models.TeacherCourse{})
+	s := &service{db: db, now: func() time.Time { return today }}
+
+	teacher := models.Teacher{UserID: 1, Active: true}
+	class := models.CourseClass{CourseID: 1, Code: "2026A", StartDate: today.AddDate(0, -1, 0), EndDate: today.AddDate(0, 5, 0)}
+	for _, row := range []interface{}{&teacher, &class} {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.AssignTx(db, AssignInput{CourseClassID: class.ID, TeacherID: teacher.ID, StartDate: class.StartDate}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.ClosePrimaryTx(db, class.ID, today); err != nil {
+		t.Fatal(err)
+	}
+
+	var assignment models.TeacherCourse
+	if err := db.Where("teacher_id = ?", teacher.ID).First(&assignment).Error; err != nil {
+		t.Fatal(err)
+	}
+	if assignment.EndDate == nil || !assignment.EndDate.Equal(today.AddDate(0, 0, -1)) {
+		t.Errorf("Expected the assignment to end yesterday, got %v", assignment.EndDate)
+	}
+	if err := db.First(&class, class.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if class.DefaultTeacherID != nil {
+		t.Errorf("Expected no default teacher, got %d", *class.DefaultTeacherID)
+	}
+}
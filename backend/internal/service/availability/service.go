@@ -0,0 +1,265 @@
+// backend/internal/service/availability/service.go
+package availability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+const timeLayout = "15:04"
+
+var (
+	// ErrNotFound is returned when the availability window does not exist or belongs to another teacher
+	ErrNotFound = errors.New("availability window not found")
+	// ErrInvalidWindow is returned for malformed windows
+	ErrInvalidWindow = errors.New("invalid availability window")
+	// ErrOverlap is returned when a window overlaps another active window on the same day
+	ErrOverlap = errors.New("availability window overlaps an existing window")
+	// ErrNotTeacher is returned when the user has no teacher record
+	ErrNotTeacher = errors.New("user is not a teacher")
+)
+
+// WindowInput is a weekly availability window
+type WindowInput struct {
+	DayOfWeek int    `json:"dayOfWeek"` // 0=Domingo, 1=Segunda...
+	StartTime string `json:"startTime"` // "09:00"
+	EndTime   string `json:"endTime"`   // "18:00"
+	IsActive  *bool  `json:"isActive"`  // padrão: true
+}
+
+// Service defines the interface for teacher availability management
+type Service interface {
+	List(ctx context.Context, teacherID uint) ([]models.TeacherAvailability, error)
+	Create(ctx context.Context, teacherID uint, input WindowInput) (*models.TeacherAvailability, error)
+	Update(ctx context.Context, teacherID uint, id uint, input WindowInput) (*models.TeacherAvailability, error)
+	Delete(ctx context.Context, teacherID uint, id uint) error
+	Replace(ctx context.Context, teacherID uint, inputs []WindowInput) ([]models.TeacherAvailability, error)
+	TeacherIDForUser(ctx context.Context, userID uint) (uint, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db *gorm.DB
+}
+
+// NewService creates a new availability service
+func NewService(db *gorm.DB) Service {
+	return &service{db: db}
+}
+
+// List returns the windows of a teacher ordered by day and start time
+func (s *service) List(ctx context.Context, teacherID uint) ([]models.TeacherAvailability, error) {
+	var windows []models.TeacherAvailability
+	if err := s.db.WithContext(ctx).
+		Where("teacher_id = ?", teacherID).
+		Order("day_of_week ASC, start_time ASC").
+		Find(&windows).Error; err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+// Create adds a window, rejecting overlaps with the active windows of the same day
+func (s *service) Create(ctx context.Context, teacherID uint, input WindowInput) (*models.TeacherAvailability, error) {
+	window, err := buildWindow(teacherID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkOverlap(tx, window); err != nil {
+			return err
+		}
+		return tx.Create(window).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return window, nil
+}
+
+// Update changes a window of the teacher
+func (s *service) Update(ctx context.Context, teacherID uint, id uint, input WindowInput) (*models.TeacherAvailability, error) {
+	window, err := buildWindow(teacherID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current models.TeacherAvailability
+		if err := tx.Where("id = ? AND teacher_id = ?", id, teacherID).First(&current).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		window.ID = current.ID
+		if err := checkOverlap(tx, window); err != nil {
+			return err
+		}
+		return tx.Model(&current).Updates(map[string]interface{}{
+			"day_of_week": window.DayOfWeek,
+			"start_time":  window.StartTime,
+			"end_time":    window.EndTime,
+			"is_active":   window.IsActive,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var updated models.TeacherAvailability
+	if err := s.db.WithContext(ctx).First(&updated, id).Error; err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Delete removes a window of the teacher
+func (s *service) Delete(ctx context.Context, teacherID uint, id uint) error {
+	result := s.db.WithContext(ctx).Where("id = ? AND teacher_id = ?", id, teacherID).Delete(&models.TeacherAvailability{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Replace swaps the whole weekly availability of the teacher in one transaction
+func (s *service) Replace(ctx context.Context, teacherID uint, inputs []WindowInput) ([]models.TeacherAvailability, error) {
+	windows, err := Normalize(teacherID, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("teacher_id = ?", teacherID).Delete(&models.TeacherAvailability{}).Error; err != nil {
+			return err
+		}
+		if len(windows) == 0 {
+			return nil
+		}
+		return tx.Create(&windows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.List(ctx, teacherID)
+}
+
+// TeacherIDForUser resolves the teacher record of a logged user
+func (s *service) TeacherIDForUser(ctx context.Context, userID uint) (uint, error) {
+	var teacher models.Teacher
+	if err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&teacher).Error; err != nil {
+		return 0, ErrNotTeacher
+	}
+	return teacher.ID, nil
+}
+
+// Normalize validates a full weekly availability, rejecting malformed and overlapping windows
+func Normalize(teacherID uint, inputs []WindowInput) ([]models.TeacherAvailability, error) {
+	windows := make([]models.TeacherAvailability, 0, len(inputs))
+	for _, input := range inputs {
+		window, err := buildWindow(teacherID, input)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, *window)
+	}
+	if err := validateSet(windows); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
+
+// checkOverlap rejects a window overlapping another active window of the same day
+func checkOverlap(tx *gorm.DB, window *models.TeacherAvailability) error {
+	if !window.IsActive {
+		return nil
+	}
+	query := tx.Model(&models.TeacherAvailability{}).
+		Where("teacher_id = ? AND day_of_week = ? AND is_active = ?", window.TeacherID, window.DayOfWeek, true).
+		Where("start_time < ? AND end_time > ?", window.EndTime, window.StartTime)
+	if window.ID != 0 {
+		query = query.Where("id <> ?", window.ID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %s %s-%s", ErrOverlap, dayName(window.DayOfWeek), window.StartTime, window.EndTime)
+	}
+	return nil
+}
+
+// buildWindow validates an input and normalizes its times to HH:MM
+func buildWindow(teacherID uint, input WindowInput) (*models.TeacherAvailability, error) {
+	if input.DayOfWeek < 0 || input.DayOfWeek > 6 {
+		return nil, fmt.Errorf("%w: dayOfWeek must be between 0 (Sunday) and 6 (Saturday)", ErrInvalidWindow)
+	}
+	start, err := time.Parse(timeLayout, input.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid startTime, expected HH:MM", ErrInvalidWindow)
+	}
+	end, err := time.Parse(timeLayout, input.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid endTime, expected HH:MM", ErrInvalidWindow)
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: endTime must be after startTime", ErrInvalidWindow)
+	}
+
+	active := true
+	if input.IsActive != nil {
+		active = *input.IsActive
+	}
+	return &models.TeacherAvailability{
+		TeacherID: teacherID,
+		DayOfWeek: input.DayOfWeek,
+		StartTime: start.Format(timeLayout),
+		EndTime:   end.Format(timeLayout),
+		IsActive:  active,
+	}, nil
+}
+
+// validateSet rejects overlapping active windows within a replacement set
+func validateSet(windows []models.TeacherAvailability) error {
+	active := make([]models.TeacherAvailability, 0, len(windows))
+	for _, window := range windows {
+		if window.IsActive {
+			active = append(active, window)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		if active[i].DayOfWeek != active[j].DayOfWeek {
+			return active[i].DayOfWeek < active[j].DayOfWeek
+		}
+		return active[i].StartTime < active[j].StartTime
+	})
+	for i := 1; i < len(active); i++ {
+		prev, cur := active[i-1], active[i]
+		// HH:MM normalizado permite comparar como texto
+		if prev.DayOfWeek == cur.DayOfWeek && cur.StartTime < prev.EndTime {
+			return fmt.Errorf("%w: %s %s-%s and %s-%s", ErrOverlap, dayName(cur.DayOfWeek), prev.StartTime, prev.EndTime, cur.StartTime, cur.EndTime)
+		}
+	}
+	return nil
+}
+
+var dayNames = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+func dayName(day int) string {
+	return dayNames[day]
+}
@@ -0,0 +1,60 @@
+package availability
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+func TestBuildWindow(t *testing.T) {
+	t.Run("normalizes times and defaults to active", func(t *testing.T) {
+		window, err := buildWindow(7, WindowInput{DayOfWeek: 1, StartTime: "9:00", EndTime: "12:30"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if window.StartTime != "09:00" || window.EndTime != "12:30" {
+			t.Errorf("Expected 09:00-12:30, got %s-%s", window.StartTime, window.EndTime)
+		}
+		if !window.IsActive || window.TeacherID != 7 {
+			t.Errorf("Expected active window of teacher 7, got %+v", window)
+		}
+	})
+
+	invalid := map[string]WindowInput{
+		"day out of range": {DayOfWeek: 7, StartTime: "09:00", EndTime: "10:00"},
+		"malformed time":   {DayOfWeek: 1, StartTime: "9h", EndTime: "10:00"},
+		"end before start": {DayOfWeek: 1, StartTime: "10:00", EndTime: "09:00"},
+	}
+	for name, input := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := buildWindow(1, input); !errors.Is(err, ErrInvalidWindow) {
+				t.Errorf("Expected ErrInvalidWindow, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateSet(t *testing.T) {
+	t.Run("rejects overlapping windows on the same day", func(t *testing.T) {
+		err := validateSet([]models.TeacherAvailability{
+			{DayOfWeek: 1, StartTime: "13:00", EndTime: "17:00", IsActive: true},
+			{DayOfWeek: 1, StartTime: "08:00", EndTime: "14:00", IsActive: true},
+		})
+		if !errors.Is(err, ErrOverlap) {
+			t.Errorf("Expected ErrOverlap, got %v", err)
+		}
+	})
+
+	t.Run("accepts adjacent windows, other days and inactive windows", func(t *testing.T) {
+		err := validateSet([]models.TeacherAvailability{
+			{DayOfWeek: 1, StartTime: "08:00", EndTime: "12:00", IsActive: true},
+			{DayOfWeek: 1, StartTime: "12:00", EndTime: "18:00", IsActive: true},
+			{DayOfWeek: 2, StartTime: "08:00", EndTime: "12:00", IsActive: true},
+			{DayOfWeek: 1, StartTime: "09:00", EndTime: "10:00", IsActive: false},
+		})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+// backend/internal/service/calendar/ics.go
+package calendar
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// icsTimestampFormat is the UTC date-time format used by RFC 5545
+const icsTimestampFormat = "20060102T150405Z"
+
+// icsMaxLineOctets is the maximum line length before folding (RFC 5545 §3.1)
+const icsMaxLineOctets = 75
+
+// Event is a single VEVENT in a feed
+type Event struct {
+	UID          string
+	Start        time.Time
+	End          time.Time
+	Summary      string
+	Description  string
+	Location     string
+	Cancelled    bool
+	LastModified time.Time
+}
+
+// Calendar is a VCALENDAR document
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Render serializes the calendar as an iCalendar (.ics) document
+func (c Calendar) Render(now time.Time) []byte {
+	var buf bytes.Buffer
+
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:-//CECOR//Calendario de Aulas//PT-BR")
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	writeLine(&buf, "METHOD:PUBLISH")
+	writeLine(&buf, "X-WR-CALNAME:"+escapeText(c.Name))
+	writeLine(&buf, "X-WR-TIMEZONE:"+timezoneName)
+
+	dtstamp := now.UTC().Format(icsTimestampFormat)
+	for _, e := range c.Events {
+		writeLine(&buf, "BEGIN:VEVENT")
+		writeLine(&buf, "UID:"+e.UID)
+		writeLine(&buf, "DTSTAMP:"+dtstamp)
+		writeLine(&buf, "DTSTART:"+e.Start.UTC().Format(icsTimestampFormat))
+		writeLine(&buf, "DTEND:"+e.End.UTC().Format(icsTimestampFormat))
+		writeLine(&buf, "SUMMARY:"+escapeText(e.Summary))
+		if e.Location != "" {
+			writeLine(&buf, "LOCATION:"+escapeText(e.Location))
+		}
+		if e.Description != "" {
+			writeLine(&buf, "DESCRIPTION:"+escapeText(e.Description))
+		}
+		if e.Cancelled {
+			writeLine(&buf, "STATUS:CANCELLED")
+		} else {
+			writeLine(&buf, "STATUS:CONFIRMED")
+		}
+		if !e.LastModified.IsZero() {
+			writeLine(&buf, "LAST-MODIFIED:"+e.LastModified.UTC().Format(icsTimestampFormat))
+		}
+		writeLine(&buf, "END:VEVENT")
+	}
+
+	writeLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// escapeText escapes a TEXT value (RFC 5545 §3.3.11)
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, ";", "\\;")
+	s = strings.ReplaceAll(s, ",", "\\,")
+	s = strings.ReplaceAll(s, "\r\n", "\\n")
+	s = strings.ReplaceAll(s, "\n", "\\n")
+	return s
+}
+
+// writeLine writes a content line folded at 75 octets, without splitting UTF-8 sequences
+func writeLine(buf *bytes.Buffer, line string) {
+	limit := icsMaxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = icsMaxLineOctets - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// eventUID builds a stable UID for a class session
+func eventUID(sessionID uint) string {
+	return fmt.Sprintf("class-session-%d@cecor", sessionID)
+}
@@ -0,0 +1,89 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestCalendarRender(t *testing.T) {
+	loc := time.FixedZone("BRT", -3*60*60)
+	start := time.Date(2026, 3, 7, 9, 0, 0, 0, loc)
+
+	cal := Calendar{
+		Name: "CECOR - Aulas de Ana",
+		Events: []Event{
+			{
+				UID:         eventUID(42),
+				Start:       start,
+				End:         start.Add(2 * time.Hour),
+				Summary:     "Inglês Básico, Turma A",
+				Description: "Tema: Apresentação; saudações\nTraga o caderno",
+				Location:    "Sala 3",
+			},
+			{
+				UID:       eventUID(43),
+				Start:     start.AddDate(0, 0, 7),
+				End:       start.AddDate(0, 0, 7).Add(2 * time.Hour),
+				Summary:   "[Cancelada] Inglês Básico",
+				Cancelled: true,
+			},
+		},
+	}
+
+	out := string(cal.Render(start))
+
+	t.Run("UsesCRLF", func(t *testing.T) {
+		if strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+			t.Errorf("Expected every line to end with CRLF")
+		}
+	})
+
+	t.Run("ConvertsToUTC", func(t *testing.T) {
+		if !strings.Contains(out, "DTSTART:20260307T120000Z\r\n") {
+			t.Errorf("Expected DTSTART in UTC, got:\n%s", out)
+		}
+	})
+
+	t.Run("EscapesText", func(t *testing.T) {
+		if !strings.Contains(out, `SUMMARY:Inglês Básico\, Turma A`) {
+			t.Errorf("Expected comma to be escaped, got:\n%s", out)
+		}
+		if !strings.Contains(out, `DESCRIPTION:Tema: Apresentação\; saudações\nTraga o caderno`) {
+			t.Errorf("Expected semicolon and newline to be escaped, got:\n%s", out)
+		}
+	})
+
+	t.Run("MarksCancelledSessions", func(t *testing.T) {
+		if strings.Count(out, "STATUS:CANCELLED") != 1 || strings.Count(out, "STATUS:CONFIRMED") != 1 {
+			t.Errorf("Expected one cancelled and one confirmed event, got:\n%s", out)
+		}
+	})
+}
+
+func TestWriteLineFolding(t *testing.T) {
+	var line strings.Builder
+	line.WriteString("DESCRIPTION:")
+	for i := 0; i < 40; i++ {
+		line.WriteString("ção")
+	}
+
+	var buf bytes.Buffer
+	writeLine(&buf, line.String())
+	out := strings.TrimSuffix(buf.String(), "\r\n")
+
+	for _, l := range strings.Split(out, "\r\n") {
+		if len(l) > icsMaxLineOctets {
+			t.Errorf("Expected lines of at most %d octets, got %d", icsMaxLineOctets, len(l))
+		}
+		if !utf8.ValidString(l) {
+			t.Errorf("Expected folding to keep UTF-8 sequences intact: %q", l)
+		}
+	}
+
+	if unfolded := strings.ReplaceAll(out, "\r\n ", ""); unfolded != line.String() {
+		t.Errorf("Expected unfolding to restore the original line")
+	}
+}
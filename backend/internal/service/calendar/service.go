@@ -0,0 +1,417 @@
+// backend/internal/service/calendar/service.go
+package calendar
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+// timezoneName is the timezone the class schedules are written in
+const timezoneName = "America/Sao_Paulo"
+
+// Feed window: recent history plus the upcoming semester
+const (
+	feedPastDays   = 30
+	feedFutureDays = 180
+)
+
+var (
+	// ErrFeedNotFound is returned for unknown or revoked feed tokens
+	ErrFeedNotFound = errors.New("calendar feed not found")
+	// ErrInvalidOwner is returned when the feed owner does not exist
+	ErrInvalidOwner = errors.New("invalid calendar feed owner")
+)
+
+// Service defines the interface for iCalendar feed operations
+type Service interface {
+	// Tokens
+	CreateToken(ctx context.Context, ownerType models.CalendarFeedOwnerType, ownerID uint, label string, createdByID uint) (*models.CalendarFeedToken, error)
+	ListTokens(ctx context.Context, ownerType models.CalendarFeedOwnerType, ownerID uint) ([]models.CalendarFeedToken, error)
+	RevokeToken(ctx context.Context, ownerType models.CalendarFeedOwnerType, ownerID uint, tokenID uint) error
+
+	// Feed
+	RenderFeed(ctx context.Context, token string) ([]byte, error)
+
+	// Owner resolution for the portals
+	TeacherIDForUser(ctx context.Context, userID uint) (uint, error)
+	StudentIDForUser(ctx context.Context, userID uint) (uint, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db  *gorm.DB
+	loc *time.Location
+}
+
+// NewService creates a new calendar feed service
+func NewService(db *gorm.DB) Service {
+	loc, err := time.LoadLocation(timezoneName)
+	if err != nil {
+		// Brasília time has no daylight saving since 2019
+		loc = time.FixedZone("BRT", -3*60*60)
+	}
+	return &service{db: db, loc: loc}
+}
+
+// sessionRow is a class session with everything needed to build a VEVENT
+type sessionRow struct {
+	ID                  uint
+	Date                time.Time
+	StartTime           string
+	EndTime             string
+	Topic               string
+	TopicOverride       string
+	SyllabusTitle       *string
+	IsCancelled         bool
+	CancellationReason  string
+	SubstituteTeacherID *uint
+	DefaultTeacherID    *uint
+	SubstituteName      *string
+	DefaultTeacherName  *string
+	CourseName          string
+	ClassName           *string
+	LocationName        *string
+	UpdatedAt           time.Time
+}
+
+// CreateToken generates a new feed token for the owner
+func (s *service) CreateToken(ctx context.Context, ownerType models.CalendarFeedOwnerType, ownerID uint, label string, createdByID uint) (*models.CalendarFeedToken, error) {
+	if err := s.ensureOwnerExists(ctx, ownerType, ownerID); err != nil {
+		return nil, err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate feed token: %w", err)
+	}
+
+	feed := &models.CalendarFeedToken{
+		Token:       token,
+		OwnerType:   ownerType,
+		OwnerID:     ownerID,
+		Label:       label,
+		CreatedByID: createdByID,
+	}
+	if err := s.db.WithContext(ctx).Create(feed).Error; err != nil {
+		return nil, fmt.Errorf("failed to create feed token: %w", err)
+	}
+
+	return feed, nil
+}
+
+// ListTokens lists the active feed tokens of an owner
+func (s *service) ListTokens(ctx context.Context, ownerType models.CalendarFeedOwnerType, ownerID uint) ([]models.CalendarFeedToken, error) {
+	var tokens []models.CalendarFeedToken
+	err := s.db.WithContext(ctx).
+		Where("owner_type = ? AND owner_id = ? AND revoked_at IS NULL", ownerType, ownerID).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// RevokeToken revokes a feed token; the owner check prevents revoking someone else's feed
+func (s *service) RevokeToken(ctx context.Context, ownerType models.CalendarFeedOwnerType, ownerID uint, tokenID uint) error {
+	now := time.Now()
+	result := s.db.WithContext(ctx).Model(&models.CalendarFeedToken{}).
+		Where("id = ? AND owner_type = ? AND owner_id = ? AND revoked_at IS NULL", tokenID, ownerType, ownerID).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrFeedNotFound
+	}
+	return nil
+}
+
+// RenderFeed builds the .ics document for a token
+func (s *service) RenderFeed(ctx context.Context, token string) ([]byte, error) {
+	var feed models.CalendarFeedToken
+	if err := s.db.WithContext(ctx).Where("token = ?", token).First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFeedNotFound
+		}
+		return nil, err
+	}
+	if feed.IsRevoked() {
+		return nil, ErrFeedNotFound
+	}
+
+	name, err := s.calendarName(ctx, feed.OwnerType, feed.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.fetchSessions(ctx, feed.OwnerType, feed.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := Calendar{Name: name}
+	for _, row := range rows {
+		cal.Events = append(cal.Events, s.buildEvent(feed.OwnerType, feed.OwnerID, row))
+	}
+
+	now := time.Now()
+	s.db.WithContext(ctx).Model(&feed).Update("last_accessed_at", now)
+
+	return cal.Render(now), nil
+}
+
+// TeacherIDForUser returns the teacher record linked to a user
+func (s *service) TeacherIDForUser(ctx context.Context, userID uint) (uint, error) {
+	var teacher models.Teacher
+	if err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&teacher).Error; err != nil {
+		return 0, ErrInvalidOwner
+	}
+	return teacher.ID, nil
+}
+
+// StudentIDForUser returns the student record linked to a user
+func (s *service) StudentIDForUser(ctx context.Context, userID uint) (uint, error) {
+	var student models.Student
+	if err := s.db.WithContext(ctx).Where("user_id = ? AND deleted_at IS NULL", userID).First(&student).Error; err != nil {
+		return 0, ErrInvalidOwner
+	}
+	return student.ID, nil
+}
+
+// ensureOwnerExists validates the owner of a new feed
+func (s *service) ensureOwnerExists(ctx context.Context, ownerType models.CalendarFeedOwnerType, ownerID uint) error {
+	var model interface{}
+	switch ownerType {
+	case models.CalendarFeedOwnerTeacher:
+		model = &models.Teacher{}
+	case models.CalendarFeedOwnerStudent:
+		model = &models.Student{}
+	case models.CalendarFeedOwnerLocation:
+		model = &models.Location{}
+	default:
+		return ErrInvalidOwner
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Model(model).Where("id = ?", ownerID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrInvalidOwner
+	}
+	return nil
+}
+
+// calendarName returns the display name of the calendar
+func (s *service) calendarName(ctx context.Context, ownerType models.CalendarFeedOwnerType, ownerID uint) (string, error) {
+	var name string
+	var err error
+
+	switch ownerType {
+	case models.CalendarFeedOwnerTeacher:
+		err = s.db.WithContext(ctx).Raw(`
+			SELECT u.name FROM teachers t JOIN users u ON u.id = t.user_id WHERE t.id = ?
+		`, ownerID).Scan(&name).Error
+		name = "CECOR - Aulas de " + name
+	case models.CalendarFeedOwnerStudent:
+		err = s.db.WithContext(ctx).Raw(`
+			SELECT u.name FROM students s JOIN users u ON u.id = s.user_id WHERE s.id = ?
+		`, ownerID).Scan(&name).Error
+		name = "CECOR - Aulas de " + name
+	case models.CalendarFeedOwnerLocation:
+		err = s.db.WithContext(ctx).Raw(`SELECT name FROM locations WHERE id = ?`, ownerID).Scan(&name).Error
+		name = "CECOR - Sala " + name
+	default:
+		return "", ErrInvalidOwner
+	}
+
+	return name, err
+}
+
+// fetchSessions loads the sessions in the feed window for an owner
+func (s *service) fetchSessions(ctx context.Context, ownerType models.CalendarFeedOwnerType, ownerID uint) ([]sessionRow, error) {
+	var filter string
+	var args []interface{}
+
+	switch ownerType {
+	case models.CalendarFeedOwnerTeacher:
+		// Effective teacher (substitute), default teacher of the class (even when substituted,
+		// so the teacher sees the hand-over) or legacy course assignment
+		filter = `(
+			cs.teacher_id = ?
+			OR cc.default_teacher_id = ?
+			OR (cs.course_class_id IS NULL AND cs.teacher_id IS NULL AND EXISTS (
+				SELECT 1 FROM teacher_courses tc
+				WHERE tc.course_id = cs.course_id AND tc.teacher_id = ? AND tc.active = true
+			))
+		)`
+		args = append(args, ownerID, ownerID, ownerID)
+	case models.CalendarFeedOwnerStudent:
+		filter = `(
+			cs.course_class_id IN (
+				SELECT ecc.course_class_id FROM enrollment_course_classes ecc
+				JOIN enrollments e ON e.id = ecc.enrollment_id
+				WHERE e.student_id = ? AND e.status IN ('active', 'in_progress') AND e.deleted_at IS NULL
+			)
+			OR (cs.course_class_id IS NULL AND cs.course_id IN (
+				SELECT e.course_id FROM enrollments e
+				WHERE e.student_id = ? AND e.status IN ('active', 'in_progress') AND e.deleted_at IS NULL
+			))
+		)`
+		args = append(args, ownerID, ownerID)
+	case models.CalendarFeedOwnerLocation:
+		filter = `COALESCE(cs.location_id, cc.default_location_id) = ?`
+		args = append(args, ownerID)
+	default:
+		return nil, ErrInvalidOwner
+	}
+
+	now := time.Now()
+	args = append(args, now.AddDate(0, 0, -feedPastDays), now.AddDate(0, 0, feedFutureDays))
+
+	query := `
+		SELECT
+			cs.id,
+			cs.date,
+			COALESCE(NULLIF(cs.start_time, ''), cc.start_time, c.start_time) as start_time,
+			COALESCE(NULLIF(cs.end_time, ''), cc.end_time, c.end_time) as end_time,
+			COALESCE(cs.topic, '') as topic,
+			COALESCE(cs.topic_override, '') as topic_override,
+			st.title as syllabus_title,
+			cs.is_cancelled,
+			COALESCE(cs.cancellation_reason, '') as cancellation_reason,
+			cs.teacher_id as substitute_teacher_id,
+			cc.default_teacher_id,
+			su.name as substitute_name,
+			du.name as default_teacher_name,
+			c.name as course_name,
+			cc.name as class_name,
+			l.name as location_name,
+			cs.updated_at
+		FROM class_sessions cs
+		INNER JOIN courses c ON c.id = cs.course_id
+		LEFT JOIN course_classes cc ON cc.id = cs.course_class_id
+		LEFT JOIN locations l ON l.id = COALESCE(cs.location_id, cc.default_location_id)
+		LEFT JOIN syllabus_topics st ON st.id = cs.syllabus_topic_id
+		LEFT JOIN teachers subt ON subt.id = cs.teacher_id
+		LEFT JOIN users su ON su.id = subt.user_id
+		LEFT JOIN teachers deft ON deft.id = cc.default_teacher_id
+		LEFT JOIN users du ON du.id = deft.user_id
+		WHERE ` + filter + `
+			AND cs.date BETWEEN ? AND ?
+		ORDER BY cs.date ASC
+	`
+
+	var rows []sessionRow
+	if err := s.db.WithContext(ctx).Raw(query, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch sessions for calendar feed: %w", err)
+	}
+	return rows, nil
+}
+
+// buildEvent converts a session row into a VEVENT from the point of view of the feed owner
+func (s *service) buildEvent(ownerType models.CalendarFeedOwnerType, ownerID uint, row sessionRow) Event {
+	start, end := s.sessionTimes(row)
+
+	summary := row.CourseName
+	if row.ClassName != nil && *row.ClassName != "" {
+		summary = *row.ClassName
+	}
+
+	var description []string
+	if topic := sessionTopic(row); topic != "" {
+		description = append(description, "Tema: "+topic)
+	}
+
+	cancelled := row.IsCancelled
+	if row.IsCancelled {
+		summary = "[Cancelada] " + summary
+		if row.CancellationReason != "" {
+			description = append(description, "Motivo do cancelamento: "+row.CancellationReason)
+		}
+	}
+
+	if row.SubstituteTeacherID != nil {
+		substitute := valueOrEmpty(row.SubstituteName)
+		switch {
+		case ownerType == models.CalendarFeedOwnerTeacher && *row.SubstituteTeacherID == ownerID:
+			summary = "[Substituição] " + summary
+			if original := valueOrEmpty(row.DefaultTeacherName); original != "" {
+				description = append(description, "Substituindo: "+original)
+			}
+		case ownerType == models.CalendarFeedOwnerTeacher:
+			// The default teacher handed this session over; it no longer blocks their calendar
+			cancelled = true
+			summary = "[Com substituto] " + summary
+			description = append(description, "Aula assumida por: "+substitute)
+		default:
+			description = append(description, "Professor substituto: "+substitute)
+		}
+	}
+
+	return Event{
+		UID:          eventUID(row.ID),
+		Start:        start,
+		End:          end,
+		Summary:      summary,
+		Description:  strings.Join(description, "\n"),
+		Location:     valueOrEmpty(row.LocationName),
+		Cancelled:    cancelled,
+		LastModified: row.UpdatedAt,
+	}
+}
+
+// sessionTimes combines the session date with the scheduled "HH:MM" times. The date column
+// comes back as midnight UTC, so the calendar day is read as stored and only then placed in
+// s.loc (converting first would move it to the previous day in Brasília).
+func (s *service) sessionTimes(row sessionRow) (time.Time, time.Time) {
+	day := row.Date
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, s.loc)
+	if t, err := time.Parse("15:04", row.StartTime); err == nil {
+		start = time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, s.loc)
+	}
+
+	end := start.Add(2 * time.Hour)
+	if t, err := time.Parse("15:04", row.EndTime); err == nil {
+		candidate := time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, s.loc)
+		if candidate.After(start) {
+			end = candidate
+		}
+	}
+
+	return start, end
+}
+
+// sessionTopic returns the effective topic: override, syllabus or legacy free text
+func sessionTopic(row sessionRow) string {
+	if row.TopicOverride != "" {
+		return row.TopicOverride
+	}
+	if row.SyllabusTitle != nil && *row.SyllabusTitle != "" {
+		return *row.SyllabusTitle
+	}
+	return row.Topic
+}
+
+func valueOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// generateToken returns a random 256-bit hex token
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
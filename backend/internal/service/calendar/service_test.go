@@ -0,0 +1,81 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+func newTestService() *service {
+	return &service{loc: time.FixedZone("BRT", -3*60*60)}
+}
+
+func TestSessionTimes(t *testing.T) {
+	s := newTestService()
+
+	// Postgres date columns are scanned as midnight UTC: the day must not move back in Brasília
+	row := sessionRow{Date: time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC), StartTime: "09:00", EndTime: "11:30"}
+	start, end := s.sessionTimes(row)
+	if want := time.Date(2026, 3, 7, 9, 0, 0, 0, s.loc); !start.Equal(want) {
+		t.Errorf("Expected start %v, got %v", want, start)
+	}
+	if want := time.Date(2026, 3, 7, 11, 30, 0, 0, s.loc); !end.Equal(want) {
+		t.Errorf("Expected end %v, got %v", want, end)
+	}
+
+	// Invalid or inverted end times fall back to two hours
+	row.EndTime = "08:00"
+	if _, end := s.sessionTimes(row); !end.Equal(start.Add(2 * time.Hour)) {
+		t.Errorf("Expected two-hour fallback, got %v", end)
+	}
+
+	// Without a start time the event starts at midnight of the stored day
+	row.StartTime = ""
+	if start, _ := s.sessionTimes(row); !start.Equal(time.Date(2026, 3, 7, 0, 0, 0, 0, s.loc)) {
+		t.Errorf("Expected midnight of the session day, got %v", start)
+	}
+}
+
+func TestBuildEvent(t *testing.T) {
+	s := newTestService()
+	className := "Turma A"
+	substitute := "Bruno"
+	original := "Ana"
+	substituteID := uint(7)
+	row := sessionRow{
+		ID:                  42,
+		Date:                time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC),
+		StartTime:           "09:00",
+		EndTime:             "11:00",
+		Topic:               "Saudações",
+		CourseName:          "Inglês Básico",
+		ClassName:           &className,
+		SubstituteTeacherID: &substituteID,
+		SubstituteName:      &substitute,
+		DefaultTeacherName:  &original,
+	}
+
+	event := s.buildEvent(models.CalendarFeedOwnerTeacher, substituteID, row)
+	if event.UID != eventUID(42) || event.Start.Day() != 7 || event.Cancelled {
+		t.Errorf("Unexpected event %+v", event)
+	}
+	if event.Summary != "[Substituição] Turma A" || !strings.Contains(event.Description, "Substituindo: Ana") {
+		t.Errorf("Expected substitute view, got %q / %q", event.Summary, event.Description)
+	}
+
+	// The default teacher sees the session as handed over
+	event = s.buildEvent(models.CalendarFeedOwnerTeacher, 3, row)
+	if !event.Cancelled || event.Summary != "[Com substituto] Turma A" {
+		t.Errorf("Expected handed over session, got %+v", event)
+	}
+
+	row.SubstituteTeacherID = nil
+	row.IsCancelled = true
+	row.CancellationReason = "Feriado"
+	event = s.buildEvent(models.CalendarFeedOwnerStudent, 1, row)
+	if !event.Cancelled || event.Summary != "[Cancelada] Turma A" || !strings.Contains(event.Description, "Motivo do cancelamento: Feriado") {
+		t.Errorf("Expected cancelled session, got %+v", event)
+	}
+}
@@ -0,0 +1,477 @@
+// backend/internal/service/catalog/service.go
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+// courseSearchVector must match the expression of idx_courses_search (see migrations/sql/0002_course_search_index.up.sql)
+const courseSearchVector = `to_tsvector('portuguese',
+	coalesce(c.name, '') || ' ' ||
+	coalesce(c.short_description, '') || ' ' ||
+	coalesce(c.detailed_description, ''))`
+
+// activeEnrollmentStatuses are the enrollment statuses that take a seat
+var activeEnrollmentStatuses = []string{"active", "in_progress"}
+
+// Periods of the day used by the period filter, based on the class start time
+const (
+	PeriodMorning   = "morning"   // before 12:00
+	PeriodAfternoon = "afternoon" // 12:00 to 17:59
+	PeriodEvening   = "evening"   // from 18:00
+)
+
+var (
+	// ErrInvalidFilter is returned when a filter value is not recognized
+	ErrInvalidFilter = errors.New("invalid catalog filter")
+	// ErrClassNotFound is returned when the class is not open in the catalog
+	ErrClassNotFound = errors.New("course class not found in catalog")
+	// ErrCategoryNotFound is returned when the category does not exist
+	ErrCategoryNotFound = errors.New("course category not found")
+	// ErrInvalidCategory is returned when a category fails validation
+	ErrInvalidCategory = errors.New("invalid course category")
+)
+
+// Service defines the public course catalog operations
+type Service interface {
+	// Public catalog
+	ListClasses(ctx context.Context, filter Filter) (*Page, error)
+	GetClass(ctx context.Context, courseClassID uint) (*ClassItem, error)
+	ListCategories(ctx context.Context) ([]CategoryWithCount, error)
+
+	// Category management (coordination)
+	CreateCategory(ctx context.Context, category *models.CourseCategory) error
+	UpdateCategory(ctx context.Context, category *models.CourseCategory) error
+	DeleteCategory(ctx context.Context, id uint) error
+}
+
+// service implements the Service interface
+type service struct {
+	db *gorm.DB
+}
+
+// NewService creates a new catalog service
+func NewService(db *gorm.DB) Service {
+	return &service{db: db}
+}
+
+// Filter holds the catalog search parameters
+type Filter struct {
+	CategoryID *uint
+	WeekDay    *int   // 0 = Sunday ... 6 = Saturday, same as CourseClass.WeekDays
+	Period     string // morning, afternoon, evening
+	Difficulty string
+	Tags       []string // every tag must be present
+	Search     string   // full-text search on name and descriptions
+	Page       int
+	PageSize   int
+}
+
+// Page is a page of catalog results
+type Page struct {
+	Items      []ClassItem `json:"data"`
+	Page       int         `json:"page"`
+	PageSize   int         `json:"pageSize"`
+	TotalItems int64       `json:"totalItems"`
+	TotalPages int64       `json:"totalPages"`
+}
+
+// CategoryInfo is the category summary shown in the catalog
+type CategoryInfo struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+	Icon  string `json:"icon"`
+}
+
+// CategoryWithCount is a category with the number of open classes
+type CategoryWithCount struct {
+	models.CourseCategory
+	OpenClasses int64 `json:"openClasses"`
+}
+
+// ClassItem is an open course class as shown in the public catalog
+type ClassItem struct {
+	CourseClassID    uint          `json:"courseClassId"`
+	Code             string        `json:"code"`
+	Name             string        `json:"name"`
+	CourseID         uint          `json:"courseId"`
+	CourseName       string        `json:"courseName"`
+	ShortDescription string        `json:"shortDescription"`
+	Description      string        `json:"detailedDescription,omitempty"`
+	CoverImage       string        `json:"coverImage"`
+	Workload         int           `json:"workload"`
+	DifficultyLevel  string        `json:"difficultyLevel"`
+	TargetAudience   string        `json:"targetAudience"`
+	Prerequisites    string        `json:"prerequisites"`
+	Tags             []string      `json:"tags"`
+	Category         *CategoryInfo `json:"category,omitempty"`
+	WeekDays         []int         `json:"weekDays"`
+	StartTime        string        `json:"startTime"`
+	EndTime          string        `json:"endTime"`
+	StartDate        time.Time     `json:"startDate"`
+	EndDate          time.Time     `json:"endDate"`
+	LocationName     string        `json:"locationName,omitempty"`
+	TeacherName      string        `json:"teacherName,omitempty"`
+	Seats            int           `json:"seats"`
+	EnrolledCount    int64         `json:"enrolledCount"`
+	RemainingSeats   int           `json:"remainingSeats"`
+}
+
+// classRow is the raw result of the catalog query
+type classRow struct {
+	CourseClassID       uint
+	Code                string
+	Name                string
+	CourseID            uint
+	CourseName          string
+	ShortDescription    string
+	DetailedDescription string
+	CoverImage          string
+	Workload            int
+	DifficultyLevel     string
+	TargetAudience      string
+	Prerequisites       string
+	Tags                *string
+	CategoryID          *uint
+	CategoryName        *string
+	CategoryColor       *string
+	CategoryIcon        *string
+	WeekDays            string
+	StartTime           string
+	EndTime             string
+	StartDate           time.Time
+	EndDate             time.Time
+	LocationName        *string
+	TeacherName         *string
+	Seats               int
+	EnrolledCount       int64
+}
+
+// ListClasses lists the open course classes matching the filter
+func (s *service) ListClasses(ctx context.Context, filter Filter) (*Page, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = 20
+	}
+	if filter.PageSize > 100 {
+		filter.PageSize = 100
+	}
+
+	query, err := s.filteredQuery(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// A new session lets the count and the page query share the filters
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count catalog classes: %w", err)
+	}
+
+	query = selectClassColumns(query)
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		// Most relevant first when searching
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ts_rank(" + courseSearchVector + ", websearch_to_tsquery('portuguese', ?)) DESC, cc.start_date ASC, c.name ASC",
+			Vars:               []interface{}{search},
+			WithoutParentheses: true,
+		}})
+	} else {
+		query = query.Order("cc.start_date ASC, c.name ASC")
+	}
+
+	var rows []classRow
+	err = query.
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalog classes: %w", err)
+	}
+
+	items := make([]ClassItem, 0, len(rows))
+	for _, row := range rows {
+		item := row.toItem()
+		item.Description = ""
+		items = append(items, item)
+	}
+
+	return &Page{
+		Items:      items,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalItems: total,
+		TotalPages: (total + int64(filter.PageSize) - 1) / int64(filter.PageSize),
+	}, nil
+}
+
+// GetClass returns an open course class with its full description
+func (s *service) GetClass(ctx context.Context, courseClassID uint) (*ClassItem, error) {
+	var rows []classRow
+	err := selectClassColumns(s.openClasses(ctx)).
+		Where("cc.id = ?", courseClassID).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog class: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, ErrClassNotFound
+	}
+
+	item := rows[0].toItem()
+	return &item, nil
+}
+
+// ListCategories lists every category with its number of open classes
+func (s *service) ListCategories(ctx context.Context) ([]CategoryWithCount, error) {
+	var categories []models.CourseCategory
+	if err := s.db.WithContext(ctx).Order(`"order" ASC, name ASC`).Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	var counts []struct {
+		CategoryID  uint
+		OpenClasses int64
+	}
+	err := s.openClasses(ctx).
+		Select("c.category_id AS category_id, COUNT(cc.id) AS open_classes").
+		Where("c.category_id IS NOT NULL").
+		Group("c.category_id").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to count classes per category: %w", err)
+	}
+
+	byCategory := make(map[uint]int64, len(counts))
+	for _, count := range counts {
+		byCategory[count.CategoryID] = count.OpenClasses
+	}
+
+	result := make([]CategoryWithCount, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, CategoryWithCount{
+			CourseCategory: category,
+			OpenClasses:    byCategory[category.ID],
+		})
+	}
+	return result, nil
+}
+
+// CreateCategory creates a course category
+func (s *service) CreateCategory(ctx context.Context, category *models.CourseCategory) error {
+	if strings.TrimSpace(category.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCategory)
+	}
+	return s.db.WithContext(ctx).Create(category).Error
+}
+
+// UpdateCategory updates a course category
+func (s *service) UpdateCategory(ctx context.Context, category *models.CourseCategory) error {
+	if strings.TrimSpace(category.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCategory)
+	}
+	result := s.db.WithContext(ctx).Model(&models.CourseCategory{}).
+		Where("id = ?", category.ID).
+		Updates(map[string]interface{}{
+			"name":        category.Name,
+			"description": category.Description,
+			"color":       category.Color,
+			"icon":        category.Icon,
+			"order":       category.Order,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCategoryNotFound
+	}
+	return nil
+}
+
+// DeleteCategory deletes a category and unlinks its courses
+func (s *service) DeleteCategory(ctx context.Context, id uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Course{}).Where("category_id = ?", id).Update("category_id", nil).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.CourseCategory{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCategoryNotFound
+		}
+		return nil
+	})
+}
+
+// openClasses is the base query: active classes of active courses that have not ended yet
+func (s *service) openClasses(ctx context.Context) *gorm.DB {
+	today := time.Now().Truncate(24 * time.Hour)
+	return s.db.WithContext(ctx).
+		Table("course_classes AS cc").
+		Joins("JOIN courses c ON c.id = cc.course_id AND c.deleted_at IS NULL").
+		Where("cc.status = ? AND c.status = ?", "active", "active").
+		Where("cc.end_date >= ?", today)
+}
+
+// filteredQuery applies the catalog filters to the base query
+func (s *service) filteredQuery(ctx context.Context, filter Filter) (*gorm.DB, error) {
+	query := s.openClasses(ctx)
+
+	if filter.CategoryID != nil {
+		query = query.Where("c.category_id = ?", *filter.CategoryID)
+	}
+
+	if filter.WeekDay != nil {
+		if *filter.WeekDay < 0 || *filter.WeekDay > 6 {
+			return nil, fmt.Errorf("%w: weekDay must be between 0 and 6", ErrInvalidFilter)
+		}
+		query = query.Where("? = ANY(string_to_array(replace(cc.week_days, ' ', ''), ','))", strconv.Itoa(*filter.WeekDay))
+	}
+
+	switch filter.Period {
+	case "":
+	case PeriodMorning:
+		query = query.Where("cc.start_time < ?", "12:00")
+	case PeriodAfternoon:
+		query = query.Where("cc.start_time >= ? AND cc.start_time < ?", "12:00", "18:00")
+	case PeriodEvening:
+		query = query.Where("cc.start_time >= ?", "18:00")
+	default:
+		return nil, fmt.Errorf("%w: period must be morning, afternoon or evening", ErrInvalidFilter)
+	}
+
+	if filter.Difficulty != "" {
+		query = query.Where("LOWER(c.difficulty_level) = LOWER(?)", filter.Difficulty)
+	}
+
+	if len(filter.Tags) > 0 {
+		tags, err := json.Marshal(filter.Tags)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("COALESCE(NULLIF(c.tags::text, ''), '[]')::jsonb @> ?::jsonb", string(tags))
+	}
+
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		query = query.Where(courseSearchVector+" @@ websearch_to_tsquery('portuguese', ?)", search)
+	}
+
+	return query, nil
+}
+
+// selectClassColumns adds the joins and columns needed to build a ClassItem
+func selectClassColumns(query *gorm.DB) *gorm.DB {
+	return query.
+		Joins("LEFT JOIN course_categories cat ON cat.id = c.category_id").
+		Joins("LEFT JOIN locations l ON l.id = cc.default_location_id").
+		Joins("LEFT JOIN teachers t ON t.id = cc.default_teacher_id").
+		Joins("LEFT JOIN users tu ON tu.id = t.user_id").
+		Joins(`LEFT JOIN (
+			SELECT ecc.course_class_id, COUNT(*) AS enrolled_count
+			FROM enrollment_course_classes ecc
+			JOIN enrollments e ON e.id = ecc.enrollment_id
+			WHERE e.status IN ? AND e.deleted_at IS NULL
+			GROUP BY ecc.course_class_id
+		) enr ON enr.course_class_id = cc.id`, activeEnrollmentStatuses).
+		Select(`cc.id AS course_class_id, cc.code, cc.name,
+			c.id AS course_id, c.name AS course_name,
+			c.short_description, c.detailed_description, c.cover_image, c.workload,
+			c.difficulty_level, c.target_audience, c.prerequisites, c.tags::text AS tags,
+			cat.id AS category_id, cat.name AS category_name, cat.color AS category_color, cat.icon AS category_icon,
+			cc.week_days, cc.start_time, cc.end_time, cc.start_date, cc.end_date,
+			l.name AS location_name, tu.name AS teacher_name,
+			CASE WHEN cc.max_students > 0 THEN cc.max_students ELSE cc.capacity END AS seats,
+			COALESCE(enr.enrolled_count, 0) AS enrolled_count`)
+}
+
+// toItem converts a query row to the catalog representation
+func (row classRow) toItem() ClassItem {
+	item := ClassItem{
+		CourseClassID:    row.CourseClassID,
+		Code:             row.Code,
+		Name:             row.Name,
+		CourseID:         row.CourseID,
+		CourseName:       row.CourseName,
+		ShortDescription: row.ShortDescription,
+		Description:      row.DetailedDescription,
+		CoverImage:       row.CoverImage,
+		Workload:         row.Workload,
+		DifficultyLevel:  row.DifficultyLevel,
+		TargetAudience:   row.TargetAudience,
+		Prerequisites:    row.Prerequisites,
+		Tags:             parseTags(row.Tags),
+		WeekDays:         parseWeekDays(row.WeekDays),
+		StartTime:        row.StartTime,
+		EndTime:          row.EndTime,
+		StartDate:        row.StartDate,
+		EndDate:          row.EndDate,
+		Seats:            row.Seats,
+		EnrolledCount:    row.EnrolledCount,
+	}
+
+	if row.CategoryID != nil {
+		item.Category = &CategoryInfo{
+			ID:    *row.CategoryID,
+			Name:  deref(row.CategoryName),
+			Color: deref(row.CategoryColor),
+			Icon:  deref(row.CategoryIcon),
+		}
+	}
+	item.LocationName = deref(row.LocationName)
+	item.TeacherName = deref(row.TeacherName)
+
+	item.RemainingSeats = row.Seats - int(row.EnrolledCount)
+	if item.RemainingSeats < 0 {
+		item.RemainingSeats = 0
+	}
+	return item
+}
+
+// parseTags decodes Course.Tags, a JSON array of strings
+func parseTags(raw *string) []string {
+	tags := []string{}
+	if raw == nil || *raw == "" {
+		return tags
+	}
+	if err := json.Unmarshal([]byte(*raw), &tags); err != nil {
+		return []string{}
+	}
+	return tags
+}
+
+// parseWeekDays decodes the "1,3,5" week day format
+func parseWeekDays(raw string) []int {
+	days := []int{}
+	for _, part := range strings.Split(raw, ",") {
+		day, err := strconv.Atoi(strings.TrimSpace(part))
+		if err == nil {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,587 @@
+// backend/internal/service/diary/service.go
+package diary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+// TopicStatus values of models.LessonDiaryEntry
+const (
+	TopicCovered    = "covered"
+	TopicPartial    = "partial"
+	TopicNotCovered = "not_covered"
+)
+
+var validTopicStatuses = map[string]bool{TopicCovered: true, TopicPartial: true, TopicNotCovered: true}
+
+// Assignment roles that make a teacher responsible for the session (see assignments.Role*)
+var editorRoles = map[string]bool{"primary": true, "substitute": true}
+
+var (
+	// ErrSessionNotFound is returned when the class session does not exist
+	ErrSessionNotFound = errors.New("class session not found")
+	// ErrClassNotFound is returned when the course class does not exist
+	ErrClassNotFound = errors.New("course class not found")
+	// ErrNotTeacher is returned when the user has no teacher record or does not teach the session
+	ErrNotTeacher = errors.New("user is not a teacher of this session")
+	// ErrNotEditable is returned when a teacher other than the effective one edits the diary
+	ErrNotEditable = errors.New("only the effective teacher of the session can edit the diary")
+	// ErrInvalidEntry is returned for malformed diary entries
+	ErrInvalidEntry = errors.New("invalid lesson diary entry")
+)
+
+// EntryInput is the diary of a session filled in by the teacher
+type EntryInput struct {
+	SyllabusTopicID *uint // nil: tópico planejado da aula
+	TopicStatus     string
+	Content         string
+	Homework        string
+	Observations    string
+}
+
+// Period narrows the sessions of a class; nil bounds are open
+type Period struct {
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// SessionDiary is a session with its planned topic and diary entry
+type SessionDiary struct {
+	SessionID      uint      `json:"sessionId"`
+	CourseID       uint      `json:"courseId"`
+	CourseName     string    `json:"courseName"`
+	CourseClassID  *uint     `json:"courseClassId"`
+	ClassName      string    `json:"className"`
+	Date           time.Time `json:"date"`
+	StartTime      string    `json:"startTime"`
+	EndTime        string    `json:"endTime"`
+	IsCancelled    bool      `json:"isCancelled"`
+	PlannedTopicID *uint     `json:"plannedTopicId"`
+	PlannedTopic   string    `json:"plannedTopic"` // tema especial, ementa ou texto livre
+	TeacherID      *uint     `json:"teacherId"`    // professor efetivo
+	TeacherName    string    `json:"teacherName"`
+	IsSubstituted  bool      `json:"isSubstituted"`
+
+	Entry      *models.LessonDiaryEntry `json:"entry"`      // nil: aula sem registro
+	TopicTitle string                   `json:"topicTitle"` // tópico trabalhado, conforme o registro
+	AuthorName string                   `json:"authorName"`
+
+	// Somente no portal do professor
+	CanEdit bool `json:"canEdit"`
+
+	defaultTeacherID *uint
+}
+
+// RegisterSession is a line of the class register
+type RegisterSession struct {
+	SessionDiary
+	Present int `json:"present"`
+	Absent  int `json:"absent"`
+	Partial int `json:"partial"`
+}
+
+// RegisterSummary totals the class register
+type RegisterSummary struct {
+	TotalSessions int `json:"totalSessions"`
+	Cancelled     int `json:"cancelled"`
+	Given         int `json:"given"`    // aulas já ocorridas e não canceladas
+	Recorded      int `json:"recorded"` // aulas com diário
+	Missing       int `json:"missing"`  // aulas ocorridas sem diário
+}
+
+// ClassRegister is the class register (diário de classe) of a course class
+type ClassRegister struct {
+	CourseClassID uint              `json:"courseClassId"`
+	ClassName     string            `json:"className"`
+	ClassCode     string            `json:"classCode"`
+	CourseName    string            `json:"courseName"`
+	StartDate     *time.Time        `json:"startDate"`
+	EndDate       *time.Time        `json:"endDate"`
+	Summary       RegisterSummary   `json:"summary"`
+	Sessions      []RegisterSession `json:"sessions"`
+}
+
+// Service defines the interface for lesson diary operations
+type Service interface {
+	// Teacher portal
+	GetForTeacher(ctx context.Context, userID uint, sessionID uint) (*SessionDiary, error)
+	SaveForTeacher(ctx context.Context, userID uint, sessionID uint, input EntryInput) (*SessionDiary, error)
+	ListForTeacher(ctx context.Context, userID uint, classID uint, period Period) ([]SessionDiary, error)
+
+	// Coordination
+	Get(ctx context.Context, sessionID uint) (*SessionDiary, error)
+	ListClass(ctx context.Context, classID uint, period Period) ([]SessionDiary, error)
+	Register(ctx context.Context, classID uint, period Period) (*ClassRegister, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewService creates a new lesson diary service
+func NewService(db *gorm.DB) Service {
+	return &service{db: db, now: time.Now}
+}
+
+// assignmentRow is a teacher assignment (models.TeacherCourse) in the course of a session
+type assignmentRow struct {
+	CourseClassID *uint
+	Role          string
+	StartDate     time.Time
+	EndDate       *time.Time
+}
+
+// entryRow is a diary entry with the titles shown in the listings
+type entryRow struct {
+	models.LessonDiaryEntry
+	TopicTitle string
+	AuthorName string
+}
+
+// sessionQuery selects the sessions with the planned topic and the effective teacher
+const sessionQuery = `
+	SELECT
+		cs.id AS session_id,
+		cs.course_id,
+		c.name AS course_name,
+		cs.course_class_id,
+		COALESCE(cc.name, '') AS class_name,
+		cs.date,
+		COALESCE(NULLIF(cs.start_time, ''), cc.start_time, c.start_time, '') AS start_time,
+		COALESCE(NULLIF(cs.end_time, ''), cc.end_time, c.end_time, '') AS end_time,
+		cs.is_cancelled,
+		cs.syllabus_topic_id AS planned_topic_id,
+		COALESCE(NULLIF(cs.topic_override, ''), st.title, cs.topic, '') AS planned_topic,
+		COALESCE(cs.teacher_id, cc.default_teacher_id) AS teacher_id,
+		COALESCE(u.name, '') AS teacher_name,
+		cs.teacher_id IS NOT NULL AS is_substituted,
+		cc.default_teacher_id
+	FROM class_sessions cs
+	INNER JOIN courses c ON c.id = cs.course_id
+	LEFT JOIN course_classes cc ON cc.id = cs.course_class_id
+	LEFT JOIN syllabus_topics st ON st.id = cs.syllabus_topic_id
+	LEFT JOIN teachers t ON t.id = COALESCE(cs.teacher_id, cc.default_teacher_id)
+	LEFT JOIN users u ON u.id = t.user_id
+`
+
+// sessionRow is scanned from sessionQuery
+type sessionRow struct {
+	SessionID        uint
+	CourseID         uint
+	CourseName       string
+	CourseClassID    *uint
+	ClassName        string
+	Date             time.Time
+	StartTime        string
+	EndTime          string
+	IsCancelled      bool
+	PlannedTopicID   *uint
+	PlannedTopic     string
+	TeacherID        *uint
+	TeacherName      string
+	IsSubstituted    bool
+	DefaultTeacherID *uint
+}
+
+func (r sessionRow) diary() SessionDiary {
+	return SessionDiary{
+		SessionID:        r.SessionID,
+		CourseID:         r.CourseID,
+		CourseName:       r.CourseName,
+		CourseClassID:    r.CourseClassID,
+		ClassName:        r.ClassName,
+		Date:             r.Date,
+		StartTime:        r.StartTime,
+		EndTime:          r.EndTime,
+		IsCancelled:      r.IsCancelled,
+		PlannedTopicID:   r.PlannedTopicID,
+		PlannedTopic:     r.PlannedTopic,
+		TeacherID:        r.TeacherID,
+		TeacherName:      r.TeacherName,
+		IsSubstituted:    r.IsSubstituted,
+		defaultTeacherID: r.DefaultTeacherID,
+	}
+}
+
+// GetForTeacher returns the diary of a session the teacher teaches, assists or handed over
+func (s *service) GetForTeacher(ctx context.Context, userID uint, sessionID uint) (*SessionDiary, error) {
+	teacherID, err := s.teacherIDForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	diary, err := s.Get(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	assigned, err := s.assignments(ctx, teacherID, diary.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	canRead, canEdit := access(teacherID, diary, assigned)
+	if !canRead {
+		return nil, ErrNotTeacher
+	}
+	diary.CanEdit = canEdit
+	return diary, nil
+}
+
+// SaveForTeacher creates or replaces the diary of a session given by the teacher (the substitute, when there is one)
+func (s *service) SaveForTeacher(ctx context.Context, userID uint, sessionID uint, input EntryInput) (*SessionDiary, error) {
+	diary, err := s.GetForTeacher(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !diary.CanEdit {
+		return nil, ErrNotEditable
+	}
+	if diary.IsCancelled {
+		return nil, fmt.Errorf("%w: the session was cancelled", ErrInvalidEntry)
+	}
+	if day(diary.Date) > day(s.now()) {
+		return nil, fmt.Errorf("%w: the session has not taken place yet", ErrInvalidEntry)
+	}
+
+	entry, err := buildEntry(input, diary.PlannedTopicID)
+	if err != nil {
+		return nil, err
+	}
+	teacherID, err := s.teacherIDForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	entry.ClassSessionID = sessionID
+	entry.TeacherID = teacherID
+	entry.UpdatedByID = userID
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if entry.SyllabusTopicID != nil {
+			var count int64
+			if err := tx.Model(&models.SyllabusTopic{}).
+				Where("id = ? AND course_id = ?", *entry.SyllabusTopicID, diary.CourseID).
+				Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return fmt.Errorf("%w: syllabus topic does not belong to the course", ErrInvalidEntry)
+			}
+		}
+
+		var existing models.LessonDiaryEntry
+		err := tx.Where("class_session_id = ?", sessionID).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(entry).Error
+		}
+		if err != nil {
+			return err
+		}
+		entry.ID = existing.ID
+		entry.CreatedAt = existing.CreatedAt
+		return tx.Save(entry).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetForTeacher(ctx, userID, sessionID)
+}
+
+// ListForTeacher lists the diary of the class sessions the teacher can read
+func (s *service) ListForTeacher(ctx context.Context, userID uint, classID uint, period Period) ([]SessionDiary, error) {
+	teacherID, err := s.teacherIDForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	all, err := s.ListClass(ctx, classID, period)
+	if err != nil {
+		return nil, err
+	}
+	if len(all) == 0 {
+		return all, nil
+	}
+
+	assigned, err := s.assignments(ctx, teacherID, all[0].CourseID)
+	if err != nil {
+		return nil, err
+	}
+	visible := make([]SessionDiary, 0, len(all))
+	for i := range all {
+		canRead, canEdit := access(teacherID, &all[i], assigned)
+		if !canRead {
+			continue
+		}
+		all[i].CanEdit = canEdit
+		visible = append(visible, all[i])
+	}
+	if len(visible) == 0 {
+		return nil, ErrNotTeacher
+	}
+	return visible, nil
+}
+
+// Get returns the diary of a session
+func (s *service) Get(ctx context.Context, sessionID uint) (*SessionDiary, error) {
+	var rows []sessionRow
+	if err := s.db.WithContext(ctx).Raw(sessionQuery+" WHERE cs.id = ?", sessionID).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load class session: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, ErrSessionNotFound
+	}
+
+	diaries, err := s.withEntries(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	return &diaries[0], nil
+}
+
+// ListClass lists the sessions of a class in the period with their diary
+func (s *service) ListClass(ctx context.Context, classID uint, period Period) ([]SessionDiary, error) {
+	if _, err := s.class(ctx, classID); err != nil {
+		return nil, err
+	}
+
+	query := sessionQuery + " WHERE cs.course_class_id = ?"
+	args := []interface{}{classID}
+	if period.StartDate != nil {
+		query += " AND cs.date >= ?"
+		args = append(args, *period.StartDate)
+	}
+	if period.EndDate != nil {
+		query += " AND cs.date < ?"
+		args = append(args, period.EndDate.AddDate(0, 0, 1))
+	}
+	query += " ORDER BY cs.date ASC, cs.id ASC"
+
+	var rows []sessionRow
+	if err := s.db.WithContext(ctx).Raw(query, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list class sessions: %w", err)
+	}
+	return s.withEntries(ctx, rows)
+}
+
+// Register builds the class register: the sessions with their diary and attendance counts
+func (s *service) Register(ctx context.Context, classID uint, period Period) (*ClassRegister, error) {
+	class, err := s.class(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	diaries, err := s.ListClass(ctx, classID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.attendanceCounts(ctx, diaries)
+	if err != nil {
+		return nil, err
+	}
+
+	register := &ClassRegister{
+		CourseClassID: class.ID,
+		ClassName:     class.Name,
+		ClassCode:     class.Code,
+		CourseName:    class.Course.Name,
+		StartDate:     period.StartDate,
+		EndDate:       period.EndDate,
+		Sessions:      make([]RegisterSession, 0, len(diaries)),
+	}
+	today := day(s.now())
+	for _, diary := range diaries {
+		line := RegisterSession{SessionDiary: diary}
+		if count, ok := counts[diary.SessionID]; ok {
+			line.Present, line.Absent, line.Partial = count.Present, count.Absent, count.Partial
+		}
+		register.Sessions = append(register.Sessions, line)
+
+		register.Summary.TotalSessions++
+		switch {
+		case diary.IsCancelled:
+			register.Summary.Cancelled++
+		case day(diary.Date) <= today:
+			register.Summary.Given++
+			if diary.Entry == nil {
+				register.Summary.Missing++
+			}
+		}
+		if diary.Entry != nil {
+			register.Summary.Recorded++
+		}
+	}
+	return register, nil
+}
+
+// attendanceCount is the attendance of the class students on the session day
+type attendanceCount struct {
+	SessionID uint
+	Present   int
+	Absent    int
+	Partial   int
+}
+
+func (s *service) attendanceCounts(ctx context.Context, diaries []SessionDiary) (map[uint]attendanceCount, error) {
+	counts := make(map[uint]attendanceCount)
+	if len(diaries) == 0 {
+		return counts, nil
+	}
+	ids := make([]uint, len(diaries))
+	for i, diary := range diaries {
+		ids[i] = diary.SessionID
+	}
+
+	var rows []attendanceCount
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT
+			cs.id AS session_id,
+			COUNT(a.id) FILTER (WHERE a.status = 'present') AS present,
+			COUNT(a.id) FILTER (WHERE a.status = 'absent') AS absent,
+			COUNT(a.id) FILTER (WHERE a.status = 'partial') AS partial
+		FROM class_sessions cs
+		INNER JOIN enrollment_course_classes ecc ON ecc.course_class_id = cs.course_class_id
+		INNER JOIN attendances a ON a.enrollment_id = ecc.enrollment_id AND a.date::date = cs.date::date
+		WHERE cs.id IN ?
+		GROUP BY cs.id
+	`, ids).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count attendance: %w", err)
+	}
+	for _, row := range rows {
+		counts[row.SessionID] = row
+	}
+	return counts, nil
+}
+
+// withEntries attaches the diary entries to the sessions
+func (s *service) withEntries(ctx context.Context, rows []sessionRow) ([]SessionDiary, error) {
+	diaries := make([]SessionDiary, len(rows))
+	if len(rows) == 0 {
+		return diaries, nil
+	}
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.SessionID
+	}
+
+	var entries []entryRow
+	if err := s.db.WithContext(ctx).Table("lesson_diary_entries e").
+		Select("e.*, COALESCE(st.title, '') AS topic_title, COALESCE(u.name, '') AS author_name").
+		Joins("LEFT JOIN syllabus_topics st ON st.id = e.syllabus_topic_id").
+		Joins("LEFT JOIN teachers t ON t.id = e.teacher_id").
+		Joins("LEFT JOIN users u ON u.id = t.user_id").
+		Where("e.class_session_id IN ?", ids).
+		Scan(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to load lesson diary: %w", err)
+	}
+	bySession := make(map[uint]entryRow, len(entries))
+	for _, entry := range entries {
+		bySession[entry.ClassSessionID] = entry
+	}
+
+	for i, row := range rows {
+		diaries[i] = row.diary()
+		if entry, ok := bySession[row.SessionID]; ok {
+			value := entry.LessonDiaryEntry
+			diaries[i].Entry = &value
+			diaries[i].TopicTitle = entry.TopicTitle
+			diaries[i].AuthorName = entry.AuthorName
+		}
+	}
+	return diaries, nil
+}
+
+func (s *service) class(ctx context.Context, classID uint) (*models.CourseClass, error) {
+	var class models.CourseClass
+	if err := s.db.WithContext(ctx).Preload("Course").First(&class, classID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrClassNotFound
+		}
+		return nil, err
+	}
+	return &class, nil
+}
+
+// assignments loads the active assignments of the teacher in the course (any period)
+func (s *service) assignments(ctx context.Context, teacherID uint, courseID uint) ([]assignmentRow, error) {
+	var rows []assignmentRow
+	if err := s.db.WithContext(ctx).Model(&models.TeacherCourse{}).
+		Select("course_class_id, role, start_date, end_date").
+		Where("teacher_id = ? AND course_id = ? AND active = ?", teacherID, courseID, true).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load teacher assignments: %w", err)
+	}
+	return rows, nil
+}
+
+func (s *service) teacherIDForUser(ctx context.Context, userID uint) (uint, error) {
+	var teacher models.Teacher
+	if err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&teacher).Error; err != nil {
+		return 0, ErrNotTeacher
+	}
+	return teacher.ID, nil
+}
+
+// access decides what a teacher may do with the diary of a session. The effective teacher
+// (the substitute, when the session has one) edits it; without a substitute, a primary or
+// substitute assignment valid on the day also does. Assistants and the default teacher of a
+// substituted session only read it.
+func access(teacherID uint, diary *SessionDiary, assigned []assignmentRow) (canRead, canEdit bool) {
+	if diary.TeacherID != nil && *diary.TeacherID == teacherID {
+		return true, true
+	}
+	if diary.defaultTeacherID != nil && *diary.defaultTeacherID == teacherID {
+		canRead = true
+	}
+
+	date := day(diary.Date)
+	for _, assignment := range assigned {
+		if assignment.CourseClassID != nil && (diary.CourseClassID == nil || *assignment.CourseClassID != *diary.CourseClassID) {
+			continue
+		}
+		if day(assignment.StartDate) > date || (assignment.EndDate != nil && day(*assignment.EndDate) < date) {
+			continue
+		}
+		canRead = true
+		if !diary.IsSubstituted && editorRoles[assignment.Role] {
+			canEdit = true
+		}
+	}
+	return canRead, canEdit
+}
+
+// buildEntry validates the input; the planned topic is used when none is informed
+func buildEntry(input EntryInput, plannedTopicID *uint) (*models.LessonDiaryEntry, error) {
+	entry := &models.LessonDiaryEntry{
+		SyllabusTopicID: input.SyllabusTopicID,
+		TopicStatus:     strings.TrimSpace(input.TopicStatus),
+		Content:         strings.TrimSpace(input.Content),
+		Homework:        strings.TrimSpace(input.Homework),
+		Observations:    strings.TrimSpace(input.Observations),
+	}
+	if entry.Content == "" {
+		return nil, fmt.Errorf("%w: content is required", ErrInvalidEntry)
+	}
+	if entry.TopicStatus == "" {
+		entry.TopicStatus = TopicCovered
+	}
+	if !validTopicStatuses[entry.TopicStatus] {
+		return nil, fmt.Errorf("%w: topicStatus must be %s, %s or %s", ErrInvalidEntry, TopicCovered, TopicPartial, TopicNotCovered)
+	}
+	if entry.SyllabusTopicID == nil {
+		entry.SyllabusTopicID = plannedTopicID
+	}
+	return entry, nil
+}
+
+// day formats the calendar day, so dates stored at different times compare by day
+func day(t time.Time) string {
+	return t.Format("2006-01-02")
+}
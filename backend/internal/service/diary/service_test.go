@@ -0,0 +1,74 @@
+package diary
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAccess(t *testing.T) {
+	classID, otherClassID := uint(7), uint(8)
+	defaultTeacher, substitute, assistant := uint(1), uint(2), uint(3)
+	date := time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)
+	period := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	regular := &SessionDiary{CourseClassID: &classID, Date: date, TeacherID: &defaultTeacher, defaultTeacherID: &defaultTeacher}
+	substituted := &SessionDiary{CourseClassID: &classID, Date: date, TeacherID: &substitute, IsSubstituted: true, defaultTeacherID: &defaultTeacher}
+
+	t.Run("the substitute edits and the default teacher only reads", func(t *testing.T) {
+		if read, edit := access(substitute, substituted, nil); !read || !edit {
+			t.Errorf("Expected the substitute to edit, got read=%v edit=%v", read, edit)
+		}
+		primary := []assignmentRow{{CourseClassID: &classID, Role: "primary", StartDate: period}}
+		if read, edit := access(defaultTeacher, substituted, primary); !read || edit {
+			t.Errorf("Expected the default teacher to only read, got read=%v edit=%v", read, edit)
+		}
+	})
+
+	t.Run("assistants read the sessions of their class in the period", func(t *testing.T) {
+		helping := []assignmentRow{{CourseClassID: &classID, Role: "assistant", StartDate: period}}
+		if read, edit := access(assistant, regular, helping); !read || edit {
+			t.Errorf("Expected the assistant to only read, got read=%v edit=%v", read, edit)
+		}
+
+		ended := period.AddDate(0, 0, 5)
+		past := []assignmentRow{{CourseClassID: &classID, Role: "assistant", StartDate: period, EndDate: &ended}}
+		if read, _ := access(assistant, regular, past); read {
+			t.Error("Expected no access after the assignment ended")
+		}
+
+		elsewhere := []assignmentRow{{CourseClassID: &otherClassID, Role: "assistant", StartDate: period}}
+		if read, _ := access(assistant, regular, elsewhere); read {
+			t.Error("Expected no access through another class")
+		}
+	})
+
+	t.Run("a primary assignment valid on the day edits", func(t *testing.T) {
+		courseWide := []assignmentRow{{Role: "primary", StartDate: period}}
+		if read, edit := access(assistant, regular, courseWide); !read || !edit {
+			t.Errorf("Expected the primary to edit, got read=%v edit=%v", read, edit)
+		}
+	})
+}
+
+func TestBuildEntry(t *testing.T) {
+	planned := uint(4)
+
+	entry, err := buildEntry(EntryInput{Content: "  Verbo to be  "}, &planned)
+	if err != nil {
+		t.Fatalf("Expected a valid entry, got %v", err)
+	}
+	if entry.Content != "Verbo to be" || entry.TopicStatus != TopicCovered {
+		t.Errorf("Expected trimmed content and covered topic, got %q %q", entry.Content, entry.TopicStatus)
+	}
+	if entry.SyllabusTopicID == nil || *entry.SyllabusTopicID != planned {
+		t.Errorf("Expected the planned topic, got %v", entry.SyllabusTopicID)
+	}
+
+	if _, err := buildEntry(EntryInput{Content: " "}, nil); !errors.Is(err, ErrInvalidEntry) {
+		t.Errorf("Expected ErrInvalidEntry without content, got %v", err)
+	}
+	if _, err := buildEntry(EntryInput{Content: "x", TopicStatus: "done"}, nil); !errors.Is(err, ErrInvalidEntry) {
+		t.Errorf("Expected ErrInvalidEntry for an unknown status, got %v", err)
+	}
+}
@@ -0,0 +1,323 @@
+// backend/internal/service/documents/service.go
+package documents
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/infrastructure/storage"
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+// sniffLength is how much of the file http.DetectContentType looks at
+const sniffLength = 512
+
+// allowedTypes maps the accepted content types (detected from the bytes, never from the client) to the stored extension
+var allowedTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+}
+
+var (
+	// ErrStudentNotFound is returned when the student does not exist
+	ErrStudentNotFound = errors.New("student not found")
+	// ErrDocumentNotFound is returned when the document does not exist
+	ErrDocumentNotFound = errors.New("document not found")
+	// ErrForbidden is returned when the user cannot access the documents of the student
+	ErrForbidden = errors.New("not allowed to access the documents of this student")
+	// ErrInvalidDocument is returned for malformed uploads
+	ErrInvalidDocument = errors.New("invalid document")
+	// ErrTooLarge is returned when the file exceeds the upload limit
+	ErrTooLarge = errors.New("document exceeds the size limit")
+	// ErrUnsupportedType is returned when the file content is not an accepted type
+	ErrUnsupportedType = errors.New("unsupported document type")
+	// ErrInvalidLink is returned for download links with a bad signature or past their expiry
+	ErrInvalidLink = errors.New("invalid or expired download link")
+)
+
+// Viewer is the user acting on the documents
+type Viewer struct {
+	UserID uint
+	Admin  bool
+}
+
+// UploadInput is a file received for a student
+type UploadInput struct {
+	StudentID uint
+	Name      string // Nome de exibição (padrão: nome do arquivo)
+	Type      string // Tipo do documento (ex: rg, cpf, comprovante_residencia)
+	FileName  string
+	File      io.Reader
+	Size      int64
+}
+
+// Link is a time-limited download link
+type Link struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Content is an opened document; the caller closes Body
+type Content struct {
+	Document models.Document
+	Body     io.ReadCloser
+}
+
+// Service defines the interface for student document files
+type Service interface {
+	Upload(ctx context.Context, input UploadInput, viewer Viewer) (*models.Document, error)
+	Delete(ctx context.Context, id uint, viewer Viewer) error
+	Link(ctx context.Context, id uint, viewer Viewer) (*Link, error)
+	Open(ctx context.Context, id uint, expires string, signature string) (*Content, error)
+	MaxSize() int64
+}
+
+// service implements the Service interface
+type service struct {
+	db        *gorm.DB
+	store     storage.Storage
+	maxSize   int64
+	linkKey   []byte
+	linkTTL   time.Duration
+	publicURL string
+	now       func() time.Time
+}
+
+// NewService creates a new document service. Files up to maxSize bytes are kept in store and
+// downloaded through links to publicURL signed with linkKey and valid for linkTTL.
+func NewService(db *gorm.DB, store storage.Storage, maxSize int64, linkKey string, linkTTL time.Duration, publicURL string) Service {
+	return &service{
+		db:        db,
+		store:     store,
+		maxSize:   maxSize,
+		linkKey:   []byte(linkKey),
+		linkTTL:   linkTTL,
+		publicURL: strings.TrimRight(publicURL, "/"),
+		now:       time.Now,
+	}
+}
+
+// MaxSize returns the upload limit in bytes
+func (s *service) MaxSize() int64 {
+	return s.maxSize
+}
+
+// Upload checks the content type from the file bytes, stores the file and records the document
+func (s *service) Upload(ctx context.Context, input UploadInput, viewer Viewer) (*models.Document, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		name = strings.TrimSpace(input.FileName)
+	}
+	docType := strings.TrimSpace(input.Type)
+	if name == "" || docType == "" {
+		return nil, fmt.Errorf("%w: name and type are required", ErrInvalidDocument)
+	}
+	if input.File == nil || input.Size <= 0 {
+		return nil, fmt.Errorf("%w: the file is empty", ErrInvalidDocument)
+	}
+	if input.Size > s.maxSize {
+		return nil, fmt.Errorf("%w: %d bytes, limit is %d", ErrTooLarge, input.Size, s.maxSize)
+	}
+	if err := s.authorize(ctx, input.StudentID, viewer); err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(input.File, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read the file: %w", err)
+	}
+	contentType, ext, err := sniff(head[:n])
+	if err != nil {
+		return nil, err
+	}
+
+	suffix, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("students/%d/%s%s", input.StudentID, suffix, ext)
+	hash := sha256.New()
+	body := io.TeeReader(io.MultiReader(bytes.NewReader(head[:n]), input.File), hash)
+	if err := s.store.Put(ctx, key, body, input.Size, contentType); err != nil {
+		return nil, fmt.Errorf("failed to store the file: %w", err)
+	}
+
+	document := models.Document{
+		StudentID:    input.StudentID,
+		Name:         name,
+		Type:         docType,
+		Path:         key,
+		ContentType:  contentType,
+		Size:         input.Size,
+		Checksum:     hex.EncodeToString(hash.Sum(nil)),
+		UploadedByID: viewer.UserID,
+	}
+	if err := s.db.WithContext(ctx).Create(&document).Error; err != nil {
+		s.store.Delete(ctx, key)
+		return nil, fmt.Errorf("error adding document: %w", err)
+	}
+	return &document, nil
+}
+
+// Delete removes the record and the stored file
+func (s *service) Delete(ctx context.Context, id uint, viewer Viewer) error {
+	document, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !viewer.Admin && document.UploadedByID != viewer.UserID {
+		return ErrForbidden
+	}
+
+	if err := s.db.WithContext(ctx).Delete(&models.Document{}, id).Error; err != nil {
+		return fmt.Errorf("error removing document: %w", err)
+	}
+	if err := s.store.Delete(ctx, document.Path); err != nil && !errors.Is(err, storage.ErrInvalidKey) {
+		// O registro já foi removido; o arquivo órfão não fica acessível por nenhum link
+		fmt.Printf("Warning: failed to delete the file of document %d: %v\n", id, err)
+	}
+	return nil
+}
+
+// Link checks the access to the student and signs a download link
+func (s *service) Link(ctx context.Context, id uint, viewer Viewer) (*Link, error) {
+	document, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, document.StudentID, viewer); err != nil {
+		return nil, err
+	}
+
+	expiresAt := s.now().Add(s.linkTTL).Truncate(time.Second)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	return &Link{
+		URL:       fmt.Sprintf("%s/api/v1/documents/%d/content?expires=%s&signature=%s", s.publicURL, id, expires, s.sign(id, expires)),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Open validates a download link and opens the file
+func (s *service) Open(ctx context.Context, id uint, expires string, signature string) (*Content, error) {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(s.sign(id, expires))) || s.now().Unix() > expiresAt {
+		return nil, ErrInvalidLink
+	}
+
+	document, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	body, err := s.store.Get(ctx, document.Path)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+			return nil, ErrDocumentNotFound
+		}
+		return nil, err
+	}
+	return &Content{Document: *document, Body: body}, nil
+}
+
+// authorize allows the coordination, the student and the teachers of the classes the student is enrolled in
+func (s *service) authorize(ctx context.Context, studentID uint, viewer Viewer) error {
+	var student struct {
+		ID     uint
+		UserID uint
+	}
+	if err := s.db.WithContext(ctx).Table("students").
+		Select("id, user_id").
+		Where("id = ? AND deleted_at IS NULL", studentID).
+		Scan(&student).Error; err != nil {
+		return err
+	}
+	if student.ID == 0 {
+		return ErrStudentNotFound
+	}
+	if viewer.Admin || (viewer.UserID != 0 && viewer.UserID == student.UserID) {
+		return nil
+	}
+	if viewer.UserID == 0 {
+		return ErrForbidden
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT COUNT(*) FROM enrollments e
+		INNER JOIN enrollment_course_classes ecc ON ecc.enrollment_id = e.id
+		INNER JOIN course_classes cc ON cc.id = ecc.course_class_id
+		WHERE e.student_id = ? AND e.deleted_at IS NULL AND e.status <> 'cancelled' AND (
+			cc.default_teacher_id IN (SELECT id FROM teachers WHERE user_id = ?)
+			OR EXISTS (
+				SELECT 1 FROM teacher_courses tc
+				INNER JOIN teachers t ON t.id = tc.teacher_id
+				WHERE t.user_id = ?
+					AND tc.active = true
+					AND tc.course_id = cc.course_id
+					AND (tc.course_class_id IS NULL OR tc.course_class_id = cc.id)
+					AND tc.start_date <= CURRENT_DATE
+					AND (tc.end_date IS NULL OR tc.end_date >= CURRENT_DATE)
+			)
+		)
+	`, studentID, viewer.UserID, viewer.UserID).Scan(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func (s *service) find(ctx context.Context, id uint) (*models.Document, error) {
+	var document models.Document
+	if err := s.db.WithContext(ctx).First(&document, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDocumentNotFound
+		}
+		return nil, err
+	}
+	return &document, nil
+}
+
+// sign authenticates the document and the expiry of a download link
+func (s *service) sign(id uint, expires string) string {
+	mac := hmac.New(sha256.New, s.linkKey)
+	fmt.Fprintf(mac, "%d|%s", id, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// sniff detects the content type from the first bytes of the file
+func sniff(head []byte) (string, string, error) {
+	contentType := http.DetectContentType(head)
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	ext, ok := allowedTypes[contentType]
+	if !ok {
+		return "", "", fmt.Errorf("%w: %s (accepted: PDF, JPEG, PNG, WebP)", ErrUnsupportedType, contentType)
+	}
+	return contentType, ext, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,53 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSniff(t *testing.T) {
+	cases := map[string]string{
+		"%PDF-1.7\n%âãÏÓ":              "application/pdf",
+		"\xff\xd8\xff\xe0\x00\x10JFIF": "image/jpeg",
+		"\x89PNG\x0d\x0a\x1a\x0a":      "image/png",
+		"RIFF\x00\x00\x00\x00WEBPVP":   "image/webp",
+	}
+	for head, want := range cases {
+		contentType, ext, err := sniff([]byte(head))
+		if err != nil || contentType != want || ext == "" {
+			t.Errorf("Expected %s, got %q %q (%v)", want, contentType, ext, err)
+		}
+	}
+
+	for _, head := range []string{"<html><script>alert(1)</script>", "MZ\x90\x00", "texto simples"} {
+		if _, _, err := sniff([]byte(head)); !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("Expected ErrUnsupportedType for %q, got %v", head, err)
+		}
+	}
+}
+
+func TestOpenRejectsInvalidLinks(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	s := &service{linkKey: []byte("key"), now: func() time.Time { return now }}
+	ctx := context.Background()
+
+	expired := strconv.FormatInt(now.Add(-time.Second).Unix(), 10)
+	if _, err := s.Open(ctx, 7, expired, s.sign(7, expired)); !errors.Is(err, ErrInvalidLink) {
+		t.Errorf("Expected ErrInvalidLink for an expired link, got %v", err)
+	}
+
+	valid := strconv.FormatInt(now.Add(time.Minute).Unix(), 10)
+	if _, err := s.Open(ctx, 8, valid, s.sign(7, valid)); !errors.Is(err, ErrInvalidLink) {
+		t.Errorf("Expected ErrInvalidLink for a link of another document, got %v", err)
+	}
+	later := strconv.FormatInt(now.Add(time.Hour).Unix(), 10)
+	if _, err := s.Open(ctx, 7, later, s.sign(7, valid)); !errors.Is(err, ErrInvalidLink) {
+		t.Errorf("Expected ErrInvalidLink for an extended expiry, got %v", err)
+	}
+	if _, err := s.Open(ctx, 7, "abc", s.sign(7, "abc")); !errors.Is(err, ErrInvalidLink) {
+		t.Errorf("Expected ErrInvalidLink for a malformed expiry, got %v", err)
+	}
+}
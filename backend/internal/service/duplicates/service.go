@@ -0,0 +1,825 @@
+// backend/internal/service/duplicates/service.go
+package duplicates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/pkg/cpf"
+	"github.com/devdavidalonso/cecor/backend/pkg/fieldcrypt"
+)
+
+// Match reasons, strongest first
+const (
+	ReasonCPF           = "cpf"
+	ReasonEmail         = "email"
+	ReasonPhone         = "phone"
+	ReasonNameBirthDate = "name_birth_date"
+)
+
+// reasonWeight orders the candidates: the same CPF is almost certainly the same person, while a
+// shared phone is often a family number
+var reasonWeight = map[string]int{
+	ReasonCPF:           8,
+	ReasonEmail:         4,
+	ReasonNameBirthDate: 3,
+	ReasonPhone:         1,
+}
+
+// studentTables are the tables whose student_id moves to the surviving student
+var studentTables = []string{
+	"enrollments",
+	"waiting_list",
+	"registrations",
+	"attendances",
+	"absence_justifications",
+	"absence_alerts",
+	"certificates",
+	"guardians",
+	"documents",
+	"student_notes",
+	"incidents",
+	"user_contacts",
+	"guardian_consents",
+	"pickup_records",
+	"student_suspensions",
+	"student_status_changes",
+}
+
+// userTables are the tables whose user_id moves to the user of the surviving student
+var userTables = []string{
+	"user_contacts",
+	"notifications",
+	"interviews",
+	"form_responses",
+}
+
+// nameParticles are ignored when comparing names ("Maria da Silva" = "Maria Silva")
+var nameParticles = map[string]bool{"da": true, "das": true, "de": true, "di": true, "do": true, "dos": true, "e": true}
+
+var (
+	// ErrStudentNotFound is returned when a student does not exist or was already merged
+	ErrStudentNotFound = errors.New("student not found")
+	// ErrMergeNotFound is returned when the merge record does not exist
+	ErrMergeNotFound = errors.New("merge not found")
+	// ErrInvalidMerge is returned for malformed merge or dismissal requests
+	ErrInvalidMerge = errors.New("invalid merge")
+	// ErrMergeConflict is returned when both students have records that cannot simply be moved
+	// (enrollments in the same course for overlapping periods, attendance on the same class day,
+	// pickups of the same class session)
+	ErrMergeConflict = errors.New("merge conflicts must be resolved first")
+)
+
+// ResponseMover moves the interview responses kept in MongoDB (implemented by mongodb.FormRepository)
+type ResponseMover interface {
+	ReassignResponses(ctx context.Context, fromStudentID, toStudentID uint) (int64, error)
+}
+
+// StudentSummary identifies a student in a candidate pair
+type StudentSummary struct {
+	ID                 uint       `json:"id"`
+	UserID             uint       `json:"userId"`
+	RegistrationNumber string     `json:"registrationNumber"`
+	Status             string     `json:"status"`
+	Name               string     `json:"name"`
+	Email              string     `json:"email"`
+	CPF                string     `json:"cpf"`
+	Phone              string     `json:"phone"`
+	BirthDate          *time.Time `json:"birthDate"`
+	CreatedAt          time.Time  `json:"createdAt"`
+}
+
+// Candidate is a pair of students that may be the same person
+type Candidate struct {
+	Students [2]StudentSummary `json:"students"` // Menor ID (cadastro mais antigo) primeiro
+	Reasons  []string          `json:"reasons"`
+	Score    int               `json:"score"`
+}
+
+// MergeInput keeps SurvivorID and removes DuplicateID
+type MergeInput struct {
+	SurvivorID  uint   `json:"survivorId"`
+	DuplicateID uint   `json:"duplicateId"`
+	Reason      string `json:"reason"`
+}
+
+// Service defines the interface for duplicate detection and merge of student registrations
+type Service interface {
+	Detect(ctx context.Context) ([]Candidate, error)
+	Dismiss(ctx context.Context, studentA, studentB uint, actorID uint) error
+	Merge(ctx context.Context, input MergeInput, actorID uint) (*models.StudentMerge, error)
+	ListMerges(ctx context.Context, studentID uint) ([]models.StudentMerge, error)
+	GetMerge(ctx context.Context, id uint) (*models.StudentMerge, error)
+	// RetryPendingResponses moves the interview responses of the merges whose move failed
+	RetryPendingResponses(ctx context.Context) (int, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db        *gorm.DB
+	responses ResponseMover
+	now       func() time.Time
+}
+
+// NewService creates a new duplicate detection and merge service
+func NewService(db *gorm.DB, responses ResponseMover) Service {
+	return &service{db: db, responses: responses, now: time.Now}
+}
+
+// record is a registered student with the user data used for matching
+type record struct {
+	StudentID          uint
+	UserID             uint
+	RegistrationNumber string
+	Status             string
+	Name               string
+	Email              string
+	CPF                string `gorm:"serializer:encrypted"`
+	Phone              string
+	BirthDate          *time.Time
+	CreatedAt          time.Time
+}
+
+// snapshotSensitiveKeys are the snapshot fields stored encrypted in the audit trail (the same
+// keys are covered by migrations.RotateFieldKeys)
+var snapshotSensitiveKeys = []string{"specialNeeds", "medicalInfo", "cpf"}
+
+// snapshot is the state of a student and its user kept in the audit trail (no credentials)
+type snapshot struct {
+	StudentID          uint       `json:"studentId"`
+	RegistrationNumber string     `json:"registrationNumber"`
+	Status             string     `json:"status"`
+	SpecialNeeds       string     `json:"specialNeeds" gorm:"serializer:encrypted"`
+	MedicalInfo        string     `json:"medicalInfo" gorm:"serializer:encrypted"`
+	Notes              string     `json:"notes"`
+	UserID             uint       `json:"userId"`
+	Name               string     `json:"name"`
+	Email              string     `json:"email"`
+	CPF                string     `json:"cpf" gorm:"serializer:encrypted"`
+	Phone              string     `json:"phone"`
+	BirthDate          *time.Time `json:"birthDate"`
+	PhotoURL           string     `json:"photoUrl"`
+	Active             bool       `json:"active"`
+}
+
+// Detect lists the pairs of active students sharing a CPF, email, phone or normalized name
+// plus birth date, leaving out the pairs dismissed by the coordination
+func (s *service) Detect(ctx context.Context) ([]Candidate, error) {
+	var records []record
+	if err := s.db.WithContext(ctx).Table("students s").
+		Select(`s.id AS student_id, s.user_id, s.registration_number, s.status, s.created_at,
+			u.name, u.email, COALESCE(u.cpf, '') AS cpf, COALESCE(u.phone, '') AS phone, u.birth_date`).
+		Joins("INNER JOIN users u ON u.id = s.user_id").
+		Where("s.deleted_at IS NULL AND u.deleted_at IS NULL").
+		Order("s.id").
+		Scan(&records).Error; err != nil {
+		return nil, fmt.Errorf("error loading students: %w", err)
+	}
+
+	var dismissals []models.StudentDuplicateDismissal
+	if err := s.db.WithContext(ctx).Find(&dismissals).Error; err != nil {
+		return nil, fmt.Errorf("error loading dismissals: %w", err)
+	}
+	dismissed := make(map[[2]uint]bool, len(dismissals))
+	for _, d := range dismissals {
+		dismissed[[2]uint{d.StudentAID, d.StudentBID}] = true
+	}
+
+	return findCandidates(records, dismissed), nil
+}
+
+// Dismiss marks a pair as not being the same person
+func (s *service) Dismiss(ctx context.Context, studentA, studentB uint, actorID uint) error {
+	if studentA == 0 || studentB == 0 || studentA == studentB {
+		return fmt.Errorf("%w: two different students are required", ErrInvalidMerge)
+	}
+	pair := orderedPair(studentA, studentB)
+
+	var count int64
+	if err := s.db.WithContext(ctx).Table("students").Where("id IN ?", pair[:]).Count(&count).Error; err != nil {
+		return err
+	}
+	if count != 2 {
+		return ErrStudentNotFound
+	}
+
+	dismissal := models.StudentDuplicateDismissal{StudentAID: pair[0], StudentBID: pair[1], DismissedByID: actorID}
+	if err := s.db.WithContext(ctx).
+		Where("student_a_id = ? AND student_b_id = ?", pair[0], pair[1]).
+		FirstOrCreate(&dismissal).Error; err != nil {
+		return fmt.Errorf("error dismissing pair: %w", err)
+	}
+	return nil
+}
+
+// Merge moves everything linked to the duplicate student and its user to the surviving ones,
+// completes the blank fields of the survivor, removes the duplicate and records the audit trail.
+// Records of both students that would clash once moved are listed in ErrMergeConflict instead.
+// The interview responses in MongoDB are moved after the commit; if that fails the merge keeps
+// ResponsesPending and RetryPendingResponses moves them later.
+func (s *service) Merge(ctx context.Context, input MergeInput, actorID uint) (*models.StudentMerge, error) {
+	if input.SurvivorID == 0 || input.DuplicateID == 0 || input.SurvivorID == input.DuplicateID {
+		return nil, fmt.Errorf("%w: survivorId and duplicateId must be two different students", ErrInvalidMerge)
+	}
+
+	var merge models.StudentMerge
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		survivor, err := loadSnapshot(tx, input.SurvivorID)
+		if err != nil {
+			return err
+		}
+		duplicate, err := loadSnapshot(tx, input.DuplicateID)
+		if err != nil {
+			return err
+		}
+		if err := checkConflicts(tx, survivor.StudentID, duplicate.StudentID); err != nil {
+			return err
+		}
+
+		moved := map[string][]uint{}
+		for _, table := range studentTables {
+			ids, err := reassign(tx, table, "student_id", duplicate.StudentID, survivor.StudentID)
+			if err != nil {
+				return err
+			}
+			if len(ids) > 0 {
+				moved[table] = ids
+			}
+		}
+		for _, table := range userTables {
+			ids, err := reassign(tx, table, "user_id", duplicate.UserID, survivor.UserID)
+			if err != nil {
+				return err
+			}
+			if len(ids) > 0 {
+				moved[table+".user_id"] = ids
+			}
+		}
+
+		var feedIDs []uint
+		if err := tx.Raw(`UPDATE calendar_feed_tokens SET owner_id = ?, updated_at = ?
+			WHERE owner_type = ? AND owner_id = ? RETURNING id`,
+			survivor.StudentID, s.now(), models.CalendarFeedOwnerStudent, duplicate.StudentID).Scan(&feedIDs).Error; err != nil {
+			return fmt.Errorf("error moving calendar feeds: %w", err)
+		}
+		if len(feedIDs) > 0 {
+			moved["calendar_feed_tokens"] = feedIDs
+		}
+
+		// addresses.user_id é único: o endereço do duplicado só é aproveitado se o mantido não tiver
+		var addressIDs []uint
+		if err := tx.Raw(`UPDATE addresses SET user_id = ?
+			WHERE user_id = ? AND NOT EXISTS (SELECT 1 FROM addresses WHERE user_id = ?) RETURNING id`,
+			survivor.UserID, duplicate.UserID, survivor.UserID).Scan(&addressIDs).Error; err != nil {
+			return fmt.Errorf("error moving address: %w", err)
+		}
+		if len(addressIDs) > 0 {
+			moved["addresses"] = addressIDs
+		}
+
+		merged := consolidate(*survivor, *duplicate)
+		if err := s.applyConsolidation(tx, *survivor, *duplicate, merged); err != nil {
+			return err
+		}
+
+		now := s.now()
+		if err := tx.Table("students").Where("id = ?", duplicate.StudentID).Updates(map[string]interface{}{
+			"status":     models.StudentStatusInactive,
+			"deleted_at": now,
+			"updated_at": now,
+		}).Error; err != nil {
+			return fmt.Errorf("error removing duplicate student: %w", err)
+		}
+		if err := tx.Table("users").Where("id = ?", duplicate.UserID).Updates(map[string]interface{}{
+			"active":     false,
+			"deleted_at": now,
+			"updated_at": now,
+		}).Error; err != nil {
+			return fmt.Errorf("error removing duplicate user: %w", err)
+		}
+
+		movedJSON, _ := json.Marshal(moved)
+		duplicateJSON, err := sealSnapshot(*duplicate)
+		if err != nil {
+			return err
+		}
+		merge = models.StudentMerge{
+			SurvivorStudentID:  survivor.StudentID,
+			DuplicateStudentID: duplicate.StudentID,
+			SurvivorUserID:     survivor.UserID,
+			DuplicateUserID:    duplicate.UserID,
+			Reason:             strings.TrimSpace(input.Reason),
+			DuplicateSnapshot:  string(duplicateJSON),
+			Moved:              string(movedJSON),
+			ResponsesPending:   s.responses != nil,
+			MergedByID:         actorID,
+		}
+		if err := tx.Create(&merge).Error; err != nil {
+			return fmt.Errorf("error recording merge: %w", err)
+		}
+
+		survivorJSON, err := sealSnapshot(*survivor)
+		if err != nil {
+			return err
+		}
+		mergedJSON, err := sealSnapshot(merged)
+		if err != nil {
+			return err
+		}
+		removedJSON, _ := json.Marshal(map[string]interface{}{"mergedInto": survivor.StudentID, "mergeId": merge.ID})
+		logs := []models.AuditLog{
+			{EntityType: "student", EntityID: survivor.StudentID, Action: "Merge", UserID: actorID, OldData: string(survivorJSON), NewData: string(mergedJSON)},
+			{EntityType: "student", EntityID: duplicate.StudentID, Action: "Merge", UserID: actorID, OldData: string(duplicateJSON), NewData: string(removedJSON)},
+		}
+		if err := tx.Create(&logs).Error; err != nil {
+			return fmt.Errorf("error recording audit log: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if merge.ResponsesPending {
+		if err := s.moveResponses(ctx, &merge); err != nil {
+			fmt.Printf("Warning: interview responses of merge %d will be moved later: %v\n", merge.ID, err)
+		}
+	}
+	return &merge, nil
+}
+
+// RetryPendingResponses moves the interview responses left pending by merges, oldest first, and
+// returns how many merges were completed
+func (s *service) RetryPendingResponses(ctx context.Context) (int, error) {
+	if s.responses == nil {
+		return 0, nil
+	}
+
+	var pending []models.StudentMerge
+	if err := s.db.WithContext(ctx).Where("responses_pending = ?", true).Order("id").Find(&pending).Error; err != nil {
+		return 0, fmt.Errorf("error loading pending merges: %w", err)
+	}
+
+	completed := 0
+	for i := range pending {
+		if err := s.moveResponses(ctx, &pending[i]); err != nil {
+			return completed, err
+		}
+		completed++
+	}
+	return completed, nil
+}
+
+// moveResponses moves the interview responses of the duplicate to the current survivor (the
+// survivor may itself have been merged since) and clears ResponsesPending
+func (s *service) moveResponses(ctx context.Context, merge *models.StudentMerge) error {
+	target, err := s.finalSurvivor(ctx, merge.SurvivorStudentID)
+	if err != nil {
+		return err
+	}
+	count, err := s.responses.ReassignResponses(ctx, merge.DuplicateStudentID, target)
+	if err != nil {
+		return fmt.Errorf("error moving interview responses of merge %d: %w", merge.ID, err)
+	}
+
+	if err := s.db.WithContext(ctx).Model(merge).Updates(map[string]interface{}{
+		"interview_responses": gorm.Expr("interview_responses + ?", count),
+		"responses_pending":   false,
+	}).Error; err != nil {
+		return fmt.Errorf("error recording interview responses of merge %d: %w", merge.ID, err)
+	}
+	merge.InterviewResponses += count
+	merge.ResponsesPending = false
+	return nil
+}
+
+// finalSurvivor follows the later merges of a student to the registration that still exists
+func (s *service) finalSurvivor(ctx context.Context, studentID uint) (uint, error) {
+	seen := map[uint]bool{}
+	for !seen[studentID] {
+		seen[studentID] = true
+		var next []uint
+		if err := s.db.WithContext(ctx).Model(&models.StudentMerge{}).
+			Where("duplicate_student_id = ?", studentID).
+			Limit(1).Pluck("survivor_student_id", &next).Error; err != nil {
+			return 0, err
+		}
+		if len(next) == 0 {
+			break
+		}
+		studentID = next[0]
+	}
+	return studentID, nil
+}
+
+// ListMerges lists the merges, optionally those involving a student (as survivor or duplicate)
+func (s *service) ListMerges(ctx context.Context, studentID uint) ([]models.StudentMerge, error) {
+	query := s.db.WithContext(ctx).Order("created_at DESC")
+	if studentID != 0 {
+		query = query.Where("survivor_student_id = ? OR duplicate_student_id = ?", studentID, studentID)
+	}
+	var merges []models.StudentMerge
+	if err := query.Find(&merges).Error; err != nil {
+		return nil, fmt.Errorf("error listing merges: %w", err)
+	}
+	for i := range merges {
+		if err := openMerge(&merges[i]); err != nil {
+			return nil, err
+		}
+	}
+	return merges, nil
+}
+
+// GetMerge returns a merge record
+func (s *service) GetMerge(ctx context.Context, id uint) (*models.StudentMerge, error) {
+	var merge models.StudentMerge
+	if err := s.db.WithContext(ctx).First(&merge, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMergeNotFound
+		}
+		return nil, err
+	}
+	if err := openMerge(&merge); err != nil {
+		return nil, err
+	}
+	return &merge, nil
+}
+
+// applyConsolidation writes the fields the survivor received from the duplicate
+func (s *service) applyConsolidation(tx *gorm.DB, survivor, duplicate, merged snapshot) error {
+	// Atualizações por mapa não passam pelo serializer: os campos sensíveis são cifrados aqui
+	studentUpdates := map[string]interface{}{}
+	if merged.SpecialNeeds != survivor.SpecialNeeds {
+		encrypted, err := fieldcrypt.Encrypt(merged.SpecialNeeds)
+		if err != nil {
+			return err
+		}
+		studentUpdates["special_needs"] = encrypted
+	}
+	if merged.MedicalInfo != survivor.MedicalInfo {
+		encrypted, err := fieldcrypt.Encrypt(merged.MedicalInfo)
+		if err != nil {
+			return err
+		}
+		studentUpdates["medical_info"] = encrypted
+	}
+	if merged.Notes != survivor.Notes {
+		studentUpdates["notes"] = merged.Notes
+	}
+	if len(studentUpdates) > 0 {
+		studentUpdates["updated_at"] = s.now()
+		if err := tx.Table("students").Where("id = ?", survivor.StudentID).Updates(studentUpdates).Error; err != nil {
+			return fmt.Errorf("error updating surviving student: %w", err)
+		}
+	}
+
+	userUpdates := map[string]interface{}{}
+	if merged.Phone != survivor.Phone {
+		userUpdates["phone"] = merged.Phone
+	}
+	if merged.PhotoURL != survivor.PhotoURL {
+		userUpdates["photo_url"] = merged.PhotoURL
+	}
+	if merged.BirthDate != survivor.BirthDate {
+		userUpdates["birth_date"] = merged.BirthDate
+	}
+	if merged.CPF != survivor.CPF {
+		// O índice do CPF é único: o CPF sai do cadastro removido antes de ir para o mantido
+		if err := tx.Table("users").Where("id = ?", duplicate.UserID).
+			Updates(map[string]interface{}{"cpf": gorm.Expr("NULL"), "cpf_hash": gorm.Expr("NULL")}).Error; err != nil {
+			return fmt.Errorf("error moving cpf: %w", err)
+		}
+		encrypted, err := fieldcrypt.Encrypt(merged.CPF)
+		if err != nil {
+			return err
+		}
+		index, err := models.CPFIndex(merged.CPF)
+		if err != nil {
+			return err
+		}
+		userUpdates["cpf"] = encrypted
+		userUpdates["cpf_hash"] = index
+	}
+	if len(userUpdates) > 0 {
+		userUpdates["updated_at"] = s.now()
+		if err := tx.Table("users").Where("id = ?", survivor.UserID).Updates(userUpdates).Error; err != nil {
+			return fmt.Errorf("error updating surviving user: %w", err)
+		}
+	}
+	return nil
+}
+
+// sealSnapshot returns the JSON of the snapshot with the sensitive fields encrypted
+func sealSnapshot(snap snapshot) ([]byte, error) {
+	for _, field := range []*string{&snap.SpecialNeeds, &snap.MedicalInfo, &snap.CPF} {
+		encrypted, err := fieldcrypt.Encrypt(*field)
+		if err != nil {
+			return nil, err
+		}
+		*field = encrypted
+	}
+	return json.Marshal(snap)
+}
+
+// openMerge decrypts the sensitive fields of the stored snapshot for the coordination
+func openMerge(merge *models.StudentMerge) error {
+	if merge.DuplicateSnapshot == "" {
+		return nil
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(merge.DuplicateSnapshot), &data); err != nil {
+		return fmt.Errorf("error reading snapshot of merge %d: %w", merge.ID, err)
+	}
+	for _, key := range snapshotSensitiveKeys {
+		value, ok := data[key].(string)
+		if !ok || value == "" {
+			continue
+		}
+		plaintext, err := fieldcrypt.Decrypt(value)
+		if err != nil {
+			return fmt.Errorf("error decrypting snapshot of merge %d: %w", merge.ID, err)
+		}
+		data[key] = plaintext
+	}
+	opened, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	merge.DuplicateSnapshot = string(opened)
+	return nil
+}
+
+// loadSnapshot reads an active student and its user
+func loadSnapshot(tx *gorm.DB, studentID uint) (*snapshot, error) {
+	var snap snapshot
+	if err := tx.Table("students s").
+		Select(`s.id AS student_id, s.registration_number, s.status,
+			COALESCE(s.special_needs, '') AS special_needs, COALESCE(s.medical_info, '') AS medical_info, COALESCE(s.notes, '') AS notes,
+			u.id AS user_id, u.name, u.email, COALESCE(u.cpf, '') AS cpf, COALESCE(u.phone, '') AS phone, u.birth_date,
+			COALESCE(u.photo_url, '') AS photo_url, u.active`).
+		Joins("INNER JOIN users u ON u.id = s.user_id").
+		Where("s.id = ? AND s.deleted_at IS NULL AND u.deleted_at IS NULL", studentID).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Scan(&snap).Error; err != nil {
+		return nil, err
+	}
+	if snap.StudentID == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrStudentNotFound, studentID)
+	}
+	if snap.BirthDate != nil && snap.BirthDate.IsZero() {
+		snap.BirthDate = nil
+	}
+	return &snap, nil
+}
+
+// checkConflicts lists the records of the two students that would become duplicated under the
+// survivor: enrollments in the same course for overlapping periods (cancelled ones aside),
+// attendance of the same course on the same day and pickups of the same session (unique)
+func checkConflicts(tx *gorm.DB, survivorID, duplicateID uint) error {
+	var enrollments []struct {
+		CourseID    uint
+		SurvivorID  uint
+		DuplicateID uint
+	}
+	if err := tx.Raw(`
+		SELECT d.course_id, s.id AS survivor_id, d.id AS duplicate_id
+		FROM enrollments d
+		INNER JOIN enrollments s ON s.course_id = d.course_id AND s.student_id = ?
+			AND s.deleted_at IS NULL AND s.status <> 'cancelled'
+		WHERE d.student_id = ? AND d.deleted_at IS NULL AND d.status <> 'cancelled'
+			AND s.start_date <= COALESCE(d.end_date, 'infinity')
+			AND d.start_date <= COALESCE(s.end_date, 'infinity')
+		ORDER BY d.course_id, s.id, d.id`, survivorID, duplicateID).Scan(&enrollments).Error; err != nil {
+		return fmt.Errorf("error checking enrollments: %w", err)
+	}
+
+	var attendances []struct {
+		CourseID    uint
+		Day         string
+		SurvivorID  uint
+		DuplicateID uint
+	}
+	if err := tx.Raw(`
+		SELECT d.course_id, TO_CHAR(d.date, 'YYYY-MM-DD') AS day, s.id AS survivor_id, d.id AS duplicate_id
+		FROM attendances d
+		INNER JOIN attendances s ON s.course_id = d.course_id AND s.student_id = ? AND s.date::date = d.date::date
+		WHERE d.student_id = ?
+		ORDER BY d.date, d.course_id, s.id, d.id`, survivorID, duplicateID).Scan(&attendances).Error; err != nil {
+		return fmt.Errorf("error checking attendances: %w", err)
+	}
+
+	var pickups []struct {
+		ClassSessionID uint
+		SurvivorID     uint
+		DuplicateID    uint
+	}
+	if err := tx.Raw(`
+		SELECT d.class_session_id, s.id AS survivor_id, d.id AS duplicate_id
+		FROM pickup_records d
+		INNER JOIN pickup_records s ON s.class_session_id = d.class_session_id AND s.student_id = ?
+		WHERE d.student_id = ?
+		ORDER BY d.class_session_id`, survivorID, duplicateID).Scan(&pickups).Error; err != nil {
+		return fmt.Errorf("error checking pickups: %w", err)
+	}
+
+	var conflicts []string
+	for _, e := range enrollments {
+		conflicts = append(conflicts, fmt.Sprintf("course %d: enrollments %d and %d overlap", e.CourseID, e.SurvivorID, e.DuplicateID))
+	}
+	for _, a := range attendances {
+		conflicts = append(conflicts, fmt.Sprintf("course %d on %s: attendances %d and %d", a.CourseID, a.Day, a.SurvivorID, a.DuplicateID))
+	}
+	for _, p := range pickups {
+		conflicts = append(conflicts, fmt.Sprintf("session %d: pickups %d and %d", p.ClassSessionID, p.SurvivorID, p.DuplicateID))
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%w: %s", ErrMergeConflict, strings.Join(conflicts, "; "))
+	}
+	return nil
+}
+
+// reassign points the rows of table from one owner to another and returns the moved IDs
+func reassign(tx *gorm.DB, table, column string, from, to uint) ([]uint, error) {
+	var ids []uint
+	sql := fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ? RETURNING id", table, column, column)
+	if err := tx.Raw(sql, to, from).Scan(&ids).Error; err != nil {
+		return nil, fmt.Errorf("error moving %s: %w", table, err)
+	}
+	return ids, nil
+}
+
+// consolidate fills the blank fields of the survivor with the duplicate's; free-text health and
+// notes fields are kept from both so nothing recorded about the student is lost
+func consolidate(survivor, duplicate snapshot) snapshot {
+	merged := survivor
+	merged.SpecialNeeds = joinText(survivor.SpecialNeeds, duplicate.SpecialNeeds)
+	merged.MedicalInfo = joinText(survivor.MedicalInfo, duplicate.MedicalInfo)
+	merged.Notes = joinText(survivor.Notes, duplicate.Notes)
+	if strings.TrimSpace(merged.Phone) == "" {
+		merged.Phone = duplicate.Phone
+	}
+	if strings.TrimSpace(merged.PhotoURL) == "" {
+		merged.PhotoURL = duplicate.PhotoURL
+	}
+	if merged.BirthDate == nil {
+		merged.BirthDate = duplicate.BirthDate
+	}
+	if strings.TrimSpace(merged.CPF) == "" {
+		merged.CPF = duplicate.CPF
+	}
+	return merged
+}
+
+func joinText(a, b string) string {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	switch {
+	case a == "":
+		return b
+	case b == "" || strings.EqualFold(a, b):
+		return a
+	default:
+		return a + "\n" + b
+	}
+}
+
+// findCandidates groups the records by each matching key and scores every pair found
+func findCandidates(records []record, dismissed map[[2]uint]bool) []Candidate {
+	byID := make(map[uint]record, len(records))
+	buckets := map[string][]uint{}
+	for _, r := range records {
+		byID[r.StudentID] = r
+		for _, key := range matchKeys(r) {
+			buckets[key] = append(buckets[key], r.StudentID)
+		}
+	}
+
+	reasons := map[[2]uint]map[string]bool{}
+	for key, ids := range buckets {
+		reason := key[:strings.Index(key, ":")]
+		for i := 0; i < len(ids); i++ {
+			for j := i + 1; j < len(ids); j++ {
+				pair := orderedPair(ids[i], ids[j])
+				if dismissed[pair] {
+					continue
+				}
+				if reasons[pair] == nil {
+					reasons[pair] = map[string]bool{}
+				}
+				reasons[pair][reason] = true
+			}
+		}
+	}
+
+	candidates := make([]Candidate, 0, len(reasons))
+	for pair, set := range reasons {
+		candidate := Candidate{Students: [2]StudentSummary{summary(byID[pair[0]]), summary(byID[pair[1]])}}
+		for _, reason := range []string{ReasonCPF, ReasonEmail, ReasonNameBirthDate, ReasonPhone} {
+			if set[reason] {
+				candidate.Reasons = append(candidate.Reasons, reason)
+				candidate.Score += reasonWeight[reason]
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Students[0].ID < candidates[j].Students[0].ID ||
+			(candidates[i].Students[0].ID == candidates[j].Students[0].ID && candidates[i].Students[1].ID < candidates[j].Students[1].ID)
+	})
+	return candidates
+}
+
+// matchKeys returns the normalized keys of a record, prefixed by the match reason
+func matchKeys(r record) []string {
+	var keys []string
+	if number, err := cpf.Parse(r.CPF); err == nil {
+		keys = append(keys, ReasonCPF+":"+number)
+	}
+	if email := strings.ToLower(strings.TrimSpace(r.Email)); strings.Contains(email, "@") {
+		keys = append(keys, ReasonEmail+":"+email)
+	}
+	if phone := normalizePhone(r.Phone); phone != "" {
+		keys = append(keys, ReasonPhone+":"+phone)
+	}
+	if name := NormalizeName(r.Name); name != "" && r.BirthDate != nil && !r.BirthDate.IsZero() {
+		keys = append(keys, ReasonNameBirthDate+":"+name+"|"+r.BirthDate.Format("2006-01-02"))
+	}
+	return keys
+}
+
+// NormalizeName lowercases, strips accents and punctuation and drops the particles (de, da, dos...)
+func NormalizeName(name string) string {
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), name)
+	if err != nil {
+		stripped = name
+	}
+	words := strings.FieldsFunc(strings.ToLower(stripped), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	kept := words[:0]
+	for _, word := range words {
+		if !nameParticles[word] {
+			kept = append(kept, word)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// normalizePhone keeps the digits without the country code; numbers too short to be a phone are ignored
+func normalizePhone(phone string) string {
+	d := digits(phone)
+	if (len(d) == 12 || len(d) == 13) && strings.HasPrefix(d, "55") {
+		d = d[2:]
+	}
+	if len(d) < 10 {
+		return ""
+	}
+	return d
+}
+
+func digits(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func orderedPair(a, b uint) [2]uint {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]uint{a, b}
+}
+
+func summary(r record) StudentSummary {
+	return StudentSummary{
+		ID:                 r.StudentID,
+		UserID:             r.UserID,
+		RegistrationNumber: r.RegistrationNumber,
+		Status:             r.Status,
+		Name:               r.Name,
+		Email:              r.Email,
+		CPF:                r.CPF,
+		Phone:              r.Phone,
+		BirthDate:          r.BirthDate,
+		CreatedAt:          r.CreatedAt,
+	}
+}
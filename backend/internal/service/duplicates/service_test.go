@@ -0,0 +1,120 @@
+package duplicates
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/pkg/fieldcrypt"
+)
+
+func TestNormalizeName(t *testing.T) {
+	cases := map[string]string{
+		"  João  da Silva ":      "joao silva",
+		"JOAO SILVA":             "joao silva",
+		"Maria das Graças-Sousa": "maria gracas sousa",
+		"Ana d'Ávila":            "ana d avila",
+		"":                       "",
+	}
+	for name, want := range cases {
+		if got := NormalizeName(name); got != want {
+			t.Errorf("NormalizeName(%q) = %q, expected %q", name, got, want)
+		}
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	cases := map[string]string{
+		"(11) 98765-4321":   "11987654321",
+		"+55 11 98765-4321": "11987654321",
+		"55 (21) 3456-7890": "2134567890",
+		"98765-4321":        "",
+		"":                  "",
+	}
+	for phone, want := range cases {
+		if got := normalizePhone(phone); got != want {
+			t.Errorf("normalizePhone(%q) = %q, expected %q", phone, got, want)
+		}
+	}
+}
+
+func TestFindCandidates(t *testing.T) {
+	birth := time.Date(2010, 5, 3, 0, 0, 0, 0, time.UTC)
+	other := time.Date(2012, 1, 9, 0, 0, 0, 0, time.UTC)
+	records := []record{
+		{StudentID: 1, Name: "João da Silva", Email: "joao@email.com", CPF: "123.456.789-09", Phone: "(11) 98765-4321", BirthDate: &birth},
+		{StudentID: 2, Name: "JOAO SILVA", Email: "Joao@Email.com ", CPF: "", Phone: "+55 11 98765-4321", BirthDate: &birth},
+		{StudentID: 3, Name: "Pedro Silva", Email: "pedro@email.com", CPF: "12345678909", Phone: "11987654321", BirthDate: &other},
+		{StudentID: 4, Name: "Pedro Silva", Email: "outro@email.com", CPF: "000.000.000-00", Phone: "1234", BirthDate: &other},
+		{StudentID: 5, Name: "Ana Souza", Email: "ana@email.com", CPF: "00000000000", Phone: "1234", BirthDate: nil},
+	}
+
+	candidates := findCandidates(records, map[[2]uint]bool{{1, 3}: true})
+
+	got := map[[2]uint][]string{}
+	for _, c := range candidates {
+		got[[2]uint{c.Students[0].ID, c.Students[1].ID}] = c.Reasons
+	}
+	want := map[[2]uint][]string{
+		{1, 2}: {ReasonEmail, ReasonNameBirthDate, ReasonPhone},
+		{2, 3}: {ReasonPhone},
+		{3, 4}: {ReasonNameBirthDate},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unexpected candidates %v, expected %v", got, want)
+	}
+	if candidates[0].Students[0].ID != 1 || candidates[0].Students[1].ID != 2 {
+		t.Errorf("Expected the strongest pair first, got %d-%d", candidates[0].Students[0].ID, candidates[0].Students[1].ID)
+	}
+	if candidates[len(candidates)-1].Score != reasonWeight[ReasonPhone] {
+		t.Errorf("Expected the phone-only pair last, got score %d", candidates[len(candidates)-1].Score)
+	}
+}
+
+func TestConsolidate(t *testing.T) {
+	birth := time.Date(2010, 5, 3, 0, 0, 0, 0, time.UTC)
+	survivor := snapshot{StudentID: 1, Name: "João Silva", Phone: "", CPF: "", MedicalInfo: "Asma", Notes: "Gosta de música"}
+	duplicate := snapshot{StudentID: 2, Name: "Joao", Phone: "11987654321", CPF: "12345678909", BirthDate: &birth, MedicalInfo: "asma", SpecialNeeds: "Baixa visão", Notes: "Transferido"}
+
+	merged := consolidate(survivor, duplicate)
+	if merged.Name != "João Silva" || merged.StudentID != 1 {
+		t.Errorf("Expected the survivor identity to be kept, got %+v", merged)
+	}
+	if merged.Phone != "11987654321" || merged.CPF != "12345678909" || merged.BirthDate != &birth {
+		t.Errorf("Expected the blank fields to be filled from the duplicate, got %+v", merged)
+	}
+	if merged.MedicalInfo != "Asma" || merged.SpecialNeeds != "Baixa visão" || merged.Notes != "Gosta de música\nTransferido" {
+		t.Errorf("Unexpected free-text consolidation %+v", merged)
+	}
+}
+
+func TestSealSnapshot(t *testing.T) {
+	keyring, err := fieldcrypt.NewKeyring("test", map[string]string{"test": "test secret"}, "test index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fieldcrypt.SetDefault(keyring)
+
+	sealed, err := sealSnapshot(snapshot{StudentID: 2, Name: "Joao", CPF: "12345678909", MedicalInfo: "Asma"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(sealed), "12345678909") || strings.Contains(string(sealed), "Asma") {
+		t.Fatalf("Expected the sensitive fields to be encrypted, got %s", sealed)
+	}
+
+	merge := models.StudentMerge{DuplicateSnapshot: string(sealed)}
+	if err := openMerge(&merge); err != nil {
+		t.Fatal(err)
+	}
+	var opened snapshot
+	if err := json.Unmarshal([]byte(merge.DuplicateSnapshot), &opened); err != nil {
+		t.Fatal(err)
+	}
+	if opened.CPF != "12345678909" || opened.MedicalInfo != "Asma" || opened.SpecialNeeds != "" || opened.Name != "Joao" {
+		t.Errorf("Expected the snapshot to be read back, got %+v", opened)
+	}
+}
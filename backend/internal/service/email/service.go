@@ -7,6 +7,7 @@ import (
 	"html/template"
 	"net/smtp"
 	"os"
+	"time"
 )
 
 // EmailService handles sending emails
@@ -152,6 +153,113 @@ func (s *EmailService) renderWelcomeTemplate(data WelcomeEmailData) (string, err
 	return buf.String(), nil
 }
 
+// TeacherInvitationEmailData represents data for the teacher invitation email template
+type TeacherInvitationEmailData struct {
+	Name         string
+	InviteURL    string
+	Message      string
+	ExpiresAt    string
+	SupportEmail string
+}
+
+// SendTeacherInvitationEmail sends the one-time link to complete the volunteer teacher registration.
+// The link is a credential: it is never logged (the coordinator gets it in the invitation response).
+func (s *EmailService) SendTeacherInvitationEmail(invitationID uint, to, name, inviteURL, message string, expiresAt time.Time) error {
+	// Check if SMTP is configured
+	if s.smtpHost == "" || s.smtpPort == "" {
+		fmt.Printf("SMTP not configured, skipping invitation %d email to %s\n", invitationID, to)
+		return nil
+	}
+
+	data := TeacherInvitationEmailData{
+		Name:         name,
+		InviteURL:    inviteURL,
+		Message:      message,
+		ExpiresAt:    expiresAt.Format("02/01/2006 15:04"),
+		SupportEmail: os.Getenv("SUPPORT_EMAIL"),
+	}
+
+	subject := "Convite para ser professor voluntário no CECOR"
+	body, err := s.renderTeacherInvitationTemplate(data)
+	if err != nil {
+		return fmt.Errorf("failed to render email template: %w", err)
+	}
+
+	return s.sendEmail(to, subject, body)
+}
+
+// renderTeacherInvitationTemplate renders the teacher invitation email HTML template
+func (s *EmailService) renderTeacherInvitationTemplate(data TeacherInvitationEmailData) (string, error) {
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #4CAF50; color: white; padding: 20px; text-align: center; }
+        .content { background-color: #f9f9f9; padding: 20px; }
+        .message { background-color: #fff; border-left: 4px solid #4CAF50; padding: 10px 15px; margin: 15px 0; }
+        .button { background-color: #4CAF50; color: white; padding: 12px 30px; text-decoration: none; display: inline-block; margin: 20px 0; border-radius: 5px; }
+        .footer { text-align: center; color: #666; font-size: 12px; margin-top: 20px; }
+        .warning { background-color: #fff3cd; border-left: 4px solid #ffc107; padding: 10px; margin: 15px 0; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>Convite para o CECOR</h1>
+        </div>
+
+        <div class="content">
+            <p>Olá{{if .Name}} <strong>{{.Name}}</strong>{{end}},</p>
+
+            <p>Você foi convidado(a) para fazer parte da equipe de professores voluntários do CECOR (Centro Educacional Comunitário de Referência).</p>
+
+            {{if .Message}}<div class="message">{{.Message}}</div>{{end}}
+
+            <div style="text-align: center;">
+                <a href="{{.InviteURL}}" class="button">Completar meu cadastro</a>
+            </div>
+
+            <h3>Próximos Passos:</h3>
+            <ol>
+                <li>Clique no botão acima ou acesse: <a href="{{.InviteURL}}">{{.InviteURL}}</a></li>
+                <li>Preencha seus dados, suas habilidades e sua disponibilidade semanal</li>
+                <li>Leia e aceite o termo de voluntariado</li>
+                <li>Defina sua senha de acesso ao sistema</li>
+            </ol>
+
+            <div class="warning">
+                <strong>⚠️ Importante:</strong> Este link é pessoal, pode ser usado uma única vez e é válido até {{.ExpiresAt}}.
+            </div>
+
+            <p>Se você tiver alguma dúvida ou precisar de ajuda, entre em contato conosco em: <a href="mailto:{{.SupportEmail}}">{{.SupportEmail}}</a></p>
+        </div>
+
+        <div class="footer">
+            <p>Este é um email automático, por favor não responda.</p>
+            <p>&copy; 2024 CECOR - Centro Educacional Comunitário de Referência</p>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+	t, err := template.New("teacher-invitation").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
 // Service interface define os métodos do serviço de email
 type Service interface {
 	SendWelcomeEmail(to, studentName, temporaryPassword string) error
@@ -0,0 +1,708 @@
+// backend/internal/service/gradebook/service.go
+package gradebook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+// Result values of models.EnrollmentResult
+const (
+	ResultPassed = "passed"
+	ResultFailed = "failed"
+)
+
+// Enrollment status set when the final result is recorded
+const enrollmentCompleted = "completed"
+
+// Enrollment statuses still open for a final result
+var openStatuses = []string{"active", "in_progress"}
+
+var (
+	// ErrClassNotFound is returned when the course class does not exist
+	ErrClassNotFound = errors.New("course class not found")
+	// ErrAssessmentNotFound is returned when the assessment does not exist in the class
+	ErrAssessmentNotFound = errors.New("assessment not found")
+	// ErrInvalidAssessment is returned for malformed assessments
+	ErrInvalidAssessment = errors.New("invalid assessment")
+	// ErrInvalidScore is returned for scores out of range or of enrollments outside the class
+	ErrInvalidScore = errors.New("invalid score")
+	// ErrInvalidRule is returned for malformed grading rules
+	ErrInvalidRule = errors.New("invalid grading rule")
+	// ErrNotTeacher is returned when the user does not teach the class
+	ErrNotTeacher = errors.New("user is not a teacher of this class")
+	// ErrFinalized is returned when changing grades that already produced a final result
+	ErrFinalized = errors.New("final result already recorded")
+	// ErrMissingScores is returned when finalizing enrollments with assessments still without a score
+	ErrMissingScores = errors.New("enrollments with missing scores")
+)
+
+// Rule combines grade and attendance into the final result
+type Rule struct {
+	PassingGrade      float64 `json:"passingGrade"`      // média mínima (escala 0-10)
+	MinAttendanceRate float64 `json:"minAttendanceRate"` // frequência mínima (%)
+}
+
+// RuleView is the rule applied to a class
+type RuleView struct {
+	Rule
+	IsDefault bool `json:"isDefault"` // a turma não definiu regra própria
+}
+
+// AssessmentInput creates or updates an assessment
+type AssessmentInput struct {
+	Name        string
+	Description string
+	Weight      float64 // 0: peso 1
+	MaxScore    float64 // 0: nota máxima 10
+	Date        *time.Time
+}
+
+// ScoreInput is the score of an enrollment in an assessment
+type ScoreInput struct {
+	EnrollmentID uint     `json:"enrollmentId"`
+	Score        *float64 `json:"score"` // nil remove a nota
+	Notes        string   `json:"notes"`
+}
+
+// ScoreCell is a score of the gradebook (nil: not graded yet)
+type ScoreCell struct {
+	AssessmentID uint     `json:"assessmentId"`
+	Score        *float64 `json:"score"`
+	Notes        string   `json:"notes"`
+}
+
+// StudentGrades is a line of the gradebook
+type StudentGrades struct {
+	EnrollmentID      uint                     `json:"enrollmentId"`
+	StudentID         uint                     `json:"studentId"`
+	StudentName       string                   `json:"studentName"`
+	EnrollmentStatus  string                   `json:"enrollmentStatus"`
+	Scores            []ScoreCell              `json:"scores"`
+	FinalGrade        float64                  `json:"finalGrade"`
+	MissingScores     int                      `json:"missingScores"`
+	AttendanceRate    float64                  `json:"attendanceRate"`
+	AttendanceRecords int                      `json:"attendanceRecords"`
+	Projected         string                   `json:"projected"` // resultado com os dados atuais
+	Reason            string                   `json:"reason"`
+	Result            *models.EnrollmentResult `json:"result"` // resultado final registrado
+}
+
+// Gradebook is the gradebook of a course class
+type Gradebook struct {
+	CourseClassID uint                `json:"courseClassId"`
+	ClassName     string              `json:"className"`
+	CourseName    string              `json:"courseName"`
+	Rule          RuleView            `json:"rule"`
+	Assessments   []models.Assessment `json:"assessments"`
+	Students      []StudentGrades     `json:"students"`
+}
+
+// Service defines the interface for assessments and final results
+type Service interface {
+	ListAssessments(ctx context.Context, classID uint) ([]models.Assessment, error)
+	CreateAssessment(ctx context.Context, classID uint, input AssessmentInput, userID uint) (*models.Assessment, error)
+	UpdateAssessment(ctx context.Context, classID uint, id uint, input AssessmentInput) (*models.Assessment, error)
+	DeleteAssessment(ctx context.Context, classID uint, id uint) error
+	RecordScores(ctx context.Context, classID uint, assessmentID uint, scores []ScoreInput, userID uint) (*Gradebook, error)
+	Get(ctx context.Context, classID uint) (*Gradebook, error)
+
+	// Coordination
+	GetRule(ctx context.Context, classID uint) (*RuleView, error)
+	SetRule(ctx context.Context, classID uint, rule Rule, userID uint) (*RuleView, error)
+	Finalize(ctx context.Context, classID uint, enrollmentIDs []uint, allowMissingScores bool, userID uint) ([]models.EnrollmentResult, error)
+
+	// Teacher portal
+	AuthorizeTeacher(ctx context.Context, userID uint, classID uint) error
+}
+
+// service implements the Service interface
+type service struct {
+	db          *gorm.DB
+	defaultRule Rule
+	now         func() time.Time
+}
+
+// NewService creates a new gradebook service; defaultRule applies to classes without a rule of their own
+func NewService(db *gorm.DB, defaultRule Rule) Service {
+	return &service{db: db, defaultRule: defaultRule, now: time.Now}
+}
+
+// enrollmentRow is an enrollment of the class
+type enrollmentRow struct {
+	EnrollmentID uint
+	StudentID    uint
+	StudentName  string
+	Status       string
+}
+
+// attendanceRow totals the attendance of an enrollment
+type attendanceRow struct {
+	EnrollmentID uint
+	Total        int
+	Present      int
+}
+
+func (s *service) ListAssessments(ctx context.Context, classID uint) ([]models.Assessment, error) {
+	if _, err := s.class(ctx, classID); err != nil {
+		return nil, err
+	}
+	var assessments []models.Assessment
+	if err := s.db.WithContext(ctx).Where("course_class_id = ?", classID).
+		Order("date ASC NULLS LAST, id ASC").Find(&assessments).Error; err != nil {
+		return nil, err
+	}
+	return assessments, nil
+}
+
+func (s *service) CreateAssessment(ctx context.Context, classID uint, input AssessmentInput, userID uint) (*models.Assessment, error) {
+	assessment, err := buildAssessment(input)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.class(ctx, classID); err != nil {
+		return nil, err
+	}
+	if err := s.ensureOpen(ctx, classID); err != nil {
+		return nil, err
+	}
+
+	assessment.CourseClassID = classID
+	assessment.CreatedByID = userID
+	if err := s.db.WithContext(ctx).Create(assessment).Error; err != nil {
+		return nil, err
+	}
+	return assessment, nil
+}
+
+func (s *service) UpdateAssessment(ctx context.Context, classID uint, id uint, input AssessmentInput) (*models.Assessment, error) {
+	changes, err := buildAssessment(input)
+	if err != nil {
+		return nil, err
+	}
+	assessment, err := s.assessment(ctx, classID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureOpen(ctx, classID); err != nil {
+		return nil, err
+	}
+
+	var maxScore float64
+	if err := s.db.WithContext(ctx).Model(&models.AssessmentScore{}).
+		Where("assessment_id = ?", id).Select("COALESCE(MAX(score), 0)").Scan(&maxScore).Error; err != nil {
+		return nil, err
+	}
+	if maxScore > changes.MaxScore {
+		return nil, fmt.Errorf("%w: there are scores above %.2f", ErrInvalidAssessment, changes.MaxScore)
+	}
+
+	assessment.Name = changes.Name
+	assessment.Description = changes.Description
+	assessment.Weight = changes.Weight
+	assessment.MaxScore = changes.MaxScore
+	assessment.Date = changes.Date
+	if err := s.db.WithContext(ctx).Save(assessment).Error; err != nil {
+		return nil, err
+	}
+	return assessment, nil
+}
+
+// DeleteAssessment removes the assessment and its scores
+func (s *service) DeleteAssessment(ctx context.Context, classID uint, id uint) error {
+	if _, err := s.assessment(ctx, classID, id); err != nil {
+		return err
+	}
+	if err := s.ensureOpen(ctx, classID); err != nil {
+		return err
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("assessment_id = ?", id).Delete(&models.AssessmentScore{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Assessment{}, id).Error
+	})
+}
+
+// RecordScores creates, updates or removes (nil score) the scores of an assessment
+func (s *service) RecordScores(ctx context.Context, classID uint, assessmentID uint, scores []ScoreInput, userID uint) (*Gradebook, error) {
+	assessment, err := s.assessment(ctx, classID, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(scores) == 0 {
+		return nil, fmt.Errorf("%w: no scores provided", ErrInvalidScore)
+	}
+
+	enrollments, err := s.enrollments(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	inClass := make(map[uint]bool, len(enrollments))
+	for _, enrollment := range enrollments {
+		inClass[enrollment.EnrollmentID] = true
+	}
+	finalized, err := s.results(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, input := range scores {
+		if !inClass[input.EnrollmentID] {
+			return nil, fmt.Errorf("%w: enrollment %d is not in the class", ErrInvalidScore, input.EnrollmentID)
+		}
+		if _, ok := finalized[input.EnrollmentID]; ok {
+			return nil, fmt.Errorf("%w: enrollment %d", ErrFinalized, input.EnrollmentID)
+		}
+		if input.Score != nil && (*input.Score < 0 || *input.Score > assessment.MaxScore) {
+			return nil, fmt.Errorf("%w: score must be between 0 and %.2f", ErrInvalidScore, assessment.MaxScore)
+		}
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, input := range scores {
+			if input.Score == nil {
+				if err := tx.Where("assessment_id = ? AND enrollment_id = ?", assessmentID, input.EnrollmentID).
+					Delete(&models.AssessmentScore{}).Error; err != nil {
+					return err
+				}
+				continue
+			}
+
+			var score models.AssessmentScore
+			err := tx.Where("assessment_id = ? AND enrollment_id = ?", assessmentID, input.EnrollmentID).First(&score).Error
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			score.AssessmentID = assessmentID
+			score.EnrollmentID = input.EnrollmentID
+			score.Score = *input.Score
+			score.Notes = strings.TrimSpace(input.Notes)
+			score.RecordedByID = userID
+			if err := tx.Save(&score).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, classID)
+}
+
+// Get builds the gradebook with the projected result of each enrollment
+func (s *service) Get(ctx context.Context, classID uint) (*Gradebook, error) {
+	class, err := s.class(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := s.GetRule(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	assessments, err := s.ListAssessments(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	enrollments, err := s.enrollments(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	scores, err := s.scores(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	attendance, err := s.attendance(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.results(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	book := &Gradebook{
+		CourseClassID: class.ID,
+		ClassName:     class.Name,
+		CourseName:    class.Course.Name,
+		Rule:          *rule,
+		Assessments:   assessments,
+		Students:      make([]StudentGrades, 0, len(enrollments)),
+	}
+	for _, enrollment := range enrollments {
+		grades := StudentGrades{
+			EnrollmentID:     enrollment.EnrollmentID,
+			StudentID:        enrollment.StudentID,
+			StudentName:      enrollment.StudentName,
+			EnrollmentStatus: enrollment.Status,
+			Scores:           make([]ScoreCell, 0, len(assessments)),
+		}
+		values := make(map[uint]float64)
+		for _, assessment := range assessments {
+			cell := ScoreCell{AssessmentID: assessment.ID}
+			if score, ok := scores[enrollment.EnrollmentID][assessment.ID]; ok {
+				value := score.Score
+				cell.Score = &value
+				cell.Notes = score.Notes
+				values[assessment.ID] = value
+			}
+			grades.Scores = append(grades.Scores, cell)
+		}
+
+		grades.FinalGrade, grades.MissingScores = finalGrade(assessments, values)
+		count := attendance[enrollment.EnrollmentID]
+		grades.AttendanceRecords = count.Total
+		grades.AttendanceRate = attendanceRate(count)
+		grades.Projected, grades.Reason = decide(rule.Rule, len(assessments) > 0, grades.FinalGrade, grades.AttendanceRate)
+		if result, ok := results[enrollment.EnrollmentID]; ok {
+			value := result
+			grades.Result = &value
+		}
+		book.Students = append(book.Students, grades)
+	}
+	return book, nil
+}
+
+func (s *service) GetRule(ctx context.Context, classID uint) (*RuleView, error) {
+	var rule models.GradingRule
+	err := s.db.WithContext(ctx).Where("course_class_id = ?", classID).First(&rule).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if _, err := s.class(ctx, classID); err != nil {
+			return nil, err
+		}
+		return &RuleView{Rule: s.defaultRule, IsDefault: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &RuleView{Rule: Rule{PassingGrade: rule.PassingGrade, MinAttendanceRate: rule.MinAttendanceRate}}, nil
+}
+
+// SetRule defines the rule of the class; results already recorded keep the rule they were decided with
+func (s *service) SetRule(ctx context.Context, classID uint, rule Rule, userID uint) (*RuleView, error) {
+	if rule.PassingGrade < 0 || rule.PassingGrade > 10 {
+		return nil, fmt.Errorf("%w: passingGrade must be between 0 and 10", ErrInvalidRule)
+	}
+	if rule.MinAttendanceRate < 0 || rule.MinAttendanceRate > 100 {
+		return nil, fmt.Errorf("%w: minAttendanceRate must be between 0 and 100", ErrInvalidRule)
+	}
+	if _, err := s.class(ctx, classID); err != nil {
+		return nil, err
+	}
+
+	record := models.GradingRule{
+		CourseClassID:     classID,
+		PassingGrade:      rule.PassingGrade,
+		MinAttendanceRate: rule.MinAttendanceRate,
+		UpdatedByID:       userID,
+	}
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "course_class_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"passing_grade", "min_attendance_rate", "updated_by_id", "updated_at"}),
+	}).Create(&record).Error; err != nil {
+		return nil, err
+	}
+	return &RuleView{Rule: rule}, nil
+}
+
+// Finalize records the final result of the open enrollments (all, or the informed ones) and
+// moves them to completed. Enrollments with missing scores are rejected unless allowMissingScores
+// confirms that they count as zero; without assessments only attendance counts.
+func (s *service) Finalize(ctx context.Context, classID uint, enrollmentIDs []uint, allowMissingScores bool, userID uint) ([]models.EnrollmentResult, error) {
+	book, err := s.Get(ctx, classID)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := make(map[uint]bool, len(enrollmentIDs))
+	for _, id := range enrollmentIDs {
+		selected[id] = true
+	}
+	open := make(map[string]bool, len(openStatuses))
+	for _, status := range openStatuses {
+		open[status] = true
+	}
+
+	now := s.now()
+	var results []models.EnrollmentResult
+	var missing []string
+	for _, student := range book.Students {
+		if len(selected) > 0 && !selected[student.EnrollmentID] {
+			continue
+		}
+		if student.Result != nil || !open[student.EnrollmentStatus] {
+			if len(selected) > 0 {
+				return nil, fmt.Errorf("%w: enrollment %d is not open", ErrFinalized, student.EnrollmentID)
+			}
+			continue
+		}
+		delete(selected, student.EnrollmentID)
+		if student.MissingScores > 0 {
+			missing = append(missing, fmt.Sprintf("%d (%d)", student.EnrollmentID, student.MissingScores))
+		}
+
+		results = append(results, models.EnrollmentResult{
+			EnrollmentID:      student.EnrollmentID,
+			CourseClassID:     classID,
+			FinalGrade:        student.FinalGrade,
+			AttendanceRate:    student.AttendanceRate,
+			Result:            student.Projected,
+			Reason:            student.Reason,
+			PassingGrade:      book.Rule.PassingGrade,
+			MinAttendanceRate: book.Rule.MinAttendanceRate,
+			DecidedByID:       userID,
+			DecidedAt:         now,
+		})
+	}
+	for id := range selected {
+		return nil, fmt.Errorf("%w: enrollment %d is not in the class", ErrInvalidScore, id)
+	}
+	if len(missing) > 0 && !allowMissingScores {
+		return nil, fmt.Errorf("%w: enrollments (missing scores) %s; record them or confirm that they count as zero",
+			ErrMissingScores, strings.Join(missing, ", "))
+	}
+	if len(results) == 0 {
+		return []models.EnrollmentResult{}, nil
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range results {
+			if err := tx.Create(&results[i]).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.Enrollment{}).
+				Where("id = ?", results[i].EnrollmentID).
+				Updates(map[string]interface{}{
+					"status":   enrollmentCompleted,
+					"end_date": gorm.Expr("COALESCE(end_date, ?)", now),
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// AuthorizeTeacher checks that the user is the default teacher of the class or holds an assignment valid today
+func (s *service) AuthorizeTeacher(ctx context.Context, userID uint, classID uint) error {
+	if _, err := s.class(ctx, classID); err != nil {
+		return err
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT COUNT(*) FROM course_classes cc
+		WHERE cc.id = ? AND (
+			cc.default_teacher_id IN (SELECT id FROM teachers WHERE user_id = ?)
+			OR EXISTS (
+				SELECT 1 FROM teacher_courses tc
+				INNER JOIN teachers t ON t.id = tc.teacher_id
+				WHERE t.user_id = ?
+					AND tc.active = true
+					AND tc.course_id = cc.course_id
+					AND (tc.course_class_id IS NULL OR tc.course_class_id = cc.id)
+					AND tc.start_date <= CURRENT_DATE
+					AND (tc.end_date IS NULL OR tc.end_date >= CURRENT_DATE)
+			)
+		)
+	`, classID, userID, userID).Scan(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotTeacher
+	}
+	return nil
+}
+
+func (s *service) class(ctx context.Context, classID uint) (*models.CourseClass, error) {
+	var class models.CourseClass
+	if err := s.db.WithContext(ctx).Preload("Course").First(&class, classID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrClassNotFound
+		}
+		return nil, err
+	}
+	return &class, nil
+}
+
+func (s *service) assessment(ctx context.Context, classID uint, id uint) (*models.Assessment, error) {
+	var assessment models.Assessment
+	if err := s.db.WithContext(ctx).Where("id = ? AND course_class_id = ?", id, classID).First(&assessment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, err
+	}
+	return &assessment, nil
+}
+
+// ensureOpen blocks changes to the assessments once a final result was recorded in the class
+func (s *service) ensureOpen(ctx context.Context, classID uint) error {
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.EnrollmentResult{}).
+		Where("course_class_id = ?", classID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: the assessments of the class can no longer change", ErrFinalized)
+	}
+	return nil
+}
+
+// enrollments lists the enrollments of the class, except cancelled ones
+func (s *service) enrollments(ctx context.Context, classID uint) ([]enrollmentRow, error) {
+	var rows []enrollmentRow
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT e.id AS enrollment_id, e.student_id, u.name AS student_name, e.status
+		FROM enrollment_course_classes ecc
+		INNER JOIN enrollments e ON e.id = ecc.enrollment_id
+		INNER JOIN students st ON st.id = e.student_id
+		INNER JOIN users u ON u.id = st.user_id
+		WHERE ecc.course_class_id = ? AND e.deleted_at IS NULL AND e.status <> 'cancelled'
+		ORDER BY u.name ASC
+	`, classID).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list class enrollments: %w", err)
+	}
+	return rows, nil
+}
+
+// scores indexes the scores of the class by enrollment and assessment
+func (s *service) scores(ctx context.Context, classID uint) (map[uint]map[uint]models.AssessmentScore, error) {
+	var scores []models.AssessmentScore
+	if err := s.db.WithContext(ctx).
+		Where("assessment_id IN (SELECT id FROM assessments WHERE course_class_id = ?)", classID).
+		Find(&scores).Error; err != nil {
+		return nil, err
+	}
+	indexed := make(map[uint]map[uint]models.AssessmentScore)
+	for _, score := range scores {
+		if indexed[score.EnrollmentID] == nil {
+			indexed[score.EnrollmentID] = make(map[uint]models.AssessmentScore)
+		}
+		indexed[score.EnrollmentID][score.AssessmentID] = score
+	}
+	return indexed, nil
+}
+
+// attendance totals the attendance records of the class enrollments
+func (s *service) attendance(ctx context.Context, classID uint) (map[uint]attendanceRow, error) {
+	var rows []attendanceRow
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT
+			a.enrollment_id,
+			COUNT(a.id) AS total,
+			COUNT(a.id) FILTER (WHERE a.status = 'present') AS present
+		FROM attendances a
+		INNER JOIN enrollment_course_classes ecc ON ecc.enrollment_id = a.enrollment_id
+		WHERE ecc.course_class_id = ?
+			-- Só a frequência das aulas desta turma (a matrícula pode estar em outras turmas)
+			AND EXISTS (
+				SELECT 1 FROM class_sessions cs
+				WHERE cs.course_class_id = ecc.course_class_id AND cs.date::date = a.date::date
+			)
+		GROUP BY a.enrollment_id
+	`, classID).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count attendance: %w", err)
+	}
+	indexed := make(map[uint]attendanceRow, len(rows))
+	for _, row := range rows {
+		indexed[row.EnrollmentID] = row
+	}
+	return indexed, nil
+}
+
+func (s *service) results(ctx context.Context, classID uint) (map[uint]models.EnrollmentResult, error) {
+	var results []models.EnrollmentResult
+	if err := s.db.WithContext(ctx).Where("course_class_id = ?", classID).Find(&results).Error; err != nil {
+		return nil, err
+	}
+	indexed := make(map[uint]models.EnrollmentResult, len(results))
+	for _, result := range results {
+		indexed[result.EnrollmentID] = result
+	}
+	return indexed, nil
+}
+
+func buildAssessment(input AssessmentInput) (*models.Assessment, error) {
+	assessment := &models.Assessment{
+		Name:        strings.TrimSpace(input.Name),
+		Description: strings.TrimSpace(input.Description),
+		Weight:      input.Weight,
+		MaxScore:    input.MaxScore,
+		Date:        input.Date,
+	}
+	if assessment.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidAssessment)
+	}
+	if assessment.Weight == 0 {
+		assessment.Weight = 1
+	}
+	if assessment.MaxScore == 0 {
+		assessment.MaxScore = 10
+	}
+	if assessment.Weight < 0 || assessment.MaxScore < 0 {
+		return nil, fmt.Errorf("%w: weight and maxScore must be positive", ErrInvalidAssessment)
+	}
+	return assessment, nil
+}
+
+// finalGrade is the weighted average of the scores on a 0-10 scale; missing scores count as zero
+func finalGrade(assessments []models.Assessment, scores map[uint]float64) (grade float64, missing int) {
+	var weighted, totalWeight float64
+	for _, assessment := range assessments {
+		totalWeight += assessment.Weight
+		score, ok := scores[assessment.ID]
+		if !ok {
+			missing++
+			continue
+		}
+		weighted += assessment.Weight * score / assessment.MaxScore
+	}
+	if totalWeight == 0 {
+		return 0, missing
+	}
+	return round2(weighted / totalWeight * 10), missing
+}
+
+// attendanceRate is the share of records marked present, in %
+func attendanceRate(count attendanceRow) float64 {
+	if count.Total == 0 {
+		return 0
+	}
+	return round2(float64(count.Present) / float64(count.Total) * 100)
+}
+
+// decide applies the rule; the grade only counts when the class has assessments
+func decide(rule Rule, graded bool, grade float64, attendance float64) (result string, reason string) {
+	var reasons []string
+	if graded && grade < rule.PassingGrade {
+		reasons = append(reasons, fmt.Sprintf("grade %.2f below %.2f", grade, rule.PassingGrade))
+	}
+	if attendance < rule.MinAttendanceRate {
+		reasons = append(reasons, fmt.Sprintf("attendance %.2f%% below %.2f%%", attendance, rule.MinAttendanceRate))
+	}
+	if len(reasons) > 0 {
+		return ResultFailed, strings.Join(reasons, "; ")
+	}
+	return ResultPassed, ""
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,112 @@
+package gradebook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devdavidalonso/cecor/backend/internal/database/testdb"
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+func TestFinalGrade(t *testing.T) {
+	assessments := []models.Assessment{
+		{ID: 1, Weight: 1, MaxScore: 10},
+		{ID: 2, Weight: 2, MaxScore: 20},
+		{ID: 3, Weight: 1, MaxScore: 100},
+	}
+
+	t.Run("weighted average on the 0-10 scale", func(t *testing.T) {
+		grade, missing := finalGrade(assessments, map[uint]float64{1: 8, 2: 15, 3: 50})
+		// (1*0.8 + 2*0.75 + 1*0.5) / 4 * 10 = 7
+		if grade != 7 || missing != 0 {
+			t.Errorf("Expected grade 7 with no missing scores, got %v (%d missing)", grade, missing)
+		}
+	})
+
+	t.Run("missing scores count as zero", func(t *testing.T) {
+		grade, missing := finalGrade(assessments, map[uint]float64{2: 20})
+		if grade != 5 || missing != 2 {
+			t.Errorf("Expected grade 5 with 2 missing scores, got %v (%d missing)", grade, missing)
+		}
+	})
+
+	t.Run("no assessments", func(t *testing.T) {
+		if grade, _ := finalGrade(nil, nil); grade != 0 {
+			t.Errorf("Expected grade 0, got %v", grade)
+		}
+	})
+}
+
+func TestDecide(t *testing.T) {
+	rule := Rule{PassingGrade: 6, MinAttendanceRate: 75}
+
+	if result, reason := decide(rule, true, 6, 75); result != ResultPassed || reason != "" {
+		t.Errorf("Expected a pass on the limits, got %s (%s)", result, reason)
+	}
+	if result, reason := decide(rule, true, 5.5, 70); result != ResultFailed || reason != "grade 5.50 below 6.00; attendance 70.00% below 75.00%" {
+		t.Errorf("Expected a fail on grade and attendance, got %s (%s)", result, reason)
+	}
+	if result, _ := decide(rule, false, 0, 80); result != ResultPassed {
+		t.Errorf("Expected attendance alone to decide without assessments, got %s", result)
+	}
+}
+
+func TestBuildAssessment(t *testing.T) {
+	assessment, err := buildAssessment(AssessmentInput{Name: " Prova 1 "})
+	if err != nil {
+		t.Fatalf("Expected a valid assessment, got %v", err)
+	}
+	if assessment.Name != "Prova 1" || assessment.Weight != 1 || assessment.MaxScore != 10 {
+		t.Errorf("Expected defaults weight 1 and max score 10, got %+v", assessment)
+	}
+
+	if _, err := buildAssessment(AssessmentInput{}); !errors.Is(err, ErrInvalidAssessment) {
+		t.Errorf("Expected ErrInvalidAssessment without name, got %v", err)
+	}
+	if _, err := buildAssessment(AssessmentInput{Name: "x", Weight: -1}); !errors.Is(err, ErrInvalidAssessment) {
+		t.Errorf("Expected ErrInvalidAssessment for a negative weight, got %v", err)
+	}
+}
+
+func TestAttendanceCountsOnlyTheClassSessions(t *testing.T) {
+	db := testdb.Open(t, &models.Attendance{}, &models.EnrollmentCourseClass{}, &models.ClassSession{})
+	s := &service{db: db, now: time.Now}
+
+	day := func(d int) time.Time { return time.Date(2026, time.March, d, 19, 0, 0, 0, time.UTC) }
+	classA, classB := uint(1), uint(2)
+	// A matrícula 10 está nas duas turmas; a turma A tem aulas nos dias 2 e 3, a turma B no dia 4
+	seed := []interface{}{
+		&models.EnrollmentCourseClass{EnrollmentID: 10, CourseClassID: classA, IsPrimary: true},
+		&models.EnrollmentCourseClass{EnrollmentID: 10, CourseClassID: classB},
+		&models.ClassSession{CourseID: 1, CourseClassID: &classA, Date: day(2)},
+		&models.ClassSession{CourseID: 1, CourseClassID: &classA, Date: day(3)},
+		&models.ClassSession{CourseID: 1, CourseClassID: &classB, Date: day(4)},
+		&models.Attendance{StudentID: 1, EnrollmentID: 10, CourseID: 1, Date: day(2), Status: "present", RegisteredByID: 1},
+		&models.Attendance{StudentID: 1, EnrollmentID: 10, CourseID: 1, Date: day(3), Status: "absent", RegisteredByID: 1},
+		&models.Attendance{StudentID: 1, EnrollmentID: 10, CourseID: 1, Date: day(4), Status: "present", RegisteredByID: 1},
+	}
+	for _, row := range seed {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		classID        uint
+		total, present int
+	}{
+		{classA, 2, 1},
+		{classB, 1, 1},
+	}
+	for _, c := range cases {
+		rows, err := s.attendance(context.Background(), c.classID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := rows[10]; got.Total != c.total || got.Present != c.present {
+			t.Errorf("class %d: expected %d/%d, got %d/%d", c.classID, c.present, c.total, got.Present, got.Total)
+		}
+	}
+}
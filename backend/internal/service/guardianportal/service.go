@@ -0,0 +1,614 @@
+// backend/internal/service/guardianportal/service.go
+package guardianportal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+var (
+	// ErrNoDependents is returned when the user is not the guardian of any student
+	ErrNoDependents = errors.New("user is not a guardian of any student")
+	// ErrDependentNotFound is returned for students that are not linked to the guardian
+	ErrDependentNotFound = errors.New("dependent not found")
+	// ErrConsentNotFound is returned for consents that are not asked of the guardian
+	ErrConsentNotFound = errors.New("consent not found")
+	// ErrInvalidJustification is returned for incomplete or inconsistent justifications
+	ErrInvalidJustification = errors.New("invalid absence justification")
+)
+
+// maxJustificationDays limits the period covered by a single justification
+const maxJustificationDays = 90
+
+// visibleIncidentTypes are the incidents shown to guardians; infrastructure and other
+// incidents are internal even when a student is involved
+var visibleIncidentTypes = []models.IncidentType{
+	models.IncidentTypeDisciplinary,
+	models.IncidentTypeHealth,
+	models.IncidentTypeSafety,
+}
+
+// Dependent is a student linked to the logged guardian
+type Dependent struct {
+	StudentID          uint                 `json:"studentId"`
+	GuardianID         uint                 `json:"guardianId"`
+	Name               string               `json:"name"`
+	RegistrationNumber string               `json:"registrationNumber"`
+	Status             models.StudentStatus `json:"status"`
+	Age                int                  `json:"age"`
+	Relationship       string               `json:"relationship"`
+	CanPickup          bool                 `json:"canPickup"`
+	OpenAlerts         int64                `json:"openAlerts"`
+	PendingConsents    int                  `json:"pendingConsents"`
+}
+
+// DependentClass is an enrollment of the dependent with its class and attendance
+type DependentClass struct {
+	EnrollmentID      uint    `json:"enrollmentId"`
+	EnrollmentStatus  string  `json:"enrollmentStatus"`
+	CourseID          uint    `json:"courseId"`
+	CourseName        string  `json:"courseName"`
+	CourseClassID     *uint   `json:"courseClassId,omitempty"`
+	ClassCode         string  `json:"classCode,omitempty"`
+	ClassName         string  `json:"className,omitempty"`
+	WeekDays          string  `json:"weekDays,omitempty"`
+	StartTime         string  `json:"startTime,omitempty"`
+	EndTime           string  `json:"endTime,omitempty"`
+	LocationName      string  `json:"locationName,omitempty"`
+	TeacherName       string  `json:"teacherName,omitempty"`
+	RecordedSessions  int64   `json:"recordedSessions"`
+	PresentSessions   int64   `json:"presentSessions"`
+	AttendancePercent float64 `json:"attendancePercent"`
+}
+
+// AttendanceEntry is an attendance record of the dependent
+type AttendanceEntry struct {
+	Date          time.Time `json:"date"`
+	CourseID      uint      `json:"courseId"`
+	CourseName    string    `json:"courseName"`
+	Status        string    `json:"status"` // present, absent, partial
+	Justification string    `json:"justification,omitempty"`
+}
+
+// AttendanceFilter narrows the attendance history
+type AttendanceFilter struct {
+	CourseID *uint
+	From     *time.Time
+	To       *time.Time
+}
+
+// AbsenceAlert is an absence alert of the dependent with the course name
+type AbsenceAlert struct {
+	models.AbsenceAlert
+	CourseName string `json:"courseName"`
+}
+
+// Incident is the part of an incident shown to guardians (no reporter, comments or attachments)
+type Incident struct {
+	ID          uint                    `json:"id"`
+	Type        models.IncidentType     `json:"type"`
+	Severity    models.IncidentSeverity `json:"severity"`
+	Title       string                  `json:"title"`
+	Description string                  `json:"description"`
+	Status      models.IncidentStatus   `json:"status"`
+	CourseName  string                  `json:"courseName,omitempty"`
+	CreatedAt   time.Time               `json:"createdAt"`
+	ResolvedAt  *time.Time              `json:"resolvedAt,omitempty"`
+}
+
+// Pickup is a check-out of the dependent at the end of a session
+type Pickup struct {
+	ID           uint                `json:"id"`
+	Status       models.PickupStatus `json:"status"`
+	PersonName   string              `json:"personName"`
+	Authorized   bool                `json:"authorized"`
+	Notes        string              `json:"notes,omitempty"`
+	CheckedOutAt time.Time           `json:"checkedOutAt"`
+	CourseName   string              `json:"courseName"`
+}
+
+// Consent is an authorization asked of the guardian, answered or not
+type Consent struct {
+	Type         models.ConsentType `json:"type"`
+	EnrollmentID *uint              `json:"enrollmentId,omitempty"`
+	CourseName   string             `json:"courseName,omitempty"`
+	DocumentURL  string             `json:"documentUrl,omitempty"`
+	Pending      bool               `json:"pending"`
+	Accepted     *bool              `json:"accepted,omitempty"`
+	RespondedAt  *time.Time         `json:"respondedAt,omitempty"`
+}
+
+// ConsentAnswer is the guardian's response to a consent
+type ConsentAnswer struct {
+	Type         models.ConsentType
+	EnrollmentID *uint
+	Accepted     bool
+	IPAddress    string
+}
+
+// JustificationInput is an absence justification sent by the guardian
+type JustificationInput struct {
+	CourseID    uint
+	StartDate   time.Time
+	EndDate     time.Time
+	Reason      string
+	DocumentURL string
+}
+
+// Service defines the interface for the guardian portal. Every method takes the logged user and
+// only reaches students linked to that user through guardians.user_id.
+type Service interface {
+	ListDependents(ctx context.Context, userID uint) ([]Dependent, error)
+	GetClasses(ctx context.Context, userID uint, studentID uint) ([]DependentClass, error)
+	GetAttendance(ctx context.Context, userID uint, studentID uint, filter AttendanceFilter) ([]AttendanceEntry, error)
+	GetAbsenceAlerts(ctx context.Context, userID uint, studentID uint) ([]AbsenceAlert, error)
+	GetIncidents(ctx context.Context, userID uint, studentID uint) ([]Incident, error)
+	GetPickups(ctx context.Context, userID uint, studentID uint) ([]Pickup, error)
+
+	// Consents
+	ListConsents(ctx context.Context, userID uint, studentID uint) ([]Consent, error)
+	RespondConsent(ctx context.Context, userID uint, studentID uint, answer ConsentAnswer) (*Consent, error)
+
+	// Absence justifications
+	ListJustifications(ctx context.Context, userID uint, studentID uint) ([]models.AbsenceJustification, error)
+	SubmitJustification(ctx context.Context, userID uint, studentID uint, input JustificationInput) (*models.AbsenceJustification, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+// NewService creates a new guardian portal service
+func NewService(db *gorm.DB) Service {
+	return &service{db: db, now: time.Now}
+}
+
+// guardianLinks selects the active guardian records of a user with their (not deleted) students
+const guardianLinks = `
+	SELECT g.id, g.student_id, g.relationship, g.can_pickup, g.authorize_activities
+	FROM guardians g
+	INNER JOIN students st ON st.id = g.student_id AND st.deleted_at IS NULL
+	WHERE g.user_id = ? AND g.deleted_at IS NULL
+`
+
+// guardianLink is a guardian record of the logged user
+type guardianLink struct {
+	ID                  uint
+	StudentID           uint
+	Relationship        string
+	CanPickup           bool
+	AuthorizeActivities bool
+}
+
+// ListDependents returns the students linked to the user as guardian
+func (s *service) ListDependents(ctx context.Context, userID uint) ([]Dependent, error) {
+	var links []guardianLink
+	if err := s.db.WithContext(ctx).Raw(guardianLinks+" ORDER BY g.student_id", userID).Scan(&links).Error; err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, ErrNoDependents
+	}
+
+	dependents := make([]Dependent, 0, len(links))
+	for _, link := range links {
+		var student models.Student
+		if err := s.db.WithContext(ctx).Preload("User").First(&student, link.StudentID).Error; err != nil {
+			return nil, err
+		}
+
+		var openAlerts int64
+		if err := s.db.WithContext(ctx).Model(&models.AbsenceAlert{}).
+			Where("student_id = ? AND status = ?", link.StudentID, "open").
+			Count(&openAlerts).Error; err != nil {
+			return nil, err
+		}
+
+		consents, err := s.consents(ctx, link)
+		if err != nil {
+			return nil, err
+		}
+		pending := 0
+		for _, consent := range consents {
+			if consent.Pending {
+				pending++
+			}
+		}
+
+		dependent := Dependent{
+			StudentID:          student.ID,
+			GuardianID:         link.ID,
+			Name:               student.User.Name,
+			RegistrationNumber: student.RegistrationNumber,
+			Status:             student.Status,
+			Relationship:       link.Relationship,
+			CanPickup:          link.CanPickup,
+			OpenAlerts:         openAlerts,
+			PendingConsents:    pending,
+		}
+		if !student.User.BirthDate.IsZero() {
+			dependent.Age = student.User.CalculateAge()
+		}
+		dependents = append(dependents, dependent)
+	}
+	return dependents, nil
+}
+
+// GetClasses returns the enrollments of the dependent that were not cancelled
+func (s *service) GetClasses(ctx context.Context, userID uint, studentID uint) ([]DependentClass, error) {
+	if _, err := s.link(ctx, userID, studentID); err != nil {
+		return nil, err
+	}
+
+	classes := []DependentClass{}
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT e.id AS enrollment_id, e.status AS enrollment_status, e.course_id, c.name AS course_name,
+			cc.id AS course_class_id, COALESCE(cc.code, '') AS class_code, COALESCE(cc.name, '') AS class_name,
+			COALESCE(cc.week_days, '') AS week_days, COALESCE(cc.start_time, '') AS start_time, COALESCE(cc.end_time, '') AS end_time,
+			COALESCE(l.name, '') AS location_name, COALESCE(tu.name, '') AS teacher_name,
+			(SELECT COUNT(*) FROM attendances a WHERE a.enrollment_id = e.id) AS recorded_sessions,
+			(SELECT COUNT(*) FROM attendances a WHERE a.enrollment_id = e.id AND a.status = 'present') AS present_sessions
+		FROM enrollments e
+		INNER JOIN courses c ON c.id = e.course_id
+		LEFT JOIN enrollment_course_classes ecc ON ecc.enrollment_id = e.id AND ecc.is_primary = true
+		LEFT JOIN course_classes cc ON cc.id = ecc.course_class_id
+		LEFT JOIN locations l ON l.id = cc.default_location_id
+		LEFT JOIN teachers t ON t.id = cc.default_teacher_id
+		LEFT JOIN users tu ON tu.id = t.user_id
+		WHERE e.student_id = ? AND e.deleted_at IS NULL AND e.status <> 'cancelled'
+		ORDER BY e.start_date DESC, c.name
+	`, studentID).Scan(&classes).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range classes {
+		if classes[i].RecordedSessions > 0 {
+			classes[i].AttendancePercent = float64(classes[i].PresentSessions) / float64(classes[i].RecordedSessions) * 100
+		}
+	}
+	return classes, nil
+}
+
+// GetAttendance returns the attendance history of the dependent, most recent first
+func (s *service) GetAttendance(ctx context.Context, userID uint, studentID uint, filter AttendanceFilter) ([]AttendanceEntry, error) {
+	if _, err := s.link(ctx, userID, studentID); err != nil {
+		return nil, err
+	}
+
+	query := s.db.WithContext(ctx).Table("attendances a").
+		Select("a.date, a.course_id, c.name AS course_name, a.status, a.justification").
+		Joins("INNER JOIN courses c ON c.id = a.course_id").
+		Where("a.student_id = ?", studentID)
+	if filter.CourseID != nil {
+		query = query.Where("a.course_id = ?", *filter.CourseID)
+	}
+	if filter.From != nil {
+		query = query.Where("a.date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("a.date < ?", filter.To.AddDate(0, 0, 1))
+	}
+
+	entries := []AttendanceEntry{}
+	if err := query.Order("a.date DESC").Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetAbsenceAlerts returns the absence alerts of the dependent, open ones first
+func (s *service) GetAbsenceAlerts(ctx context.Context, userID uint, studentID uint) ([]AbsenceAlert, error) {
+	if _, err := s.link(ctx, userID, studentID); err != nil {
+		return nil, err
+	}
+
+	alerts := []AbsenceAlert{}
+	if err := s.db.WithContext(ctx).Table("absence_alerts aa").
+		Select("aa.*, c.name AS course_name").
+		Joins("INNER JOIN courses c ON c.id = aa.course_id").
+		Where("aa.student_id = ?", studentID).
+		Order("CASE aa.status WHEN 'open' THEN 0 ELSE 1 END, aa.last_absence_date DESC").
+		Scan(&alerts).Error; err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// GetIncidents returns the disciplinary, health and safety incidents of the dependent that were not cancelled
+func (s *service) GetIncidents(ctx context.Context, userID uint, studentID uint) ([]Incident, error) {
+	if _, err := s.link(ctx, userID, studentID); err != nil {
+		return nil, err
+	}
+
+	incidents := []Incident{}
+	if err := s.db.WithContext(ctx).Table("incidents i").
+		Select("i.id, i.type, i.severity, i.title, i.description, i.status, COALESCE(c.name, '') AS course_name, i.created_at, i.resolved_at").
+		Joins("LEFT JOIN courses c ON c.id = i.course_id").
+		Where("i.student_id = ? AND i.deleted_at IS NULL", studentID).
+		Where("i.type IN ? AND i.status <> ?", visibleIncidentTypes, models.IncidentStatusCancelled).
+		Order("i.created_at DESC").
+		Scan(&incidents).Error; err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+// GetPickups returns the check-outs of the dependent, most recent first
+func (s *service) GetPickups(ctx context.Context, userID uint, studentID uint) ([]Pickup, error) {
+	if _, err := s.link(ctx, userID, studentID); err != nil {
+		return nil, err
+	}
+
+	pickups := []Pickup{}
+	if err := s.db.WithContext(ctx).Table("pickup_records pr").
+		Select("pr.id, pr.status, pr.person_name, pr.authorized, pr.notes, pr.checked_out_at, c.name AS course_name").
+		Joins("INNER JOIN class_sessions cs ON cs.id = pr.class_session_id").
+		Joins("INNER JOIN courses c ON c.id = cs.course_id").
+		Where("pr.student_id = ?", studentID).
+		Order("pr.checked_out_at DESC").
+		Scan(&pickups).Error; err != nil {
+		return nil, err
+	}
+	return pickups, nil
+}
+
+// ListConsents returns the consents asked of the guardian for the dependent, pending ones first
+func (s *service) ListConsents(ctx context.Context, userID uint, studentID uint) ([]Consent, error) {
+	link, err := s.link(ctx, userID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.consents(ctx, *link)
+}
+
+// RespondConsent records the guardian's answer. Answering again replaces the previous answer;
+// the activities consent also updates the guardian's AuthorizeActivities flag.
+func (s *service) RespondConsent(ctx context.Context, userID uint, studentID uint, answer ConsentAnswer) (*Consent, error) {
+	link, err := s.link(ctx, userID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	consents, err := s.consents(ctx, *link)
+	if err != nil {
+		return nil, err
+	}
+	var asked *Consent
+	for i := range consents {
+		if consents[i].Type == answer.Type && sameEnrollment(consents[i].EnrollmentID, answer.EnrollmentID) {
+			asked = &consents[i]
+			break
+		}
+	}
+	if asked == nil {
+		return nil, ErrConsentNotFound
+	}
+
+	now := s.now()
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Where("guardian_id = ? AND type = ?", link.ID, answer.Type)
+		if answer.EnrollmentID != nil {
+			query = query.Where("enrollment_id = ?", *answer.EnrollmentID)
+		} else {
+			query = query.Where("enrollment_id IS NULL")
+		}
+
+		var record models.GuardianConsent
+		if err := query.First(&record).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		record.GuardianID = link.ID
+		record.StudentID = studentID
+		record.Type = answer.Type
+		record.EnrollmentID = answer.EnrollmentID
+		record.Accepted = answer.Accepted
+		record.RespondedAt = now
+		record.RespondedBy = userID
+		record.IPAddress = answer.IPAddress
+		if err := tx.Save(&record).Error; err != nil {
+			return err
+		}
+
+		if answer.Type == models.ConsentTypeActivities {
+			return tx.Model(&models.Guardian{}).Where("id = ?", link.ID).
+				Update("authorize_activities", answer.Accepted).Error
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	asked.Pending = false
+	asked.Accepted = &answer.Accepted
+	asked.RespondedAt = &now
+	return asked, nil
+}
+
+// consents builds the consents asked of a guardian: the activities authorization and the terms of
+// each enrollment with an agreement document. A term accepted by another guardian of the student
+// is no longer pending.
+func (s *service) consents(ctx context.Context, link guardianLink) ([]Consent, error) {
+	var answers []models.GuardianConsent
+	if err := s.db.WithContext(ctx).
+		Where("student_id = ? AND (guardian_id = ? OR (type = ? AND accepted = true))", link.StudentID, link.ID, models.ConsentTypeEnrollment).
+		Order("responded_at").
+		Find(&answers).Error; err != nil {
+		return nil, err
+	}
+
+	activities := Consent{Type: models.ConsentTypeActivities, Pending: true}
+	ownTerms := map[uint]*models.GuardianConsent{}
+	acceptedTerms := map[uint]*models.GuardianConsent{}
+	for i := range answers {
+		answer := &answers[i]
+		switch {
+		case answer.Type == models.ConsentTypeActivities:
+			activities.Pending = false
+			activities.Accepted = &answer.Accepted
+			activities.RespondedAt = &answer.RespondedAt
+		case answer.EnrollmentID == nil:
+		case answer.GuardianID == link.ID:
+			ownTerms[*answer.EnrollmentID] = answer
+		default:
+			acceptedTerms[*answer.EnrollmentID] = answer
+		}
+	}
+	// Quem já autorizava atividades pelo cadastro não precisa responder de novo
+	if activities.Pending && link.AuthorizeActivities {
+		accepted := true
+		activities.Pending = false
+		activities.Accepted = &accepted
+	}
+
+	var enrollments []struct {
+		ID           uint
+		CourseName   string
+		AgreementURL string
+	}
+	if err := s.db.WithContext(ctx).Table("enrollments e").
+		Select("e.id, c.name AS course_name, e.agreement_url").
+		Joins("INNER JOIN courses c ON c.id = e.course_id").
+		Where("e.student_id = ? AND e.deleted_at IS NULL AND e.status NOT IN ?", link.StudentID, []string{"cancelled", "completed"}).
+		Where("e.agreement_url <> ''").
+		Order("e.id").
+		Scan(&enrollments).Error; err != nil {
+		return nil, err
+	}
+
+	pending, answered := []Consent{}, []Consent{}
+	add := func(consent Consent) {
+		if consent.Pending {
+			pending = append(pending, consent)
+		} else {
+			answered = append(answered, consent)
+		}
+	}
+	add(activities)
+	for _, enrollment := range enrollments {
+		enrollmentID := enrollment.ID
+		consent := Consent{
+			Type:         models.ConsentTypeEnrollment,
+			EnrollmentID: &enrollmentID,
+			CourseName:   enrollment.CourseName,
+			DocumentURL:  enrollment.AgreementURL,
+			Pending:      true,
+		}
+		// Vale a resposta do próprio responsável; sem ela, o aceite de outro responsável do aluno
+		answer, ok := ownTerms[enrollment.ID]
+		if !ok {
+			answer, ok = acceptedTerms[enrollment.ID]
+		}
+		if ok {
+			consent.Pending = false
+			consent.Accepted = &answer.Accepted
+			consent.RespondedAt = &answer.RespondedAt
+		}
+		add(consent)
+	}
+	return append(pending, answered...), nil
+}
+
+// ListJustifications returns the absence justifications of the dependent, most recent first
+func (s *service) ListJustifications(ctx context.Context, userID uint, studentID uint) ([]models.AbsenceJustification, error) {
+	if _, err := s.link(ctx, userID, studentID); err != nil {
+		return nil, err
+	}
+
+	justifications := []models.AbsenceJustification{}
+	if err := s.db.WithContext(ctx).
+		Where("student_id = ?", studentID).
+		Order("start_date DESC").
+		Find(&justifications).Error; err != nil {
+		return nil, err
+	}
+	return justifications, nil
+}
+
+// SubmitJustification sends an absence justification for a course the dependent is enrolled in;
+// it waits for the coordination's review like the ones registered at the front desk
+func (s *service) SubmitJustification(ctx context.Context, userID uint, studentID uint, input JustificationInput) (*models.AbsenceJustification, error) {
+	if _, err := s.link(ctx, userID, studentID); err != nil {
+		return nil, err
+	}
+
+	input.Reason = strings.TrimSpace(input.Reason)
+	if err := validateJustification(input); err != nil {
+		return nil, err
+	}
+
+	var enrolled int64
+	if err := s.db.WithContext(ctx).Model(&models.Enrollment{}).
+		Where("student_id = ? AND course_id = ? AND deleted_at IS NULL AND status <> ?", studentID, input.CourseID, "cancelled").
+		Count(&enrolled).Error; err != nil {
+		return nil, err
+	}
+	if enrolled == 0 {
+		return nil, fmt.Errorf("%w: the student is not enrolled in course %d", ErrInvalidJustification, input.CourseID)
+	}
+
+	justification := models.AbsenceJustification{
+		StudentID:     studentID,
+		CourseID:      input.CourseID,
+		StartDate:     input.StartDate,
+		EndDate:       input.EndDate,
+		Reason:        input.Reason,
+		DocumentURL:   strings.TrimSpace(input.DocumentURL),
+		Status:        "pending",
+		SubmittedByID: userID,
+	}
+	if err := s.db.WithContext(ctx).Create(&justification).Error; err != nil {
+		return nil, err
+	}
+	return &justification, nil
+}
+
+// validateJustification checks the fields of a justification before the enrollment lookup
+func validateJustification(input JustificationInput) error {
+	switch {
+	case input.CourseID == 0:
+		return fmt.Errorf("%w: courseId is required", ErrInvalidJustification)
+	case input.Reason == "":
+		return fmt.Errorf("%w: reason is required", ErrInvalidJustification)
+	case input.StartDate.IsZero() || input.EndDate.IsZero():
+		return fmt.Errorf("%w: startDate and endDate are required", ErrInvalidJustification)
+	case input.EndDate.Before(input.StartDate):
+		return fmt.Errorf("%w: endDate is before startDate", ErrInvalidJustification)
+	case input.EndDate.Sub(input.StartDate) > maxJustificationDays*24*time.Hour:
+		return fmt.Errorf("%w: the period is longer than %d days", ErrInvalidJustification, maxJustificationDays)
+	}
+	return nil
+}
+
+// link returns the guardian record tying the user to the student. Students of other families get
+// the same error as missing ones, so IDs cannot be probed.
+func (s *service) link(ctx context.Context, userID uint, studentID uint) (*guardianLink, error) {
+	if userID == 0 {
+		return nil, ErrDependentNotFound
+	}
+	var link guardianLink
+	if err := s.db.WithContext(ctx).Raw(guardianLinks+" AND g.student_id = ? ORDER BY g.id LIMIT 1", userID, studentID).
+		Scan(&link).Error; err != nil {
+		return nil, err
+	}
+	if link.ID == 0 {
+		return nil, ErrDependentNotFound
+	}
+	return &link, nil
+}
+
+func sameEnrollment(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
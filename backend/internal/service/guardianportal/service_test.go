@@ -0,0 +1,47 @@
+package guardianportal
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateJustification(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	valid := JustificationInput{CourseID: 4, StartDate: monday, EndDate: monday.AddDate(0, 0, 2), Reason: "Consulta médica"}
+
+	if err := validateJustification(valid); err != nil {
+		t.Fatalf("Expected a valid justification, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*JustificationInput)
+	}{
+		{"missing course", func(in *JustificationInput) { in.CourseID = 0 }},
+		{"missing reason", func(in *JustificationInput) { in.Reason = "" }},
+		{"missing end date", func(in *JustificationInput) { in.EndDate = time.Time{} }},
+		{"end before start", func(in *JustificationInput) { in.EndDate = monday.AddDate(0, 0, -1) }},
+		{"period too long", func(in *JustificationInput) { in.EndDate = monday.AddDate(0, 0, maxJustificationDays+1) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := valid
+			tt.modify(&input)
+			if err := validateJustification(input); !errors.Is(err, ErrInvalidJustification) {
+				t.Errorf("Expected ErrInvalidJustification, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSameEnrollment(t *testing.T) {
+	one, otherOne, two := uint(1), uint(1), uint(2)
+
+	if !sameEnrollment(nil, nil) || !sameEnrollment(&one, &otherOne) {
+		t.Error("Expected nil/nil and equal IDs to match")
+	}
+	if sameEnrollment(&one, nil) || sameEnrollment(nil, &one) || sameEnrollment(&one, &two) {
+		t.Error("Expected different enrollments not to match")
+	}
+}
@@ -0,0 +1,587 @@
+// backend/internal/service/invitations/service.go
+package invitations
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/teachers"
+)
+
+// Status values of models.TeacherInvitation; expired is derived from ExpiresAt and never stored.
+// Accepting marks an invitation claimed by an acceptance in progress (see Accept).
+const (
+	StatusPending   = "pending"
+	StatusAccepting = "accepting"
+	StatusAccepted  = "accepted"
+	StatusRevoked   = "revoked"
+	StatusExpired   = "expired"
+)
+
+// Volunteer terms signed on acceptance are valid for one year
+const termValidity = 365 * 24 * time.Hour
+
+// minPasswordLength is the minimum length of the password chosen by the volunteer
+const minPasswordLength = 8
+
+var (
+	// ErrInvitationNotFound is returned for unknown invitations or tokens
+	ErrInvitationNotFound = errors.New("invitation not found")
+	// ErrInvitationExpired is returned when the link is used after its expiry
+	ErrInvitationExpired = errors.New("invitation expired")
+	// ErrInvitationClosed is returned when the invitation was already accepted or was revoked
+	ErrInvitationClosed = errors.New("invitation is no longer available")
+	// ErrAlreadyInvited is returned when the email already has a pending invitation (resend it instead)
+	ErrAlreadyInvited = errors.New("there is already a pending invitation for this email")
+	// ErrEmailInUse is returned when a user already has the email
+	ErrEmailInUse = errors.New("a user with this email already exists")
+	// ErrInvalidInvitation is returned for malformed invitations
+	ErrInvalidInvitation = errors.New("invalid invitation")
+	// ErrInvalidAcceptance is returned when the volunteer data is incomplete or the term was not accepted
+	ErrInvalidAcceptance = errors.New("invalid acceptance")
+	// ErrNoVolunteerTerm is returned when no volunteer term template is active
+	ErrNoVolunteerTerm = errors.New("no active volunteer term")
+	// ErrTermOutdated is returned when the volunteer accepted a term version that is no longer active
+	ErrTermOutdated = errors.New("the volunteer term was updated, review it again")
+	// ErrInvalidTerm is returned for malformed volunteer term templates
+	ErrInvalidTerm = errors.New("invalid volunteer term")
+)
+
+// Mailer sends the invitation links
+type Mailer interface {
+	SendTeacherInvitationEmail(invitationID uint, to, name, inviteURL, message string, expiresAt time.Time) error
+}
+
+// Professors creates the teacher records and accounts (implemented by teachers.Service)
+type Professors interface {
+	RegisterInvitedProfessor(ctx context.Context, input teachers.ProfessorInput, password string) (*teachers.Professor, error)
+	GetProfessorByID(ctx context.Context, id uint) (*teachers.Professor, error)
+}
+
+// InviteInput is the coordinator's invitation
+type InviteInput struct {
+	Email   string `json:"email"`
+	Name    string `json:"name"`
+	Message string `json:"message"` // Recado opcional incluído no email
+}
+
+// Sent is an invitation just sent; InviteURL carries the one-time token and is returned only here,
+// so the coordinator can also share it by other channels
+type Sent struct {
+	Invitation models.TeacherInvitation `json:"invitation"`
+	InviteURL  string                   `json:"inviteUrl"`
+	EmailSent  bool                     `json:"emailSent"`
+}
+
+// TermTemplate is a version of the volunteer term
+type TermTemplate struct {
+	ID        uint      `json:"id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Version   string    `json:"version"`
+	IsActive  bool      `json:"isActive"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TermTemplateInput publishes a new version of the volunteer term
+type TermTemplateInput struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	Version string `json:"version"`
+}
+
+// Onboarding is what the volunteer sees when opening the link
+type Onboarding struct {
+	Email     string       `json:"email"`
+	Name      string       `json:"name"`
+	Message   string       `json:"message"`
+	ExpiresAt time.Time    `json:"expiresAt"`
+	Term      TermTemplate `json:"term"`
+}
+
+// AcceptInput completes the registration: profile, skills and availability, the accepted term and
+// the password of the new account. The email always comes from the invitation.
+type AcceptInput struct {
+	Profile        teachers.ProfessorInput
+	Password       string
+	AcceptTerm     bool
+	TermTemplateID uint // Versão do termo lida pelo voluntário
+	IPAddress      string
+	DeviceInfo     string
+}
+
+// Service defines the interface for the teacher onboarding invitations
+type Service interface {
+	// Coordenação
+	Invite(ctx context.Context, input InviteInput, invitedByID uint) (*Sent, error)
+	List(ctx context.Context, status string) ([]models.TeacherInvitation, error)
+	Resend(ctx context.Context, id uint) (*Sent, error)
+	Revoke(ctx context.Context, id uint) (*models.TeacherInvitation, error)
+	ListTermTemplates(ctx context.Context) ([]TermTemplate, error)
+	PublishTermTemplate(ctx context.Context, input TermTemplateInput, userID uint) (*TermTemplate, error)
+
+	// Voluntário (link de uso único)
+	Open(ctx context.Context, token string) (*Onboarding, error)
+	Accept(ctx context.Context, token string, input AcceptInput) (*teachers.Professor, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db          *gorm.DB
+	professors  Professors
+	mailer      Mailer
+	frontendURL string
+	validFor    time.Duration
+	now         func() time.Time
+}
+
+// NewService creates a new invitation service. The links point to frontendURL and are valid for validFor.
+func NewService(db *gorm.DB, professors Professors, mailer Mailer, frontendURL string, validFor time.Duration) Service {
+	return &service{
+		db:          db,
+		professors:  professors,
+		mailer:      mailer,
+		frontendURL: strings.TrimRight(frontendURL, "/"),
+		validFor:    validFor,
+		now:         time.Now,
+	}
+}
+
+// Invite creates the invitation and emails the one-time link
+func (s *service) Invite(ctx context.Context, input InviteInput, invitedByID uint) (*Sent, error) {
+	address, err := normalizeEmail(input.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	var users int64
+	if err := s.db.WithContext(ctx).Model(&models.User{}).
+		Where("LOWER(email) = ?", address).
+		Count(&users).Error; err != nil {
+		return nil, err
+	}
+	if users > 0 {
+		return nil, ErrEmailInUse
+	}
+
+	var pending int64
+	if err := s.db.WithContext(ctx).Model(&models.TeacherInvitation{}).
+		Where("LOWER(email) = ? AND status IN ?", address, []string{StatusPending, StatusAccepting}).
+		Count(&pending).Error; err != nil {
+		return nil, err
+	}
+	if pending > 0 {
+		return nil, ErrAlreadyInvited
+	}
+
+	token, hash, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	invitation := models.TeacherInvitation{
+		Email:       address,
+		Name:        strings.TrimSpace(input.Name),
+		Message:     strings.TrimSpace(input.Message),
+		TokenHash:   hash,
+		Status:      StatusPending,
+		ExpiresAt:   now.Add(s.validFor),
+		SentCount:   1,
+		LastSentAt:  now,
+		InvitedByID: invitedByID,
+	}
+	if err := s.db.WithContext(ctx).Create(&invitation).Error; err != nil {
+		return nil, fmt.Errorf("error creating invitation: %w", err)
+	}
+
+	return s.send(invitation, token), nil
+}
+
+// List returns the invitations, newest first; status filters by pending, accepting, accepted, revoked or expired
+func (s *service) List(ctx context.Context, status string) ([]models.TeacherInvitation, error) {
+	now := s.now()
+	query := s.db.WithContext(ctx).Model(&models.TeacherInvitation{})
+	switch status {
+	case "":
+	case StatusPending:
+		query = query.Where("status = ? AND expires_at > ?", StatusPending, now)
+	case StatusExpired:
+		query = query.Where("status = ? AND expires_at <= ?", StatusPending, now)
+	case StatusAccepting, StatusAccepted, StatusRevoked:
+		query = query.Where("status = ?", status)
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidInvitation, status)
+	}
+
+	var list []models.TeacherInvitation
+	if err := query.Order("created_at DESC").Find(&list).Error; err != nil {
+		return nil, err
+	}
+	for i := range list {
+		list[i].Status = effectiveStatus(list[i], now)
+	}
+	return list, nil
+}
+
+// Resend issues a new link (the previous one stops working) and renews the expiry
+func (s *service) Resend(ctx context.Context, id uint) (*Sent, error) {
+	invitation, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.Status != StatusPending {
+		return nil, fmt.Errorf("%w: invitation is %s", ErrInvitationClosed, invitation.Status)
+	}
+
+	token, hash, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	invitation.TokenHash = hash
+	invitation.ExpiresAt = now.Add(s.validFor)
+	invitation.SentCount++
+	invitation.LastSentAt = now
+	if err := s.db.WithContext(ctx).Model(invitation).Updates(map[string]interface{}{
+		"token_hash":   invitation.TokenHash,
+		"expires_at":   invitation.ExpiresAt,
+		"sent_count":   invitation.SentCount,
+		"last_sent_at": invitation.LastSentAt,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	return s.send(*invitation, token), nil
+}
+
+// Revoke cancels a pending invitation, or one left accepting by an acceptance that stopped before
+// creating the teacher
+func (s *service) Revoke(ctx context.Context, id uint) (*models.TeacherInvitation, error) {
+	invitation, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.Status != StatusPending && (invitation.Status != StatusAccepting || invitation.TeacherID != nil) {
+		return nil, fmt.Errorf("%w: invitation is %s", ErrInvitationClosed, invitation.Status)
+	}
+
+	result := s.db.WithContext(ctx).Model(&models.TeacherInvitation{}).
+		Where("id = ? AND status = ? AND teacher_id IS NULL", invitation.ID, invitation.Status).
+		Update("status", StatusRevoked)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInvitationClosed
+	}
+	invitation.Status = StatusRevoked
+	return invitation, nil
+}
+
+// ListTermTemplates returns the versions of the volunteer term, newest first
+func (s *service) ListTermTemplates(ctx context.Context) ([]TermTemplate, error) {
+	var templates []models.VolunteerTermTemplate
+	if err := s.db.WithContext(ctx).Order("id DESC").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+
+	list := make([]TermTemplate, 0, len(templates))
+	for _, template := range templates {
+		list = append(list, newTermTemplate(template))
+	}
+	return list, nil
+}
+
+// PublishTermTemplate creates a new version of the volunteer term and deactivates the previous ones
+func (s *service) PublishTermTemplate(ctx context.Context, input TermTemplateInput, userID uint) (*TermTemplate, error) {
+	template := models.VolunteerTermTemplate{
+		Title:       strings.TrimSpace(input.Title),
+		Content:     strings.TrimSpace(input.Content),
+		Version:     strings.TrimSpace(input.Version),
+		IsActive:    true,
+		CreatedByID: userID,
+	}
+	if template.Title == "" || template.Content == "" || template.Version == "" {
+		return nil, fmt.Errorf("%w: title, content and version are required", ErrInvalidTerm)
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.VolunteerTermTemplate{}).
+			Where("is_active = ?", true).
+			Update("is_active", false).Error; err != nil {
+			return err
+		}
+		return tx.Omit("CreatedBy").Create(&template).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := newTermTemplate(template)
+	return &result, nil
+}
+
+// Open returns the invitation data and the term to be accepted
+func (s *service) Open(ctx context.Context, token string) (*Onboarding, error) {
+	invitation, err := s.findByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	term, err := s.activeTerm(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Onboarding{
+		Email:     invitation.Email,
+		Name:      invitation.Name,
+		Message:   invitation.Message,
+		ExpiresAt: invitation.ExpiresAt,
+		Term:      newTermTemplate(*term),
+	}, nil
+}
+
+// Accept creates the teacher (profile, skills, availability and account) and records the signed term.
+// The invitation is claimed (pending -> accepting) before the teacher is created, so concurrent uses of
+// the link create a single teacher; if recording the term fails, using the link again only retries
+// that step. The link cannot be used again afterwards.
+func (s *service) Accept(ctx context.Context, token string, input AcceptInput) (*teachers.Professor, error) {
+	invitation, err := s.findByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !input.AcceptTerm {
+		return nil, fmt.Errorf("%w: the volunteer term must be accepted", ErrInvalidAcceptance)
+	}
+	if len([]rune(input.Password)) < minPasswordLength {
+		return nil, fmt.Errorf("%w: the password must have at least %d characters", ErrInvalidAcceptance, minPasswordLength)
+	}
+	term, err := s.activeTerm(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if input.TermTemplateID != term.ID {
+		return nil, ErrTermOutdated
+	}
+
+	professor, err := s.register(ctx, invitation, input)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// volunteer_terms.teacher_id references the user of the teacher
+		signed := models.VolunteerTerm{
+			TeacherID:      professor.UserID,
+			TemplateID:     term.ID,
+			SignedAt:       now,
+			ExpirationDate: now.Add(termValidity),
+			IPAddress:      input.IPAddress,
+			DeviceInfo:     input.DeviceInfo,
+			SignatureType:  "digital",
+			Status:         "active",
+			CreatedByID:    professor.UserID,
+		}
+		if err := tx.Omit("Teacher", "Template", "CreatedBy", "History").Create(&signed).Error; err != nil {
+			return err
+		}
+		history := models.VolunteerTermHistory{
+			TermID:      signed.ID,
+			ActionType:  "signed",
+			ActionDate:  now,
+			ActionByID:  &professor.UserID,
+			Details:     fmt.Sprintf("Termo versão %s aceito no convite de cadastro #%d", term.Version, invitation.ID),
+			CreatedByID: professor.UserID,
+		}
+		if err := tx.Omit("Term", "ActionBy", "CreatedBy").Create(&history).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.TeacherInvitation{}).
+			Where("id = ? AND status = ? AND teacher_id = ?", invitation.ID, StatusAccepting, professor.ID).
+			Updates(map[string]interface{}{
+				"status":      StatusAccepted,
+				"accepted_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvitationClosed
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error completing invitation %d for teacher %d: %w", invitation.ID, professor.ID, err)
+	}
+
+	return professor, nil
+}
+
+// register claims the invitation and creates the teacher; an invitation already accepting with its
+// teacher created resumes with that teacher
+func (s *service) register(ctx context.Context, invitation *models.TeacherInvitation, input AcceptInput) (*teachers.Professor, error) {
+	if invitation.Status == StatusAccepting {
+		if invitation.TeacherID == nil {
+			return nil, fmt.Errorf("%w: the invitation is already being accepted", ErrInvitationClosed)
+		}
+		return s.professors.GetProfessorByID(ctx, *invitation.TeacherID)
+	}
+
+	claim := s.db.WithContext(ctx).Model(&models.TeacherInvitation{}).
+		Where("id = ? AND status = ?", invitation.ID, StatusPending).
+		Update("status", StatusAccepting)
+	if claim.Error != nil {
+		return nil, claim.Error
+	}
+	if claim.RowsAffected == 0 {
+		return nil, ErrInvitationClosed
+	}
+
+	profile := input.Profile
+	profile.Email = invitation.Email
+	if strings.TrimSpace(profile.Name) == "" {
+		profile.Name = invitation.Name
+	}
+	active := true
+	profile.Active = &active
+
+	professor, err := s.professors.RegisterInvitedProfessor(ctx, profile, input.Password)
+	if err != nil {
+		// Nothing was created: the link can be used again
+		if release := s.db.WithContext(ctx).Model(&models.TeacherInvitation{}).
+			Where("id = ? AND status = ? AND teacher_id IS NULL", invitation.ID, StatusAccepting).
+			Update("status", StatusPending).Error; release != nil {
+			fmt.Printf("Warning: failed to release invitation %d: %v\n", invitation.ID, release)
+		}
+		if errors.Is(err, teachers.ErrInvalidProfessor) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAcceptance, err)
+		}
+		if errors.Is(err, teachers.ErrEmailInUse) {
+			return nil, ErrEmailInUse
+		}
+		return nil, err
+	}
+
+	if err := s.db.WithContext(ctx).Model(&models.TeacherInvitation{}).
+		Where("id = ?", invitation.ID).
+		Update("teacher_id", professor.ID).Error; err != nil {
+		return nil, fmt.Errorf("error recording teacher %d on invitation %d: %w", professor.ID, invitation.ID, err)
+	}
+	return professor, nil
+}
+
+func (s *service) find(ctx context.Context, id uint) (*models.TeacherInvitation, error) {
+	var invitation models.TeacherInvitation
+	if err := s.db.WithContext(ctx).First(&invitation, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// findByToken returns the pending (or accepting) invitation of a link
+func (s *service) findByToken(ctx context.Context, token string) (*models.TeacherInvitation, error) {
+	if token == "" {
+		return nil, ErrInvitationNotFound
+	}
+
+	var invitation models.TeacherInvitation
+	if err := s.db.WithContext(ctx).Where("token_hash = ?", hashToken(token)).First(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvitationNotFound
+		}
+		return nil, err
+	}
+
+	switch effectiveStatus(invitation, s.now()) {
+	case StatusPending, StatusAccepting:
+		return &invitation, nil
+	case StatusExpired:
+		return nil, ErrInvitationExpired
+	default:
+		return nil, ErrInvitationClosed
+	}
+}
+
+func (s *service) activeTerm(ctx context.Context) (*models.VolunteerTermTemplate, error) {
+	var term models.VolunteerTermTemplate
+	if err := s.db.WithContext(ctx).Where("is_active = ?", true).Order("id DESC").First(&term).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoVolunteerTerm
+		}
+		return nil, err
+	}
+	return &term, nil
+}
+
+// send emails the link; a failure is reported in EmailSent so the coordinator can resend or share it
+func (s *service) send(invitation models.TeacherInvitation, token string) *Sent {
+	sent := &Sent{
+		Invitation: invitation,
+		InviteURL:  s.frontendURL + "/convite-professor/" + token,
+	}
+	if s.mailer != nil {
+		if err := s.mailer.SendTeacherInvitationEmail(invitation.ID, invitation.Email, invitation.Name, sent.InviteURL, invitation.Message, invitation.ExpiresAt); err != nil {
+			fmt.Printf("Warning: failed to send invitation %d: %v\n", invitation.ID, err)
+		} else {
+			sent.EmailSent = true
+		}
+	}
+	return sent
+}
+
+// effectiveStatus reports pending invitations past their expiry as expired
+func effectiveStatus(invitation models.TeacherInvitation, now time.Time) string {
+	if invitation.Status == StatusPending && !now.Before(invitation.ExpiresAt) {
+		return StatusExpired
+	}
+	return invitation.Status
+}
+
+func normalizeEmail(value string) (string, error) {
+	address, err := mail.ParseAddress(strings.TrimSpace(value))
+	if err != nil || address.Address != strings.TrimSpace(value) {
+		return "", fmt.Errorf("%w: invalid email %q", ErrInvalidInvitation, value)
+	}
+	return strings.ToLower(address.Address), nil
+}
+
+func newTermTemplate(template models.VolunteerTermTemplate) TermTemplate {
+	return TermTemplate{
+		ID:        template.ID,
+		Title:     template.Title,
+		Content:   template.Content,
+		Version:   template.Version,
+		IsActive:  template.IsActive,
+		CreatedAt: template.CreatedAt,
+	}
+}
+
+// generateToken returns a random 256-bit hex token and the hash stored in place of it
+func generateToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := hex.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,59 @@
+package invitations
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+func TestEffectiveStatus(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	pending := models.TeacherInvitation{Status: StatusPending, ExpiresAt: now.Add(time.Hour)}
+	if status := effectiveStatus(pending, now); status != StatusPending {
+		t.Errorf("Expected pending before the expiry, got %s", status)
+	}
+
+	pending.ExpiresAt = now
+	if status := effectiveStatus(pending, now); status != StatusExpired {
+		t.Errorf("Expected expired at the expiry, got %s", status)
+	}
+
+	accepted := models.TeacherInvitation{Status: StatusAccepted, ExpiresAt: now.Add(-time.Hour)}
+	if status := effectiveStatus(accepted, now); status != StatusAccepted {
+		t.Errorf("Expected accepted invitations to keep their status, got %s", status)
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	address, err := normalizeEmail("  Maria.Silva@Example.org ")
+	if err != nil || address != "maria.silva@example.org" {
+		t.Errorf("Expected the lowercased address, got %q (%v)", address, err)
+	}
+
+	for _, value := range []string{"", "maria", "Maria <maria@example.org>"} {
+		if _, err := normalizeEmail(value); !errors.Is(err, ErrInvalidInvitation) {
+			t.Errorf("Expected ErrInvalidInvitation for %q, got %v", value, err)
+		}
+	}
+}
+
+func TestGenerateToken(t *testing.T) {
+	token, hash, err := generateToken()
+	if err != nil {
+		t.Fatalf("Expected a token, got %v", err)
+	}
+	if len(token) != 64 || len(hash) != 64 {
+		t.Errorf("Expected 64 hex characters, got %d and %d", len(token), len(hash))
+	}
+	if hash == token || hash != hashToken(token) {
+		t.Error("Expected only the token hash to be stored and to match the token")
+	}
+
+	other, _, _ := generateToken()
+	if other == token {
+		t.Error("Expected distinct tokens")
+	}
+}
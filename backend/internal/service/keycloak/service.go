@@ -188,6 +188,20 @@ func (s *KeycloakService) SetTemporaryPassword(ctx context.Context, userID, pass
 	return nil
 }
 
+// SetPassword sets a permanent password chosen by the user (no reset on the first login)
+func (s *KeycloakService) SetPassword(ctx context.Context, userID, password string) error {
+	// Authenticate first
+	if err := s.authenticate(ctx); err != nil {
+		return err
+	}
+
+	if err := s.client.SetPassword(ctx, s.accessToken, userID, s.realm, password, false); err != nil {
+		return fmt.Errorf("failed to set password: %w", err)
+	}
+
+	return nil
+}
+
 // GetUsersByRole fetches users who have a specific realm role
 func (s *KeycloakService) GetUsersByRole(ctx context.Context, roleName string) ([]*gocloak.User, error) {
 	// Authenticate first
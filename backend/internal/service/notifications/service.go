@@ -41,6 +41,7 @@ type NotificationRequest struct {
 	Data          map[string]interface{} // Dados extras
 	ActionURL     string                 // Link para ação
 	ForceInApp    bool                   // Forçar visualização in-app
+	Buttons       []InlineButton         // Ações extras no Telegram (ex: aceitar/recusar)
 }
 
 // Service interface para o serviço de notificações
@@ -192,11 +193,12 @@ func (s *service) sendTelegram(req NotificationRequest) error {
 	// Formatar mensagem baseada no tipo de evento
 	text := s.formatTelegramMessage(req)
 
-	// Adicionar botões se houver ActionURL
+	// Adicionar botões se houver ActionURL ou ações
+	buttons := append([]InlineButton{}, req.Buttons...)
 	if req.ActionURL != "" {
-		buttons := []InlineButton{
-			{Text: "📱 Ver no App", CallbackData: fmt.Sprintf("open:%s", req.ActionURL)},
-		}
+		buttons = append(buttons, InlineButton{Text: "📱 Ver no App", CallbackData: fmt.Sprintf("open:%s", req.ActionURL)})
+	}
+	if len(buttons) > 0 {
 		return s.telegramService.SendMessageWithButtons(channel.Identifier, text, buttons)
 	}
 
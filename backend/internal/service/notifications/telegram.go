@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 // TelegramService gerencia comunicação com Telegram Bot API
@@ -131,6 +133,59 @@ type Update struct {
 		} `json:"from"`
 		Text string `json:"text"`
 	} `json:"message"`
+	CallbackQuery *CallbackQuery `json:"callback_query"`
+}
+
+// CallbackQuery representa o clique em um botão inline
+type CallbackQuery struct {
+	ID   string `json:"id"`
+	From struct {
+		ID int64 `json:"id"`
+	} `json:"from"`
+	Message *struct {
+		Chat struct {
+			ID int64 `json:"id"`
+		} `json:"chat"`
+	} `json:"message"`
+	Data string `json:"data"`
+}
+
+// AnswerCallbackQuery confirma o clique no botão, exibindo um aviso curto ao usuário
+func (s *TelegramService) AnswerCallbackQuery(callbackQueryID string, text string) error {
+	if !s.IsConfigured() {
+		return fmt.Errorf("telegram service not configured")
+	}
+
+	url := fmt.Sprintf("%s/answerCallbackQuery", s.apiURL)
+
+	payload := map[string]interface{}{
+		"callback_query_id": callbackQueryID,
+		"text":              text,
+	}
+
+	return s.sendRequest(url, payload)
+}
+
+// SubstitutionCallbackData monta o callback dos botões de resposta a uma substituição
+func SubstitutionCallbackData(substitutionID uint, accept bool) string {
+	action := "decline"
+	if accept {
+		action = "accept"
+	}
+	return fmt.Sprintf("subst:%s:%d", action, substitutionID)
+}
+
+// ParseSubstitutionCallbackData interpreta o callback gerado por SubstitutionCallbackData
+func ParseSubstitutionCallbackData(data string) (substitutionID uint, accept bool, ok bool) {
+	parts := strings.Split(data, ":")
+	if len(parts) != 3 || parts[0] != "subst" || (parts[1] != "accept" && parts[1] != "decline") {
+		return 0, false, false
+	}
+	id, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil || id == 0 {
+		return 0, false, false
+	}
+	return uint(id), parts[1] == "accept", true
 }
 
 // sendRequest envia requisição POST para a API do Telegram
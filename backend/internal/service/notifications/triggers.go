@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
 )
 
 // NotificationTriggers encapsula os gatilhos de notificação
@@ -93,17 +95,22 @@ func (t *NotificationTriggers) OnClassCancelled(studentIDs []uint, courseName st
 
 // === GATILHOS DE SUBSTITUIÇÃO ===
 
-// OnSubstitutionRequested dispara para professor substituto
-func (t *NotificationTriggers) OnSubstitutionRequested(substituteTeacherID uint, courseName string, date string, originalTeacher string) {
+// OnSubstitutionRequested dispara para professor substituto, com botões para aceitar ou recusar pelo Telegram
+func (t *NotificationTriggers) OnSubstitutionRequested(substituteUserID uint, substitutionID uint, courseName string, date string, originalTeacher string) {
 	req := NotificationRequest{
-		UserID:    substituteTeacherID,
+		UserID:    substituteUserID,
 		EventType: "substitution",
 		Title:     "Solicitação de Substituição",
 		Message:   fmt.Sprintf("Você foi selecionado para substituir %s na aula de %s no dia %s. Por favor, confirme sua disponibilidade.", originalTeacher, courseName, date),
 		Priority:  PriorityUrgent,
 		ActionURL: "/teacher/substitutions",
 		ForceInApp: true,
+		Buttons: []InlineButton{
+			{Text: "✅ Aceitar", CallbackData: SubstitutionCallbackData(substitutionID, true)},
+			{Text: "❌ Recusar", CallbackData: SubstitutionCallbackData(substitutionID, false)},
+		},
 		Data: map[string]interface{}{
+			"substitutionId":  substitutionID,
 			"courseName":      courseName,
 			"date":            date,
 			"originalTeacher": originalTeacher,
@@ -116,6 +123,70 @@ func (t *NotificationTriggers) OnSubstitutionRequested(substituteTeacherID uint,
 	t.service.SendNotification(ctx, req)
 }
 
+// OnSubstitutionAnswered avisa a coordenação da resposta do substituto
+func (t *NotificationTriggers) OnSubstitutionAnswered(requesterUserID uint, courseName string, date string, substituteName string, accepted bool) {
+	title, verb := "Substituição Confirmada", "aceitou"
+	if !accepted {
+		title, verb = "Substituição Recusada", "recusou"
+	}
+	req := NotificationRequest{
+		UserID:    requesterUserID,
+		EventType: "substitution",
+		Title:     title,
+		Message:   fmt.Sprintf("%s %s a substituição na aula de %s no dia %s.", substituteName, verb, courseName, date),
+		Priority:  PriorityMedium,
+		ActionURL: "/admin/substitutions",
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.service.SendNotification(ctx, req)
+}
+
+// OnSubstitutionUnfilled avisa a coordenação que nenhum candidato aceitou a substituição
+func (t *NotificationTriggers) OnSubstitutionUnfilled(requesterUserID uint, courseName string, date string) {
+	req := NotificationRequest{
+		UserID:    requesterUserID,
+		EventType: "substitution",
+		Title:     "Substituição Sem Professor",
+		Message:   fmt.Sprintf("Nenhum professor disponível aceitou a substituição na aula de %s no dia %s. Defina um substituto ou cancele a aula.", courseName, date),
+		Priority:  PriorityHigh,
+		ActionURL: "/admin/substitutions",
+		ForceInApp: true,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.service.SendNotification(ctx, req)
+}
+
+// OnTeacherAbsenceReported avisa a coordenação de uma ausência de professor e das aulas afetadas
+func (t *NotificationTriggers) OnTeacherAbsenceReported(coordinatorUserIDs []uint, teacherName string, period string, affected int, substitutionsRequested int, cancellationsProposed int) {
+	req := NotificationRequest{
+		EventType: "teacher_absence",
+		Title:     "Ausência de Professor",
+		Message: fmt.Sprintf("%s estará ausente em %s. Aulas afetadas: %d (substituição solicitada: %d, cancelamento proposto: %d).",
+			teacherName, period, affected, substitutionsRequested, cancellationsProposed),
+		Priority:  PriorityHigh,
+		ActionURL: "/admin/teacher-absences",
+		ForceInApp: cancellationsProposed > 0,
+		Data: map[string]interface{}{
+			"teacherName":            teacherName,
+			"period":                 period,
+			"affected":               affected,
+			"substitutionsRequested": substitutionsRequested,
+			"cancellationsProposed":  cancellationsProposed,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.service.SendToMultiple(ctx, coordinatorUserIDs, req)
+}
+
 // === GATILHOS DE MATRÍCULA ===
 
 // OnEnrollmentConfirmed dispara quando matrícula é confirmada
@@ -178,6 +249,81 @@ func (t *NotificationTriggers) OnIncidentCreated(studentID uint, incidentType st
 	t.service.SendNotification(ctx, req)
 }
 
+// === GATILHOS DE SAÍDA SUPERVISIONADA ===
+
+// OnStudentPickedUp avisa os responsáveis de como o aluno saiu da aula
+func (t *NotificationTriggers) OnStudentPickedUp(guardianUserIDs []uint, studentName string, courseName string, personName string, at time.Time, status models.PickupStatus) {
+	when := at.Format("02/01/2006 15:04")
+	req := NotificationRequest{
+		EventType: "pickup",
+		Title:     "Saída do Aluno",
+		Message:   fmt.Sprintf("%s foi retirado(a) da aula de %s por %s em %s.", studentName, courseName, personName, when),
+		Priority:  PriorityMedium,
+		ActionURL: "/guardian/dependents",
+		Data: map[string]interface{}{
+			"studentName": studentName,
+			"courseName":  courseName,
+			"personName":  personName,
+			"status":      status,
+		},
+	}
+	switch status {
+	case models.PickupStatusUnauthorized:
+		req.Title = "Retirada por Pessoa Não Autorizada"
+		req.Message = fmt.Sprintf("%s foi retirado(a) da aula de %s por %s, que não está autorizado(a) no cadastro, em %s. Entre em contato com a coordenação se não reconhecer essa pessoa.",
+			studentName, courseName, personName, when)
+		req.Priority = PriorityUrgent
+		req.ForceInApp = true
+	case models.PickupStatusNoPickup:
+		req.Title = "Aluno Sem Retirada"
+		req.Message = fmt.Sprintf("Ninguém retirou %s ao fim da aula de %s (%s). Entre em contato com a coordenação.", studentName, courseName, when)
+		req.Priority = PriorityUrgent
+		req.ForceInApp = true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.service.SendToMultiple(ctx, guardianUserIDs, req)
+}
+
+// === GATILHOS DE SITUAÇÃO DO ALUNO ===
+
+// OnStudentStatusChanged avisa o aluno da mudança de situação e do motivo
+func (t *NotificationTriggers) OnStudentStatusChanged(userID uint, status models.StudentStatus, reason string) {
+	title, label := "Situação Atualizada", string(status)
+	priority := PriorityMedium
+	switch status {
+	case models.StudentStatusActive:
+		title, label = "Cadastro Reativado", "ativo"
+	case models.StudentStatusInactive:
+		title, label = "Cadastro Inativado", "inativo"
+	case models.StudentStatusSuspended:
+		title, label = "Suspensão", "suspenso"
+		priority = PriorityHigh
+	}
+
+	req := NotificationRequest{
+		UserID:    userID,
+		EventType: "student_status",
+		Title:     title,
+		Message:   fmt.Sprintf("Sua situação no CECOR mudou para %s. Motivo: %s", label, reason),
+		Priority:  priority,
+		ActionURL: "/student/dashboard",
+		Data: map[string]interface{}{
+			"status": status,
+			"reason": reason,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := t.service.SendNotification(ctx, req); err != nil {
+		fmt.Printf("Erro ao enviar notificação de situação do aluno: %v\n", err)
+	}
+}
+
 // === GATILHOS DE PESQUISAS ===
 
 // OnSurveyAvailable dispara quando pesquisa está disponível
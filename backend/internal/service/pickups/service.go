@@ -0,0 +1,573 @@
+// backend/internal/service/pickups/service.go
+package pickups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+var (
+	// ErrSessionNotFound is returned when the class session does not exist
+	ErrSessionNotFound = errors.New("class session not found")
+	// ErrForbidden is returned when the user does not work in the session
+	ErrForbidden = errors.New("only the coordination and the teachers of the session can register pickups")
+	// ErrStudentNotInSession is returned for students not enrolled in the session's class
+	ErrStudentNotInSession = errors.New("student is not enrolled in the session's class")
+	// ErrAlreadyCheckedOut is returned when the student already has a pickup in the session
+	ErrAlreadyCheckedOut = errors.New("student already checked out of this session")
+	// ErrInvalidCheckout is returned for incomplete or inconsistent check-outs
+	ErrInvalidCheckout = errors.New("invalid check-out")
+)
+
+// adultAge is the age from which students leave on their own and the pickup is optional
+const adultAge = 18
+
+// Notifier notifies the guardians with a user account
+type Notifier interface {
+	OnStudentPickedUp(guardianUserIDs []uint, studentName string, courseName string, personName string, at time.Time, status models.PickupStatus)
+}
+
+// Mailer emails the guardians without a user account
+type Mailer interface {
+	SendEmail(ctx context.Context, to, subject, body string) error
+}
+
+// Staff is the user registering the check-out
+type Staff struct {
+	UserID uint
+	Admin  bool
+}
+
+// AuthorizedPerson is someone registered for the student who may be selected at check-out
+type AuthorizedPerson struct {
+	GuardianID   *uint  `json:"guardianId,omitempty"`
+	ContactID    *uint  `json:"contactId,omitempty"`
+	Name         string `json:"name"`
+	Relationship string `json:"relationship"`
+	Phone        string `json:"phone,omitempty"`
+	CanPickup    bool   `json:"canPickup"`
+}
+
+// RosterEntry is a student of the session with the people registered for the pickup
+type RosterEntry struct {
+	StudentID      uint                 `json:"studentId"`
+	Name           string               `json:"name"`
+	Age            int                  `json:"age"`
+	RequiresPickup bool                 `json:"requiresPickup"` // Menor de idade
+	People         []AuthorizedPerson   `json:"people"`
+	Pickup         *models.PickupRecord `json:"pickup,omitempty"`
+}
+
+// Roster is the check-out list of a session
+type Roster struct {
+	SessionID  uint          `json:"sessionId"`
+	CourseName string        `json:"courseName"`
+	ClassName  string        `json:"className,omitempty"`
+	Date       time.Time     `json:"date"`
+	Students   []RosterEntry `json:"students"`
+}
+
+// CheckoutInput selects who picked up the student. Exactly one of GuardianID, ContactID,
+// PersonName (someone not registered) or NoPickup is expected.
+type CheckoutInput struct {
+	StudentID      uint
+	GuardianID     *uint
+	ContactID      *uint
+	PersonName     string
+	PersonDocument string
+	NoPickup       bool
+	Notes          string
+}
+
+// Filter narrows the pickup history
+type Filter struct {
+	StudentID      *uint
+	From           *time.Time
+	To             *time.Time
+	ExceptionsOnly bool
+}
+
+// PickupEntry is a pickup record with the student, course and staff names
+type PickupEntry struct {
+	models.PickupRecord
+	StudentName    string    `json:"studentName"`
+	CourseName     string    `json:"courseName"`
+	SessionDate    time.Time `json:"sessionDate"`
+	RecordedByName string    `json:"recordedByName"`
+}
+
+// Service defines the interface for the supervised pickup registry
+type Service interface {
+	GetRoster(ctx context.Context, staff Staff, sessionID uint) (*Roster, error)
+	CheckOut(ctx context.Context, staff Staff, sessionID uint, input CheckoutInput) (*models.PickupRecord, error)
+	List(ctx context.Context, filter Filter) ([]PickupEntry, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db       *gorm.DB
+	notifier Notifier
+	mailer   Mailer
+	now      func() time.Time
+}
+
+// NewService creates a new pickup service. notifier and mailer may be nil.
+func NewService(db *gorm.DB, notifier Notifier, mailer Mailer) Service {
+	return &service{db: db, notifier: notifier, mailer: mailer, now: time.Now}
+}
+
+// sessionRow is the session with what the check-out needs
+type sessionRow struct {
+	ID               uint
+	CourseID         uint
+	CourseName       string
+	CourseClassID    *uint
+	ClassName        string
+	Date             time.Time
+	IsCancelled      bool
+	TeacherID        *uint // Professor efetivo (o substituto, quando há)
+	DefaultTeacherID *uint
+}
+
+// GetRoster returns the students of the session with the people who may pick them up and the
+// check-outs already registered
+func (s *service) GetRoster(ctx context.Context, staff Staff, sessionID uint) (*Roster, error) {
+	session, err := s.session(ctx, staff, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var students []struct {
+		ID        uint
+		Name      string
+		BirthDate time.Time
+	}
+	if err := s.enrolled(ctx, session).
+		Select("DISTINCT st.id, u.name, u.birth_date").
+		Joins("INNER JOIN users u ON u.id = st.user_id").
+		Order("u.name").
+		Scan(&students).Error; err != nil {
+		return nil, err
+	}
+
+	roster := &Roster{
+		SessionID:  session.ID,
+		CourseName: session.CourseName,
+		ClassName:  session.ClassName,
+		Date:       session.Date,
+		Students:   make([]RosterEntry, 0, len(students)),
+	}
+	for _, student := range students {
+		people, err := s.people(ctx, student.ID)
+		if err != nil {
+			return nil, err
+		}
+		entry := RosterEntry{StudentID: student.ID, Name: student.Name, People: people, RequiresPickup: true}
+		if !student.BirthDate.IsZero() {
+			user := models.User{BirthDate: student.BirthDate}
+			entry.Age = user.CalculateAge()
+			entry.RequiresPickup = entry.Age < adultAge
+		}
+
+		var record models.PickupRecord
+		err = s.db.WithContext(ctx).Where("class_session_id = ? AND student_id = ?", sessionID, student.ID).First(&record).Error
+		switch {
+		case err == nil:
+			entry.Pickup = &record
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, err
+		}
+		roster.Students = append(roster.Students, entry)
+	}
+	return roster, nil
+}
+
+// CheckOut registers who picked up the student, checking the person against the guardians and
+// contacts allowed to pick up. Unauthorized people and missing pickups are recorded as exceptions
+// (an unauthorized pickup needs notes); the guardians are notified in every case.
+func (s *service) CheckOut(ctx context.Context, staff Staff, sessionID uint, input CheckoutInput) (*models.PickupRecord, error) {
+	session, err := s.session(ctx, staff, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.IsCancelled {
+		return nil, fmt.Errorf("%w: the session was cancelled", ErrInvalidCheckout)
+	}
+	if err := validateCheckout(input); err != nil {
+		return nil, err
+	}
+
+	var enrolled int64
+	if err := s.enrolled(ctx, session).Where("st.id = ?", input.StudentID).Count(&enrolled).Error; err != nil {
+		return nil, err
+	}
+	if enrolled == 0 {
+		return nil, ErrStudentNotInSession
+	}
+
+	record := &models.PickupRecord{
+		ClassSessionID: sessionID,
+		StudentID:      input.StudentID,
+		GuardianID:     input.GuardianID,
+		ContactID:      input.ContactID,
+		PersonName:     strings.TrimSpace(input.PersonName),
+		PersonDocument: strings.TrimSpace(input.PersonDocument),
+		Notes:          strings.TrimSpace(input.Notes),
+		CheckedOutAt:   s.now(),
+		RecordedByID:   staff.UserID,
+	}
+	if !input.NoPickup {
+		if err := s.identify(ctx, record); err != nil {
+			return nil, err
+		}
+	}
+	record.Status = pickupStatus(input.NoPickup, record.Authorized)
+	if record.Status == models.PickupStatusUnauthorized && record.Notes == "" {
+		return nil, fmt.Errorf("%w: notes are required when the person is not authorized to pick up the student", ErrInvalidCheckout)
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&models.PickupRecord{}).
+			Where("class_session_id = ? AND student_id = ?", sessionID, input.StudentID).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrAlreadyCheckedOut
+		}
+		if err := tx.Create(record).Error; err != nil {
+			// Saída registrada por outra requisição entre a verificação e a gravação
+			if strings.Contains(err.Error(), "idx_pickup_records_session_student") {
+				return ErrAlreadyCheckedOut
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notified := s.notifyGuardians(ctx, session, record)
+	if notified > 0 {
+		record.GuardiansNotified = notified
+		if err := s.db.WithContext(ctx).Model(record).Update("guardians_notified", notified).Error; err != nil {
+			fmt.Printf("Warning: failed to update pickup %d: %v\n", record.ID, err)
+		}
+	}
+	return record, nil
+}
+
+// List returns the pickups for the coordination, most recent first
+func (s *service) List(ctx context.Context, filter Filter) ([]PickupEntry, error) {
+	query := s.db.WithContext(ctx).Table("pickup_records pr").
+		Select("pr.*, su.name AS student_name, c.name AS course_name, cs.date AS session_date, COALESCE(ru.name, '') AS recorded_by_name").
+		Joins("INNER JOIN students st ON st.id = pr.student_id").
+		Joins("INNER JOIN users su ON su.id = st.user_id").
+		Joins("INNER JOIN class_sessions cs ON cs.id = pr.class_session_id").
+		Joins("INNER JOIN courses c ON c.id = cs.course_id").
+		Joins("LEFT JOIN users ru ON ru.id = pr.recorded_by_id")
+	if filter.StudentID != nil {
+		query = query.Where("pr.student_id = ?", *filter.StudentID)
+	}
+	if filter.From != nil {
+		query = query.Where("pr.checked_out_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("pr.checked_out_at < ?", filter.To.AddDate(0, 0, 1))
+	}
+	if filter.ExceptionsOnly {
+		query = query.Where("pr.status <> ?", models.PickupStatusPickedUp)
+	}
+
+	entries := []PickupEntry{}
+	if err := query.Order("pr.checked_out_at DESC").Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// session loads the session and checks that the user may register its pickups: the coordination,
+// the effective or default teacher of the class, or a teacher assigned to it on the day
+func (s *service) session(ctx context.Context, staff Staff, sessionID uint) (*sessionRow, error) {
+	var session sessionRow
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT cs.id, cs.course_id, c.name AS course_name, cs.course_class_id, COALESCE(cc.name, '') AS class_name,
+			cs.date, cs.is_cancelled, COALESCE(cs.teacher_id, cc.default_teacher_id) AS teacher_id, cc.default_teacher_id
+		FROM class_sessions cs
+		INNER JOIN courses c ON c.id = cs.course_id
+		LEFT JOIN course_classes cc ON cc.id = cs.course_class_id
+		WHERE cs.id = ?
+	`, sessionID).Scan(&session).Error; err != nil {
+		return nil, err
+	}
+	if session.ID == 0 {
+		return nil, ErrSessionNotFound
+	}
+	if staff.Admin {
+		return &session, nil
+	}
+	if staff.UserID == 0 {
+		return nil, ErrForbidden
+	}
+
+	var teacherID uint
+	if err := s.db.WithContext(ctx).Model(&models.Teacher{}).
+		Select("id").Where("user_id = ?", staff.UserID).
+		Scan(&teacherID).Error; err != nil {
+		return nil, err
+	}
+	if teacherID == 0 {
+		return nil, ErrForbidden
+	}
+	if (session.TeacherID != nil && *session.TeacherID == teacherID) ||
+		(session.DefaultTeacherID != nil && *session.DefaultTeacherID == teacherID) {
+		return &session, nil
+	}
+
+	var assigned int64
+	query := s.db.WithContext(ctx).Model(&models.TeacherCourse{}).
+		Where("teacher_id = ? AND course_id = ? AND active = ?", teacherID, session.CourseID, true).
+		Where("start_date::date <= ?::date AND (end_date IS NULL OR end_date::date >= ?::date)", session.Date, session.Date)
+	if session.CourseClassID != nil {
+		query = query.Where("course_class_id IS NULL OR course_class_id = ?", *session.CourseClassID)
+	} else {
+		query = query.Where("course_class_id IS NULL")
+	}
+	if err := query.Count(&assigned).Error; err != nil {
+		return nil, err
+	}
+	if assigned == 0 {
+		return nil, ErrForbidden
+	}
+	return &session, nil
+}
+
+// enrolled selects (as st) the students with a live enrollment in the session's class; sessions
+// created before the classes use the course enrollments
+func (s *service) enrolled(ctx context.Context, session *sessionRow) *gorm.DB {
+	query := s.db.WithContext(ctx).Table("students st").
+		Joins("INNER JOIN enrollments e ON e.student_id = st.id AND e.deleted_at IS NULL AND e.status <> 'cancelled'").
+		Where("st.deleted_at IS NULL")
+	if session.CourseClassID != nil {
+		return query.
+			Joins("INNER JOIN enrollment_course_classes ecc ON ecc.enrollment_id = e.id").
+			Where("ecc.course_class_id = ?", *session.CourseClassID)
+	}
+	return query.Where("e.course_id = ?", session.CourseID)
+}
+
+// people lists the guardians and contacts of the student, those allowed to pick up first
+func (s *service) people(ctx context.Context, studentID uint) ([]AuthorizedPerson, error) {
+	var guardians []models.Guardian
+	if err := s.db.WithContext(ctx).
+		Where("student_id = ? AND deleted_at IS NULL", studentID).
+		Order("name").
+		Find(&guardians).Error; err != nil {
+		return nil, err
+	}
+	permitted, err := s.pickupPermissions(ctx, guardians)
+	if err != nil {
+		return nil, err
+	}
+
+	var contacts []models.UserContact
+	if err := s.db.WithContext(ctx).
+		Where("student_id = ?", studentID).
+		Order("name").
+		Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+
+	allowed, others := []AuthorizedPerson{}, []AuthorizedPerson{}
+	add := func(person AuthorizedPerson) {
+		if person.CanPickup {
+			allowed = append(allowed, person)
+		} else {
+			others = append(others, person)
+		}
+	}
+	for _, guardian := range guardians {
+		id := guardian.ID
+		add(AuthorizedPerson{
+			GuardianID:   &id,
+			Name:         guardian.Name,
+			Relationship: guardian.Relationship,
+			Phone:        guardian.Phone,
+			CanPickup:    guardian.CanPickup || permitted[guardian.ID],
+		})
+	}
+	for _, contact := range contacts {
+		id := contact.ID
+		add(AuthorizedPerson{
+			ContactID:    &id,
+			Name:         contact.Name,
+			Relationship: contact.Relationship,
+			Phone:        contact.Phone,
+			CanPickup:    contact.CanPickup,
+		})
+	}
+	return append(allowed, others...), nil
+}
+
+// pickupPermissions returns the guardians allowed to pick up by GuardianPermissions.PickupStudent
+func (s *service) pickupPermissions(ctx context.Context, guardians []models.Guardian) (map[uint]bool, error) {
+	permitted := map[uint]bool{}
+	if len(guardians) == 0 {
+		return permitted, nil
+	}
+	ids := make([]uint, 0, len(guardians))
+	for _, guardian := range guardians {
+		ids = append(ids, guardian.ID)
+	}
+
+	var granted []uint
+	if err := s.db.WithContext(ctx).Model(&models.GuardianPermissions{}).
+		Where("guardian_id IN ? AND pickup_student = ?", ids, true).
+		Pluck("guardian_id", &granted).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range granted {
+		permitted[id] = true
+	}
+	return permitted, nil
+}
+
+// identify fills in the person who picked up the student and whether they are authorized
+func (s *service) identify(ctx context.Context, record *models.PickupRecord) error {
+	switch {
+	case record.GuardianID != nil:
+		var guardian models.Guardian
+		if err := s.db.WithContext(ctx).
+			Where("id = ? AND student_id = ? AND deleted_at IS NULL", *record.GuardianID, record.StudentID).
+			First(&guardian).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: guardian %d is not registered for the student", ErrInvalidCheckout, *record.GuardianID)
+			}
+			return err
+		}
+		permitted, err := s.pickupPermissions(ctx, []models.Guardian{guardian})
+		if err != nil {
+			return err
+		}
+		record.PersonName = guardian.Name
+		record.PersonDocument = guardian.CPF
+		record.Authorized = guardian.CanPickup || permitted[guardian.ID]
+
+	case record.ContactID != nil:
+		var contact models.UserContact
+		if err := s.db.WithContext(ctx).
+			Where("id = ? AND student_id = ?", *record.ContactID, record.StudentID).
+			First(&contact).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: contact %d is not registered for the student", ErrInvalidCheckout, *record.ContactID)
+			}
+			return err
+		}
+		record.PersonName = contact.Name
+		record.PersonDocument = contact.CPF
+		record.Authorized = contact.CanPickup
+	}
+	return nil
+}
+
+// notifyGuardians tells the guardians who receive notifications how the student left: in the app
+// when they have an account, by email otherwise. Returns how many were notified.
+func (s *service) notifyGuardians(ctx context.Context, session *sessionRow, record *models.PickupRecord) int {
+	var guardians []models.Guardian
+	if err := s.db.WithContext(ctx).
+		Where("student_id = ? AND receive_notifications = ? AND deleted_at IS NULL", record.StudentID, true).
+		Find(&guardians).Error; err != nil {
+		fmt.Printf("Warning: failed to load guardians of student %d: %v\n", record.StudentID, err)
+		return 0
+	}
+
+	var studentName string
+	s.db.WithContext(ctx).Table("students st").
+		Select("u.name").
+		Joins("INNER JOIN users u ON u.id = st.user_id").
+		Where("st.id = ?", record.StudentID).
+		Scan(&studentName)
+
+	notified := 0
+	var userIDs []uint
+	for _, guardian := range guardians {
+		switch {
+		case guardian.UserID != nil:
+			userIDs = append(userIDs, *guardian.UserID)
+		case guardian.Email != "" && s.mailer != nil:
+			subject, body := pickupEmail(studentName, session.CourseName, record)
+			if err := s.mailer.SendEmail(ctx, guardian.Email, subject, body); err != nil {
+				fmt.Printf("Warning: failed to email guardian %d about pickup %d: %v\n", guardian.ID, record.ID, err)
+				continue
+			}
+			notified++
+		}
+	}
+	if len(userIDs) > 0 && s.notifier != nil {
+		s.notifier.OnStudentPickedUp(userIDs, studentName, session.CourseName, record.PersonName, record.CheckedOutAt, record.Status)
+		notified += len(userIDs)
+	}
+	return notified
+}
+
+// validateCheckout checks that exactly one way of leaving was selected
+func validateCheckout(input CheckoutInput) error {
+	if input.StudentID == 0 {
+		return fmt.Errorf("%w: studentId is required", ErrInvalidCheckout)
+	}
+	selected := 0
+	if input.GuardianID != nil {
+		selected++
+	}
+	if input.ContactID != nil {
+		selected++
+	}
+	if strings.TrimSpace(input.PersonName) != "" {
+		selected++
+	}
+	if input.NoPickup {
+		selected++
+	}
+	if selected != 1 {
+		return fmt.Errorf("%w: inform exactly one of guardianId, contactId, personName or noPickup", ErrInvalidCheckout)
+	}
+	return nil
+}
+
+// pickupStatus classifies the check-out
+func pickupStatus(noPickup bool, authorized bool) models.PickupStatus {
+	switch {
+	case noPickup:
+		return models.PickupStatusNoPickup
+	case authorized:
+		return models.PickupStatusPickedUp
+	default:
+		return models.PickupStatusUnauthorized
+	}
+}
+
+// pickupEmail writes the email sent to guardians without an account
+func pickupEmail(studentName string, courseName string, record *models.PickupRecord) (string, string) {
+	at := record.CheckedOutAt.Format("02/01/2006 15:04")
+	switch record.Status {
+	case models.PickupStatusNoPickup:
+		return "CECOR - Aluno sem retirada",
+			fmt.Sprintf("Ninguém retirou %s ao fim da aula de %s (%s). Entre em contato com a coordenação.", studentName, courseName, at)
+	case models.PickupStatusUnauthorized:
+		return "CECOR - Retirada por pessoa não autorizada",
+			fmt.Sprintf("%s foi retirado(a) da aula de %s por %s, que não está autorizado(a) no cadastro, em %s. Observação da equipe: %s",
+				studentName, courseName, record.PersonName, at, record.Notes)
+	default:
+		return "CECOR - Saída do aluno",
+			fmt.Sprintf("%s foi retirado(a) da aula de %s por %s em %s.", studentName, courseName, record.PersonName, at)
+	}
+}
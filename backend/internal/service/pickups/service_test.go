@@ -0,0 +1,75 @@
+package pickups
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+func TestValidateCheckout(t *testing.T) {
+	guardianID, contactID := uint(2), uint(3)
+
+	valid := []CheckoutInput{
+		{StudentID: 1, GuardianID: &guardianID},
+		{StudentID: 1, ContactID: &contactID},
+		{StudentID: 1, PersonName: "Vizinha"},
+		{StudentID: 1, NoPickup: true},
+	}
+	for _, input := range valid {
+		if err := validateCheckout(input); err != nil {
+			t.Errorf("Expected %+v to be valid, got %v", input, err)
+		}
+	}
+
+	invalid := []CheckoutInput{
+		{GuardianID: &guardianID},
+		{StudentID: 1},
+		{StudentID: 1, PersonName: "   "},
+		{StudentID: 1, GuardianID: &guardianID, NoPickup: true},
+		{StudentID: 1, GuardianID: &guardianID, ContactID: &contactID},
+	}
+	for _, input := range invalid {
+		if err := validateCheckout(input); !errors.Is(err, ErrInvalidCheckout) {
+			t.Errorf("Expected ErrInvalidCheckout for %+v, got %v", input, err)
+		}
+	}
+}
+
+func TestPickupStatus(t *testing.T) {
+	tests := []struct {
+		noPickup   bool
+		authorized bool
+		want       models.PickupStatus
+	}{
+		{false, true, models.PickupStatusPickedUp},
+		{false, false, models.PickupStatusUnauthorized},
+		{true, false, models.PickupStatusNoPickup},
+	}
+	for _, tt := range tests {
+		if got := pickupStatus(tt.noPickup, tt.authorized); got != tt.want {
+			t.Errorf("pickupStatus(%v, %v) = %s, want %s", tt.noPickup, tt.authorized, got, tt.want)
+		}
+	}
+}
+
+func TestPickupEmail(t *testing.T) {
+	record := &models.PickupRecord{
+		Status:       models.PickupStatusUnauthorized,
+		PersonName:   "Carlos",
+		Notes:        "Liberado após ligação para a mãe",
+		CheckedOutAt: time.Date(2026, 3, 2, 11, 5, 0, 0, time.UTC),
+	}
+
+	subject, body := pickupEmail("Ana", "Inglês Kids", record)
+	if !strings.Contains(subject, "não autorizada") {
+		t.Errorf("Expected the unauthorized subject, got %q", subject)
+	}
+	for _, part := range []string{"Ana", "Inglês Kids", "Carlos", "02/03/2026 11:05", record.Notes} {
+		if !strings.Contains(body, part) {
+			t.Errorf("Expected the body to mention %q, got %q", part, body)
+		}
+	}
+}
@@ -10,6 +10,37 @@ import (
 type Service interface {
 	GetCourseAttendanceStats(ctx context.Context, courseID uint, startDate, endDate time.Time) ([]repository.CourseAttendanceStats, error)
 	GetStudentAttendanceStats(ctx context.Context, studentID uint, startDate, endDate time.Time) ([]repository.StudentAttendanceStats, error)
+	GetOccupancyDashboard(ctx context.Context, filter repository.OccupancyFilter) (*OccupancyDashboard, error)
+	GetClassOccupancyDetail(ctx context.Context, courseClassID uint, filter repository.OccupancyFilter) (*ClassOccupancyDetail, error)
+}
+
+// OccupancySummary totals the indicators of every class in the dashboard
+type OccupancySummary struct {
+	TotalClasses      int     `json:"totalClasses"`
+	TotalSeats        int     `json:"totalSeats"`
+	TotalEnrolled     int64   `json:"totalEnrolled"`
+	TotalWaitingList  int64   `json:"totalWaitingList"`
+	TotalDropouts     int64   `json:"totalDropouts"`
+	OccupancyRate     float64 `json:"occupancyRate"`
+	AttendanceRate    float64 `json:"attendanceRate"`
+	FullClasses       int     `json:"fullClasses"`
+	AttendanceRecords int64   `json:"attendanceRecords"`
+}
+
+// OccupancyDashboard is the occupancy view of the active classes
+type OccupancyDashboard struct {
+	StartDate time.Time                   `json:"startDate"`
+	EndDate   time.Time                   `json:"endDate"`
+	Summary   OccupancySummary            `json:"summary"`
+	Classes   []repository.ClassOccupancy `json:"classes"`
+}
+
+// ClassOccupancyDetail is the drill-down of a single class
+type ClassOccupancyDetail struct {
+	StartDate time.Time                          `json:"startDate"`
+	EndDate   time.Time                          `json:"endDate"`
+	Class     repository.ClassOccupancy          `json:"class"`
+	Students  []repository.ClassStudentOccupancy `json:"students"`
 }
 
 type service struct {
@@ -27,3 +58,76 @@ func (s *service) GetCourseAttendanceStats(ctx context.Context, courseID uint, s
 func (s *service) GetStudentAttendanceStats(ctx context.Context, studentID uint, startDate, endDate time.Time) ([]repository.StudentAttendanceStats, error) {
 	return s.repo.GetStudentAttendanceStats(ctx, studentID, startDate, endDate)
 }
+
+func (s *service) GetOccupancyDashboard(ctx context.Context, filter repository.OccupancyFilter) (*OccupancyDashboard, error) {
+	classes, err := s.repo.GetClassOccupancy(ctx, filter, nil)
+	if err != nil {
+		return nil, err
+	}
+	if classes == nil {
+		classes = []repository.ClassOccupancy{}
+	}
+
+	// The waiting list belongs to the course, so it is counted once per course
+	summary := OccupancySummary{TotalClasses: len(classes)}
+	var presentCount int64
+	countedCourses := make(map[uint]bool)
+	for _, class := range classes {
+		seats := class.MaxStudents
+		if seats <= 0 {
+			seats = class.Capacity
+		}
+		summary.TotalSeats += seats
+		summary.TotalEnrolled += class.EnrolledCount
+		summary.TotalDropouts += class.DropoutCount
+		summary.AttendanceRecords += class.AttendanceRecords
+		presentCount += class.PresentCount
+		if seats > 0 && class.EnrolledCount >= int64(seats) {
+			summary.FullClasses++
+		}
+		if !countedCourses[class.CourseID] {
+			countedCourses[class.CourseID] = true
+			summary.TotalWaitingList += class.WaitingListCount
+		}
+	}
+	if summary.TotalSeats > 0 {
+		summary.OccupancyRate = float64(summary.TotalEnrolled) / float64(summary.TotalSeats) * 100
+	}
+	if summary.AttendanceRecords > 0 {
+		summary.AttendanceRate = float64(presentCount) / float64(summary.AttendanceRecords) * 100
+	}
+
+	return &OccupancyDashboard{
+		StartDate: filter.StartDate,
+		EndDate:   filter.EndDate,
+		Summary:   summary,
+		Classes:   classes,
+	}, nil
+}
+
+// GetClassOccupancyDetail returns nil when the class is not active in the period
+func (s *service) GetClassOccupancyDetail(ctx context.Context, courseClassID uint, filter repository.OccupancyFilter) (*ClassOccupancyDetail, error) {
+	filter.CategoryID = nil
+	classes, err := s.repo.GetClassOccupancy(ctx, filter, &courseClassID)
+	if err != nil {
+		return nil, err
+	}
+	if len(classes) == 0 {
+		return nil, nil
+	}
+
+	students, err := s.repo.GetClassStudentsOccupancy(ctx, courseClassID, filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if students == nil {
+		students = []repository.ClassStudentOccupancy{}
+	}
+
+	return &ClassOccupancyDetail{
+		StartDate: filter.StartDate,
+		EndDate:   filter.EndDate,
+		Class:     classes[0],
+		Students:  students,
+	}, nil
+}
@@ -0,0 +1,813 @@
+// backend/internal/service/studentimport/service.go
+package studentimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/addresses"
+	"github.com/devdavidalonso/cecor/backend/internal/service/duplicates"
+	"github.com/devdavidalonso/cecor/backend/internal/service/keycloak"
+	"github.com/devdavidalonso/cecor/backend/pkg/cpf"
+)
+
+var (
+	// ErrInvalidFile is returned when the spreadsheet cannot be read or lacks required columns
+	ErrInvalidFile = errors.New("invalid import file")
+	// ErrInvalidRows is returned with the report when a real import has invalid rows
+	ErrInvalidRows = errors.New("the spreadsheet has invalid rows")
+)
+
+const (
+	// maxRows limits the size of an import
+	maxRows = 2000
+	// adultAge is the age from which no guardian is required
+	adultAge = 18
+	// maxAge rejects birth dates that are most likely typos
+	maxAge = 110
+	// maxAttempts is how many times an account is tried before the job fails
+	maxAttempts = 5
+	// claimTimeout releases jobs claimed by a run that stopped before finishing them
+	claimTimeout = 30 * time.Minute
+	// temporaryPassword and studentRole follow the individual student registration
+	temporaryPassword = "aluno123"
+	studentRole       = "aluno"
+	// placeholderPassword satisfies the users.password constraint; access goes through Keycloak
+	placeholderPassword = "temp123456"
+)
+
+// Fields of the spreadsheet
+const (
+	fieldName                 = "name"
+	fieldEmail                = "email"
+	fieldPhone                = "phone"
+	fieldCPF                  = "cpf"
+	fieldBirthDate            = "birthDate"
+	fieldSpecialNeeds         = "specialNeeds"
+	fieldMedicalInfo          = "medicalInfo"
+	fieldNotes                = "notes"
+	fieldCEP                  = "cep"
+	fieldStreet               = "street"
+	fieldNumber               = "number"
+	fieldComplement           = "complement"
+	fieldNeighborhood         = "neighborhood"
+	fieldCity                 = "city"
+	fieldState                = "state"
+	fieldGuardianName         = "guardianName"
+	fieldGuardianEmail        = "guardianEmail"
+	fieldGuardianPhone        = "guardianPhone"
+	fieldGuardianCPF          = "guardianCpf"
+	fieldGuardianRelationship = "guardianRelationship"
+)
+
+// requiredFields must have a column in the spreadsheet
+var requiredFields = []string{fieldName, fieldEmail, fieldPhone, fieldBirthDate}
+
+// columnAliases maps the normalized column headers (no accents, lowercase, "_" between words)
+var columnAliases = map[string]string{
+	"nome": fieldName, "nome_completo": fieldName, "aluno": fieldName, "nome_do_aluno": fieldName, "name": fieldName,
+	"email": fieldEmail, "e_mail": fieldEmail, "email_do_aluno": fieldEmail,
+	"telefone": fieldPhone, "celular": fieldPhone, "whatsapp": fieldPhone, "phone": fieldPhone,
+	"cpf": fieldCPF, "cpf_do_aluno": fieldCPF,
+	"data_de_nascimento": fieldBirthDate, "data_nascimento": fieldBirthDate, "nascimento": fieldBirthDate, "birth_date": fieldBirthDate,
+	"necessidades_especiais": fieldSpecialNeeds, "special_needs": fieldSpecialNeeds,
+	"informacoes_medicas": fieldMedicalInfo, "medical_info": fieldMedicalInfo,
+	"observacoes": fieldNotes, "obs": fieldNotes, "notes": fieldNotes,
+	"cep": fieldCEP,
+	"rua": fieldStreet, "logradouro": fieldStreet, "endereco": fieldStreet, "street": fieldStreet,
+	"numero": fieldNumber, "n": fieldNumber, "number": fieldNumber,
+	"complemento": fieldComplement, "complement": fieldComplement,
+	"bairro": fieldNeighborhood, "neighborhood": fieldNeighborhood,
+	"cidade": fieldCity, "municipio": fieldCity, "city": fieldCity,
+	"uf": fieldState, "estado": fieldState, "state": fieldState,
+	"responsavel": fieldGuardianName, "nome_do_responsavel": fieldGuardianName, "responsavel_nome": fieldGuardianName, "guardian_name": fieldGuardianName,
+	"email_do_responsavel": fieldGuardianEmail, "responsavel_email": fieldGuardianEmail, "guardian_email": fieldGuardianEmail,
+	"telefone_do_responsavel": fieldGuardianPhone, "responsavel_telefone": fieldGuardianPhone, "guardian_phone": fieldGuardianPhone,
+	"cpf_do_responsavel": fieldGuardianCPF, "responsavel_cpf": fieldGuardianCPF, "guardian_cpf": fieldGuardianCPF,
+	"parentesco": fieldGuardianRelationship, "grau_de_parentesco": fieldGuardianRelationship, "guardian_relationship": fieldGuardianRelationship,
+}
+
+// AddressValidator checks an address against its CEP and fills in the official names
+// (implemented by addresses.Service)
+type AddressValidator interface {
+	ValidateAddress(ctx context.Context, address *models.Address) error
+}
+
+// Accounts creates the access accounts (implemented by keycloak.KeycloakService)
+type Accounts interface {
+	CreateUser(ctx context.Context, req keycloak.CreateUserRequest) (string, error)
+	AssignRole(ctx context.Context, userID, roleName string) error
+	SetTemporaryPassword(ctx context.Context, userID, password string) error
+}
+
+// Mailer sends the welcome email with the temporary password (implemented by email.EmailService)
+type Mailer interface {
+	SendWelcomeEmail(to, studentName, temporaryPassword string) error
+}
+
+// Options controls an import
+type Options struct {
+	FileName string
+	// DryRun only validates and returns the report
+	DryRun bool
+	// SkipInvalid imports the valid rows even if others are invalid
+	SkipInvalid bool
+}
+
+// RowResult is the validation of a spreadsheet row
+type RowResult struct {
+	Line      int      `json:"line"`
+	Name      string   `json:"name"`
+	Email     string   `json:"email"`
+	Valid     bool     `json:"valid"`
+	Errors    []string `json:"errors,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"`
+	StudentID *uint    `json:"studentId,omitempty"`
+}
+
+// Report is the result of an import (or of its dry run)
+type Report struct {
+	ImportID       *uint        `json:"importId,omitempty"`
+	FileName       string       `json:"fileName"`
+	DryRun         bool         `json:"dryRun"`
+	TotalRows      int          `json:"totalRows"`
+	ValidRows      int          `json:"validRows"`
+	InvalidRows    int          `json:"invalidRows"`
+	Created        int          `json:"created"`
+	IgnoredColumns []string     `json:"ignoredColumns,omitempty"`
+	Rows           []*RowResult `json:"rows"`
+}
+
+// ImportSummary is a registered import with the progress of its accounts
+type ImportSummary struct {
+	models.StudentImport
+	AccountsPending int `json:"accountsPending"`
+	AccountsDone    int `json:"accountsDone"`
+	AccountsFailed  int `json:"accountsFailed"`
+}
+
+// Service defines the interface for the bulk student import
+type Service interface {
+	Import(ctx context.Context, rows [][]string, options Options, userID uint) (*Report, error)
+	ListImports(ctx context.Context) ([]ImportSummary, error)
+
+	// ProcessAccounts creates the queued access accounts and sends the welcome emails
+	ProcessAccounts(ctx context.Context, limit int) (int, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db        *gorm.DB
+	addresses AddressValidator
+	accounts  Accounts
+	mailer    Mailer
+	now       func() time.Time
+}
+
+// NewService creates a new student import service. addresses, accounts and mailer may be nil.
+func NewService(db *gorm.DB, addresses AddressValidator, accounts Accounts, mailer Mailer) Service {
+	return &service{db: db, addresses: addresses, accounts: accounts, mailer: mailer, now: time.Now}
+}
+
+// record is a spreadsheet row converted to the models
+type record struct {
+	result   *RowResult
+	user     models.User
+	student  models.Student
+	address  *models.Address
+	guardian *models.Guardian
+}
+
+// Import validates the rows (header first) and, unless it is a dry run, creates the users,
+// addresses, students and guardians in a single transaction. The access accounts and welcome
+// emails are queued for ProcessAccounts. A real import with invalid rows creates nothing and
+// returns the report with ErrInvalidRows, unless SkipInvalid is set.
+func (s *service) Import(ctx context.Context, rows [][]string, options Options, userID uint) (*Report, error) {
+	columns, ignored, err := mapColumns(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	today := s.now()
+	report := &Report{FileName: options.FileName, DryRun: options.DryRun, IgnoredColumns: ignored, Rows: []*RowResult{}}
+	var records []*record
+	for i, row := range rows[1:] {
+		values := rowValues(columns, row)
+		if len(values) == 0 {
+			continue
+		}
+		rec := parseRow(i+2, values, today)
+		records = append(records, rec)
+		report.Rows = append(report.Rows, rec.result)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: the spreadsheet has no students", ErrInvalidFile)
+	}
+	if len(records) > maxRows {
+		return nil, fmt.Errorf("%w: %d rows, the limit is %d per import", ErrInvalidFile, len(records), maxRows)
+	}
+
+	checkFileDuplicates(records)
+	if err := s.checkExisting(ctx, records); err != nil {
+		return nil, err
+	}
+	s.validateAddresses(ctx, records)
+
+	valid := make([]*record, 0, len(records))
+	for _, rec := range records {
+		rec.result.Valid = len(rec.result.Errors) == 0
+		if rec.result.Valid {
+			valid = append(valid, rec)
+			report.ValidRows++
+		} else {
+			report.InvalidRows++
+		}
+	}
+	report.TotalRows = len(records)
+
+	if options.DryRun {
+		return report, nil
+	}
+	if report.InvalidRows > 0 && (!options.SkipInvalid || len(valid) == 0) {
+		return report, fmt.Errorf("%w: %d of %d rows", ErrInvalidRows, report.InvalidRows, report.TotalRows)
+	}
+
+	batch := &models.StudentImport{
+		FileName:     options.FileName,
+		TotalRows:    report.TotalRows,
+		CreatedCount: len(valid),
+		SkippedCount: report.InvalidRows,
+		CreatedByID:  userID,
+	}
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(batch).Error; err != nil {
+			return err
+		}
+		for _, rec := range valid {
+			if err := create(tx, rec, batch.ID, today); err != nil {
+				return fmt.Errorf("line %d: %w", rec.result.Line, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.ImportID = &batch.ID
+	report.Created = len(valid)
+	return report, nil
+}
+
+// ListImports returns the registered imports, most recent first
+func (s *service) ListImports(ctx context.Context) ([]ImportSummary, error) {
+	summaries := []ImportSummary{}
+	if err := s.db.WithContext(ctx).Table("student_imports i").
+		Select(`i.*,
+			COUNT(j.id) FILTER (WHERE j.status IN ?) AS accounts_pending,
+			COUNT(j.id) FILTER (WHERE j.status = ?) AS accounts_done,
+			COUNT(j.id) FILTER (WHERE j.status = ?) AS accounts_failed`,
+			[]models.AccountJobStatus{models.AccountJobPending, models.AccountJobProcessing}, models.AccountJobDone, models.AccountJobFailed).
+		Joins("LEFT JOIN account_provisioning_jobs j ON j.import_id = i.id").
+		Group("i.id").
+		Order("i.created_at DESC").
+		Scan(&summaries).Error; err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// ProcessAccounts creates up to limit queued accounts. Failures are retried on the next runs
+// until maxAttempts. The jobs are claimed first, so concurrent runs (the API and the
+// import_students command) never provision the same account twice.
+func (s *service) ProcessAccounts(ctx context.Context, limit int) (int, error) {
+	if s.accounts == nil {
+		return 0, nil
+	}
+
+	jobs, err := s.claimJobs(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for i := range jobs {
+		job := &jobs[i]
+		err := s.provision(ctx, job.UserID)
+
+		now := s.now()
+		job.Attempts++
+		job.ProcessedAt = &now
+		switch {
+		case err == nil:
+			job.Status = models.AccountJobDone
+			job.LastError = ""
+			processed++
+		case job.Attempts >= maxAttempts:
+			job.Status = models.AccountJobFailed
+			job.LastError = err.Error()
+		default:
+			job.Status = models.AccountJobPending
+			job.LastError = err.Error()
+		}
+		if err := s.db.WithContext(ctx).Save(job).Error; err != nil {
+			return processed, err
+		}
+	}
+	return processed, nil
+}
+
+// claimJobs marks up to limit pending jobs (and the ones left processing past claimTimeout) as
+// processing and returns them; rows locked by another run are skipped
+func (s *service) claimJobs(ctx context.Context, limit int) ([]models.AccountProvisioningJob, error) {
+	now := s.now()
+	var jobs []models.AccountProvisioningJob
+	if err := s.db.WithContext(ctx).Raw(`
+		UPDATE account_provisioning_jobs SET status = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM account_provisioning_jobs
+			WHERE status = ? OR (status = ? AND updated_at < ?)
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		models.AccountJobProcessing, now,
+		models.AccountJobPending, models.AccountJobProcessing, now.Add(-claimTimeout),
+		limit).Scan(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("error claiming account jobs: %w", err)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	return jobs, nil
+}
+
+// provision creates the Keycloak account of the user (once), sets the role and the temporary
+// password and sends the welcome email
+func (s *service) provision(ctx context.Context, userID uint) error {
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, userID).Error; err != nil {
+		return err
+	}
+
+	if user.KeycloakUserID == nil {
+		firstName, lastName := splitName(user.Name)
+		keycloakUserID, err := s.accounts.CreateUser(ctx, keycloak.CreateUserRequest{
+			Username:      user.Email,
+			Email:         user.Email,
+			FirstName:     firstName,
+			LastName:      lastName,
+			Enabled:       true,
+			EmailVerified: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create account: %w", err)
+		}
+		if err := s.db.WithContext(ctx).Model(&user).Update("keycloak_user_id", keycloakUserID).Error; err != nil {
+			return err
+		}
+		user.KeycloakUserID = &keycloakUserID
+	}
+
+	if err := s.accounts.AssignRole(ctx, *user.KeycloakUserID, studentRole); err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+	if err := s.accounts.SetTemporaryPassword(ctx, *user.KeycloakUserID, temporaryPassword); err != nil {
+		return fmt.Errorf("failed to set temporary password: %w", err)
+	}
+	if s.mailer != nil {
+		if err := s.mailer.SendWelcomeEmail(user.Email, user.Name, temporaryPassword); err != nil {
+			return fmt.Errorf("failed to send welcome email: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkExisting compares the rows with the registered users (email and CPF are unique) and the
+// registered students (same name and birth date is only a warning)
+func (s *service) checkExisting(ctx context.Context, records []*record) error {
+	// CPFs are encrypted: they are compared by their blind index
+	var emails, indexes []string
+	cpfByIndex := map[string]string{}
+	for _, rec := range records {
+		if rec.user.Email != "" {
+			emails = append(emails, rec.user.Email)
+		}
+		if rec.user.CPF != "" {
+			index, err := models.CPFIndex(rec.user.CPF)
+			if err != nil {
+				return err
+			}
+			indexes = append(indexes, index)
+			cpfByIndex[index] = rec.user.CPF
+		}
+	}
+
+	var takenEmails, takenIndexes []string
+	if len(emails) > 0 {
+		if err := s.db.WithContext(ctx).Table("users").
+			Where("LOWER(email) IN ?", emails).
+			Pluck("LOWER(email)", &takenEmails).Error; err != nil {
+			return err
+		}
+	}
+	if len(indexes) > 0 {
+		if err := s.db.WithContext(ctx).Table("users").
+			Where("cpf_hash IN ?", indexes).
+			Pluck("cpf_hash", &takenIndexes).Error; err != nil {
+			return err
+		}
+	}
+	taken := map[string]bool{}
+	for _, email := range takenEmails {
+		taken["email:"+email] = true
+	}
+	for _, index := range takenIndexes {
+		taken["cpf:"+cpfByIndex[index]] = true
+	}
+
+	var students []struct {
+		RegistrationNumber string
+		Name               string
+		BirthDate          *time.Time
+	}
+	if err := s.db.WithContext(ctx).Table("students st").
+		Select("st.registration_number, u.name, u.birth_date").
+		Joins("JOIN users u ON u.id = st.user_id").
+		Where("st.deleted_at IS NULL").
+		Scan(&students).Error; err != nil {
+		return err
+	}
+	registered := map[string]string{}
+	for _, student := range students {
+		if student.BirthDate != nil {
+			registered[personKey(student.Name, *student.BirthDate)] = student.RegistrationNumber
+		}
+	}
+
+	for _, rec := range records {
+		if rec.user.Email != "" && taken["email:"+rec.user.Email] {
+			rec.fail("email %s is already registered", rec.user.Email)
+		}
+		if rec.user.CPF != "" && taken["cpf:"+rec.user.CPF] {
+			rec.fail("CPF %s is already registered", cpf.Format(rec.user.CPF))
+		}
+		if !rec.user.BirthDate.IsZero() {
+			if number, ok := registered[personKey(rec.user.Name, rec.user.BirthDate)]; ok {
+				rec.warn("possible duplicate of student %s (same name and birth date)", number)
+			}
+		}
+	}
+	return nil
+}
+
+// validateAddresses checks the addresses of the rows still valid against the CEP
+func (s *service) validateAddresses(ctx context.Context, records []*record) {
+	if s.addresses == nil {
+		return
+	}
+	for _, rec := range records {
+		if rec.address == nil || len(rec.result.Errors) > 0 {
+			continue
+		}
+		err := s.addresses.ValidateAddress(ctx, rec.address)
+		switch {
+		case err == nil:
+		case errors.Is(err, addresses.ErrInvalidAddress):
+			rec.fail("%v", err)
+		default:
+			rec.warn("address not verified: %v", err)
+		}
+	}
+}
+
+// create inserts the records of a row and queues its access account
+func create(tx *gorm.DB, rec *record, importID uint, now time.Time) error {
+	rec.user.ProfileID = 3
+	rec.user.Password = placeholderPassword
+	rec.user.Active = true
+	if err := tx.Omit(clause.Associations).Create(&rec.user).Error; err != nil {
+		return fmt.Errorf("error creating user: %w", err)
+	}
+
+	if rec.address != nil {
+		rec.address.UserID = rec.user.ID
+		if err := tx.Create(rec.address).Error; err != nil {
+			return fmt.Errorf("error creating address: %w", err)
+		}
+	}
+
+	rec.student.UserID = rec.user.ID
+	rec.student.RegistrationNumber = fmt.Sprintf("%d%06d", now.Year(), rec.user.ID)
+	rec.student.Status = models.StudentStatusActive
+	if err := tx.Omit(clause.Associations).Create(&rec.student).Error; err != nil {
+		return fmt.Errorf("error creating student: %w", err)
+	}
+	rec.result.StudentID = &rec.student.ID
+
+	if rec.guardian != nil {
+		rec.guardian.StudentID = rec.student.ID
+		if err := tx.Create(rec.guardian).Error; err != nil {
+			return fmt.Errorf("error creating guardian: %w", err)
+		}
+	}
+
+	return tx.Create(&models.AccountProvisioningJob{
+		UserID:   rec.user.ID,
+		ImportID: &importID,
+		Status:   models.AccountJobPending,
+	}).Error
+}
+
+// mapColumns maps each column of the header to a field; unknown columns are ignored
+func mapColumns(rows [][]string) ([]string, []string, error) {
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("%w: the spreadsheet is empty", ErrInvalidFile)
+	}
+
+	columns := make([]string, len(rows[0]))
+	ignored := []string{}
+	seen := map[string]bool{}
+	for i, header := range rows[0] {
+		if strings.TrimSpace(header) == "" {
+			continue
+		}
+		field, ok := columnAliases[normalizeHeader(header)]
+		if !ok {
+			ignored = append(ignored, strings.TrimSpace(header))
+			continue
+		}
+		if seen[field] {
+			return nil, nil, fmt.Errorf("%w: column %q appears more than once", ErrInvalidFile, header)
+		}
+		seen[field] = true
+		columns[i] = field
+	}
+
+	var missing []string
+	for _, field := range requiredFields {
+		if !seen[field] {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("%w: missing required columns: %s", ErrInvalidFile, strings.Join(missing, ", "))
+	}
+	return columns, ignored, nil
+}
+
+// rowValues returns the filled cells of a row by field; an empty map means a blank row
+func rowValues(columns []string, row []string) map[string]string {
+	values := map[string]string{}
+	for i, cell := range row {
+		if i >= len(columns) || columns[i] == "" {
+			continue
+		}
+		if value := strings.TrimSpace(cell); value != "" {
+			values[columns[i]] = value
+		}
+	}
+	return values
+}
+
+// parseRow converts and validates a row on its own (duplicates are checked afterwards)
+func parseRow(line int, values map[string]string, today time.Time) *record {
+	rec := &record{result: &RowResult{Line: line, Name: values[fieldName], Email: strings.ToLower(values[fieldEmail])}}
+
+	rec.user = models.User{
+		Name:  strings.Join(strings.Fields(values[fieldName]), " "),
+		Email: rec.result.Email,
+		Phone: values[fieldPhone],
+	}
+	rec.student = models.Student{
+		SpecialNeeds: values[fieldSpecialNeeds],
+		MedicalInfo:  values[fieldMedicalInfo],
+		Notes:        values[fieldNotes],
+	}
+
+	if rec.user.Name == "" {
+		rec.fail("name is required")
+	}
+	if rec.user.Email == "" {
+		rec.fail("email is required")
+	} else if address, err := mail.ParseAddress(rec.user.Email); err != nil || address.Address != rec.user.Email {
+		rec.fail("invalid email %q", values[fieldEmail])
+	}
+	if rec.user.Phone == "" {
+		rec.fail("phone is required")
+	} else if !validPhone(rec.user.Phone) {
+		rec.fail("invalid phone %q", rec.user.Phone)
+	}
+	if value := values[fieldCPF]; value != "" {
+		if number, err := cpf.Parse(value); err != nil {
+			rec.fail("invalid CPF %q", value)
+		} else {
+			rec.user.CPF = number
+		}
+	}
+
+	age := -1
+	if value := values[fieldBirthDate]; value == "" {
+		rec.fail("birth date is required")
+	} else if birthDate, err := parseDate(value); err != nil {
+		rec.fail("invalid birth date %q, expected DD/MM/YYYY", value)
+	} else {
+		rec.user.BirthDate = birthDate
+		age = ageOn(birthDate, today)
+		switch {
+		case birthDate.After(today):
+			rec.fail("birth date %s is in the future", birthDate.Format("02/01/2006"))
+		case age > maxAge:
+			rec.fail("birth date %s gives an age of %d years", birthDate.Format("02/01/2006"), age)
+		}
+	}
+
+	if values[fieldCEP] != "" || values[fieldStreet] != "" {
+		rec.address = &models.Address{
+			CEP:          values[fieldCEP],
+			Street:       values[fieldStreet],
+			Number:       values[fieldNumber],
+			Complement:   values[fieldComplement],
+			Neighborhood: values[fieldNeighborhood],
+			City:         values[fieldCity],
+			State:        strings.ToUpper(values[fieldState]),
+		}
+		if rec.address.CEP == "" {
+			rec.warn("address without CEP was not verified")
+		}
+	}
+
+	rec.guardian = parseGuardian(rec, values)
+	if rec.guardian == nil && age >= 0 && age < adultAge {
+		rec.fail("students under %d need a guardian (name, relationship and phone or email)", adultAge)
+	}
+	return rec
+}
+
+// parseGuardian reads the guardian columns; a guardian needs a name, a relationship and a contact
+func parseGuardian(rec *record, values map[string]string) *models.Guardian {
+	name := strings.Join(strings.Fields(values[fieldGuardianName]), " ")
+	email := strings.ToLower(values[fieldGuardianEmail])
+	phone := values[fieldGuardianPhone]
+	if name == "" && email == "" && phone == "" && values[fieldGuardianCPF] == "" {
+		return nil
+	}
+
+	guardian := &models.Guardian{
+		Name:                 name,
+		Email:                email,
+		Phone:                phone,
+		Relationship:         values[fieldGuardianRelationship],
+		ReceiveNotifications: true,
+	}
+	if name == "" {
+		rec.fail("guardian name is required when guardian data is given")
+	}
+	if guardian.Relationship == "" {
+		rec.fail("guardian relationship is required")
+	}
+	if email == "" && phone == "" {
+		rec.fail("guardian needs a phone or an email")
+	}
+	if email != "" {
+		if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+			rec.fail("invalid guardian email %q", values[fieldGuardianEmail])
+		}
+	}
+	if phone != "" && !validPhone(phone) {
+		rec.fail("invalid guardian phone %q", phone)
+	}
+	if value := values[fieldGuardianCPF]; value != "" {
+		if number, err := cpf.Parse(value); err != nil {
+			rec.fail("invalid guardian CPF %q", value)
+		} else {
+			guardian.CPF = number
+		}
+	}
+	return guardian
+}
+
+// checkFileDuplicates flags rows repeating the email or CPF of a previous row (an error) or its
+// name and birth date (a warning)
+func checkFileDuplicates(records []*record) {
+	emails := map[string]int{}
+	cpfs := map[string]int{}
+	people := map[string]int{}
+	for _, rec := range records {
+		line := rec.result.Line
+		if email := rec.user.Email; email != "" {
+			if first, ok := emails[email]; ok {
+				rec.fail("same email as line %d", first)
+			} else {
+				emails[email] = line
+			}
+		}
+		if number := rec.user.CPF; number != "" {
+			if first, ok := cpfs[number]; ok {
+				rec.fail("same CPF as line %d", first)
+			} else {
+				cpfs[number] = line
+			}
+		}
+		if rec.user.Name != "" && !rec.user.BirthDate.IsZero() {
+			key := personKey(rec.user.Name, rec.user.BirthDate)
+			if first, ok := people[key]; ok {
+				rec.warn("same name and birth date as line %d", first)
+			} else {
+				people[key] = line
+			}
+		}
+	}
+}
+
+func (r *record) fail(format string, args ...interface{}) {
+	r.result.Errors = append(r.result.Errors, fmt.Sprintf(format, args...))
+}
+
+func (r *record) warn(format string, args ...interface{}) {
+	r.result.Warnings = append(r.result.Warnings, fmt.Sprintf(format, args...))
+}
+
+// personKey matches people by normalized name and birth date, as the duplicate detection does
+func personKey(name string, birthDate time.Time) string {
+	return duplicates.NormalizeName(name) + "|" + birthDate.Format("2006-01-02")
+}
+
+// normalizeHeader lowercases, strips accents and joins the words with "_" ("Data de Nascimento"
+// → "data_de_nascimento")
+func normalizeHeader(header string) string {
+	stripped, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), header)
+	if err != nil {
+		stripped = header
+	}
+	words := strings.FieldsFunc(strings.ToLower(stripped), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "_")
+}
+
+// dateLayouts are the accepted text formats of the birth date
+var dateLayouts = []string{"02/01/2006", "2/1/2006", "02-01-2006", "02.01.2006", "2006-01-02"}
+
+// parseDate reads a date as text or as an Excel serial number (days since 1899-12-30)
+func parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	if serial, err := strconv.ParseFloat(value, 64); err == nil && serial >= 1 && serial < 100000 {
+		return time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(serial)), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// ageOn returns the age in full years on the given day
+func ageOn(birthDate, day time.Time) int {
+	age := day.Year() - birthDate.Year()
+	if day.Month() < birthDate.Month() || (day.Month() == birthDate.Month() && day.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
+
+// validPhone accepts Brazilian numbers with area code, with or without the country code
+func validPhone(phone string) bool {
+	digits := onlyDigits(phone)
+	if (len(digits) == 12 || len(digits) == 13) && strings.HasPrefix(digits, "55") {
+		digits = digits[2:]
+	}
+	return len(digits) == 10 || len(digits) == 11
+}
+
+func onlyDigits(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func splitName(name string) (string, string) {
+	parts := strings.Fields(name)
+	if len(parts) == 0 {
+		return "", ""
+	}
+	return parts[0], strings.Join(parts[1:], " ")
+}
@@ -0,0 +1,167 @@
+package studentimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var today = time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+func TestMapColumns(t *testing.T) {
+	columns, ignored, err := mapColumns([][]string{{"Nome Completo", "E-mail", "Celular", "Data de Nascimento", "Nome do Responsável", "Turma"}})
+	if err != nil {
+		t.Fatalf("Expected header to map, got %v", err)
+	}
+	expected := []string{fieldName, fieldEmail, fieldPhone, fieldBirthDate, fieldGuardianName, ""}
+	for i, field := range expected {
+		if columns[i] != field {
+			t.Errorf("Column %d: expected %q, got %q", i, field, columns[i])
+		}
+	}
+	if len(ignored) != 1 || ignored[0] != "Turma" {
+		t.Errorf("Expected Turma to be ignored, got %v", ignored)
+	}
+
+	if _, _, err := mapColumns([][]string{{"Nome", "Email", "Telefone"}}); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("Expected ErrInvalidFile for missing birth date column, got %v", err)
+	}
+	if _, _, err := mapColumns([][]string{{"Nome", "Aluno", "Email", "Telefone", "Nascimento"}}); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("Expected ErrInvalidFile for repeated column, got %v", err)
+	}
+}
+
+func TestParseRow(t *testing.T) {
+	adult := map[string]string{
+		fieldName:      "  Maria   da Silva ",
+		fieldEmail:     "Maria@Example.com",
+		fieldPhone:     "(11) 98765-4321",
+		fieldCPF:       "529.982.247-25",
+		fieldBirthDate: "15/04/1990",
+	}
+	rec := parseRow(2, adult, today)
+	if len(rec.result.Errors) > 0 {
+		t.Fatalf("Expected valid row, got %v", rec.result.Errors)
+	}
+	if rec.user.Name != "Maria da Silva" || rec.user.Email != "maria@example.com" || rec.user.CPF != "52998224725" {
+		t.Errorf("Unexpected normalization: %+v", rec.user)
+	}
+
+	minor := map[string]string{
+		fieldName:      "João Souza",
+		fieldEmail:     "joao@example.com",
+		fieldPhone:     "11987654321",
+		fieldBirthDate: "2015-06-01",
+	}
+	if rec := parseRow(3, minor, today); len(rec.result.Errors) != 1 {
+		t.Errorf("Expected minor without guardian to be invalid, got %v", rec.result.Errors)
+	}
+	minor[fieldGuardianName] = "Ana Souza"
+	minor[fieldGuardianRelationship] = "Mãe"
+	minor[fieldGuardianPhone] = "+55 11 91234-5678"
+	if rec := parseRow(3, minor, today); len(rec.result.Errors) > 0 || rec.guardian == nil {
+		t.Errorf("Expected minor with guardian to be valid, got %v", rec.result.Errors)
+	}
+
+	invalid := map[string]string{
+		fieldName:      "Pedro",
+		fieldEmail:     "pedro@",
+		fieldPhone:     "1234",
+		fieldCPF:       "111.111.111-11",
+		fieldBirthDate: "31/02/2000",
+	}
+	if rec := parseRow(4, invalid, today); len(rec.result.Errors) != 4 {
+		t.Errorf("Expected email, phone, CPF and birth date errors, got %v", rec.result.Errors)
+	}
+
+	future := map[string]string{fieldName: "X", fieldEmail: "x@example.com", fieldPhone: "1132654321", fieldBirthDate: "01/01/2030"}
+	if rec := parseRow(5, future, today); len(rec.result.Errors) != 1 {
+		t.Errorf("Expected future birth date to be invalid, got %v", rec.result.Errors)
+	}
+}
+
+func TestCheckFileDuplicates(t *testing.T) {
+	row := func(line int, email string) *record {
+		return parseRow(line, map[string]string{
+			fieldName:      "Carla Lima",
+			fieldEmail:     email,
+			fieldPhone:     "11987654321",
+			fieldBirthDate: "01/01/1990",
+		}, today)
+	}
+	records := []*record{row(2, "carla@example.com"), row(3, "CARLA@example.com"), row(4, "carla.lima@example.com")}
+	checkFileDuplicates(records)
+
+	if len(records[0].result.Errors) > 0 || len(records[0].result.Warnings) > 0 {
+		t.Errorf("Expected first row untouched, got %v %v", records[0].result.Errors, records[0].result.Warnings)
+	}
+	if len(records[1].result.Errors) != 1 {
+		t.Errorf("Expected repeated email error, got %v", records[1].result.Errors)
+	}
+	if len(records[2].result.Errors) > 0 || len(records[2].result.Warnings) != 1 {
+		t.Errorf("Expected only a same-person warning, got %v %v", records[2].result.Errors, records[2].result.Warnings)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	expected := time.Date(1990, 4, 15, 0, 0, 0, 0, time.UTC)
+	for _, value := range []string{"15/04/1990", "15-04-1990", "1990-04-15", "32978"} {
+		date, err := parseDate(value)
+		if err != nil || !date.Equal(expected) {
+			t.Errorf("parseDate(%q) = %v, %v", value, date, err)
+		}
+	}
+	if _, err := parseDate("abril"); err == nil {
+		t.Error("Expected error for text date")
+	}
+}
+
+func TestReadSheetCSV(t *testing.T) {
+	data := "\xef\xbb\xbfNome;Email;Telefone;Nascimento\nAna;ana@example.com;11987654321;01/02/2000\n"
+	rows, err := ReadSheet("alunos.csv", strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Expected CSV to be read, got %v", err)
+	}
+	if len(rows) != 2 || rows[0][0] != "Nome" || rows[1][3] != "01/02/2000" {
+		t.Errorf("Unexpected rows: %v", rows)
+	}
+
+	if _, err := ReadSheet("alunos.pdf", strings.NewReader(data)); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("Expected ErrInvalidFile for unsupported type, got %v", err)
+	}
+}
+
+func TestReadSheetXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+			<sheets><sheet name="Alunos" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+			<Relationship Id="rId1" Type="worksheet" Target="worksheets/alunos.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst><si><t>Nome</t></si><si><r><t>Ana </t></r><r><t>Lima</t></r></si></sst>`,
+		"xl/worksheets/alunos.xml": `<worksheet><sheetData>
+			<row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="inlineStr"><is><t>Nascimento</t></is></c></row>
+			<row r="2"><c r="A2" t="s"><v>1</v></c><c r="C2"><v>32978</v></c></row>
+		</sheetData></worksheet>`,
+	}
+	for name, content := range parts {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+	}
+	archive.Close()
+
+	rows, err := ReadSheet("alunos.xlsx", &buf)
+	if err != nil {
+		t.Fatalf("Expected XLSX to be read, got %v", err)
+	}
+	if len(rows) != 2 || rows[0][0] != "Nome" || rows[0][1] != "" || rows[0][2] != "Nascimento" || rows[1][0] != "Ana Lima" || rows[1][2] != "32978" {
+		t.Errorf("Unexpected rows: %q", rows)
+	}
+}
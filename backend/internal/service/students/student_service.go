@@ -9,21 +9,30 @@ import (
 	"github.com/devdavidalonso/cecor/backend/internal/repository"
 	"github.com/devdavidalonso/cecor/backend/internal/service/email"
 	"github.com/devdavidalonso/cecor/backend/internal/service/keycloak"
+	"github.com/devdavidalonso/cecor/backend/pkg/cpf"
 )
 
+// AddressValidator checks an address against its CEP and fills in the official names
+// (implemented by addresses.Service)
+type AddressValidator interface {
+	ValidateAddress(ctx context.Context, address *models.Address) error
+}
+
 // studentService implements the Service interface
 type studentService struct {
 	studentRepo repository.StudentRepository
 	keycloak    *keycloak.KeycloakService
 	email       *email.EmailService
+	addresses   AddressValidator
 }
 
 // NewStudentService creates a new instance of studentService
-func NewStudentService(studentRepo repository.StudentRepository, keycloak *keycloak.KeycloakService, email *email.EmailService) Service {
+func NewStudentService(studentRepo repository.StudentRepository, keycloak *keycloak.KeycloakService, email *email.EmailService, addresses AddressValidator) Service {
 	return &studentService{
 		studentRepo: studentRepo,
 		keycloak:    keycloak,
 		email:       email,
+		addresses:   addresses,
 	}
 }
 
@@ -74,17 +83,16 @@ func (s *studentService) GetStudentByEmail(ctx context.Context, email string) (*
 }
 
 // GetStudentByCPF returns a student by CPF
-func (s *studentService) GetStudentByCPF(ctx context.Context, cpf string) (*models.Student, error) {
-	if cpf == "" {
+// Accepts any formatting (123.456.789-09, 12345678909, 123 456 789 09).
+func (s *studentService) GetStudentByCPF(ctx context.Context, number string) (*models.Student, error) {
+	if strings.TrimSpace(number) == "" {
 		return nil, fmt.Errorf("CPF not provided")
 	}
 
-	// Remove CPF formatting
-	cleanCPF := strings.ReplaceAll(strings.ReplaceAll(cpf, ".", ""), "-", "")
-
-	// Basic CPF validation
-	if len(cleanCPF) != 11 {
-		return nil, fmt.Errorf("invalid CPF")
+	// Validate the check digits and remove the formatting
+	cleanCPF, err := cpf.Parse(number)
+	if err != nil {
+		return nil, err
 	}
 
 	student, err := s.studentRepo.FindByCPF(ctx, cleanCPF)
@@ -131,8 +139,12 @@ func (s *studentService) CreateStudent(ctx context.Context, student *models.Stud
 
 	// Check CPF if provided
 	if student.User.CPF != "" {
-		// Clean CPF
-		student.User.CPF = strings.ReplaceAll(strings.ReplaceAll(student.User.CPF, ".", ""), "-", "")
+		// Validate and clean CPF
+		cleanCPF, err := cpf.Parse(student.User.CPF)
+		if err != nil {
+			return err
+		}
+		student.User.CPF = cleanCPF
 
 		// Check if student already exists with the same CPF
 		existingByCPF, err := s.studentRepo.FindByCPF(ctx, student.User.CPF)
@@ -144,6 +156,11 @@ func (s *studentService) CreateStudent(ctx context.Context, student *models.Stud
 		}
 	}
 
+	// Validate the address against its CEP
+	if err := s.validateAddress(ctx, student.User.Address); err != nil {
+		return err
+	}
+
 	// Set default status if not provided
 	if student.Status == "" {
 		student.Status = "active"
@@ -232,6 +249,11 @@ func (s *studentService) UpdateStudent(ctx context.Context, student *models.Stud
 		return fmt.Errorf("student not found")
 	}
 
+	// Status changes need a reason and go to the history, so they use the status endpoint
+	if student.Status != "" && student.Status != existing.Status {
+		return fmt.Errorf("status changes require a reason; use the student status endpoint")
+	}
+
 	// Check email if being changed
 	if (student.User.Email != "") && student.User.Email != existing.User.Email {
 		emailExisting, err := s.studentRepo.FindByEmail(ctx, student.User.Email)
@@ -243,10 +265,14 @@ func (s *studentService) UpdateStudent(ctx context.Context, student *models.Stud
 		}
 	}
 
-	// Check CPF if being changed
-	if (student.User.CPF != "") && student.User.CPF != existing.User.CPF {
-		// Clean CPF
-		student.User.CPF = strings.ReplaceAll(strings.ReplaceAll(student.User.CPF, ".", ""), "-", "")
+	// Check CPF if being changed (an unchanged legacy value is kept even if invalid)
+	if (student.User.CPF != "") && cpf.Normalize(student.User.CPF) != cpf.Normalize(existing.User.CPF) {
+		// Validate and clean CPF
+		cleanCPF, err := cpf.Parse(student.User.CPF)
+		if err != nil {
+			return err
+		}
+		student.User.CPF = cleanCPF
 
 		cpfExisting, err := s.studentRepo.FindByCPF(ctx, student.User.CPF)
 		if err != nil {
@@ -257,10 +283,23 @@ func (s *studentService) UpdateStudent(ctx context.Context, student *models.Stud
 		}
 	}
 
+	// Validate the address against its CEP
+	if err := s.validateAddress(ctx, student.User.Address); err != nil {
+		return err
+	}
+
 	// Delegate to repository
 	return s.studentRepo.Update(ctx, student)
 }
 
+// validateAddress checks the address when one is sent and the validator is configured
+func (s *studentService) validateAddress(ctx context.Context, address *models.Address) error {
+	if address == nil || s.addresses == nil {
+		return nil
+	}
+	return s.addresses.ValidateAddress(ctx, address)
+}
+
 // DeleteStudent removes a student
 func (s *studentService) DeleteStudent(ctx context.Context, id uint) error {
 	if id == 0 {
@@ -313,6 +352,11 @@ func (s *studentService) AddGuardian(ctx context.Context, guardian *models.Guard
 		return fmt.Errorf("relationship is required")
 	}
 
+	// Validate CPF if provided
+	if guardian.CPF != "" && !cpf.IsValid(guardian.CPF) {
+		return cpf.ErrInvalid
+	}
+
 	// Check if student exists
 	existing, err := s.studentRepo.FindByID(ctx, guardian.StudentID)
 	if err != nil {
@@ -342,6 +386,11 @@ func (s *studentService) UpdateGuardian(ctx context.Context, guardian *models.Gu
 		return fmt.Errorf("guardian ID not provided")
 	}
 
+	// Validate CPF if provided
+	if guardian.CPF != "" && !cpf.IsValid(guardian.CPF) {
+		return cpf.ErrInvalid
+	}
+
 	// Delegate to repository
 	return s.studentRepo.UpdateGuardian(ctx, guardian)
 }
@@ -0,0 +1,647 @@
+// backend/internal/service/studentstatus/service.go
+package studentstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+var (
+	// ErrStudentNotFound is returned when the student does not exist
+	ErrStudentNotFound = errors.New("student not found")
+	// ErrChangeNotFound is returned when the status change does not exist
+	ErrChangeNotFound = errors.New("status change not found")
+	// ErrSuspensionNotFound is returned when the suspension does not exist
+	ErrSuspensionNotFound = errors.New("suspension not found")
+	// ErrInvalidChange is returned for status changes that are not allowed or lack a reason
+	ErrInvalidChange = errors.New("invalid status change")
+	// ErrNotReversible is returned when the change cannot be reverted
+	ErrNotReversible = errors.New("status change cannot be reverted")
+	// ErrInvalidSuspension is returned for incomplete or inconsistent suspensions
+	ErrInvalidSuspension = errors.New("invalid suspension")
+)
+
+// liveEnrollment matches the enrollments that keep a student active
+const liveEnrollment = `e.student_id = st.id AND e.deleted_at IS NULL AND e.status IN ('active', 'in_progress')`
+
+// Notifier tells the student that the status changed
+type Notifier interface {
+	OnStudentStatusChanged(userID uint, status models.StudentStatus, reason string)
+}
+
+// SuspensionInput suspends the student involved in an incident
+type SuspensionInput struct {
+	IncidentID uint
+	StartDate  time.Time
+	EndDate    time.Time
+	Reason     string
+}
+
+// StatusChange is a history entry with the name of who made it
+type StatusChange struct {
+	models.StudentStatusChange
+	ChangedByName string `json:"changedByName,omitempty"`
+	Reversible    bool   `json:"reversible"`
+}
+
+// RulesResult counts what a run of the automatic rules changed
+type RulesResult struct {
+	Inactivated        int `json:"inactivated"`
+	Reactivated        int `json:"reactivated"`
+	SuspensionsStarted int `json:"suspensionsStarted"`
+	SuspensionsEnded   int `json:"suspensionsEnded"`
+}
+
+// Service defines the interface for the student status lifecycle. Every change has a reason and
+// is recorded in student_status_changes.
+type Service interface {
+	ChangeStatus(ctx context.Context, studentID uint, status models.StudentStatus, reason string, userID uint) (*models.StudentStatusChange, error)
+	Revert(ctx context.Context, changeID uint, reason string, userID uint) (*models.StudentStatusChange, error)
+	History(ctx context.Context, studentID uint) ([]StatusChange, error)
+
+	// Suspensions
+	Suspend(ctx context.Context, input SuspensionInput, userID uint) (*models.StudentSuspension, error)
+	RevokeSuspension(ctx context.Context, id uint, reason string, userID uint) (*models.StudentSuspension, error)
+	ListSuspensions(ctx context.Context, studentID uint) ([]models.StudentSuspension, error)
+
+	// Automatic rules (run daily)
+	RunRules(ctx context.Context) (*RulesResult, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db            *gorm.DB
+	notifier      Notifier
+	inactiveAfter time.Duration
+	now           func() time.Time
+}
+
+// NewService creates a new student status service. Students without a live enrollment for
+// inactiveAfter are inactivated by RunRules. notifier may be nil.
+func NewService(db *gorm.DB, notifier Notifier, inactiveAfter time.Duration) Service {
+	return &service{db: db, notifier: notifier, inactiveAfter: inactiveAfter, now: time.Now}
+}
+
+// transition is a status change to write
+type transition struct {
+	studentID       uint
+	to              models.StudentStatus
+	reason          string
+	source          models.StatusChangeSource
+	suspensionID    *uint
+	revertsChangeID *uint
+	changedByID     *uint
+}
+
+// ChangeStatus changes the status by hand. Suspensions go through Suspend, so they are always
+// tied to an incident, and a running suspension is ended with RevokeSuspension.
+func (s *service) ChangeStatus(ctx context.Context, studentID uint, status models.StudentStatus, reason string, userID uint) (*models.StudentStatusChange, error) {
+	reason = strings.TrimSpace(reason)
+	if err := validateManual(status, reason); err != nil {
+		return nil, err
+	}
+
+	var change *models.StudentStatusChange
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		student, err := lockStudent(tx, studentID)
+		if err != nil {
+			return err
+		}
+		if student.Status == status {
+			return fmt.Errorf("%w: the student is already %s", ErrInvalidChange, status)
+		}
+		var running int64
+		if err := tx.Model(&models.StudentSuspension{}).
+			Where("student_id = ? AND status = ?", studentID, models.SuspensionStatusActive).
+			Count(&running).Error; err != nil {
+			return err
+		}
+		if running > 0 {
+			return fmt.Errorf("%w: the student has a running suspension; revoke it instead", ErrInvalidChange)
+		}
+
+		change, err = s.apply(tx, student, transition{
+			studentID:   studentID,
+			to:          status,
+			reason:      reason,
+			source:      models.StatusSourceManual,
+			changedByID: &userID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notify(ctx, change)
+	return change, nil
+}
+
+// Revert undoes the latest change of the student, returning to the previous status. Reverting the
+// start of a suspension revokes it; finished suspensions and reversals are not reverted.
+func (s *service) Revert(ctx context.Context, changeID uint, reason string, userID uint) (*models.StudentStatusChange, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: a reason is required", ErrInvalidChange)
+	}
+
+	var change *models.StudentStatusChange
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var original models.StudentStatusChange
+		if err := tx.First(&original, changeID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrChangeNotFound
+			}
+			return err
+		}
+		student, err := lockStudent(tx, original.StudentID)
+		if err != nil {
+			return err
+		}
+
+		var latest models.StudentStatusChange
+		if err := tx.Where("student_id = ?", original.StudentID).
+			Order("created_at DESC, id DESC").
+			First(&latest).Error; err != nil {
+			return err
+		}
+		if err := reversible(&original, latest.ID, student.Status); err != nil {
+			return err
+		}
+
+		now := s.now()
+		if original.Source == models.StatusSourceSuspension && original.SuspensionID != nil {
+			if err := tx.Model(&models.StudentSuspension{}).Where("id = ?", *original.SuspensionID).Updates(map[string]interface{}{
+				"status":        models.SuspensionStatusRevoked,
+				"revoked_by_id": userID,
+				"revoked_at":    now,
+				"revoke_reason": reason,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(&original).Update("reverted_at", now).Error; err != nil {
+			return err
+		}
+
+		change, err = s.apply(tx, student, transition{
+			studentID:       original.StudentID,
+			to:              original.FromStatus,
+			reason:          reason,
+			source:          models.StatusSourceReversal,
+			suspensionID:    original.SuspensionID,
+			revertsChangeID: &original.ID,
+			changedByID:     &userID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notify(ctx, change)
+	return change, nil
+}
+
+// History returns the status changes of the student, most recent first
+func (s *service) History(ctx context.Context, studentID uint) ([]StatusChange, error) {
+	if _, err := findStudent(s.db.WithContext(ctx), studentID); err != nil {
+		return nil, err
+	}
+
+	changes := []StatusChange{}
+	if err := s.db.WithContext(ctx).Table("student_status_changes c").
+		Select("c.*, COALESCE(u.name, '') AS changed_by_name").
+		Joins("LEFT JOIN users u ON u.id = c.changed_by_id").
+		Where("c.student_id = ?", studentID).
+		Order("c.created_at DESC, c.id DESC").
+		Scan(&changes).Error; err != nil {
+		return nil, err
+	}
+	if len(changes) > 0 {
+		var student models.Student
+		if err := s.db.WithContext(ctx).Select("status").First(&student, studentID).Error; err != nil {
+			return nil, err
+		}
+		changes[0].Reversible = reversible(&changes[0].StudentStatusChange, changes[0].ID, student.Status) == nil
+	}
+	return changes, nil
+}
+
+// Suspend registers a suspension for the student involved in the incident. A suspension starting
+// today (or earlier) is applied at once; later ones start with RunRules.
+func (s *service) Suspend(ctx context.Context, input SuspensionInput, userID uint) (*models.StudentSuspension, error) {
+	input.Reason = strings.TrimSpace(input.Reason)
+	today := day(s.now())
+	if err := validateSuspension(input, today); err != nil {
+		return nil, err
+	}
+
+	var incident models.Incident
+	if err := s.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", input.IncidentID).First(&incident).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: incident %d not found", ErrInvalidSuspension, input.IncidentID)
+		}
+		return nil, err
+	}
+	if incident.StudentID == nil {
+		return nil, fmt.Errorf("%w: incident %d does not involve a student", ErrInvalidSuspension, input.IncidentID)
+	}
+	if incident.Status == models.IncidentStatusCancelled {
+		return nil, fmt.Errorf("%w: incident %d was cancelled", ErrInvalidSuspension, input.IncidentID)
+	}
+
+	suspension := &models.StudentSuspension{
+		StudentID:   *incident.StudentID,
+		IncidentID:  incident.ID,
+		StartDate:   day(input.StartDate),
+		EndDate:     day(input.EndDate),
+		Reason:      input.Reason,
+		Status:      models.SuspensionStatusScheduled,
+		CreatedByID: userID,
+	}
+	var change *models.StudentStatusChange
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		student, err := lockStudent(tx, suspension.StudentID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(suspension).Error; err != nil {
+			return err
+		}
+		if suspension.StartDate.After(today) {
+			return nil
+		}
+		change, err = s.startSuspension(tx, student, suspension, &userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notify(ctx, change)
+	return suspension, nil
+}
+
+// RevokeSuspension cancels a scheduled or running suspension; a running one returns the student
+// to the status before it
+func (s *service) RevokeSuspension(ctx context.Context, id uint, reason string, userID uint) (*models.StudentSuspension, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: a reason is required", ErrInvalidSuspension)
+	}
+
+	var suspension models.StudentSuspension
+	var change *models.StudentStatusChange
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&suspension, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrSuspensionNotFound
+			}
+			return err
+		}
+		if suspension.Status != models.SuspensionStatusScheduled && suspension.Status != models.SuspensionStatusActive {
+			return fmt.Errorf("%w: the suspension is already %s", ErrInvalidSuspension, suspension.Status)
+		}
+		wasActive := suspension.Status == models.SuspensionStatusActive
+
+		now := s.now()
+		suspension.Status = models.SuspensionStatusRevoked
+		suspension.RevokedByID = &userID
+		suspension.RevokedAt = &now
+		suspension.RevokeReason = reason
+		if err := tx.Save(&suspension).Error; err != nil {
+			return err
+		}
+		if !wasActive {
+			return nil
+		}
+
+		student, err := lockStudent(tx, suspension.StudentID)
+		if err != nil {
+			return err
+		}
+		change, err = s.endSuspension(tx, student, &suspension, "Suspensão revogada: "+reason, &userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notify(ctx, change)
+	return &suspension, nil
+}
+
+// ListSuspensions returns the suspensions of the student, most recent first
+func (s *service) ListSuspensions(ctx context.Context, studentID uint) ([]models.StudentSuspension, error) {
+	if _, err := findStudent(s.db.WithContext(ctx), studentID); err != nil {
+		return nil, err
+	}
+
+	suspensions := []models.StudentSuspension{}
+	if err := s.db.WithContext(ctx).
+		Where("student_id = ?", studentID).
+		Order("start_date DESC, id DESC").
+		Find(&suspensions).Error; err != nil {
+		return nil, err
+	}
+	return suspensions, nil
+}
+
+// RunRules applies the automatic rules: starts and ends the suspensions of the day, inactivates
+// the active students without a live enrollment for the configured time (counted from the last
+// enrollment update or reactivation) and reactivates the students inactivated by this rule that
+// enrolled again. Each student is handled in its own transaction.
+func (s *service) RunRules(ctx context.Context) (*RulesResult, error) {
+	result := &RulesResult{}
+	today := day(s.now())
+
+	// Suspensões que não chegaram a começar antes do fim do prazo
+	if err := s.db.WithContext(ctx).Model(&models.StudentSuspension{}).
+		Where("status = ? AND end_date < ?", models.SuspensionStatusScheduled, today).
+		Update("status", models.SuspensionStatusEnded).Error; err != nil {
+		return nil, err
+	}
+
+	var starting []models.StudentSuspension
+	if err := s.db.WithContext(ctx).
+		Where("status = ? AND start_date <= ? AND end_date >= ?", models.SuspensionStatusScheduled, today, today).
+		Order("start_date, id").
+		Find(&starting).Error; err != nil {
+		return nil, err
+	}
+	for i := range starting {
+		suspension := &starting[i]
+		if err := s.runForStudent(ctx, suspension.StudentID, func(tx *gorm.DB, student *models.Student) (*models.StudentStatusChange, error) {
+			return s.startSuspension(tx, student, suspension, nil)
+		}); err != nil {
+			return result, err
+		}
+		result.SuspensionsStarted++
+	}
+
+	var ending []models.StudentSuspension
+	if err := s.db.WithContext(ctx).
+		Where("status = ? AND end_date < ?", models.SuspensionStatusActive, today).
+		Order("end_date, id").
+		Find(&ending).Error; err != nil {
+		return nil, err
+	}
+	for i := range ending {
+		suspension := &ending[i]
+		if err := s.runForStudent(ctx, suspension.StudentID, func(tx *gorm.DB, student *models.Student) (*models.StudentStatusChange, error) {
+			if err := tx.Model(suspension).Update("status", models.SuspensionStatusEnded).Error; err != nil {
+				return nil, err
+			}
+			return s.endSuspension(tx, student, suspension, fmt.Sprintf("Fim da suspensão em %s", suspension.EndDate.Format("02/01/2006")), nil)
+		}); err != nil {
+			return result, err
+		}
+		result.SuspensionsEnded++
+	}
+
+	cutoff := s.now().Add(-s.inactiveAfter)
+	var idle []uint
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT st.id FROM students st
+		WHERE st.deleted_at IS NULL AND st.status = 'active' AND st.created_at < ?
+			AND NOT EXISTS (SELECT 1 FROM enrollments e WHERE `+liveEnrollment+`)
+			AND NOT EXISTS (
+				SELECT 1 FROM enrollments e
+				WHERE e.student_id = st.id AND e.deleted_at IS NULL AND GREATEST(e.updated_at, COALESCE(e.end_date, e.updated_at)) >= ?
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM student_status_changes c
+				WHERE c.student_id = st.id AND c.to_status = 'active' AND c.created_at >= ?
+			)
+		ORDER BY st.id
+	`, cutoff, cutoff, cutoff).Scan(&idle).Error; err != nil {
+		return result, err
+	}
+	reason := fmt.Sprintf("Sem matrícula ativa há mais de %d dias", int(s.inactiveAfter.Hours()/24))
+	for _, studentID := range idle {
+		if err := s.runForStudent(ctx, studentID, func(tx *gorm.DB, student *models.Student) (*models.StudentStatusChange, error) {
+			if student.Status != models.StudentStatusActive {
+				return nil, nil
+			}
+			return s.apply(tx, student, transition{studentID: studentID, to: models.StudentStatusInactive, reason: reason, source: models.StatusSourceInactivity})
+		}); err != nil {
+			return result, err
+		}
+		result.Inactivated++
+	}
+
+	var returning []uint
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT st.id FROM students st
+		WHERE st.deleted_at IS NULL AND st.status = 'inactive'
+			AND EXISTS (SELECT 1 FROM enrollments e WHERE `+liveEnrollment+`)
+			AND (
+				SELECT c.source FROM student_status_changes c
+				WHERE c.student_id = st.id
+				ORDER BY c.created_at DESC, c.id DESC
+				LIMIT 1
+			) = ?
+		ORDER BY st.id
+	`, models.StatusSourceInactivity).Scan(&returning).Error; err != nil {
+		return result, err
+	}
+	for _, studentID := range returning {
+		if err := s.runForStudent(ctx, studentID, func(tx *gorm.DB, student *models.Student) (*models.StudentStatusChange, error) {
+			if student.Status != models.StudentStatusInactive {
+				return nil, nil
+			}
+			return s.apply(tx, student, transition{studentID: studentID, to: models.StudentStatusActive, reason: "Nova matrícula ativa", source: models.StatusSourceReactivation})
+		}); err != nil {
+			return result, err
+		}
+		result.Reactivated++
+	}
+
+	return result, nil
+}
+
+// runForStudent runs a rule for one student in a transaction and notifies the change
+func (s *service) runForStudent(ctx context.Context, studentID uint, rule func(tx *gorm.DB, student *models.Student) (*models.StudentStatusChange, error)) error {
+	var change *models.StudentStatusChange
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		student, err := lockStudent(tx, studentID)
+		if err != nil {
+			return err
+		}
+		change, err = rule(tx, student)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("student %d: %w", studentID, err)
+	}
+	s.notify(ctx, change)
+	return nil
+}
+
+// startSuspension marks the suspension as running and suspends the student. A student already
+// suspended (overlapping suspensions) keeps the status without a new history entry.
+func (s *service) startSuspension(tx *gorm.DB, student *models.Student, suspension *models.StudentSuspension, changedByID *uint) (*models.StudentStatusChange, error) {
+	suspension.Status = models.SuspensionStatusActive
+	if err := tx.Model(suspension).Update("status", models.SuspensionStatusActive).Error; err != nil {
+		return nil, err
+	}
+	if student.Status == models.StudentStatusSuspended {
+		return nil, nil
+	}
+	return s.apply(tx, student, transition{
+		studentID:    student.ID,
+		to:           models.StudentStatusSuspended,
+		reason:       fmt.Sprintf("Suspensão de %s a %s: %s", suspension.StartDate.Format("02/01/2006"), suspension.EndDate.Format("02/01/2006"), suspension.Reason),
+		source:       models.StatusSourceSuspension,
+		suspensionID: &suspension.ID,
+		changedByID:  changedByID,
+	})
+}
+
+// endSuspension returns the student to the status before the suspension, unless another
+// suspension is still running
+func (s *service) endSuspension(tx *gorm.DB, student *models.Student, suspension *models.StudentSuspension, reason string, changedByID *uint) (*models.StudentStatusChange, error) {
+	var running int64
+	if err := tx.Model(&models.StudentSuspension{}).
+		Where("student_id = ? AND status = ? AND id <> ?", student.ID, models.SuspensionStatusActive, suspension.ID).
+		Count(&running).Error; err != nil {
+		return nil, err
+	}
+	if running > 0 || student.Status != models.StudentStatusSuspended {
+		return nil, nil
+	}
+
+	previous := models.StudentStatusActive
+	var started models.StudentStatusChange
+	err := tx.Where("suspension_id = ? AND source = ?", suspension.ID, models.StatusSourceSuspension).
+		Order("id DESC").
+		First(&started).Error
+	switch {
+	case err == nil:
+		if started.FromStatus != models.StudentStatusSuspended {
+			previous = started.FromStatus
+		}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	return s.apply(tx, student, transition{
+		studentID:    student.ID,
+		to:           previous,
+		reason:       reason,
+		source:       models.StatusSourceSuspensionEnd,
+		suspensionID: &suspension.ID,
+		changedByID:  changedByID,
+	})
+}
+
+// apply writes the new status and its history entry
+func (s *service) apply(tx *gorm.DB, student *models.Student, t transition) (*models.StudentStatusChange, error) {
+	change := &models.StudentStatusChange{
+		StudentID:       t.studentID,
+		FromStatus:      student.Status,
+		ToStatus:        t.to,
+		Reason:          t.reason,
+		Source:          t.source,
+		SuspensionID:    t.suspensionID,
+		RevertsChangeID: t.revertsChangeID,
+		ChangedByID:     t.changedByID,
+		CreatedAt:       s.now(),
+	}
+	if err := tx.Model(&models.Student{}).Where("id = ?", t.studentID).Update("status", t.to).Error; err != nil {
+		return nil, fmt.Errorf("failed to update student status: %w", err)
+	}
+	if err := tx.Create(change).Error; err != nil {
+		return nil, fmt.Errorf("failed to record status change: %w", err)
+	}
+	student.Status = t.to
+	return change, nil
+}
+
+// notify tells the student about the change; nil changes (nothing changed) are skipped
+func (s *service) notify(ctx context.Context, change *models.StudentStatusChange) {
+	if change == nil || s.notifier == nil {
+		return
+	}
+	var student models.Student
+	if err := s.db.WithContext(ctx).Select("user_id").First(&student, change.StudentID).Error; err != nil {
+		fmt.Printf("Warning: failed to notify status change %d: %v\n", change.ID, err)
+		return
+	}
+	s.notifier.OnStudentStatusChanged(student.UserID, change.ToStatus, change.Reason)
+}
+
+func lockStudent(tx *gorm.DB, studentID uint) (*models.Student, error) {
+	return findStudent(tx.Clauses(clause.Locking{Strength: "UPDATE"}), studentID)
+}
+
+func findStudent(db *gorm.DB, studentID uint) (*models.Student, error) {
+	var student models.Student
+	if err := db.Where("id = ? AND deleted_at IS NULL", studentID).First(&student).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrStudentNotFound
+		}
+		return nil, err
+	}
+	return &student, nil
+}
+
+// validateManual checks a status change made by hand
+func validateManual(status models.StudentStatus, reason string) error {
+	switch status {
+	case models.StudentStatusActive, models.StudentStatusInactive:
+	case models.StudentStatusSuspended:
+		return fmt.Errorf("%w: suspensions must be registered for an incident", ErrInvalidChange)
+	default:
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidChange, status)
+	}
+	if reason == "" {
+		return fmt.Errorf("%w: a reason is required", ErrInvalidChange)
+	}
+	return nil
+}
+
+// reversible tells whether a change can still be reverted: it must be the latest change of the
+// student, still in effect, and not a reversal or the end of a suspension
+func reversible(change *models.StudentStatusChange, latestID uint, current models.StudentStatus) error {
+	switch {
+	case change.RevertedAt != nil:
+		return fmt.Errorf("%w: it was already reverted", ErrNotReversible)
+	case change.Source == models.StatusSourceReversal:
+		return fmt.Errorf("%w: reversals are not reverted; change the status again", ErrNotReversible)
+	case change.Source == models.StatusSourceSuspensionEnd:
+		return fmt.Errorf("%w: a finished suspension is not reopened; register a new suspension", ErrNotReversible)
+	case change.ID != latestID:
+		return fmt.Errorf("%w: only the latest change of the student can be reverted", ErrNotReversible)
+	case change.ToStatus != current:
+		return fmt.Errorf("%w: the student is no longer %s", ErrNotReversible, change.ToStatus)
+	}
+	return nil
+}
+
+// validateSuspension checks the period and the reason of a suspension
+func validateSuspension(input SuspensionInput, today time.Time) error {
+	switch {
+	case input.IncidentID == 0:
+		return fmt.Errorf("%w: incidentId is required", ErrInvalidSuspension)
+	case input.Reason == "":
+		return fmt.Errorf("%w: a reason is required", ErrInvalidSuspension)
+	case input.StartDate.IsZero() || input.EndDate.IsZero():
+		return fmt.Errorf("%w: startDate and endDate are required", ErrInvalidSuspension)
+	case input.EndDate.Before(input.StartDate):
+		return fmt.Errorf("%w: endDate is before startDate", ErrInvalidSuspension)
+	case day(input.EndDate).Before(today):
+		return fmt.Errorf("%w: the suspension would already be over", ErrInvalidSuspension)
+	}
+	return nil
+}
+
+// day truncates to the date, in UTC like the DATE columns read by GORM
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
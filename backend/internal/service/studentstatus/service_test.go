@@ -0,0 +1,86 @@
+package studentstatus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+func TestValidateManual(t *testing.T) {
+	if err := validateManual(models.StudentStatusInactive, "Mudou de cidade"); err != nil {
+		t.Errorf("Expected valid change, got %v", err)
+	}
+
+	cases := []struct {
+		status models.StudentStatus
+		reason string
+	}{
+		{models.StudentStatusActive, ""},
+		{models.StudentStatusSuspended, "Briga no intervalo"},
+		{models.StudentStatus("graduated"), "Concluiu"},
+	}
+	for _, c := range cases {
+		if err := validateManual(c.status, c.reason); !errors.Is(err, ErrInvalidChange) {
+			t.Errorf("Expected ErrInvalidChange for %s/%q, got %v", c.status, c.reason, err)
+		}
+	}
+}
+
+func TestReversible(t *testing.T) {
+	reverted := time.Now()
+	change := func(id uint, source models.StatusChangeSource) *models.StudentStatusChange {
+		return &models.StudentStatusChange{
+			ID:         id,
+			FromStatus: models.StudentStatusActive,
+			ToStatus:   models.StudentStatusInactive,
+			Source:     source,
+		}
+	}
+
+	if err := reversible(change(5, models.StatusSourceInactivity), 5, models.StudentStatusInactive); err != nil {
+		t.Errorf("Expected the latest change to be reversible, got %v", err)
+	}
+
+	already := change(5, models.StatusSourceManual)
+	already.RevertedAt = &reverted
+	cases := map[string]error{
+		"older change":   reversible(change(4, models.StatusSourceManual), 5, models.StudentStatusInactive),
+		"not in effect":  reversible(change(5, models.StatusSourceManual), 5, models.StudentStatusActive),
+		"reversal":       reversible(change(5, models.StatusSourceReversal), 5, models.StudentStatusInactive),
+		"suspension end": reversible(change(5, models.StatusSourceSuspensionEnd), 5, models.StudentStatusInactive),
+		"reverted":       reversible(already, 5, models.StudentStatusInactive),
+	}
+	for name, err := range cases {
+		if !errors.Is(err, ErrNotReversible) {
+			t.Errorf("%s: expected ErrNotReversible, got %v", name, err)
+		}
+	}
+}
+
+func TestValidateSuspension(t *testing.T) {
+	today := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	valid := SuspensionInput{IncidentID: 1, StartDate: today, EndDate: today.AddDate(0, 0, 3), Reason: "Agressão a colega"}
+	if err := validateSuspension(valid, today); err != nil {
+		t.Errorf("Expected valid suspension, got %v", err)
+	}
+
+	// Começar no passado é permitido enquanto o prazo não acabou
+	started := valid
+	started.StartDate = today.AddDate(0, 0, -2)
+	if err := validateSuspension(started, today); err != nil {
+		t.Errorf("Expected suspension in progress to be valid, got %v", err)
+	}
+
+	noIncident, noReason, inverted, over := valid, valid, valid, valid
+	noIncident.IncidentID = 0
+	noReason.Reason = ""
+	inverted.EndDate = today.AddDate(0, 0, -1)
+	over.StartDate, over.EndDate = today.AddDate(0, 0, -5), today.AddDate(0, 0, -1)
+	for _, input := range []SuspensionInput{noIncident, noReason, inverted, over, {IncidentID: 1, Reason: "x"}} {
+		if err := validateSuspension(input, today); !errors.Is(err, ErrInvalidSuspension) {
+			t.Errorf("Expected ErrInvalidSuspension for %+v, got %v", input, err)
+		}
+	}
+}
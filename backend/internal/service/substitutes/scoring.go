@@ -0,0 +1,271 @@
+// backend/internal/service/substitutes/scoring.go
+package substitutes
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+// Pesos da pontuação
+const (
+	exactSkillBase     = 20 // skill citada no nome ou nas tags do curso
+	exactSkillPerLevel = 5
+	domainSkillBase    = 8 // skill do mesmo domínio da categoria do curso
+	domainSkillPerLvl  = 3
+	extraSkillPoints   = 3 // cada skill relevante além da melhor
+	maxExtraSkills     = 3
+	levelGapPenalty    = -10 // nível abaixo da dificuldade do curso
+	availabilityPoints = 30
+	sessionPenalty     = -50
+	absencePenalty     = -100
+)
+
+// levelWeights ranks TeacherSkill.Level
+var levelWeights = map[string]int{
+	"beginner":     1,
+	"intermediate": 2,
+	"advanced":     3,
+	"expert":       4,
+}
+
+// difficultyLevels maps Course.DifficultyLevel to the minimum skill level
+var difficultyLevels = map[string]int{
+	"basico":        1,
+	"beginner":      1,
+	"iniciante":     1,
+	"intermediario": 2,
+	"intermediate":  2,
+	"avancado":      3,
+	"advanced":      3,
+}
+
+var weekDayNames = []string{"domingo", "segunda", "terça", "quarta", "quinta", "sexta", "sábado"}
+
+// skillMatch is the relevance of one teacher skill to the slot
+type skillMatch struct {
+	kind   string // exact, domain
+	name   string
+	level  int
+	points int
+}
+
+// scoreCandidate computes the score and its explanation for one teacher
+func scoreCandidate(teacher models.Teacher, slot *Slot, conflicts []Conflict) Candidate {
+	candidate := Candidate{
+		TeacherID:  teacher.ID,
+		Name:       teacher.User.Name,
+		Email:      teacher.User.Email,
+		SkillMatch: "none",
+		Reasons:    []Reason{},
+		Conflicts:  conflicts,
+	}
+	add := func(points int, description string) {
+		candidate.Score += points
+		candidate.Reasons = append(candidate.Reasons, Reason{Points: points, Description: description})
+	}
+
+	// Skills
+	candidate.SkillMatch = scoreSkills(teacher, slot, add)
+
+	// Disponibilidade
+	weekDay := int(slot.Date.Weekday())
+	candidate.Available = coversSlot(teacher.Availability, weekDay, slot.StartTime, slot.EndTime)
+	if candidate.Available {
+		add(availabilityPoints, fmt.Sprintf("Disponível %s das %s às %s", weekDayNames[weekDay], slot.StartTime, slot.EndTime))
+	} else {
+		add(0, fmt.Sprintf("Sem disponibilidade cadastrada %s das %s às %s", weekDayNames[weekDay], slot.StartTime, slot.EndTime))
+	}
+
+	// Conflitos
+	for _, conflict := range conflicts {
+		candidate.HasConflict = true
+		if conflict.Type == "absence" {
+			add(absencePenalty, conflict.Description)
+		} else {
+			add(sessionPenalty, conflict.Description)
+		}
+	}
+
+	return candidate
+}
+
+// scoreSkills adds the points of the most relevant skill (plus related ones) and returns the match kind
+func scoreSkills(teacher models.Teacher, slot *Slot, add func(points int, description string)) string {
+	var matches []skillMatch
+	for _, ts := range teacher.Skills {
+		if match, ok := matchSkill(ts, slot); ok {
+			matches = append(matches, match)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].points > matches[j].points })
+
+	if len(matches) == 0 {
+		add(0, "Nenhuma skill relacionada ao curso")
+		return "none"
+	}
+
+	best := matches[0]
+	if best.kind == "exact" {
+		add(best.points, fmt.Sprintf("Skill %s (%s) corresponde ao curso", best.name, levelName(best.level)))
+	} else {
+		add(best.points, fmt.Sprintf("Skill %s (%s) é do domínio %s", best.name, levelName(best.level), slot.CategoryName))
+	}
+
+	if extra := len(matches) - 1; extra > 0 {
+		if extra > maxExtraSkills {
+			extra = maxExtraSkills
+		}
+		add(extra*extraSkillPoints, fmt.Sprintf("%d outra(s) skill(s) relacionada(s)", len(matches)-1))
+	}
+
+	if required, ok := difficultyLevels[normalize(slot.Difficulty)]; ok && best.level < required {
+		add(levelGapPenalty, fmt.Sprintf("Nível %s abaixo da dificuldade do curso (%s)", levelName(best.level), slot.Difficulty))
+	}
+	return best.kind
+}
+
+// matchSkill scores a teacher skill against the course name, tags and category
+func matchSkill(ts models.TeacherSkill, slot *Slot) (skillMatch, bool) {
+	level := levelWeights[strings.ToLower(strings.TrimSpace(ts.Level))]
+	if level == 0 {
+		level = levelWeights["intermediate"]
+	}
+	match := skillMatch{name: ts.Skill.Name, level: level}
+
+	name := normalize(ts.Skill.Name)
+	if name != "" {
+		if containsWords(normalize(slot.CourseName), name) {
+			match.kind = "exact"
+		}
+		for _, tag := range slot.tags {
+			if normalize(tag) == name {
+				match.kind = "exact"
+			}
+		}
+	}
+	if match.kind == "exact" {
+		match.points = exactSkillBase + exactSkillPerLevel*level
+		return match, true
+	}
+
+	if domain := normalize(ts.Skill.Domain); domain != "" && domain == normalize(slot.CategoryName) {
+		match.kind = "domain"
+		match.points = domainSkillBase + domainSkillPerLvl*level
+		return match, true
+	}
+
+	return match, false
+}
+
+// coversSlot reports whether an active availability window contains the whole slot
+func coversSlot(windows []models.TeacherAvailability, weekDay int, start, end string) bool {
+	slotStart, okStart := minutes(start)
+	slotEnd, okEnd := minutes(end)
+	if !okStart || !okEnd {
+		return false
+	}
+	for _, window := range windows {
+		if !window.IsActive || window.DayOfWeek != weekDay {
+			continue
+		}
+		windowStart, okStart := minutes(window.StartTime)
+		windowEnd, okEnd := minutes(window.EndTime)
+		if okStart && okEnd && windowStart <= slotStart && windowEnd >= slotEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// overlaps reports whether two "15:04" intervals intersect; unknown times count as overlapping
+func overlaps(startA, endA, startB, endB string) bool {
+	aStart, ok1 := minutes(startA)
+	aEnd, ok2 := minutes(endA)
+	bStart, ok3 := minutes(startB)
+	bEnd, ok4 := minutes(endB)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return true
+	}
+	return aStart < bEnd && bStart < aEnd
+}
+
+// rankCandidates orders free and available teachers first, then by score
+func rankCandidates(candidates []Candidate) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.HasConflict != b.HasConflict {
+			return !a.HasConflict
+		}
+		if a.Available != b.Available {
+			return a.Available
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Name < b.Name
+	})
+	for i := range candidates {
+		candidates[i].Rank = i + 1
+	}
+}
+
+// minutes parses "15:04" (seconds are ignored) into minutes since midnight
+func minutes(value string) (int, bool) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) < 2 {
+		return 0, false
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, false
+	}
+	mins, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, false
+	}
+	return hours*60 + mins, true
+}
+
+func levelName(level int) string {
+	for name, weight := range levelWeights {
+		if weight == level {
+			return name
+		}
+	}
+	return "intermediate"
+}
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a",
+	"é", "e", "ê", "e",
+	"í", "i",
+	"ó", "o", "ô", "o", "õ", "o",
+	"ú", "u", "ü", "u",
+	"ç", "c",
+)
+
+// normalize lowercases and strips Portuguese accents for comparisons
+func normalize(value string) string {
+	return accentReplacer.Replace(strings.ToLower(strings.TrimSpace(value)))
+}
+
+// containsWords reports whether phrase appears in text as whole words ("C" does not match "Crochê")
+func containsWords(text, phrase string) bool {
+	words := strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#' })
+	return strings.Contains(" "+strings.Join(words, " ")+" ", " "+strings.Join(strings.Fields(phrase), " ")+" ")
+}
+
+// parseTags decodes Course.Tags, a JSON array of strings
+func parseTags(raw string) []string {
+	var tags []string
+	if raw == "" || json.Unmarshal([]byte(raw), &tags) != nil {
+		return nil
+	}
+	return tags
+}
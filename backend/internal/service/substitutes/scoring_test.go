@@ -0,0 +1,110 @@
+package substitutes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+func teacherWith(id uint, name string, skills []models.TeacherSkill, availability []models.TeacherAvailability) models.Teacher {
+	return models.Teacher{
+		ID:           id,
+		User:         models.User{Name: name},
+		Skills:       skills,
+		Availability: availability,
+	}
+}
+
+func TestScoreCandidate(t *testing.T) {
+	// Quarta-feira
+	slot := &Slot{
+		Date:         time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC),
+		StartTime:    "14:00",
+		EndTime:      "16:00",
+		CourseName:   "Inglês Básico",
+		CategoryName: "Idiomas",
+		Difficulty:   "avançado",
+	}
+	english := models.Skill{Name: "Inglês", Domain: "Idiomas"}
+	spanish := models.Skill{Name: "Espanhol", Domain: "Idiomas"}
+	excel := models.Skill{Name: "Excel", Domain: "Tecnologia"}
+	wednesday := []models.TeacherAvailability{{DayOfWeek: 3, StartTime: "13:00", EndTime: "18:00", IsActive: true}}
+
+	t.Run("exact skill outranks domain skill", func(t *testing.T) {
+		exact := scoreCandidate(teacherWith(1, "Ana", []models.TeacherSkill{{Skill: english, Level: "expert"}}, nil), slot, nil)
+		domain := scoreCandidate(teacherWith(2, "Bia", []models.TeacherSkill{{Skill: spanish, Level: "expert"}}, nil), slot, nil)
+		unrelated := scoreCandidate(teacherWith(3, "Caio", []models.TeacherSkill{{Skill: excel, Level: "expert"}}, nil), slot, nil)
+
+		if exact.SkillMatch != "exact" || domain.SkillMatch != "domain" || unrelated.SkillMatch != "none" {
+			t.Errorf("Expected exact/domain/none, got %s/%s/%s", exact.SkillMatch, domain.SkillMatch, unrelated.SkillMatch)
+		}
+		if !(exact.Score > domain.Score && domain.Score > unrelated.Score) {
+			t.Errorf("Expected exact > domain > unrelated, got %d, %d, %d", exact.Score, domain.Score, unrelated.Score)
+		}
+	})
+
+	t.Run("level below course difficulty is penalized", func(t *testing.T) {
+		expert := scoreCandidate(teacherWith(1, "Ana", []models.TeacherSkill{{Skill: english, Level: "expert"}}, nil), slot, nil)
+		beginner := scoreCandidate(teacherWith(2, "Bia", []models.TeacherSkill{{Skill: english, Level: "beginner"}}, nil), slot, nil)
+
+		want := (exactSkillPerLevel * 3) - levelGapPenalty
+		if expert.Score-beginner.Score != want {
+			t.Errorf("Expected a gap of %d points, got %d", want, expert.Score-beginner.Score)
+		}
+	})
+
+	t.Run("availability must cover the whole session", func(t *testing.T) {
+		covered := scoreCandidate(teacherWith(1, "Ana", nil, wednesday), slot, nil)
+		partial := scoreCandidate(teacherWith(2, "Bia", nil, []models.TeacherAvailability{
+			{DayOfWeek: 3, StartTime: "15:00", EndTime: "18:00", IsActive: true},
+		}), slot, nil)
+		otherDay := scoreCandidate(teacherWith(3, "Caio", nil, []models.TeacherAvailability{
+			{DayOfWeek: 2, StartTime: "08:00", EndTime: "20:00", IsActive: true},
+		}), slot, nil)
+
+		if !covered.Available || partial.Available || otherDay.Available {
+			t.Errorf("Expected only the covering window to be available, got %v/%v/%v", covered.Available, partial.Available, otherDay.Available)
+		}
+	})
+
+	t.Run("conflicts are flagged and explained", func(t *testing.T) {
+		candidate := scoreCandidate(teacherWith(1, "Ana", nil, wednesday), slot, []Conflict{
+			{Type: "absence", Description: "Ausente"},
+		})
+
+		if !candidate.HasConflict {
+			t.Errorf("Expected HasConflict to be true")
+		}
+		last := candidate.Reasons[len(candidate.Reasons)-1]
+		if last.Points != absencePenalty || last.Description != "Ausente" {
+			t.Errorf("Expected the absence in the explanation, got %+v", last)
+		}
+	})
+}
+
+func TestRankCandidates(t *testing.T) {
+	candidates := []Candidate{
+		{TeacherID: 1, Score: 90, HasConflict: true, Available: true},
+		{TeacherID: 2, Score: 10, Available: false},
+		{TeacherID: 3, Score: 40, Available: true},
+		{TeacherID: 4, Score: 50, Available: true},
+	}
+	rankCandidates(candidates)
+
+	want := []uint{4, 3, 2, 1}
+	for i, id := range want {
+		if candidates[i].TeacherID != id || candidates[i].Rank != i+1 {
+			t.Errorf("Expected teacher %d at rank %d, got teacher %d (rank %d)", id, i+1, candidates[i].TeacherID, candidates[i].Rank)
+		}
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	if !overlaps("14:00", "16:00", "15:30", "17:00") {
+		t.Errorf("Expected overlapping intervals")
+	}
+	if overlaps("14:00", "16:00", "16:00", "17:00") {
+		t.Errorf("Expected back-to-back sessions not to overlap")
+	}
+}
@@ -0,0 +1,273 @@
+// backend/internal/service/substitutes/service.go
+package substitutes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+const dateLayout = "2006-01-02"
+
+var (
+	// ErrClassNotFound is returned when the course class does not exist
+	ErrClassNotFound = errors.New("course class not found")
+	// ErrSessionNotFound is returned when the session does not exist or belongs to another class
+	ErrSessionNotFound = errors.New("class session not found")
+	// ErrNoUpcomingSession is returned when no slot was given and the class has no future session
+	ErrNoUpcomingSession = errors.New("class has no upcoming session")
+)
+
+// SlotOptions selects the lesson that needs a substitute.
+// With neither field set, the next non-cancelled session of the class is used.
+type SlotOptions struct {
+	SessionID *uint
+	Date      *time.Time
+}
+
+// Slot is the lesson the candidates are matched against
+type Slot struct {
+	CourseClassID uint      `json:"courseClassId"`
+	SessionID     *uint     `json:"sessionId,omitempty"`
+	Date          time.Time `json:"date"`
+	StartTime     string    `json:"startTime"`
+	EndTime       string    `json:"endTime"`
+	CourseName    string    `json:"courseName"`
+	CategoryName  string    `json:"categoryName,omitempty"`
+	Difficulty    string    `json:"difficulty,omitempty"`
+
+	currentTeacherID *uint
+	tags             []string
+}
+
+// Reason is one line of the score explanation
+type Reason struct {
+	Points      int    `json:"points"`
+	Description string `json:"description"`
+}
+
+// Conflict is a commitment that prevents the candidate from taking the slot
+type Conflict struct {
+	Type        string `json:"type"` // session, absence, class
+	SessionID   *uint  `json:"sessionId,omitempty"`
+	AbsenceID   *uint  `json:"absenceId,omitempty"`
+	Description string `json:"description"`
+}
+
+// Candidate is a ranked substitute teacher
+type Candidate struct {
+	Rank        int        `json:"rank"`
+	TeacherID   uint       `json:"teacherId"`
+	Name        string     `json:"name"`
+	Email       string     `json:"email"`
+	Score       int        `json:"score"`
+	SkillMatch  string     `json:"skillMatch"` // exact, domain, none
+	Available   bool       `json:"available"`
+	HasConflict bool       `json:"hasConflict"`
+	Reasons     []Reason   `json:"reasons"`
+	Conflicts   []Conflict `json:"conflicts,omitempty"`
+}
+
+// Result is the slot and its candidates, best first
+type Result struct {
+	Slot       Slot        `json:"slot"`
+	Candidates []Candidate `json:"candidates"`
+}
+
+// Service defines the interface for substitute teacher matching
+type Service interface {
+	FindCandidates(ctx context.Context, courseClassID uint, opts SlotOptions) (*Result, error)
+	SuggestTeachers(ctx context.Context, input ScheduleInput) ([]Suggestion, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db *gorm.DB
+}
+
+// NewService creates a new substitute matching service
+func NewService(db *gorm.DB) Service {
+	return &service{db: db}
+}
+
+// FindCandidates ranks the active teachers for one lesson of the class
+func (s *service) FindCandidates(ctx context.Context, courseClassID uint, opts SlotOptions) (*Result, error) {
+	db := s.db.WithContext(ctx)
+
+	var class models.CourseClass
+	if err := db.Preload("Course").Preload("Course.Category").First(&class, courseClassID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrClassNotFound
+		}
+		return nil, err
+	}
+
+	slot, err := s.resolveSlot(db, &class, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var teachers []models.Teacher
+	if err := db.Where("active = ?", true).
+		Preload("User").
+		Preload("Skills").
+		Preload("Skills.Skill").
+		Preload("Availability").
+		Find(&teachers).Error; err != nil {
+		return nil, err
+	}
+
+	candidates := make([]models.Teacher, 0, len(teachers))
+	ids := make([]uint, 0, len(teachers))
+	for _, teacher := range teachers {
+		if slot.currentTeacherID != nil && teacher.ID == *slot.currentTeacherID {
+			continue
+		}
+		candidates = append(candidates, teacher)
+		ids = append(ids, teacher.ID)
+	}
+
+	conflicts, err := s.findConflicts(db, slot, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Slot: *slot, Candidates: make([]Candidate, 0, len(candidates))}
+	for _, teacher := range candidates {
+		result.Candidates = append(result.Candidates, scoreCandidate(teacher, slot, conflicts[teacher.ID]))
+	}
+	rankCandidates(result.Candidates)
+
+	return result, nil
+}
+
+// resolveSlot finds the date and time of the lesson to cover
+func (s *service) resolveSlot(db *gorm.DB, class *models.CourseClass, opts SlotOptions) (*Slot, error) {
+	slot := &Slot{
+		CourseClassID:    class.ID,
+		StartTime:        class.StartTime,
+		EndTime:          class.EndTime,
+		CourseName:       class.Course.Name,
+		Difficulty:       class.Course.DifficultyLevel,
+		currentTeacherID: class.DefaultTeacherID,
+		tags:             parseTags(class.Course.Tags),
+	}
+	if class.Course.Category != nil {
+		slot.CategoryName = class.Course.Category.Name
+	}
+
+	var session models.ClassSession
+	query := db.Where("course_class_id = ?", class.ID)
+	switch {
+	case opts.SessionID != nil:
+		query = query.Where("id = ?", *opts.SessionID)
+	case opts.Date != nil:
+		query = query.Where("DATE(date) = ?", opts.Date.Format(dateLayout))
+	default:
+		query = query.Where("is_cancelled = ? AND DATE(date) >= ?", false, time.Now().Format(dateLayout)).Order("date")
+	}
+
+	err := query.First(&session).Error
+	switch {
+	case err == nil:
+		sessionID := session.ID
+		slot.SessionID = &sessionID
+		slot.Date = session.Date
+		if session.StartTime != "" {
+			slot.StartTime = session.StartTime
+		}
+		if session.EndTime != "" {
+			slot.EndTime = session.EndTime
+		}
+		if session.TeacherID != nil {
+			slot.currentTeacherID = session.TeacherID
+		}
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	case opts.SessionID != nil:
+		return nil, ErrSessionNotFound
+	case opts.Date != nil:
+		// Aula ainda não gerada: usa o horário padrão da turma
+		slot.Date = *opts.Date
+	default:
+		return nil, ErrNoUpcomingSession
+	}
+
+	return slot, nil
+}
+
+// commitmentRow is a session the teacher is already responsible for
+type commitmentRow struct {
+	SessionID  uint
+	TeacherID  uint
+	StartTime  string
+	EndTime    string
+	CourseName string
+	ClassName  string
+}
+
+// findConflicts lists, per teacher, the overlapping sessions and active absences on the slot date
+func (s *service) findConflicts(db *gorm.DB, slot *Slot, teacherIDs []uint) (map[uint][]Conflict, error) {
+	conflicts := make(map[uint][]Conflict)
+	if len(teacherIDs) == 0 {
+		return conflicts, nil
+	}
+	date := slot.Date.Format(dateLayout)
+
+	var sessionID uint
+	if slot.SessionID != nil {
+		sessionID = *slot.SessionID
+	}
+
+	var rows []commitmentRow
+	if err := db.Table("class_sessions cs").
+		Select(`cs.id AS session_id,
+			COALESCE(cs.teacher_id, cc.default_teacher_id) AS teacher_id,
+			COALESCE(NULLIF(cs.start_time, ''), cc.start_time) AS start_time,
+			COALESCE(NULLIF(cs.end_time, ''), cc.end_time) AS end_time,
+			c.name AS course_name, cc.name AS class_name`).
+		Joins("JOIN course_classes cc ON cc.id = cs.course_class_id").
+		Joins("JOIN courses c ON c.id = cc.course_id").
+		Where("DATE(cs.date) = ? AND cs.is_cancelled = ? AND cs.id <> ?", date, false, sessionID).
+		Where("COALESCE(cs.teacher_id, cc.default_teacher_id) IN ?", teacherIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load teacher sessions: %w", err)
+	}
+	for _, row := range rows {
+		if !overlaps(slot.StartTime, slot.EndTime, row.StartTime, row.EndTime) {
+			continue
+		}
+		id := row.SessionID
+		conflicts[row.TeacherID] = append(conflicts[row.TeacherID], Conflict{
+			Type:        "session",
+			SessionID:   &id,
+			Description: fmt.Sprintf("Leciona %s (%s) das %s às %s", row.CourseName, row.ClassName, row.StartTime, row.EndTime),
+		})
+	}
+
+	var absences []models.TeacherAbsence
+	if err := db.Where("status = ? AND teacher_id IN ?", "active", teacherIDs).
+		Where("DATE(start_date) <= ? AND DATE(end_date) >= ?", date, date).
+		Find(&absences).Error; err != nil {
+		return nil, fmt.Errorf("failed to load teacher absences: %w", err)
+	}
+	for _, absence := range absences {
+		id := absence.ID
+		description := fmt.Sprintf("Ausente de %s a %s", absence.StartDate.Format("02/01/2006"), absence.EndDate.Format("02/01/2006"))
+		if absence.Reason != "" {
+			description += " (" + absence.Reason + ")"
+		}
+		conflicts[absence.TeacherID] = append(conflicts[absence.TeacherID], Conflict{
+			Type:        "absence",
+			AbsenceID:   &id,
+			Description: description,
+		})
+	}
+
+	return conflicts, nil
+}
@@ -0,0 +1,272 @@
+// backend/internal/service/substitutes/suggestions.go
+package substitutes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+// Pesos da sugestão de professor para uma nova turma
+const (
+	loadPenaltyPerHour = -2 // por hora semanal já atribuída
+	maxLoadPenalty     = -30
+)
+
+var (
+	// ErrCourseNotFound is returned when the schedule references an unknown course
+	ErrCourseNotFound = errors.New("course not found")
+	// ErrInvalidSchedule is returned for schedules without week days or with invalid times
+	ErrInvalidSchedule = errors.New("invalid class schedule")
+)
+
+// ScheduleInput is the planned schedule of a class
+type ScheduleInput struct {
+	CourseID       uint      `json:"courseId"`
+	WeekDays       string    `json:"weekDays"` // "1,3,5"
+	StartTime      string    `json:"startTime"`
+	EndTime        string    `json:"endTime"`
+	StartDate      time.Time `json:"startDate"`
+	EndDate        time.Time `json:"endDate"`
+	ExcludeClassID *uint     `json:"-"` // a própria turma, ao sugerir para uma turma existente
+}
+
+// Suggestion is a ranked teacher for a class schedule
+type Suggestion struct {
+	Candidate
+	Coverage      float64 `json:"coverage"`    // fração dos dias da turma cobertos pela disponibilidade
+	CoveredDays   []int   `json:"coveredDays"` // dias (0=domingo) cobertos
+	WeeklyHours   float64 `json:"weeklyHours"` // carga atual em turmas ativas no período
+	WeeklyClasses int     `json:"weeklyClasses"`
+}
+
+// classLoadRow is an active class already assigned to a teacher
+type classLoadRow struct {
+	ID         uint
+	TeacherID  uint
+	WeekDays   string
+	StartTime  string
+	EndTime    string
+	CourseName string
+	ClassName  string
+}
+
+// SuggestTeachers ranks the active teachers for a class schedule by skills, availability coverage and current weekly load
+func (s *service) SuggestTeachers(ctx context.Context, input ScheduleInput) ([]Suggestion, error) {
+	weekDays := parseWeekDays(input.WeekDays)
+	start, okStart := minutes(input.StartTime)
+	end, okEnd := minutes(input.EndTime)
+	if len(weekDays) == 0 || !okStart || !okEnd || end <= start {
+		return nil, fmt.Errorf("%w: weekDays, startTime and endTime are required", ErrInvalidSchedule)
+	}
+
+	db := s.db.WithContext(ctx)
+
+	var course models.Course
+	if err := db.Preload("Category").First(&course, input.CourseID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseNotFound
+		}
+		return nil, err
+	}
+	slot := &Slot{
+		StartTime:  input.StartTime,
+		EndTime:    input.EndTime,
+		CourseName: course.Name,
+		Difficulty: course.DifficultyLevel,
+		tags:       parseTags(course.Tags),
+	}
+	if course.Category != nil {
+		slot.CategoryName = course.Category.Name
+	}
+
+	var teachers []models.Teacher
+	if err := db.Where("active = ?", true).
+		Preload("User").
+		Preload("Skills").
+		Preload("Skills.Skill").
+		Preload("Availability").
+		Find(&teachers).Error; err != nil {
+		return nil, err
+	}
+
+	loads, err := s.classLoads(db, input)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]Suggestion, 0, len(teachers))
+	for _, teacher := range teachers {
+		suggestions = append(suggestions, scoreSuggestion(teacher, slot, weekDays, loads[teacher.ID]))
+	}
+	rankSuggestions(suggestions)
+
+	return suggestions, nil
+}
+
+// classLoads lists, per teacher, the active classes running during the planned period
+func (s *service) classLoads(db *gorm.DB, input ScheduleInput) (map[uint][]classLoadRow, error) {
+	query := db.Table("course_classes cc").
+		Select("cc.id, cc.default_teacher_id AS teacher_id, cc.week_days, cc.start_time, cc.end_time, c.name AS course_name, cc.name AS class_name").
+		Joins("JOIN courses c ON c.id = cc.course_id").
+		Where("cc.status = ? AND cc.default_teacher_id IS NOT NULL", "active")
+	if !input.StartDate.IsZero() {
+		query = query.Where("cc.end_date >= ?", input.StartDate)
+	}
+	if !input.EndDate.IsZero() {
+		query = query.Where("cc.start_date <= ?", input.EndDate)
+	}
+	if input.ExcludeClassID != nil {
+		query = query.Where("cc.id <> ?", *input.ExcludeClassID)
+	}
+
+	var rows []classLoadRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load teacher classes: %w", err)
+	}
+
+	loads := make(map[uint][]classLoadRow)
+	for _, row := range rows {
+		loads[row.TeacherID] = append(loads[row.TeacherID], row)
+	}
+	return loads, nil
+}
+
+// scoreSuggestion computes the score and its explanation for one teacher and a weekly schedule
+func scoreSuggestion(teacher models.Teacher, slot *Slot, weekDays []int, classes []classLoadRow) Suggestion {
+	suggestion := Suggestion{
+		Candidate: Candidate{
+			TeacherID: teacher.ID,
+			Name:      teacher.User.Name,
+			Email:     teacher.User.Email,
+			Reasons:   []Reason{},
+		},
+		CoveredDays: []int{},
+	}
+	add := func(points int, description string) {
+		suggestion.Score += points
+		suggestion.Reasons = append(suggestion.Reasons, Reason{Points: points, Description: description})
+	}
+
+	// Skills
+	suggestion.SkillMatch = scoreSkills(teacher, slot, add)
+
+	// Cobertura da disponibilidade
+	var missing []string
+	for _, day := range weekDays {
+		if coversSlot(teacher.Availability, day, slot.StartTime, slot.EndTime) {
+			suggestion.CoveredDays = append(suggestion.CoveredDays, day)
+		} else {
+			missing = append(missing, weekDayName(day))
+		}
+	}
+	suggestion.Coverage = float64(len(suggestion.CoveredDays)) / float64(len(weekDays))
+	suggestion.Available = len(missing) == 0
+	points := availabilityPoints * len(suggestion.CoveredDays) / len(weekDays)
+	if suggestion.Available {
+		add(points, fmt.Sprintf("Disponível em todos os dias da turma das %s às %s", slot.StartTime, slot.EndTime))
+	} else {
+		add(points, fmt.Sprintf("Disponível em %d de %d dias; sem disponibilidade: %s",
+			len(suggestion.CoveredDays), len(weekDays), strings.Join(missing, ", ")))
+	}
+
+	// Carga atual e choques de horário
+	for _, class := range classes {
+		classDays := parseWeekDays(class.WeekDays)
+		classStart, okStart := minutes(class.StartTime)
+		classEnd, okEnd := minutes(class.EndTime)
+		if okStart && okEnd && classEnd > classStart {
+			suggestion.WeeklyHours += float64(len(classDays)*(classEnd-classStart)) / 60
+		}
+		suggestion.WeeklyClasses++
+
+		if shared := sharedDays(weekDays, classDays); len(shared) > 0 &&
+			overlaps(slot.StartTime, slot.EndTime, class.StartTime, class.EndTime) {
+			description := fmt.Sprintf("Já leciona %s (%s) %s das %s às %s", class.CourseName, class.ClassName, weekDayList(shared), class.StartTime, class.EndTime)
+			suggestion.HasConflict = true
+			suggestion.Conflicts = append(suggestion.Conflicts, Conflict{Type: "class", Description: description})
+			add(sessionPenalty, description)
+		}
+	}
+	if suggestion.WeeklyClasses > 0 {
+		penalty := int(suggestion.WeeklyHours * loadPenaltyPerHour)
+		if penalty < maxLoadPenalty {
+			penalty = maxLoadPenalty
+		}
+		add(penalty, fmt.Sprintf("Carga atual: %.1fh semanais em %d turma(s)", suggestion.WeeklyHours, suggestion.WeeklyClasses))
+	} else {
+		add(0, "Sem turmas ativas no período")
+	}
+
+	return suggestion
+}
+
+// rankSuggestions orders teachers without clashes first, then by score and lighter load
+func rankSuggestions(suggestions []Suggestion) {
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.HasConflict != b.HasConflict {
+			return !a.HasConflict
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.WeeklyHours != b.WeeklyHours {
+			return a.WeeklyHours < b.WeeklyHours
+		}
+		return a.Name < b.Name
+	})
+	for i := range suggestions {
+		suggestions[i].Rank = i + 1
+	}
+}
+
+// parseWeekDays decodes the "1,3,5" week day format, ignoring invalid days
+func parseWeekDays(raw string) []int {
+	seen := make(map[int]bool)
+	var days []int
+	for _, part := range strings.Split(raw, ",") {
+		day, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || day < 0 || day > 6 || seen[day] {
+			continue
+		}
+		seen[day] = true
+		days = append(days, day)
+	}
+	return days
+}
+
+func sharedDays(a, b []int) []int {
+	var shared []int
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				shared = append(shared, x)
+			}
+		}
+	}
+	return shared
+}
+
+func weekDayName(day int) string {
+	if day < 0 || day >= len(weekDayNames) {
+		return strconv.Itoa(day)
+	}
+	return weekDayNames[day]
+}
+
+func weekDayList(days []int) string {
+	names := make([]string, 0, len(days))
+	for _, day := range days {
+		names = append(names, weekDayName(day))
+	}
+	return strings.Join(names, ", ")
+}
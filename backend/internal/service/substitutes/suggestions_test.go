@@ -0,0 +1,66 @@
+package substitutes
+
+import (
+	"testing"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+func TestScoreSuggestion(t *testing.T) {
+	slot := &Slot{StartTime: "14:00", EndTime: "16:00", CourseName: "Inglês Básico", CategoryName: "Idiomas"}
+	weekDays := []int{1, 3}
+	english := []models.TeacherSkill{{Skill: models.Skill{Name: "Inglês", Domain: "Idiomas"}, Level: "advanced"}}
+	monday := models.TeacherAvailability{DayOfWeek: 1, StartTime: "08:00", EndTime: "18:00", IsActive: true}
+	wednesday := models.TeacherAvailability{DayOfWeek: 3, StartTime: "13:00", EndTime: "17:00", IsActive: true}
+
+	t.Run("coverage is proportional to the days covered", func(t *testing.T) {
+		full := scoreSuggestion(teacherWith(1, "Ana", english, []models.TeacherAvailability{monday, wednesday}), slot, weekDays, nil)
+		half := scoreSuggestion(teacherWith(2, "Bia", english, []models.TeacherAvailability{monday}), slot, weekDays, nil)
+
+		if full.Coverage != 1 || !full.Available {
+			t.Errorf("Expected full coverage, got %v", full.Coverage)
+		}
+		if half.Coverage != 0.5 || half.Available {
+			t.Errorf("Expected half coverage, got %v", half.Coverage)
+		}
+		if full.Score-half.Score != availabilityPoints/2 {
+			t.Errorf("Expected a gap of %d points, got %d", availabilityPoints/2, full.Score-half.Score)
+		}
+	})
+
+	t.Run("current load is penalized and clashes are flagged", func(t *testing.T) {
+		teacher := teacherWith(1, "Ana", english, []models.TeacherAvailability{monday, wednesday})
+		morning := classLoadRow{ID: 5, WeekDays: "1,3", StartTime: "08:00", EndTime: "10:00"}
+		clash := classLoadRow{ID: 6, WeekDays: "3,5", StartTime: "15:00", EndTime: "17:00"}
+
+		free := scoreSuggestion(teacher, slot, weekDays, nil)
+		loaded := scoreSuggestion(teacher, slot, weekDays, []classLoadRow{morning})
+		busy := scoreSuggestion(teacher, slot, weekDays, []classLoadRow{morning, clash})
+
+		if loaded.WeeklyHours != 4 || loaded.HasConflict {
+			t.Errorf("Expected 4 weekly hours without conflict, got %v (conflict %v)", loaded.WeeklyHours, loaded.HasConflict)
+		}
+		if free.Score-loaded.Score != -4*loadPenaltyPerHour {
+			t.Errorf("Expected a load penalty of %d, got %d", -4*loadPenaltyPerHour, free.Score-loaded.Score)
+		}
+		if !busy.HasConflict || len(busy.Conflicts) != 1 {
+			t.Errorf("Expected one class conflict, got %+v", busy.Conflicts)
+		}
+	})
+
+	t.Run("ranking puts clashes last", func(t *testing.T) {
+		suggestions := []Suggestion{
+			{Candidate: Candidate{Name: "Ana", Score: 90, HasConflict: true}},
+			{Candidate: Candidate{Name: "Bia", Score: 40}, WeeklyHours: 6},
+			{Candidate: Candidate{Name: "Caio", Score: 40}, WeeklyHours: 2},
+		}
+		rankSuggestions(suggestions)
+
+		if suggestions[0].Name != "Caio" || suggestions[1].Name != "Bia" || suggestions[2].Name != "Ana" {
+			t.Errorf("Expected Caio, Bia, Ana, got %s, %s, %s", suggestions[0].Name, suggestions[1].Name, suggestions[2].Name)
+		}
+		if suggestions[2].Rank != 3 {
+			t.Errorf("Expected rank 3, got %d", suggestions[2].Rank)
+		}
+	})
+}
@@ -0,0 +1,656 @@
+// backend/internal/service/substitutions/service.go
+package substitutions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/substitutes"
+)
+
+// ResponseWindow is how long a candidate has to answer before the request moves on
+const ResponseWindow = 24 * time.Hour
+
+// Status values of models.Substitution
+const (
+	StatusPending   = "pending"
+	StatusConfirmed = "confirmed"
+	StatusDeclined  = "declined"
+	StatusExpired   = "expired"
+	StatusCancelled = "cancelled"
+	StatusCompleted = "completed"
+)
+
+var (
+	// ErrNotFound is returned when the substitution does not exist
+	ErrNotFound = errors.New("substitution not found")
+	// ErrInvalidRequest is returned for malformed substitution requests
+	ErrInvalidRequest = errors.New("invalid substitution request")
+	// ErrNoSessions is returned when the requested session or period has no lesson to cover
+	ErrNoSessions = errors.New("no sessions to cover")
+	// ErrNoOriginalTeacher is returned when the lessons have no teacher to replace
+	ErrNoOriginalTeacher = errors.New("sessions have no assigned teacher")
+	// ErrNoCandidate is returned when no teacher is free for every affected session
+	ErrNoCandidate = errors.New("no available substitute")
+	// ErrNotSubstitute is returned when someone other than the invited teacher answers
+	ErrNotSubstitute = errors.New("substitution belongs to another teacher")
+	// ErrNotPending is returned when answering or cancelling a closed request
+	ErrNotPending = errors.New("substitution is no longer open")
+	// ErrAlreadyRequested is returned when a session already has a pending or confirmed substitution
+	ErrAlreadyRequested = errors.New("session already has an open substitution")
+)
+
+// Notifier delivers the workflow notifications (implemented by notifications.NotificationTriggers)
+type Notifier interface {
+	OnSubstitutionRequested(substituteUserID uint, substitutionID uint, courseName string, date string, originalTeacher string)
+	OnSubstitutionAnswered(requesterUserID uint, courseName string, date string, substituteName string, accepted bool)
+	OnSubstitutionUnfilled(requesterUserID uint, courseName string, date string)
+}
+
+// RequestInput asks for a substitute for one session (SessionID) or a period (StartDate/EndDate)
+type RequestInput struct {
+	CourseClassID       uint       `json:"courseClassId"`
+	SessionID           *uint      `json:"sessionId,omitempty"`
+	StartDate           *time.Time `json:"startDate,omitempty"`
+	EndDate             *time.Time `json:"endDate,omitempty"`
+	SubstituteTeacherID *uint      `json:"substituteTeacherId,omitempty"` // Opcional: sem ele, o melhor candidato é convidado
+	Notes               string     `json:"notes"`
+	RequestedByID       uint       `json:"-"`
+}
+
+// Filter narrows the coordinator listing
+type Filter struct {
+	Status        string
+	CourseClassID *uint
+	TeacherID     *uint // substituto
+}
+
+// Matcher ranks substitute candidates for a session (implemented by substitutes.Service)
+type Matcher interface {
+	FindCandidates(ctx context.Context, courseClassID uint, opts substitutes.SlotOptions) (*substitutes.Result, error)
+}
+
+// Service defines the interface for the substitution workflow
+type Service interface {
+	Request(ctx context.Context, input RequestInput) (*models.Substitution, error)
+	List(ctx context.Context, filter Filter) ([]models.Substitution, error)
+	Get(ctx context.Context, id uint) (*models.Substitution, error)
+	Cancel(ctx context.Context, id uint) (*models.Substitution, error)
+	// CancelPending closes the open requests of the given chains (by first request ID)
+	CancelPending(ctx context.Context, originIDs []uint) (int, error)
+
+	// Teacher side
+	ListForUser(ctx context.Context, userID uint, status string) ([]models.Substitution, error)
+	Respond(ctx context.Context, id uint, userID uint, accept bool, notes string) (*models.Substitution, error)
+	RespondByTelegram(ctx context.Context, chatID string, id uint, accept bool) (*models.Substitution, error)
+
+	// EscalateExpired moves unanswered requests to the next candidate (run periodically)
+	EscalateExpired(ctx context.Context) (int, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db       *gorm.DB
+	matcher  Matcher
+	notifier Notifier
+	now      func() time.Time
+}
+
+// NewService creates a new substitution workflow service. notifier may be nil.
+func NewService(db *gorm.DB, matcher Matcher, notifier Notifier) Service {
+	return &service{db: db, matcher: matcher, notifier: notifier, now: time.Now}
+}
+
+// Request creates a pending substitution and invites the chosen or best-ranked teacher
+func (s *service) Request(ctx context.Context, input RequestInput) (*models.Substitution, error) {
+	if input.CourseClassID == 0 {
+		return nil, fmt.Errorf("%w: courseClassId is required", ErrInvalidRequest)
+	}
+	if (input.SessionID == nil) == (input.StartDate == nil || input.EndDate == nil) {
+		return nil, fmt.Errorf("%w: inform either sessionId or startDate and endDate", ErrInvalidRequest)
+	}
+	if input.StartDate != nil && input.EndDate.Before(*input.StartDate) {
+		return nil, fmt.Errorf("%w: endDate before startDate", ErrInvalidRequest)
+	}
+
+	db := s.db.WithContext(ctx)
+
+	var class models.CourseClass
+	if err := db.First(&class, input.CourseClassID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: course class not found", ErrInvalidRequest)
+		}
+		return nil, err
+	}
+
+	substitution := &models.Substitution{
+		CourseClassID:  class.ID,
+		ClassSessionID: input.SessionID,
+		Status:         StatusPending,
+		RequestedByID:  input.RequestedByID,
+		Notes:          input.Notes,
+		Attempt:        1,
+	}
+	if input.StartDate != nil {
+		substitution.Date = *input.StartDate
+		substitution.EndDate = input.EndDate
+	}
+
+	sessions, err := s.sessions(db, substitution)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, ErrNoSessions
+	}
+	if input.SessionID != nil {
+		substitution.Date = sessions[0].Date
+	}
+
+	original := class.DefaultTeacherID
+	if input.SessionID != nil && sessions[0].TeacherID != nil {
+		original = sessions[0].TeacherID
+	}
+	if original == nil {
+		return nil, ErrNoOriginalTeacher
+	}
+	substitution.OriginalTeacherID = *original
+
+	if input.SubstituteTeacherID != nil {
+		var teacher models.Teacher
+		if err := db.Where("id = ? AND active = ?", *input.SubstituteTeacherID, true).First(&teacher).Error; err != nil {
+			return nil, fmt.Errorf("%w: substitute teacher not found or inactive", ErrInvalidRequest)
+		}
+		if teacher.ID == *original {
+			return nil, fmt.Errorf("%w: substitute is the original teacher", ErrInvalidRequest)
+		}
+		substitution.SubstituteTeacherID = teacher.ID
+	} else {
+		candidate, err := s.nextCandidate(ctx, class.ID, sessions, map[uint]bool{*original: true})
+		if err != nil {
+			return nil, err
+		}
+		if candidate == 0 {
+			return nil, ErrNoCandidate
+		}
+		substitution.SubstituteTeacherID = candidate
+	}
+
+	respondBy := s.now().Add(ResponseWindow)
+	substitution.RespondBy = &respondBy
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// Locking the class serializes requests for it, so two calls cannot both pass the check
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&models.CourseClass{}, class.ID).Error; err != nil {
+			return err
+		}
+		if err := s.ensureNoOpenRequest(tx, sessions); err != nil {
+			return err
+		}
+		return tx.Create(substitution).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyRequested(ctx, substitution)
+	return s.Get(ctx, substitution.ID)
+}
+
+// ensureNoOpenRequest rejects sessions already covered by a pending or confirmed request, for the
+// session itself or for a period that includes it; a second chain would invite other substitutes
+func (s *service) ensureNoOpenRequest(tx *gorm.DB, sessions []models.ClassSession) error {
+	ids := make([]uint, len(sessions))
+	for i, session := range sessions {
+		ids[i] = session.ID
+	}
+
+	var covered []uint
+	if err := tx.Table("class_sessions cs").
+		Joins(`JOIN substitutions sub ON sub.course_class_id = cs.course_class_id AND sub.status IN ?
+			AND (sub.class_session_id = cs.id
+				OR (sub.class_session_id IS NULL AND DATE(cs.date) BETWEEN DATE(sub.date) AND DATE(COALESCE(sub.end_date, sub.date))))`,
+			[]string{StatusPending, StatusConfirmed}).
+		Where("cs.id IN ?", ids).
+		Distinct().Order("cs.id").Pluck("cs.id", &covered).Error; err != nil {
+		return fmt.Errorf("failed to check open substitutions: %w", err)
+	}
+	if len(covered) > 0 {
+		return fmt.Errorf("%w: sessions %v", ErrAlreadyRequested, covered)
+	}
+	return nil
+}
+
+// List returns substitutions for the coordination, newest first
+func (s *service) List(ctx context.Context, filter Filter) ([]models.Substitution, error) {
+	query := s.preload(s.db.WithContext(ctx))
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.CourseClassID != nil {
+		query = query.Where("course_class_id = ?", *filter.CourseClassID)
+	}
+	if filter.TeacherID != nil {
+		query = query.Where("substitute_teacher_id = ?", *filter.TeacherID)
+	}
+
+	var substitutions []models.Substitution
+	err := query.Order("date DESC, id DESC").Find(&substitutions).Error
+	return substitutions, err
+}
+
+// Get returns one substitution with teachers and class
+func (s *service) Get(ctx context.Context, id uint) (*models.Substitution, error) {
+	var substitution models.Substitution
+	if err := s.preload(s.db.WithContext(ctx)).First(&substitution, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &substitution, nil
+}
+
+// Cancel closes an open or confirmed request, giving confirmed sessions back to the original teacher
+func (s *service) Cancel(ctx context.Context, id uint) (*models.Substitution, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		substitution, err := s.lock(tx, id)
+		if err != nil {
+			return err
+		}
+		if substitution.Status != StatusPending && substitution.Status != StatusConfirmed {
+			return ErrNotPending
+		}
+
+		if substitution.Status == StatusConfirmed {
+			if err := s.restoreTeacher(tx, substitution); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(substitution).Updates(map[string]interface{}{
+			"status":     StatusCancelled,
+			"respond_by": nil,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+// CancelPending closes the open requests of the given chains; confirmed ones are kept
+func (s *service) CancelPending(ctx context.Context, originIDs []uint) (int, error) {
+	if len(originIDs) == 0 {
+		return 0, nil
+	}
+	result := s.db.WithContext(ctx).Model(&models.Substitution{}).
+		Where("status = ? AND (id IN ? OR origin_id IN ?)", StatusPending, originIDs, originIDs).
+		Updates(map[string]interface{}{"status": StatusCancelled, "respond_by": nil})
+	return int(result.RowsAffected), result.Error
+}
+
+// ListForUser returns the requests addressed to the teacher linked to the user
+func (s *service) ListForUser(ctx context.Context, userID uint, status string) ([]models.Substitution, error) {
+	teacherID, err := s.teacherIDForUser(s.db.WithContext(ctx), userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.List(ctx, Filter{Status: status, TeacherID: &teacherID})
+}
+
+// Respond records the substitute answer. Accepting assigns the sessions; declining moves on to the next candidate.
+func (s *service) Respond(ctx context.Context, id uint, userID uint, accept bool, notes string) (*models.Substitution, error) {
+	teacherID, err := s.teacherIDForUser(s.db.WithContext(ctx), userID)
+	if err != nil {
+		return nil, ErrNotSubstitute
+	}
+
+	var answered *models.Substitution
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		substitution, err := s.lock(tx, id)
+		if err != nil {
+			return err
+		}
+		if substitution.SubstituteTeacherID != teacherID {
+			return ErrNotSubstitute
+		}
+		if substitution.Status != StatusPending {
+			return ErrNotPending
+		}
+
+		status := StatusDeclined
+		if accept {
+			status = StatusConfirmed
+			if err := s.sessionScope(tx, substitution).
+				Update("teacher_id", substitution.SubstituteTeacherID).Error; err != nil {
+				return err
+			}
+		}
+
+		now := s.now()
+		substitution.Status = status
+		substitution.ResponseNotes = notes
+		substitution.RespondedAt = &now
+		substitution.RespondBy = nil
+		if err := tx.Model(substitution).Updates(map[string]interface{}{
+			"status":         status,
+			"response_notes": notes,
+			"responded_at":   now,
+			"respond_by":     nil,
+		}).Error; err != nil {
+			return err
+		}
+
+		answered = substitution
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyAnswered(ctx, answered, accept)
+	if !accept {
+		if err := s.escalate(ctx, answered); err != nil {
+			log.Printf("⚠️  Erro ao escalonar substituição %d: %v", answered.ID, err)
+		}
+	}
+	return s.Get(ctx, id)
+}
+
+// RespondByTelegram answers on behalf of the user linked to the Telegram chat
+func (s *service) RespondByTelegram(ctx context.Context, chatID string, id uint, accept bool) (*models.Substitution, error) {
+	var channel models.NotificationChannel
+	if err := s.db.WithContext(ctx).
+		Where("type = ? AND identifier = ? AND active = ?", "telegram", chatID, true).
+		First(&channel).Error; err != nil {
+		return nil, ErrNotSubstitute
+	}
+	return s.Respond(ctx, id, channel.UserID, accept, "Respondido pelo Telegram")
+}
+
+// EscalateExpired marks overdue requests as expired and invites the next candidate
+func (s *service) EscalateExpired(ctx context.Context) (int, error) {
+	var overdue []models.Substitution
+	if err := s.db.WithContext(ctx).
+		Where("status = ? AND respond_by IS NOT NULL AND respond_by < ?", StatusPending, s.now()).
+		Order("respond_by").
+		Find(&overdue).Error; err != nil {
+		return 0, err
+	}
+
+	escalated := 0
+	for i := range overdue {
+		substitution := &overdue[i]
+		result := s.db.WithContext(ctx).Model(&models.Substitution{}).
+			Where("id = ? AND status = ?", substitution.ID, StatusPending).
+			Updates(map[string]interface{}{"status": StatusExpired, "respond_by": nil})
+		if result.Error != nil {
+			return escalated, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue // respondido enquanto isso
+		}
+
+		substitution.Status = StatusExpired
+		if err := s.escalate(ctx, substitution); err != nil {
+			return escalated, err
+		}
+		escalated++
+	}
+	return escalated, nil
+}
+
+// escalate invites the next-ranked teacher that was not asked yet in the chain
+func (s *service) escalate(ctx context.Context, previous *models.Substitution) error {
+	db := s.db.WithContext(ctx)
+
+	originID := previous.ID
+	if previous.OriginID != nil {
+		originID = *previous.OriginID
+	}
+
+	var chain []models.Substitution
+	if err := db.Where("id = ? OR origin_id = ?", originID, originID).Find(&chain).Error; err != nil {
+		return err
+	}
+	excluded := map[uint]bool{previous.OriginalTeacherID: true}
+	for _, asked := range chain {
+		excluded[asked.SubstituteTeacherID] = true
+		if asked.Status == StatusPending || asked.Status == StatusConfirmed {
+			return nil // a cadeia já foi atendida
+		}
+	}
+
+	sessions, err := s.sessions(db, previous)
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		return nil // as aulas foram canceladas ou já passaram
+	}
+	if err := s.ensureNoOpenRequest(db, sessions); err != nil {
+		if errors.Is(err, ErrAlreadyRequested) {
+			return nil // outra solicitação já cobre as aulas
+		}
+		return err
+	}
+
+	candidate, err := s.nextCandidate(ctx, previous.CourseClassID, sessions, excluded)
+	if err != nil {
+		return err
+	}
+	if candidate == 0 {
+		s.notifyUnfilled(ctx, previous)
+		return nil
+	}
+
+	respondBy := s.now().Add(ResponseWindow)
+	next := &models.Substitution{
+		OriginalTeacherID:   previous.OriginalTeacherID,
+		SubstituteTeacherID: candidate,
+		CourseClassID:       previous.CourseClassID,
+		ClassSessionID:      previous.ClassSessionID,
+		Date:                previous.Date,
+		EndDate:             previous.EndDate,
+		Status:              StatusPending,
+		RequestedByID:       previous.RequestedByID,
+		Notes:               previous.Notes,
+		OriginID:            &originID,
+		Attempt:             len(chain) + 1,
+		RespondBy:           &respondBy,
+	}
+	if err := db.Create(next).Error; err != nil {
+		return err
+	}
+
+	s.notifyRequested(ctx, next)
+	return nil
+}
+
+// nextCandidate returns the best teacher free for every session, or 0 when nobody is
+func (s *service) nextCandidate(ctx context.Context, classID uint, sessions []models.ClassSession, excluded map[uint]bool) (uint, error) {
+	type aggregate struct {
+		teacherID uint
+		score     int
+		available bool
+		conflict  bool
+		seen      int
+	}
+	totals := make(map[uint]*aggregate)
+
+	for _, session := range sessions {
+		sessionID := session.ID
+		result, err := s.matcher.FindCandidates(ctx, classID, substitutes.SlotOptions{SessionID: &sessionID})
+		if err != nil {
+			return 0, err
+		}
+		for _, candidate := range result.Candidates {
+			total, ok := totals[candidate.TeacherID]
+			if !ok {
+				total = &aggregate{teacherID: candidate.TeacherID, available: true}
+				totals[candidate.TeacherID] = total
+			}
+			total.score += candidate.Score
+			total.available = total.available && candidate.Available
+			total.conflict = total.conflict || candidate.HasConflict
+			total.seen++
+		}
+	}
+
+	var ranked []*aggregate
+	for _, total := range totals {
+		// Quem não aparece em todas as aulas é o próprio professor de alguma delas
+		if excluded[total.teacherID] || total.conflict || total.seen < len(sessions) {
+			continue
+		}
+		ranked = append(ranked, total)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].available != ranked[j].available {
+			return ranked[i].available
+		}
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].teacherID < ranked[j].teacherID
+	})
+
+	if len(ranked) == 0 {
+		return 0, nil
+	}
+	return ranked[0].teacherID, nil
+}
+
+// sessions lists the non-cancelled sessions covered by the substitution
+func (s *service) sessions(db *gorm.DB, substitution *models.Substitution) ([]models.ClassSession, error) {
+	var sessions []models.ClassSession
+	err := s.sessionScope(db, substitution).Order("date").Find(&sessions).Error
+	return sessions, err
+}
+
+// sessionScope selects the non-cancelled sessions of the request (one session or a date range)
+func (s *service) sessionScope(db *gorm.DB, substitution *models.Substitution) *gorm.DB {
+	query := db.Model(&models.ClassSession{}).
+		Where("course_class_id = ? AND is_cancelled = ?", substitution.CourseClassID, false)
+	if substitution.ClassSessionID != nil {
+		return query.Where("id = ?", *substitution.ClassSessionID)
+	}
+	end := substitution.Date
+	if substitution.EndDate != nil {
+		end = *substitution.EndDate
+	}
+	return query.Where("DATE(date) BETWEEN ? AND ?", substitution.Date.Format("2006-01-02"), end.Format("2006-01-02"))
+}
+
+// restoreTeacher gives the sessions back to the original teacher after a confirmed substitution is cancelled
+func (s *service) restoreTeacher(tx *gorm.DB, substitution *models.Substitution) error {
+	var class models.CourseClass
+	if err := tx.First(&class, substitution.CourseClassID).Error; err != nil {
+		return err
+	}
+
+	var teacherID interface{} = substitution.OriginalTeacherID
+	if class.DefaultTeacherID != nil && *class.DefaultTeacherID == substitution.OriginalTeacherID {
+		teacherID = nil // sem override: volta ao professor padrão da turma
+	}
+
+	return s.sessionScope(tx, substitution).
+		Where("teacher_id = ?", substitution.SubstituteTeacherID).
+		Update("teacher_id", teacherID).Error
+}
+
+func (s *service) lock(tx *gorm.DB, id uint) (*models.Substitution, error) {
+	var substitution models.Substitution
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&substitution, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &substitution, nil
+}
+
+func (s *service) preload(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("OriginalTeacher.User").
+		Preload("SubstituteTeacher.User").
+		Preload("CourseClass.Course")
+}
+
+func (s *service) teacherIDForUser(db *gorm.DB, userID uint) (uint, error) {
+	var teacher models.Teacher
+	if err := db.Where("user_id = ?", userID).First(&teacher).Error; err != nil {
+		return 0, ErrNotSubstitute
+	}
+	return teacher.ID, nil
+}
+
+// === Notificações ===
+
+// describe returns the course name and the formatted date (or period) of a request
+func (s *service) describe(ctx context.Context, substitution *models.Substitution) (string, string) {
+	var courseName string
+	s.db.WithContext(ctx).Table("course_classes cc").
+		Select("c.name").
+		Joins("JOIN courses c ON c.id = cc.course_id").
+		Where("cc.id = ?", substitution.CourseClassID).
+		Scan(&courseName)
+
+	date := substitution.Date.Format("02/01/2006")
+	if substitution.EndDate != nil && !sameDay(substitution.Date, *substitution.EndDate) {
+		date += " a " + substitution.EndDate.Format("02/01/2006")
+	}
+	return courseName, date
+}
+
+// teacherUser returns the user ID and name of a teacher
+func (s *service) teacherUser(ctx context.Context, teacherID uint) (uint, string) {
+	var teacher models.Teacher
+	if err := s.db.WithContext(ctx).Preload("User").First(&teacher, teacherID).Error; err != nil {
+		return 0, "Professor " + strconv.FormatUint(uint64(teacherID), 10)
+	}
+	return teacher.UserID, teacher.User.Name
+}
+
+func (s *service) notifyRequested(ctx context.Context, substitution *models.Substitution) {
+	if s.notifier == nil {
+		return
+	}
+	courseName, date := s.describe(ctx, substitution)
+	substituteUserID, _ := s.teacherUser(ctx, substitution.SubstituteTeacherID)
+	_, originalName := s.teacherUser(ctx, substitution.OriginalTeacherID)
+	if substituteUserID != 0 {
+		s.notifier.OnSubstitutionRequested(substituteUserID, substitution.ID, courseName, date, originalName)
+	}
+}
+
+func (s *service) notifyAnswered(ctx context.Context, substitution *models.Substitution, accepted bool) {
+	if s.notifier == nil || substitution.RequestedByID == 0 {
+		return
+	}
+	courseName, date := s.describe(ctx, substitution)
+	_, substituteName := s.teacherUser(ctx, substitution.SubstituteTeacherID)
+	s.notifier.OnSubstitutionAnswered(substitution.RequestedByID, courseName, date, substituteName, accepted)
+}
+
+func (s *service) notifyUnfilled(ctx context.Context, substitution *models.Substitution) {
+	if s.notifier == nil || substitution.RequestedByID == 0 {
+		return
+	}
+	courseName, date := s.describe(ctx, substitution)
+	s.notifier.OnSubstitutionUnfilled(substitution.RequestedByID, courseName, date)
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Format("2006-01-02") == b.Format("2006-01-02")
+}
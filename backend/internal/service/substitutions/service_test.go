@@ -0,0 +1,59 @@
+package substitutions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/substitutes"
+)
+
+// fakeMatcher returns fixed candidates per session
+type fakeMatcher map[uint][]substitutes.Candidate
+
+func (f fakeMatcher) FindCandidates(ctx context.Context, courseClassID uint, opts substitutes.SlotOptions) (*substitutes.Result, error) {
+	return &substitutes.Result{Candidates: f[*opts.SessionID]}, nil
+}
+
+func TestNextCandidate(t *testing.T) {
+	sessions := []models.ClassSession{{ID: 1}, {ID: 2}}
+	matcher := fakeMatcher{
+		1: {
+			{TeacherID: 10, Score: 90, Available: true},
+			{TeacherID: 20, Score: 50, Available: true},
+			{TeacherID: 30, Score: 80, Available: true},
+			{TeacherID: 40, Score: 99, Available: true},
+		},
+		2: {
+			{TeacherID: 10, Score: 90, Available: true, HasConflict: true},
+			{TeacherID: 20, Score: 50, Available: true},
+			{TeacherID: 30, Score: 80, Available: false},
+			// 40 is the teacher of session 2
+		},
+	}
+	s := &service{matcher: matcher}
+
+	t.Run("skips conflicts and prefers available in every session", func(t *testing.T) {
+		got, err := s.nextCandidate(context.Background(), 1, sessions, map[uint]bool{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got != 20 {
+			t.Errorf("Expected teacher 20, got %d", got)
+		}
+	})
+
+	t.Run("moves on past teachers already asked", func(t *testing.T) {
+		got, _ := s.nextCandidate(context.Background(), 1, sessions, map[uint]bool{20: true})
+		if got != 30 {
+			t.Errorf("Expected teacher 30, got %d", got)
+		}
+	})
+
+	t.Run("returns zero when nobody is left", func(t *testing.T) {
+		got, _ := s.nextCandidate(context.Background(), 1, sessions, map[uint]bool{20: true, 30: true})
+		if got != 0 {
+			t.Errorf("Expected no candidate, got %d", got)
+		}
+	})
+}
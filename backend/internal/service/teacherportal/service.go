@@ -45,6 +45,21 @@ func NewService(db *gorm.DB) Service {
 	return &service{db: db}
 }
 
+// assignedCourses selects the course IDs (course_id) and roles (role) of the assignments of a
+// teacher user that are valid today. Class assignments (course_class_id) cover only that class.
+const assignedCourses = `
+	SELECT tc.course_id, tc.course_class_id, tc.role
+	FROM teacher_courses tc
+	INNER JOIN teachers t ON t.id = tc.teacher_id
+	WHERE t.user_id = ?
+		AND tc.active = true
+		AND tc.start_date <= CURRENT_DATE
+		AND (tc.end_date IS NULL OR tc.end_date >= CURRENT_DATE)
+`
+
+// rolePriority orders the roles when a teacher holds several in the same course
+const rolePriority = `CASE role WHEN 'primary' THEN 1 WHEN 'assistant' THEN 2 ELSE 3 END`
+
 // DashboardData represents the teacher dashboard
 type DashboardData struct {
 	Teacher       TeacherInfo         `json:"teacher"`
@@ -72,6 +87,7 @@ type SessionWithDetails struct {
 	EndTime            string `json:"endTime"`
 	GoogleClassroomID  string `json:"googleClassroomId,omitempty"`
 	GoogleClassroomURL string `json:"googleClassroomUrl,omitempty"`
+	Role               string `json:"role"` // primary, assistant, substitute
 }
 
 // WeeklyStatistics represents weekly stats for the teacher
@@ -99,6 +115,7 @@ type CourseWithStats struct {
 	GoogleClassroomID  string  `json:"googleClassroomId,omitempty"`
 	GoogleClassroomURL string  `json:"googleClassroomUrl,omitempty"`
 	GoogleSyncStatus   string  `json:"googleSyncStatus"`
+	Role               string  `json:"role"` // primary, assistant, substitute
 }
 
 // StudentWithAttendance represents a student with their attendance info
@@ -199,7 +216,7 @@ func (s *service) GetTodaySessions(ctx context.Context, teacherID uint) ([]Sessi
 	var sessions []SessionWithDetails
 	
 	// Query for today's sessions with course info
-	// Using teacher_courses table to find courses taught by teacher
+	// Using the teacher assignments valid today (course-wide or for the session's class)
 	query := `
 		SELECT 
 			cs.id,
@@ -214,14 +231,19 @@ func (s *service) GetTodaySessions(ctx context.Context, teacherID uint) ([]Sessi
 			c.start_time,
 			c.end_time,
 				(SELECT COUNT(*) FROM enrollments e WHERE e.course_id = c.id AND e.status = 'active') as enrolled_count,
-				false as attendance_recorded
+				false as attendance_recorded,
+			a.role
 		FROM class_sessions cs
 		INNER JOIN courses c ON cs.course_id = c.id
-		INNER JOIN teacher_courses tc ON c.id = tc.course_id
+		INNER JOIN LATERAL (
+			SELECT role FROM (` + assignedCourses + `) assigned
+			WHERE assigned.course_id = cs.course_id
+				AND (assigned.course_class_id IS NULL OR assigned.course_class_id = cs.course_class_id)
+			ORDER BY ` + rolePriority + `
+			LIMIT 1
+		) a ON true
 		LEFT JOIN locations l ON cs.location_id = l.id
-		WHERE tc.teacher_id = ?
-			AND tc.active = true
-			AND DATE(cs.date) = ?
+		WHERE DATE(cs.date) = ?
 			AND cs.is_cancelled = false
 		ORDER BY c.start_time ASC
 	`
@@ -252,6 +274,7 @@ func (s *service) GetTodaySessions(ctx context.Context, teacherID uint) ([]Sessi
 			&endTime,
 			&session.EnrolledCount,
 			&session.AttendanceRecorded,
+			&session.Role,
 		)
 		if err != nil {
 			continue
@@ -293,6 +316,7 @@ func (s *service) GetTeacherCourses(ctx context.Context, teacherID uint) ([]Cour
 				ELSE 'not_synced'
 			END as google_sync_status,
 			c.schedule,
+			a.role,
 			(SELECT COUNT(*) FROM enrollments e WHERE e.course_id = c.id AND e.status = 'active') as enrolled_count,
 			COALESCE(
 				(SELECT AVG(attendance_percentage) FROM (
@@ -307,9 +331,12 @@ func (s *service) GetTeacherCourses(ctx context.Context, teacherID uint) ([]Cour
 				0
 			) as average_attendance
 		FROM courses c
-		INNER JOIN teacher_courses tc ON c.id = tc.course_id
-		WHERE tc.teacher_id = ?
-			AND tc.active = true
+		INNER JOIN LATERAL (
+			SELECT role FROM (` + assignedCourses + `) assigned
+			WHERE assigned.course_id = c.id
+			ORDER BY ` + rolePriority + `
+			LIMIT 1
+		) a ON true
 		ORDER BY c.name ASC
 	`
 	
@@ -334,6 +361,7 @@ func (s *service) GetTeacherCourses(ctx context.Context, teacherID uint) ([]Cour
 			&googleClassroomURL,
 			&course.GoogleSyncStatus,
 			&schedule,
+			&course.Role,
 			&course.EnrolledCount,
 			&course.AverageAttendance,
 		)
@@ -468,12 +496,11 @@ func (s *service) GetSessionAttendance(ctx context.Context, courseID uint, teach
 	return attendances, nil
 }
 
-// IsTeacherOfCourse checks if a teacher is assigned to a course
+// IsTeacherOfCourse checks if a teacher has an assignment valid today in the course (any role)
 func (s *service) IsTeacherOfCourse(ctx context.Context, teacherID uint, courseID uint) bool {
 	var count int64
-	s.db.WithContext(ctx).Model(&models.TeacherCourse{}).
-		Where("course_id = ? AND teacher_id = ? AND active = true", courseID, teacherID).
-		Count(&count)
+	s.db.WithContext(ctx).Raw(`SELECT COUNT(*) FROM (`+assignedCourses+`) assigned WHERE assigned.course_id = ?`, teacherID, courseID).
+		Scan(&count)
 	return count > 0
 }
 
@@ -484,9 +511,7 @@ func (s *service) getWeeklyStats(ctx context.Context, teacherID uint) (*WeeklySt
 	// Get total students
 	var totalStudents int64
 	s.db.WithContext(ctx).Model(&models.Enrollment{}).
-		Joins("JOIN courses c ON enrollments.course_id = c.id").
-		Joins("JOIN teacher_courses tc ON c.id = tc.course_id").
-		Where("tc.teacher_id = ? AND tc.active = true AND enrollments.status = 'active'", teacherID).
+		Where("enrollments.course_id IN (SELECT course_id FROM ("+assignedCourses+") assigned) AND enrollments.status = 'active'", teacherID).
 		Count(&totalStudents)
 	stats.TotalStudents = int(totalStudents)
 	
@@ -494,9 +519,8 @@ func (s *service) getWeeklyStats(ctx context.Context, teacherID uint) (*WeeklySt
 	weekStart := time.Now().AddDate(0, 0, -7)
 	var classesGiven int64
 	s.db.WithContext(ctx).Model(&models.ClassSession{}).
-		Joins("JOIN courses c ON class_sessions.course_id = c.id").
-		Joins("JOIN teacher_courses tc ON c.id = tc.course_id").
-		Where("tc.teacher_id = ? AND tc.active = true AND class_sessions.date >= ?", teacherID, weekStart).
+		Where("EXISTS (SELECT 1 FROM ("+assignedCourses+") assigned WHERE assigned.course_id = class_sessions.course_id AND (assigned.course_class_id IS NULL OR assigned.course_class_id = class_sessions.course_class_id))", teacherID).
+		Where("class_sessions.date >= ?", weekStart).
 		Count(&classesGiven)
 	stats.ClassesGiven = int(classesGiven)
 	
@@ -509,9 +533,11 @@ func (s *service) getWeeklyStats(ctx context.Context, teacherID uint) (*WeeklySt
 				(COUNT(CASE WHEN a.status = 'present' THEN 1 END) * 100.0 / COUNT(*)) as percentage
 			FROM attendances a
 			JOIN class_sessions cs ON a.class_session_id = cs.id
-			JOIN courses c ON cs.course_id = c.id
-			JOIN teacher_courses tc ON c.id = tc.course_id
-			WHERE tc.teacher_id = ? AND tc.active = true
+			WHERE EXISTS (
+				SELECT 1 FROM (`+assignedCourses+`) assigned
+				WHERE assigned.course_id = cs.course_id
+					AND (assigned.course_class_id IS NULL OR assigned.course_class_id = cs.course_class_id)
+			)
 			GROUP BY cs.id
 		) subquery
 	`, teacherID).Scan(&avgAttendance)
@@ -533,10 +559,8 @@ func (s *service) generateAlerts(ctx context.Context, teacherID uint) ([]Alert,
 				e.student_id,
 				(COUNT(CASE WHEN a.status = 'present' THEN 1 END) * 100.0 / NULLIF(COUNT(*), 0)) as percentage
 			FROM enrollments e
-			JOIN courses c ON e.course_id = c.id
-			JOIN teacher_courses tc ON c.id = tc.course_id
 			LEFT JOIN attendances a ON e.student_id = a.student_id
-			WHERE tc.teacher_id = ? AND tc.active = true AND e.status = 'active'
+			WHERE e.course_id IN (SELECT course_id FROM (`+assignedCourses+`) assigned) AND e.status = 'active'
 			GROUP BY e.student_id
 			HAVING (COUNT(CASE WHEN a.status = 'present' THEN 1 END) * 100.0 / NULLIF(COUNT(*), 0)) < 75
 		) subquery
@@ -555,8 +579,8 @@ func (s *service) generateAlerts(ctx context.Context, teacherID uint) ([]Alert,
 	// Check for courses not synced with Google Classroom
 	var notSyncedCount int64
 	s.db.WithContext(ctx).Model(&models.Course{}).
-		Joins("JOIN teacher_courses tc ON courses.id = tc.course_id").
-		Where("tc.teacher_id = ? AND tc.active = true AND (courses.google_classroom_id IS NULL OR courses.google_classroom_id = '')", teacherID).
+		Where("courses.id IN (SELECT course_id FROM ("+assignedCourses+") assigned)", teacherID).
+		Where("(courses.google_classroom_id IS NULL OR courses.google_classroom_id = '')").
 		Count(&notSyncedCount)
 	
 	if notSyncedCount > 0 {
@@ -2,168 +2,425 @@ package teachers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/devdavidalonso/cecor/backend/internal/models"
 	"github.com/devdavidalonso/cecor/backend/internal/repository"
-	"github.com/devdavidalonso/cecor/backend/internal/service/email"
+	"github.com/devdavidalonso/cecor/backend/internal/service/availability"
 	"github.com/devdavidalonso/cecor/backend/internal/service/keycloak"
+	"github.com/devdavidalonso/cecor/backend/pkg/cpf"
 )
 
+// professorProfileID is the user profile of teachers (2=professor)
+const professorProfileID = 2
+
+// skillLevels are the accepted proficiency levels of models.TeacherSkill
+var skillLevels = map[string]bool{"beginner": true, "intermediate": true, "advanced": true, "expert": true}
+
+var (
+	// ErrProfessorNotFound is returned when the teacher record does not exist or its user was deleted
+	ErrProfessorNotFound = errors.New("professor not found")
+	// ErrInvalidProfessor is returned for malformed payloads
+	ErrInvalidProfessor = errors.New("invalid professor")
+	// ErrEmailInUse is returned when another user already has the email
+	ErrEmailInUse = errors.New("a user with this email already exists")
+)
+
+// SkillInput is a skill of the teacher with its proficiency level
+type SkillInput struct {
+	SkillID uint   `json:"skillId"`
+	Level   string `json:"level"` // beginner, intermediate (padrão), advanced, expert
+	Notes   string `json:"notes"`
+}
+
+// ProfessorInput is the payload of the /teachers endpoints: user data plus the teacher profile.
+// Skills and Availability are replaced only when present.
+type ProfessorInput struct {
+	Name           string                     `json:"name"`
+	Email          string                     `json:"email"`
+	CPF            string                     `json:"cpf"`
+	Phone          string                     `json:"phone"`
+	BirthDate      time.Time                  `json:"birthDate"`
+	Address        *models.Address            `json:"address"`
+	UserContacts   []models.UserContact       `json:"userContacts"`
+	Specialization string                     `json:"specialization"`
+	Bio            string                     `json:"bio"`
+	Active         *bool                      `json:"active"`
+	Skills         []SkillInput               `json:"skills"`
+	Availability   []availability.WindowInput `json:"availability"`
+}
+
+// Professor is the teacher record flattened with its user data; ID is the teachers.id
+type Professor struct {
+	ID             uint                         `json:"id"`
+	UserID         uint                         `json:"userId"`
+	KeycloakUserID *string                      `json:"keycloakUserId,omitempty"`
+	Name           string                       `json:"name"`
+	Email          string                       `json:"email"`
+	CPF            string                       `json:"cpf"`
+	Phone          string                       `json:"phone"`
+	BirthDate      time.Time                    `json:"birthDate"`
+	PhotoURL       string                       `json:"photoUrl"`
+	Address        *models.Address              `json:"address,omitempty"`
+	UserContacts   []models.UserContact         `json:"userContacts,omitempty"`
+	Specialization string                       `json:"specialization"`
+	Bio            string                       `json:"bio"`
+	Active         bool                         `json:"active"`
+	Skills         []models.TeacherSkill        `json:"skills"`
+	Availability   []models.TeacherAvailability `json:"availability"`
+	CreatedAt      time.Time                    `json:"createdAt"`
+	UpdatedAt      time.Time                    `json:"updatedAt"`
+}
+
 // Service defines the professor service interface
 type Service interface {
-	CreateProfessor(ctx context.Context, professor *models.User) error
-	GetProfessors(ctx context.Context) ([]models.User, error)
-	GetProfessorByID(ctx context.Context, id uint) (*models.User, error)
-	UpdateProfessor(ctx context.Context, professor *models.User) error
+	// RegisterInvitedProfessor is the only way to create a professor: the coordination sends an
+	// invitation and the volunteer completes the registration with their own password
+	RegisterInvitedProfessor(ctx context.Context, input ProfessorInput, password string) (*Professor, error)
+	GetProfessors(ctx context.Context) ([]Professor, error)
+	GetProfessorByID(ctx context.Context, id uint) (*Professor, error)
+	UpdateProfessor(ctx context.Context, id uint, input ProfessorInput) (*Professor, error)
 	DeleteProfessor(ctx context.Context, id uint) error
 }
 
 // professorService implements the Service interface
 type professorService struct {
-	userRepo repository.UserRepository
-	keycloak *keycloak.KeycloakService
-	email    *email.EmailService
+	userRepo    repository.UserRepository
+	teacherRepo repository.TeacherRepository
+	keycloak    *keycloak.KeycloakService
 }
 
 // NewService creates a new instance of professorService
-func NewService(userRepo repository.UserRepository, keycloak *keycloak.KeycloakService, email *email.EmailService) Service {
+func NewService(userRepo repository.UserRepository, teacherRepo repository.TeacherRepository, keycloak *keycloak.KeycloakService) Service {
 	return &professorService{
-		userRepo: userRepo,
-		keycloak: keycloak,
-		email:    email,
+		userRepo:    userRepo,
+		teacherRepo: teacherRepo,
+		keycloak:    keycloak,
 	}
 }
 
-// CreateProfessor creates a new professor
-func (s *professorService) CreateProfessor(ctx context.Context, professor *models.User) error {
-	// Validate required fields
-	if professor.Name == "" || professor.Email == "" {
-		return fmt.Errorf("name and email are required")
+// RegisterInvitedProfessor creates the professor who accepted an invitation: the Keycloak account
+// with the password chosen by the volunteer, then the user, the teacher record, skills and
+// availability. Nothing is left behind on failure, so the volunteer can try again.
+func (s *professorService) RegisterInvitedProfessor(ctx context.Context, input ProfessorInput, password string) (*Professor, error) {
+	if password == "" {
+		return nil, fmt.Errorf("%w: password is required", ErrInvalidProfessor)
 	}
 
-	// Set profile as 'professor' (ProfileID = 2)
-	professor.ProfileID = 2
-	professor.Active = true
+	// Validate required fields
+	if input.Name == "" || input.Email == "" {
+		return nil, fmt.Errorf("%w: name and email are required", ErrInvalidProfessor)
+	}
+	if err := normalizeCPFs(&input, ""); err != nil {
+		return nil, err
+	}
+	skills, err := buildSkills(input.Skills)
+	if err != nil {
+		return nil, err
+	}
+	windows, err := normalizeAvailability(input.Availability)
+	if err != nil {
+		return nil, err
+	}
 
 	// Check if user already exists
-	existing, err := s.userRepo.FindByEmail(ctx, professor.Email)
+	existing, err := s.userRepo.FindByEmail(ctx, input.Email)
 	if err != nil {
-		return fmt.Errorf("error checking existing email: %w", err)
+		return nil, fmt.Errorf("error checking existing email: %w", err)
 	}
 	if existing != nil {
-		return fmt.Errorf("a user with this email already exists")
+		return nil, ErrEmailInUse
 	}
 
-	// Create user in database
-	// Set placeholder password
-	professor.Password = "temp123456"
-
-	if err := s.userRepo.Create(ctx, professor); err != nil {
-		return fmt.Errorf("error creating professor in database: %w", err)
+	keycloakID, err := s.createKeycloakAccount(ctx, input.Name, input.Email, password)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create in Keycloak
-	if s.keycloak != nil {
-		// Generate temporary password
-		tempPassword := "prof123" // Or generate random
+	// Create user in database with profile 'professor'
+	user := &models.User{
+		Name:           input.Name,
+		Email:          input.Email,
+		CPF:            input.CPF,
+		Phone:          input.Phone,
+		BirthDate:      input.BirthDate,
+		Address:        input.Address,
+		UserContacts:   input.UserContacts,
+		ProfileID:      professorProfileID,
+		Active:         true,
+		Password:       "temp123456", // Placeholder: the login is done by Keycloak
+		KeycloakUserID: keycloakID,
+	}
 
-		// Create user
-		// Split name
-		nameParts := strings.Fields(professor.Name)
-		firstName := nameParts[0]
-		lastName := ""
-		if len(nameParts) > 1 {
-			lastName = strings.Join(nameParts[1:], " ")
-		}
+	// The user, the teacher record, skills and availability are created together, so a failure
+	// leaves nothing behind that would block a new attempt with the same email
+	teacher := &models.Teacher{
+		Specialization: input.Specialization,
+		Bio:            input.Bio,
+		Phone:          input.Phone,
+		Active:         input.Active == nil || *input.Active,
+	}
+	if err := s.teacherRepo.CreateWithUser(ctx, user, teacher, skills, windows); err != nil {
+		s.deleteKeycloakAccount(ctx, keycloakID)
+		return nil, fmt.Errorf("error creating professor in database: %w", err)
+	}
 
-		req := keycloak.CreateUserRequest{
-			Username:      professor.Email,
-			Email:         professor.Email,
-			FirstName:     firstName,
-			LastName:      lastName,
-			Enabled:       true,
-			EmailVerified: true, // Auto-verify for simplicity
-		}
+	return s.GetProfessorByID(ctx, teacher.ID)
+}
 
-		keycloakID, err := s.keycloak.CreateUser(ctx, req)
-		if err != nil {
-			fmt.Printf("Warning: failed to create Keycloak user: %v\n", err)
-		} else {
-			// Assign role
-			if err := s.keycloak.AssignRole(ctx, keycloakID, "professor"); err != nil {
-				fmt.Printf("Warning: failed to assign role: %v\n", err)
-			}
-
-			// Set password
-			if err := s.keycloak.SetTemporaryPassword(ctx, keycloakID, tempPassword); err != nil {
-				fmt.Printf("Warning: failed to set password: %v\n", err)
-			}
-
-			// Update user with Keycloak ID
-			professor.KeycloakUserID = &keycloakID
-			s.userRepo.Update(ctx, professor)
-
-			// Send email
-			if s.email != nil {
-				s.email.SendWelcomeEmail(professor.Email, professor.Name, tempPassword)
-			}
-		}
+// GetProfessors returns all teachers
+func (s *professorService) GetProfessors(ctx context.Context) ([]Professor, error) {
+	teachers, err := s.teacherRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	professors := make([]Professor, 0, len(teachers))
+	for i := range teachers {
+		professors = append(professors, *newProfessor(&teachers[i]))
+	}
+	return professors, nil
 }
 
-// GetProfessors returns all professors
-func (s *professorService) GetProfessors(ctx context.Context) ([]models.User, error) {
-	return s.userRepo.FindByProfileID(ctx, 2)
+// GetProfessorByID returns a teacher by its teachers.id
+func (s *professorService) GetProfessorByID(ctx context.Context, id uint) (*Professor, error) {
+	teacher, err := s.teacherRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if teacher == nil {
+		return nil, ErrProfessorNotFound
+	}
+	return newProfessor(teacher), nil
 }
 
-// GetProfessorByID returns a professor by ID
-func (s *professorService) GetProfessorByID(ctx context.Context, id uint) (*models.User, error) {
-	// Call FindByIDWithAssociations instead of FindByID to load contacts and address
-	user, err := s.userRepo.FindByIDWithAssociations(ctx, id)
+// UpdateProfessor updates the user data and the teacher profile
+func (s *professorService) UpdateProfessor(ctx context.Context, id uint, input ProfessorInput) (*Professor, error) {
+	teacher, err := s.teacherRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	if user == nil {
-		return nil, fmt.Errorf("professor not found")
+	if teacher == nil {
+		return nil, ErrProfessorNotFound
 	}
-	if user.ProfileID != 2 {
-		return nil, fmt.Errorf("user is not a professor")
+	if input.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidProfessor)
+	}
+	if err := normalizeCPFs(&input, teacher.User.CPF); err != nil {
+		return nil, err
 	}
-	return user, nil
-}
 
-// UpdateProfessor updates a professor
-func (s *professorService) UpdateProfessor(ctx context.Context, professor *models.User) error {
-	existing, err := s.GetProfessorByID(ctx, professor.ID)
-	if err != nil {
-		return err
+	var skills []models.TeacherSkill
+	if input.Skills != nil {
+		if skills, err = buildSkills(input.Skills); err != nil {
+			return nil, err
+		}
 	}
+	var windows []models.TeacherAvailability
+	if input.Availability != nil {
+		if windows, err = normalizeAvailability(input.Availability); err != nil {
+			return nil, err
+		}
+		if windows == nil {
+			windows = []models.TeacherAvailability{} // lista vazia remove a disponibilidade
+		}
+	}
+
+	// Update allowed user fields, including associations payload
+	user := teacher.User
+	user.Name = input.Name
+	user.Phone = input.Phone
+	user.CPF = input.CPF
+	if !input.BirthDate.IsZero() {
+		user.BirthDate = input.BirthDate
+	}
+	user.Address = input.Address
+	user.UserContacts = input.UserContacts
 
-	// Update allowed fields
-	existing.Name = professor.Name
-	existing.Phone = professor.Phone
-	existing.CPF = professor.CPF
+	// Update the teacher profile
+	teacher.Specialization = input.Specialization
+	teacher.Bio = input.Bio
+	teacher.Phone = input.Phone
+	if input.Active != nil {
+		teacher.Active = *input.Active
+	}
 
-	// Include associations payload
-	existing.Address = professor.Address
-	existing.UserContacts = professor.UserContacts
+	// Skills and availability are replaced only when present (nil keeps them)
+	if err := s.teacherRepo.UpdateWithUser(ctx, &user, teacher, skills, windows); err != nil {
+		return nil, err
+	}
 
-	return s.userRepo.UpdateWithAssociations(ctx, existing)
+	return s.GetProfessorByID(ctx, teacher.ID)
 }
 
-// DeleteProfessor deletes a professor
+// DeleteProfessor deactivates the teacher record, deletes the user and disables the Keycloak account
 func (s *professorService) DeleteProfessor(ctx context.Context, id uint) error {
-	// Check if exists
-	_, err := s.GetProfessorByID(ctx, id)
+	teacher, err := s.teacherRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if teacher == nil {
+		return ErrProfessorNotFound
+	}
+
+	teacher.Active = false
+	if err := s.teacherRepo.Update(ctx, teacher); err != nil {
+		return err
+	}
+	if err := s.userRepo.Delete(ctx, teacher.UserID); err != nil {
+		return err
+	}
+
+	if s.keycloak != nil && teacher.User.KeycloakUserID != nil {
+		if err := s.keycloak.DisableUser(ctx, *teacher.User.KeycloakUserID); err != nil {
+			fmt.Printf("Warning: failed to disable Keycloak user: %v\n", err)
+		}
+	}
+	return nil
+}
+
+// createKeycloakAccount creates the SSO account with the professor role and the password chosen by
+// the volunteer. Any failure removes the partial account and is returned, so the volunteer is never
+// registered without a working login. Without Keycloak configured (tests) it does nothing.
+func (s *professorService) createKeycloakAccount(ctx context.Context, name, email, password string) (*string, error) {
+	if s.keycloak == nil {
+		return nil, nil
+	}
+
+	// Split name
+	nameParts := strings.Fields(name)
+	firstName := nameParts[0]
+	lastName := ""
+	if len(nameParts) > 1 {
+		lastName = strings.Join(nameParts[1:], " ")
+	}
+
+	req := keycloak.CreateUserRequest{
+		Username:      email,
+		Email:         email,
+		FirstName:     firstName,
+		LastName:      lastName,
+		Enabled:       true,
+		EmailVerified: true, // O email foi confirmado pelo link do convite
+	}
+
+	keycloakID, err := s.keycloak.CreateUser(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Keycloak account: %w", err)
+	}
+
+	if err := s.keycloak.AssignRole(ctx, keycloakID, "professor"); err != nil {
+		s.deleteKeycloakAccount(ctx, &keycloakID)
+		return nil, fmt.Errorf("error assigning the professor role: %w", err)
+	}
+	if err := s.keycloak.SetPassword(ctx, keycloakID, password); err != nil {
+		s.deleteKeycloakAccount(ctx, &keycloakID)
+		return nil, fmt.Errorf("error setting the password: %w", err)
+	}
+
+	return &keycloakID, nil
+}
+
+// deleteKeycloakAccount removes an account created for a registration that did not complete
+func (s *professorService) deleteKeycloakAccount(ctx context.Context, keycloakID *string) {
+	if s.keycloak == nil || keycloakID == nil {
+		return
+	}
+	if err := s.keycloak.DeleteUser(ctx, *keycloakID); err != nil {
+		fmt.Printf("Warning: failed to delete Keycloak user %s: %v\n", *keycloakID, err)
+	}
+}
+
+// normalizeCPFs validates the CPF of the teacher and of the contacts and keeps only the digits.
+// An unchanged current value is accepted as is, so old records can still be edited.
+func normalizeCPFs(input *ProfessorInput, current string) error {
+	if input.CPF != "" && cpf.Normalize(input.CPF) != cpf.Normalize(current) {
+		digits, err := cpf.Parse(input.CPF)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidProfessor, err)
+		}
+		input.CPF = digits
+	}
+	for i := range input.UserContacts {
+		if input.UserContacts[i].CPF == "" {
+			continue
+		}
+		digits, err := cpf.Parse(input.UserContacts[i].CPF)
+		if err != nil {
+			return fmt.Errorf("%w: contact %s: %v", ErrInvalidProfessor, input.UserContacts[i].Name, err)
+		}
+		input.UserContacts[i].CPF = digits
+	}
+	return nil
+}
 
-	// Disable in Keycloak if needed (omitted for brevity)
+// buildSkills validates the skills payload
+func buildSkills(inputs []SkillInput) ([]models.TeacherSkill, error) {
+	skills := make([]models.TeacherSkill, 0, len(inputs))
+	seen := make(map[uint]bool)
+	for _, input := range inputs {
+		if input.SkillID == 0 {
+			return nil, fmt.Errorf("%w: skillId is required", ErrInvalidProfessor)
+		}
+		if seen[input.SkillID] {
+			return nil, fmt.Errorf("%w: skill %d is repeated", ErrInvalidProfessor, input.SkillID)
+		}
+		seen[input.SkillID] = true
 
-	return s.userRepo.Delete(ctx, id)
+		level := input.Level
+		if level == "" {
+			level = "intermediate"
+		}
+		if !skillLevels[level] {
+			return nil, fmt.Errorf("%w: invalid skill level %q", ErrInvalidProfessor, input.Level)
+		}
+		skills = append(skills, models.TeacherSkill{SkillID: input.SkillID, Level: level, Notes: input.Notes})
+	}
+	return skills, nil
+}
+
+// normalizeAvailability validates the weekly availability payload
+func normalizeAvailability(inputs []availability.WindowInput) ([]models.TeacherAvailability, error) {
+	windows, err := availability.Normalize(0, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProfessor, err)
+	}
+	return windows, nil
+}
+
+// newProfessor flattens a teacher record with its user
+func newProfessor(teacher *models.Teacher) *Professor {
+	professor := &Professor{
+		ID:             teacher.ID,
+		UserID:         teacher.UserID,
+		KeycloakUserID: teacher.User.KeycloakUserID,
+		Name:           teacher.User.Name,
+		Email:          teacher.User.Email,
+		CPF:            cpf.Format(teacher.User.CPF),
+		Phone:          teacher.Phone,
+		BirthDate:      teacher.User.BirthDate,
+		PhotoURL:       teacher.User.PhotoURL,
+		Address:        teacher.User.Address,
+		UserContacts:   teacher.User.UserContacts,
+		Specialization: teacher.Specialization,
+		Bio:            teacher.Bio,
+		Active:         teacher.Active,
+		Skills:         teacher.Skills,
+		Availability:   teacher.Availability,
+		CreatedAt:      teacher.CreatedAt,
+		UpdatedAt:      teacher.UpdatedAt,
+	}
+	if professor.Phone == "" {
+		professor.Phone = teacher.User.Phone
+	}
+	if professor.Skills == nil {
+		professor.Skills = []models.TeacherSkill{}
+	}
+	if professor.Availability == nil {
+		professor.Availability = []models.TeacherAvailability{}
+	}
+	return professor
 }
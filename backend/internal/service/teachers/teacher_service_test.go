@@ -2,10 +2,12 @@ package teachers
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/internal/service/availability"
 )
 
 // MockUserRepository is a mock implementation of repository.UserRepository
@@ -57,12 +59,20 @@ func (m *MockUserRepository) Create(ctx context.Context, user *models.User) erro
 	return nil
 }
 
+func (m *MockUserRepository) FindByIDWithAssociations(ctx context.Context, id uint) (*models.User, error) {
+	return m.FindByID(ctx, id)
+}
+
 func (m *MockUserRepository) Update(ctx context.Context, user *models.User) error {
 	m.users[user.ID] = user
 	m.usersByEmail[user.Email] = user
 	return nil
 }
 
+func (m *MockUserRepository) UpdateWithAssociations(ctx context.Context, user *models.User) error {
+	return m.Update(ctx, user)
+}
+
 func (m *MockUserRepository) Delete(ctx context.Context, id uint) error {
 	delete(m.users, id)
 	return nil
@@ -72,6 +82,10 @@ func (m *MockUserRepository) GetUserProfiles(ctx context.Context, userID uint) (
 	return nil, nil
 }
 
+func (m *MockUserRepository) FindProfileByID(ctx context.Context, id uint) (*models.UserProfile, error) {
+	return nil, nil
+}
+
 func (m *MockUserRepository) UpdateLastLogin(ctx context.Context, id uint, timestamp time.Time) error {
 	return nil
 }
@@ -80,44 +94,154 @@ func (m *MockUserRepository) FindByProfile(ctx context.Context, profile string)
 	return nil, nil
 }
 
-func TestCreateProfessor(t *testing.T) {
-	repo := NewMockUserRepository()
-	// We pass nil for keycloak and email service since they are concrete types and not easily mockable without interfaces
-	// The CreateProfessor code handles nil checks for these.
-	svc := NewService(repo, nil, nil)
+func (m *MockUserRepository) FindByProfileID(ctx context.Context, profileID uint) ([]models.User, error) {
+	return nil, nil
+}
 
-	t.Run("Success", func(t *testing.T) {
-		professor := &models.User{
-			Name:  "Test Professor",
-			Email: "test@professor.com",
+// MockTeacherRepository is a mock implementation of repository.TeacherRepository
+type MockTeacherRepository struct {
+	users    *MockUserRepository
+	teachers map[uint]*models.Teacher
+}
+
+func NewMockTeacherRepository(users *MockUserRepository) *MockTeacherRepository {
+	return &MockTeacherRepository{users: users, teachers: make(map[uint]*models.Teacher)}
+}
+
+func (m *MockTeacherRepository) FindByID(ctx context.Context, id uint) (*models.Teacher, error) {
+	teacher, ok := m.teachers[id]
+	if !ok {
+		return nil, nil
+	}
+	if user, ok := m.users.users[teacher.UserID]; ok {
+		teacher.User = *user
+	}
+	return teacher, nil
+}
+
+func (m *MockTeacherRepository) FindByUserID(ctx context.Context, userID uint) (*models.Teacher, error) {
+	for _, teacher := range m.teachers {
+		if teacher.UserID == userID {
+			return teacher, nil
 		}
+	}
+	return nil, nil
+}
+
+func (m *MockTeacherRepository) FindAll(ctx context.Context) ([]models.Teacher, error) {
+	var teachers []models.Teacher
+	for _, teacher := range m.teachers {
+		teachers = append(teachers, *teacher)
+	}
+	return teachers, nil
+}
 
-		err := svc.CreateProfessor(context.Background(), professor)
+func (m *MockTeacherRepository) Create(ctx context.Context, teacher *models.Teacher) error {
+	teacher.ID = uint(len(m.teachers) + 1)
+	m.teachers[teacher.ID] = teacher
+	return nil
+}
+
+func (m *MockTeacherRepository) CreateWithUser(ctx context.Context, user *models.User, teacher *models.Teacher, skills []models.TeacherSkill, windows []models.TeacherAvailability) error {
+	if err := m.users.Create(ctx, user); err != nil {
+		return err
+	}
+	teacher.UserID = user.ID
+	teacher.Skills = skills
+	teacher.Availability = windows
+	return m.Create(ctx, teacher)
+}
+
+func (m *MockTeacherRepository) Update(ctx context.Context, teacher *models.Teacher) error {
+	m.teachers[teacher.ID] = teacher
+	return nil
+}
+
+func (m *MockTeacherRepository) UpdateWithUser(ctx context.Context, user *models.User, teacher *models.Teacher, skills []models.TeacherSkill, windows []models.TeacherAvailability) error {
+	if err := m.users.UpdateWithAssociations(ctx, user); err != nil {
+		return err
+	}
+	if skills != nil {
+		teacher.Skills = skills
+	}
+	if windows != nil {
+		teacher.Availability = windows
+	}
+	return m.Update(ctx, teacher)
+}
+
+func (m *MockTeacherRepository) ReplaceSkills(ctx context.Context, teacherID uint, skills []models.TeacherSkill) error {
+	m.teachers[teacherID].Skills = skills
+	return nil
+}
+
+func (m *MockTeacherRepository) ReplaceAvailability(ctx context.Context, teacherID uint, windows []models.TeacherAvailability) error {
+	m.teachers[teacherID].Availability = windows
+	return nil
+}
+
+func TestRegisterInvitedProfessor(t *testing.T) {
+	repo := NewMockUserRepository()
+	teacherRepo := NewMockTeacherRepository(repo)
+	// We pass nil for keycloak since it is a concrete type and not easily mockable without interfaces
+	// The registration code handles the nil check.
+	svc := NewService(repo, teacherRepo, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		professor, err := svc.RegisterInvitedProfessor(context.Background(), ProfessorInput{
+			Name:           "Test Professor",
+			Email:          "test@professor.com",
+			Specialization: "Idiomas",
+			Skills:         []SkillInput{{SkillID: 3, Level: "advanced"}},
+			Availability:   []availability.WindowInput{{DayOfWeek: 1, StartTime: "09:00", EndTime: "12:00"}},
+		}, "uma-senha-segura")
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
 		}
 
-		if professor.ID == 0 {
-			t.Errorf("Expected professor ID to be set")
+		if professor.ID == 0 || professor.UserID == 0 {
+			t.Errorf("Expected teacher and user IDs to be set")
 		}
 
-		if professor.ProfileID != 2 {
-			t.Errorf("Expected profile ID to be 2 (teacher), got %d", professor.ProfileID)
+		user := repo.users[professor.UserID]
+		if user.ProfileID != 2 {
+			t.Errorf("Expected profile ID to be 2 (teacher), got %d", user.ProfileID)
 		}
 
-		if !professor.Active {
+		if !professor.Active || !user.Active {
 			t.Errorf("Expected professor to be active")
 		}
+
+		if professor.Specialization != "Idiomas" || len(professor.Skills) != 1 || len(professor.Availability) != 1 {
+			t.Errorf("Expected teacher profile to be stored, got %+v", professor)
+		}
+	})
+
+	t.Run("MissingPassword", func(t *testing.T) {
+		_, err := svc.RegisterInvitedProfessor(context.Background(), ProfessorInput{
+			Name:  "Invited Professor",
+			Email: "nopassword@professor.com",
+		}, "")
+		if !errors.Is(err, ErrInvalidProfessor) {
+			t.Fatalf("Expected ErrInvalidProfessor, got %v", err)
+		}
 	})
 
 	t.Run("MissingRequiredFields", func(t *testing.T) {
-		professor := &models.User{
-			Name: "",
+		_, err := svc.RegisterInvitedProfessor(context.Background(), ProfessorInput{Name: ""}, "uma-senha-segura")
+		if !errors.Is(err, ErrInvalidProfessor) {
+			t.Fatalf("Expected ErrInvalidProfessor, got %v", err)
 		}
+	})
 
-		err := svc.CreateProfessor(context.Background(), professor)
-		if err == nil {
-			t.Fatal("Expected error for missing fields, got nil")
+	t.Run("InvalidAvailability", func(t *testing.T) {
+		_, err := svc.RegisterInvitedProfessor(context.Background(), ProfessorInput{
+			Name:         "Prof",
+			Email:        "availability@test.com",
+			Availability: []availability.WindowInput{{DayOfWeek: 1, StartTime: "12:00", EndTime: "09:00"}},
+		}, "uma-senha-segura")
+		if !errors.Is(err, ErrInvalidProfessor) {
+			t.Fatalf("Expected ErrInvalidProfessor, got %v", err)
 		}
 	})
 
@@ -128,17 +252,50 @@ func TestCreateProfessor(t *testing.T) {
 		}
 		repo.Create(context.Background(), professor1)
 
-		professor2 := &models.User{
+		_, err := svc.RegisterInvitedProfessor(context.Background(), ProfessorInput{
 			Name:  "Prof 2",
 			Email: "duplicate@test.com",
+		}, "uma-senha-segura")
+		if !errors.Is(err, ErrEmailInUse) {
+			t.Errorf("Expected ErrEmailInUse, got %v", err)
 		}
+	})
+}
 
-		err := svc.CreateProfessor(context.Background(), professor2)
-		if err == nil {
-			t.Fatal("Expected error for duplicate email, got nil")
-		}
-		if err.Error() != "a user with this email already exists" {
-			t.Errorf("Expected specific error message, got %v", err)
-		}
+func TestUpdateProfessor(t *testing.T) {
+	repo := NewMockUserRepository()
+	teacherRepo := NewMockTeacherRepository(repo)
+	svc := NewService(repo, teacherRepo, nil)
+
+	professor, err := svc.RegisterInvitedProfessor(context.Background(), ProfessorInput{
+		Name:         "Test Professor",
+		Email:        "update@professor.com",
+		Skills:       []SkillInput{{SkillID: 3, Level: "advanced"}},
+		Availability: []availability.WindowInput{{DayOfWeek: 1, StartTime: "09:00", EndTime: "12:00"}},
+	}, "uma-senha-segura")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := svc.UpdateProfessor(context.Background(), professor.ID, ProfessorInput{
+		Name:           "Renamed Professor",
+		Specialization: "Música",
+		Skills:         []SkillInput{{SkillID: 4}, {SkillID: 5, Level: "expert"}},
 	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Name != "Renamed Professor" || updated.Specialization != "Música" {
+		t.Errorf("Expected user and teacher fields to be updated, got %+v", updated)
+	}
+	if len(updated.Skills) != 2 || len(updated.Availability) != 1 {
+		t.Errorf("Expected skills to be replaced and availability kept, got %d skills and %d windows", len(updated.Skills), len(updated.Availability))
+	}
+
+	if _, err := svc.UpdateProfessor(context.Background(), professor.ID, ProfessorInput{
+		Name:   "Renamed Professor",
+		Skills: []SkillInput{{SkillID: 4}, {SkillID: 4}},
+	}); !errors.Is(err, ErrInvalidProfessor) {
+		t.Errorf("Expected ErrInvalidProfessor for repeated skills, got %v", err)
+	}
 }
@@ -0,0 +1,416 @@
+// backend/internal/service/timeline/service.go
+package timeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+var (
+	// ErrStudentNotFound is returned when the student does not exist
+	ErrStudentNotFound = errors.New("student not found")
+	// ErrForbidden is returned when the user cannot see the student's timeline
+	ErrForbidden = errors.New("only the coordination and the student's teachers can see the timeline")
+	// ErrInvalidQuery is returned for unknown event types or inconsistent pages
+	ErrInvalidQuery = errors.New("invalid timeline query")
+)
+
+// Event types of the timeline
+const (
+	TypeEnrollment   = "enrollment"
+	TypeAttendance   = "attendance"
+	TypeAbsenceAlert = "absence_alert"
+	TypeIncident     = "incident"
+	TypeNote         = "note"
+	TypeInterview    = "interview"
+	TypeDocument     = "document"
+	TypeNotification = "notification"
+	TypeStatus       = "status"
+)
+
+// Types lists every event type, in the order shown to the filters
+var Types = []string{TypeEnrollment, TypeAttendance, TypeAbsenceAlert, TypeIncident, TypeNote, TypeInterview, TypeDocument, TypeNotification, TypeStatus}
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// columns are the columns returned by every source, in order
+const columns = "type, action, occurred_at, course_id, course_name, entity_id, status, title, detail, actor_name, confidential"
+
+// sources are the queries of each event type. All return the same columns, so they are combined
+// with UNION ALL; named parameters: @student, @user (the student's user), @viewer and @admin.
+var sources = map[string][]string{
+	TypeEnrollment: {`
+		SELECT 'enrollment', 'enrolled', e.enrollment_date::timestamptz,
+			e.course_id::bigint, c.name, e.id::text, e.status,
+			'Matrícula em ' || c.name, e.enrollment_number, '', false
+		FROM enrollments e
+		JOIN courses c ON c.id = e.course_id
+		WHERE e.student_id = @student AND e.deleted_at IS NULL`, `
+		SELECT 'enrollment', e.status, COALESCE(e.end_date, e.updated_at)::timestamptz,
+			e.course_id::bigint, c.name, e.id::text, e.status,
+			CASE e.status WHEN 'completed' THEN 'Matrícula concluída em ' ELSE 'Matrícula cancelada em ' END || c.name,
+			COALESCE(e.cancellation_reason, ''), '', false
+		FROM enrollments e
+		JOIN courses c ON c.id = e.course_id
+		WHERE e.student_id = @student AND e.deleted_at IS NULL AND e.status IN ('completed', 'cancelled')`,
+	},
+	TypeAttendance: {`
+		SELECT 'attendance', a.status, a.date::timestamptz,
+			a.course_id::bigint, c.name, a.id::text, a.status,
+			CASE a.status WHEN 'absent' THEN 'Falta em ' ELSE 'Presença parcial em ' END || c.name,
+			COALESCE(NULLIF(a.justification, ''), a.notes, ''), COALESCE(u.name, ''), false
+		FROM attendances a
+		JOIN courses c ON c.id = a.course_id
+		LEFT JOIN users u ON u.id = a.registered_by_id
+		WHERE a.student_id = @student AND a.status <> 'present'`, `
+		SELECT 'attendance', 'justification', j.created_at::timestamptz,
+			j.course_id::bigint, c.name, j.id::text, j.status,
+			'Justificativa de faltas em ' || c.name || ' (' || to_char(j.start_date, 'DD/MM/YYYY') || ' a ' || to_char(j.end_date, 'DD/MM/YYYY') || ')',
+			j.reason, COALESCE(u.name, ''), false
+		FROM absence_justifications j
+		JOIN courses c ON c.id = j.course_id
+		LEFT JOIN users u ON u.id = j.submitted_by_id
+		WHERE j.student_id = @student`,
+	},
+	TypeAbsenceAlert: {`
+		SELECT 'absence_alert', 'level_' || al.level, al.created_at::timestamptz,
+			al.course_id::bigint, c.name, al.id::text, al.status,
+			'Alerta de faltas (nível ' || al.level || ') em ' || c.name,
+			al.absence_count || ' falta(s)' || CASE WHEN COALESCE(al.resolution_notes, '') <> '' THEN ' — ' || al.resolution_notes ELSE '' END,
+			'', false
+		FROM absence_alerts al
+		JOIN courses c ON c.id = al.course_id
+		WHERE al.student_id = @student`,
+	},
+	TypeIncident: {`
+		SELECT 'incident', i.type, i.created_at::timestamptz,
+			i.course_id::bigint, COALESCE(c.name, ''), i.id::text, i.status,
+			i.title, i.description, COALESCE(u.name, ''), false
+		FROM incidents i
+		LEFT JOIN courses c ON c.id = i.course_id
+		LEFT JOIN users u ON u.id = i.reported_by_id
+		WHERE i.student_id = @student AND i.deleted_at IS NULL`,
+	},
+	TypeNote: {`
+		SELECT 'note', CASE WHEN n.is_confidential THEN 'confidential' ELSE 'note' END, n.created_at::timestamptz,
+			NULL::bigint, '', n.id::text, '',
+			'Observação', n.content, COALESCE(u.name, ''), n.is_confidential
+		FROM student_notes n
+		LEFT JOIN users u ON u.id = n.author_id
+		WHERE n.student_id = @student AND (NOT n.is_confidential OR @admin OR n.author_id = @viewer)`,
+	},
+	TypeDocument: {`
+		SELECT 'document', d.type, d.created_at::timestamptz,
+			NULL::bigint, '', d.id::text, '',
+			'Documento: ' || d.name, d.content_type, COALESCE(u.name, ''), false
+		FROM documents d
+		LEFT JOIN users u ON u.id = d.uploaded_by_id
+		WHERE d.student_id = @student`,
+	},
+	TypeNotification: {`
+		SELECT 'notification', n.type, n.created_at::timestamptz,
+			NULL::bigint, '', n.id::text, n.delivery_status,
+			n.title, n.message, '', false
+		FROM notifications n
+		WHERE n.user_id = @user`,
+	},
+	TypeStatus: {`
+		SELECT 'status', sc.source, sc.created_at::timestamptz,
+			NULL::bigint, '', sc.id::text, sc.to_status,
+			'Situação: ' || sc.from_status || ' → ' || sc.to_status, sc.reason, COALESCE(u.name, ''), false
+		FROM student_status_changes sc
+		LEFT JOIN users u ON u.id = sc.changed_by_id
+		WHERE sc.student_id = @student`,
+	},
+}
+
+// inAppNotifications is created by the notification service on its first message
+const inAppNotifications = `
+		SELECT 'notification', n.event_type, n.created_at::timestamptz,
+			NULL::bigint, '', 'in_app:' || n.id, CASE WHEN n.read THEN 'read' ELSE 'unread' END,
+			n.title, n.message, '', false
+		FROM in_app_notifications n
+		WHERE n.user_id = @user`
+
+// InterviewResponses lists the interview responses kept in MongoDB (implemented by mongodb.FormRepository)
+type InterviewResponses interface {
+	ListResponsesByStudent(ctx context.Context, studentID uint) ([]models.InterviewResponse, error)
+}
+
+// Viewer is the user reading the timeline
+type Viewer struct {
+	UserID uint
+	Admin  bool
+}
+
+// Query selects a page of the timeline; no types means every type
+type Query struct {
+	Types    []string
+	From     *time.Time
+	To       *time.Time
+	Page     int
+	PageSize int
+}
+
+// Event is an entry of the timeline
+type Event struct {
+	Type         string    `json:"type"`
+	Action       string    `json:"action"`
+	OccurredAt   time.Time `json:"occurredAt"`
+	CourseID     *uint     `json:"courseId,omitempty"`
+	CourseName   string    `json:"courseName,omitempty"`
+	EntityID     string    `json:"entityId"`
+	Status       string    `json:"status,omitempty"`
+	Title        string    `json:"title"`
+	Detail       string    `json:"detail,omitempty"`
+	ActorName    string    `json:"actorName,omitempty"`
+	Confidential bool      `json:"confidential,omitempty"`
+}
+
+// Page is a page of the timeline, most recent events first
+type Page struct {
+	Events   []Event `json:"events"`
+	Total    int64   `json:"total"`
+	Page     int     `json:"page"`
+	PageSize int     `json:"pageSize"`
+}
+
+// Service defines the interface for the student timeline
+type Service interface {
+	Get(ctx context.Context, viewer Viewer, studentID uint, query Query) (*Page, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db        *gorm.DB
+	responses InterviewResponses
+}
+
+// NewService creates a new timeline service. responses may be nil (interviews are left out).
+func NewService(db *gorm.DB, responses InterviewResponses) Service {
+	return &service{db: db, responses: responses}
+}
+
+// Get merges the enrollments, absences, absence alerts, incidents, notes, interviews, documents,
+// notifications and status changes of the student into one chronological page. Attendance only
+// shows absences and partial presences. Confidential notes are only shown to the coordination
+// and to their author.
+func (s *service) Get(ctx context.Context, viewer Viewer, studentID uint, query Query) (*Page, error) {
+	types, err := normalizeQuery(&query)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := s.authorize(ctx, viewer, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cada fonte é ordenada e limitada no banco; basta buscar até o fim da página pedida
+	limit := query.Page * query.PageSize
+	var events []Event
+	var total int64
+
+	var selects []string
+	for _, eventType := range types {
+		selects = append(selects, sources[eventType]...)
+		if eventType == TypeNotification && s.db.Migrator().HasTable("in_app_notifications") {
+			selects = append(selects, inAppNotifications)
+		}
+	}
+	if len(selects) > 0 {
+		params := map[string]interface{}{
+			"student": studentID,
+			"user":    userID,
+			"viewer":  viewer.UserID,
+			"admin":   viewer.Admin,
+			"limit":   limit,
+		}
+		where := "TRUE"
+		if query.From != nil {
+			where += " AND occurred_at >= @from"
+			params["from"] = *query.From
+		}
+		if query.To != nil {
+			where += " AND occurred_at < @to"
+			params["to"] = *query.To
+		}
+		union := strings.Join(selects, "\n\t\tUNION ALL")
+
+		if err := s.db.WithContext(ctx).Raw(
+			fmt.Sprintf("SELECT COUNT(*) FROM (%s\n\t) t(%s) WHERE %s", union, columns, where), params,
+		).Scan(&total).Error; err != nil {
+			return nil, err
+		}
+		if err := s.db.WithContext(ctx).Raw(
+			fmt.Sprintf("SELECT * FROM (%s\n\t) t(%s) WHERE %s ORDER BY occurred_at DESC, type, entity_id DESC LIMIT @limit", union, columns, where), params,
+		).Scan(&events).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if s.responses != nil && includes(types, TypeInterview) {
+		interviews, err := s.interviews(ctx, studentID, query)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, interviews...)
+		total += int64(len(interviews))
+	}
+
+	return &Page{
+		Events:   paginate(events, query.Page, query.PageSize),
+		Total:    total,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}, nil
+}
+
+// interviews converts the interview responses of the student to events
+func (s *service) interviews(ctx context.Context, studentID uint, query Query) ([]Event, error) {
+	responses, err := s.responses.ListResponsesByStudent(ctx, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list interview responses: %w", err)
+	}
+
+	events := []Event{}
+	for _, response := range responses {
+		occurredAt := response.CreatedAt
+		if !response.CompletionDate.IsZero() {
+			occurredAt = response.CompletionDate
+		}
+		if (query.From != nil && occurredAt.Before(*query.From)) || (query.To != nil && !occurredAt.Before(*query.To)) {
+			continue
+		}
+		title := "Entrevista pendente"
+		if response.Status == "completed" {
+			title = "Entrevista respondida"
+		}
+		events = append(events, Event{
+			Type:       TypeInterview,
+			Action:     response.Status,
+			OccurredAt: occurredAt,
+			EntityID:   response.ID.Hex(),
+			Status:     response.Status,
+			Title:      title,
+			Detail:     "Questionário " + response.FormVersion,
+		})
+	}
+	return events, nil
+}
+
+// authorize lets the coordination and the current teachers of the student read the timeline and
+// returns the student's user
+func (s *service) authorize(ctx context.Context, viewer Viewer, studentID uint) (uint, error) {
+	var student struct {
+		ID     uint
+		UserID uint
+	}
+	if err := s.db.WithContext(ctx).Table("students").
+		Select("id, user_id").
+		Where("id = ? AND deleted_at IS NULL", studentID).
+		Scan(&student).Error; err != nil {
+		return 0, err
+	}
+	if student.ID == 0 {
+		return 0, ErrStudentNotFound
+	}
+	if viewer.Admin {
+		return student.UserID, nil
+	}
+	if viewer.UserID == 0 {
+		return 0, ErrForbidden
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT COUNT(*) FROM enrollments e
+		INNER JOIN enrollment_course_classes ecc ON ecc.enrollment_id = e.id
+		INNER JOIN course_classes cc ON cc.id = ecc.course_class_id
+		WHERE e.student_id = ? AND e.deleted_at IS NULL AND e.status <> 'cancelled' AND (
+			cc.default_teacher_id IN (SELECT id FROM teachers WHERE user_id = ?)
+			OR EXISTS (
+				SELECT 1 FROM teacher_courses tc
+				INNER JOIN teachers t ON t.id = tc.teacher_id
+				WHERE t.user_id = ?
+					AND tc.active = true
+					AND tc.course_id = cc.course_id
+					AND (tc.course_class_id IS NULL OR tc.course_class_id = cc.id)
+					AND tc.start_date <= CURRENT_DATE
+					AND (tc.end_date IS NULL OR tc.end_date >= CURRENT_DATE)
+			)
+		)
+	`, studentID, viewer.UserID, viewer.UserID).Scan(&count).Error; err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, ErrForbidden
+	}
+	return student.UserID, nil
+}
+
+// normalizeQuery applies the page defaults and returns the selected types (all when none)
+func normalizeQuery(query *Query) ([]string, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.PageSize == 0 {
+		query.PageSize = defaultPageSize
+	}
+	if query.Page < 1 || query.PageSize < 1 || query.PageSize > maxPageSize {
+		return nil, fmt.Errorf("%w: page must be positive and pageSize between 1 and %d", ErrInvalidQuery, maxPageSize)
+	}
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidQuery)
+	}
+
+	if len(query.Types) == 0 {
+		return Types, nil
+	}
+	types := []string{}
+	for _, eventType := range query.Types {
+		eventType = strings.TrimSpace(eventType)
+		if !includes(Types, eventType) {
+			return nil, fmt.Errorf("%w: unknown event type %q (expected one of %s)", ErrInvalidQuery, eventType, strings.Join(Types, ", "))
+		}
+		if !includes(types, eventType) {
+			types = append(types, eventType)
+		}
+	}
+	return types, nil
+}
+
+// paginate orders the merged events (most recent first) and returns the requested page
+func paginate(events []Event, page, pageSize int) []Event {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OccurredAt.After(events[j].OccurredAt)
+	})
+	start := (page - 1) * pageSize
+	if start >= len(events) {
+		return []Event{}
+	}
+	end := start + pageSize
+	if end > len(events) {
+		end = len(events)
+	}
+	return events[start:end]
+}
+
+func includes(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
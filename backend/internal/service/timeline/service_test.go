@@ -0,0 +1,60 @@
+package timeline
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	query := Query{}
+	types, err := normalizeQuery(&query)
+	if err != nil {
+		t.Fatalf("Expected empty query to be valid, got %v", err)
+	}
+	if len(types) != len(Types) || query.Page != 1 || query.PageSize != defaultPageSize {
+		t.Errorf("Expected all types and default page, got %v %+v", types, query)
+	}
+
+	query = Query{Types: []string{"note", " incident", "note"}}
+	types, err = normalizeQuery(&query)
+	if err != nil || len(types) != 2 || types[0] != TypeNote || types[1] != TypeIncident {
+		t.Errorf("Expected note and incident once each, got %v, %v", types, err)
+	}
+
+	from := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	invalid := []Query{
+		{Types: []string{"grade"}},
+		{Page: -1},
+		{PageSize: maxPageSize + 1},
+		{From: &from, To: &from},
+	}
+	for _, query := range invalid {
+		if _, err := normalizeQuery(&query); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("Expected ErrInvalidQuery for %+v, got %v", query, err)
+		}
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 10, 0, 0, 0, time.UTC) }
+	events := []Event{
+		{EntityID: "a", OccurredAt: day(3)},
+		{EntityID: "b", OccurredAt: day(9)},
+		{EntityID: "c", OccurredAt: day(1)},
+		{EntityID: "d", OccurredAt: day(5)},
+		{EntityID: "e", OccurredAt: day(7)},
+	}
+
+	first := paginate(events, 1, 2)
+	if len(first) != 2 || first[0].EntityID != "b" || first[1].EntityID != "e" {
+		t.Errorf("Unexpected first page: %+v", first)
+	}
+	last := paginate(events, 3, 2)
+	if len(last) != 1 || last[0].EntityID != "c" {
+		t.Errorf("Unexpected last page: %+v", last)
+	}
+	if beyond := paginate(events, 4, 2); len(beyond) != 0 {
+		t.Errorf("Expected empty page beyond the end, got %+v", beyond)
+	}
+}
@@ -0,0 +1,150 @@
+// backend/internal/service/volunteerhours/pdf.go
+package volunteerhours
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page in PDF points, with 2 cm margins
+const (
+	pageWidth    = 595.0
+	pageHeight   = 842.0
+	pageMargin   = 56.0
+	lineSpacing  = 1.4
+	avgCharWidth = 0.5 // largura média de um caractere Helvetica, em relação ao tamanho da fonte
+)
+
+// pdfDocument is a minimal text-only PDF writer (Helvetica, WinAnsiEncoding, A4)
+type pdfDocument struct {
+	pages   []*bytes.Buffer
+	current *bytes.Buffer
+	y       float64
+}
+
+func newPDFDocument() *pdfDocument {
+	d := &pdfDocument{}
+	d.newPage()
+	return d
+}
+
+func (d *pdfDocument) newPage() {
+	d.current = &bytes.Buffer{}
+	d.pages = append(d.pages, d.current)
+	d.y = pageHeight - pageMargin
+}
+
+// Text writes a left-aligned paragraph, wrapping lines and breaking pages as needed
+func (d *pdfDocument) Text(text string, size float64, bold bool) {
+	maxChars := int((pageWidth - 2*pageMargin) / (size * avgCharWidth))
+	for _, line := range wrapText(text, maxChars) {
+		d.line(line, size, bold, pageMargin)
+	}
+}
+
+// Centered writes a single centered line
+func (d *pdfDocument) Centered(text string, size float64, bold bool) {
+	width := float64(len([]rune(text))) * size * avgCharWidth
+	x := (pageWidth - width) / 2
+	if x < pageMargin {
+		x = pageMargin
+	}
+	d.line(text, size, bold, x)
+}
+
+// Space advances the cursor
+func (d *pdfDocument) Space(height float64) {
+	d.y -= height
+}
+
+func (d *pdfDocument) line(text string, size float64, bold bool, x float64) {
+	height := size * lineSpacing
+	if d.y-height < pageMargin {
+		d.newPage()
+	}
+	d.y -= height
+
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.current, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, d.y, escapePDFText(text))
+}
+
+// Bytes serializes the document
+func (d *pdfDocument) Bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1: catálogo, 2: páginas, 3-4: fontes, depois página e conteúdo de cada página
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+// wrapText breaks the text into lines of at most maxChars runes, on word boundaries
+func wrapText(text string, maxChars int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+		line := words[0]
+		for _, word := range words[1:] {
+			if len([]rune(line))+1+len([]rune(word)) > maxChars {
+				lines = append(lines, line)
+				line = word
+				continue
+			}
+			line += " " + word
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// escapePDFText converts the text to WinAnsi (Latin-1 covers Portuguese) and escapes the string delimiters
+func escapePDFText(text string) string {
+	var buf strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(byte(r))
+		case r == '–' || r == '—':
+			buf.WriteByte('-')
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			buf.WriteByte(byte(r))
+		default:
+			buf.WriteByte('?')
+		}
+	}
+	return buf.String()
+}
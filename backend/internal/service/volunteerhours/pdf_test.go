@@ -0,0 +1,54 @@
+package volunteerhours
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPDFDocument(t *testing.T) {
+	doc := newPDFDocument()
+	doc.Centered("DECLARAÇÃO", 16, true)
+	for i := 0; i < 80; i++ {
+		doc.Text(fmt.Sprintf("Linha %d (aula)", i), 11, false)
+	}
+	out := doc.Bytes()
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatal("Expected a PDF header and trailer")
+	}
+	if len(doc.pages) != 2 || !bytes.Contains(out, []byte("/Count 2")) {
+		t.Errorf("Expected the text to break into 2 pages, got %d", len(doc.pages))
+	}
+
+	t.Run("xref points to the objects", func(t *testing.T) {
+		xref := bytes.LastIndex(out, []byte("\nxref\n")) + 1
+		var startxref int
+		fmt.Sscanf(string(out[bytes.LastIndex(out, []byte("startxref\n"))+10:]), "%d", &startxref)
+		if startxref != xref {
+			t.Fatalf("Expected startxref %d, got %d", xref, startxref)
+		}
+		entries := strings.Split(string(out[xref:]), "\n")[3:]
+		for i := 1; i <= 4; i++ {
+			var offset int
+			fmt.Sscanf(entries[i-1], "%d", &offset)
+			if !bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj", i))) {
+				t.Errorf("Expected object %d at offset %d", i, offset)
+			}
+		}
+	})
+
+	t.Run("text is WinAnsi encoded and escaped", func(t *testing.T) {
+		if got := escapePDFText("Ação (1) \\ ✓"); got != "A\xe7\xe3o \\(1\\) \\\\ ?" {
+			t.Errorf("Unexpected escaped text %q", got)
+		}
+	})
+}
+
+func TestWrapText(t *testing.T) {
+	lines := wrapText("um dois três quatro", 11)
+	if len(lines) != 2 || lines[0] != "um dois" || lines[1] != "três quatro" {
+		t.Errorf("Expected 2 wrapped lines, got %q", lines)
+	}
+}
@@ -0,0 +1,548 @@
+// backend/internal/service/volunteerhours/service.go
+package volunteerhours
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+	"github.com/devdavidalonso/cecor/backend/pkg/cpf"
+)
+
+// Status values of models.VolunteerActivity
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+var validCategories = map[string]bool{"planning": true, "event": true, "training": true, "other": true}
+
+// maxActivityMinutes caps a single logged activity (one day)
+const maxActivityMinutes = 24 * 60
+
+var (
+	// ErrNotTeacher is returned when the user has no teacher record
+	ErrNotTeacher = errors.New("user is not a teacher")
+	// ErrTeacherNotFound is returned when the teacher does not exist
+	ErrTeacherNotFound = errors.New("teacher not found")
+	// ErrInvalidPeriod is returned for malformed statement periods
+	ErrInvalidPeriod = errors.New("invalid period")
+	// ErrInvalidActivity is returned for malformed activities
+	ErrInvalidActivity = errors.New("invalid volunteer activity")
+	// ErrActivityNotFound is returned when the activity does not exist or belongs to another teacher
+	ErrActivityNotFound = errors.New("volunteer activity not found")
+	// ErrNotPending is returned when changing an activity that was already reviewed
+	ErrNotPending = errors.New("volunteer activity was already reviewed")
+	// ErrDeclarationNotFound is returned for unknown verification codes
+	ErrDeclarationNotFound = errors.New("declaration not found")
+)
+
+// Period is an inclusive range of days
+type Period struct {
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// ActivityInput logs volunteer work outside the class sessions
+type ActivityInput struct {
+	Date        time.Time
+	Minutes     int
+	Category    string // planning, event, training, other
+	Description string
+}
+
+// ActivityFilter narrows the activity listings
+type ActivityFilter struct {
+	TeacherID *uint
+	Status    string
+}
+
+// SessionHours is a session effectively taught by the teacher
+type SessionHours struct {
+	SessionID    uint      `json:"sessionId"`
+	Date         time.Time `json:"date"`
+	CourseName   string    `json:"courseName"`
+	ClassName    string    `json:"className"`
+	StartTime    string    `json:"startTime"`
+	EndTime      string    `json:"endTime"`
+	Minutes      int       `json:"minutes"`
+	Substitution bool      `json:"substitution"` // aula dada como substituto
+}
+
+// Statement is the volunteer hours of a teacher in a period
+type Statement struct {
+	TeacherID         uint                       `json:"teacherId"`
+	TeacherName       string                     `json:"teacherName"`
+	StartDate         time.Time                  `json:"startDate"`
+	EndDate           time.Time                  `json:"endDate"`
+	Sessions          []SessionHours             `json:"sessions"`
+	Activities        []models.VolunteerActivity `json:"activities"`        // aprovadas
+	PendingActivities []models.VolunteerActivity `json:"pendingActivities"` // aguardando a coordenação, fora do total
+	SessionMinutes    int                        `json:"sessionMinutes"`
+	ActivityMinutes   int                        `json:"activityMinutes"`
+	PendingMinutes    int                        `json:"pendingMinutes"`
+	TotalMinutes      int                        `json:"totalMinutes"`
+
+	teacherCPF string
+}
+
+// Verification is the public check of an issued declaration
+type Verification struct {
+	Code            string    `json:"code"`
+	Organization    string    `json:"organization"`
+	TeacherName     string    `json:"teacherName"`
+	StartDate       time.Time `json:"startDate"`
+	EndDate         time.Time `json:"endDate"`
+	SessionMinutes  int       `json:"sessionMinutes"`
+	ActivityMinutes int       `json:"activityMinutes"`
+	TotalMinutes    int       `json:"totalMinutes"`
+	IssuedAt        time.Time `json:"issuedAt"`
+	Valid           bool      `json:"valid"` // assinatura confere com os dados registrados
+}
+
+// Service defines the interface for the volunteer hours ledger
+type Service interface {
+	Statement(ctx context.Context, teacherID uint, period Period) (*Statement, error)
+	ListActivities(ctx context.Context, filter ActivityFilter) ([]models.VolunteerActivity, error)
+	LogActivity(ctx context.Context, teacherID uint, input ActivityInput, userID uint, approved bool) (*models.VolunteerActivity, error)
+	DeleteActivity(ctx context.Context, teacherID uint, id uint) error
+	ReviewActivity(ctx context.Context, id uint, approve bool, notes string, userID uint) (*models.VolunteerActivity, error)
+	IssueDeclaration(ctx context.Context, teacherID uint, period Period, userID uint) (*models.VolunteerDeclaration, []byte, error)
+	VerifyDeclaration(ctx context.Context, code string) (*Verification, error)
+
+	// Teacher portal
+	TeacherIDForUser(ctx context.Context, userID uint) (uint, error)
+}
+
+// service implements the Service interface
+type service struct {
+	db           *gorm.DB
+	organization string
+	key          []byte
+	publicURL    string
+	now          func() time.Time
+}
+
+// NewService creates a new volunteer hours service. signingKey signs the declarations and
+// publicURL is the API address printed on them for verification.
+func NewService(db *gorm.DB, organization string, signingKey string, publicURL string) Service {
+	return &service{
+		db:           db,
+		organization: organization,
+		key:          []byte(signingKey),
+		publicURL:    strings.TrimRight(publicURL, "/"),
+		now:          time.Now,
+	}
+}
+
+// taughtSessions selects the past, non-cancelled sessions whose effective teacher is the given one:
+// the substitute of the session or, without one, the primary assigned to the class on that day
+// (the default teacher for classes without assignments)
+const taughtSessions = `
+	SELECT
+		cs.id AS session_id,
+		cs.date,
+		c.name AS course_name,
+		COALESCE(cc.name, '') AS class_name,
+		COALESCE(NULLIF(cs.start_time, ''), cc.start_time, c.start_time, '') AS start_time,
+		COALESCE(NULLIF(cs.end_time, ''), cc.end_time, c.end_time, '') AS end_time,
+		cs.teacher_id IS NOT NULL AS substitution
+	FROM class_sessions cs
+	INNER JOIN courses c ON c.id = cs.course_id
+	LEFT JOIN course_classes cc ON cc.id = cs.course_class_id
+	WHERE cs.is_cancelled = false
+		AND cs.date >= ? AND cs.date < ?
+		AND cs.date::date <= CURRENT_DATE
+		AND COALESCE(
+			cs.teacher_id,
+			(
+				SELECT tc.teacher_id FROM teacher_courses tc
+				WHERE tc.course_class_id = cs.course_class_id
+					AND tc.role = 'primary'
+					AND tc.active = true
+					AND tc.start_date::date <= cs.date::date
+					AND (tc.end_date IS NULL OR tc.end_date::date >= cs.date::date)
+				ORDER BY tc.start_date DESC
+				LIMIT 1
+			),
+			cc.default_teacher_id
+		) = ?
+	ORDER BY cs.date ASC, cs.id ASC
+`
+
+// Statement computes the hours of the sessions taught and of the logged activities in the period
+func (s *service) Statement(ctx context.Context, teacherID uint, period Period) (*Statement, error) {
+	if err := validatePeriod(period); err != nil {
+		return nil, err
+	}
+
+	var teacher struct {
+		Name string
+		CPF  string `gorm:"serializer:encrypted"`
+	}
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT u.name, COALESCE(u.cpf, '') AS cpf
+		FROM teachers t
+		INNER JOIN users u ON u.id = t.user_id
+		WHERE t.id = ?
+	`, teacherID).Scan(&teacher).Error; err != nil {
+		return nil, err
+	}
+	if teacher.Name == "" {
+		return nil, ErrTeacherNotFound
+	}
+
+	end := period.EndDate.AddDate(0, 0, 1)
+	var sessions []SessionHours
+	if err := s.db.WithContext(ctx).Raw(taughtSessions, period.StartDate, end, teacherID).Scan(&sessions).Error; err != nil {
+		return nil, fmt.Errorf("failed to load taught sessions: %w", err)
+	}
+
+	var activities []models.VolunteerActivity
+	if err := s.db.WithContext(ctx).
+		Where("teacher_id = ? AND date >= ? AND date < ? AND status IN ?", teacherID, period.StartDate, end, []string{StatusApproved, StatusPending}).
+		Order("date ASC, id ASC").
+		Find(&activities).Error; err != nil {
+		return nil, err
+	}
+
+	statement := &Statement{
+		TeacherID:         teacherID,
+		TeacherName:       teacher.Name,
+		StartDate:         period.StartDate,
+		EndDate:           period.EndDate,
+		Sessions:          make([]SessionHours, 0, len(sessions)),
+		Activities:        []models.VolunteerActivity{},
+		PendingActivities: []models.VolunteerActivity{},
+		teacherCPF:        cpf.Format(teacher.CPF),
+	}
+	for _, session := range sessions {
+		session.Minutes = sessionMinutes(session.StartTime, session.EndTime)
+		statement.SessionMinutes += session.Minutes
+		statement.Sessions = append(statement.Sessions, session)
+	}
+	for _, activity := range activities {
+		if activity.Status == StatusPending {
+			statement.PendingMinutes += activity.Minutes
+			statement.PendingActivities = append(statement.PendingActivities, activity)
+			continue
+		}
+		statement.ActivityMinutes += activity.Minutes
+		statement.Activities = append(statement.Activities, activity)
+	}
+	statement.TotalMinutes = statement.SessionMinutes + statement.ActivityMinutes
+	return statement, nil
+}
+
+func (s *service) ListActivities(ctx context.Context, filter ActivityFilter) ([]models.VolunteerActivity, error) {
+	query := s.db.WithContext(ctx).Model(&models.VolunteerActivity{})
+	if filter.TeacherID != nil {
+		query = query.Where("teacher_id = ?", *filter.TeacherID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var activities []models.VolunteerActivity
+	if err := query.Order("date DESC, id DESC").Find(&activities).Error; err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
+// LogActivity records volunteer work; activities logged by the teacher wait for the coordination review
+func (s *service) LogActivity(ctx context.Context, teacherID uint, input ActivityInput, userID uint, approved bool) (*models.VolunteerActivity, error) {
+	input.Category = strings.TrimSpace(input.Category)
+	input.Description = strings.TrimSpace(input.Description)
+	if input.Date.IsZero() || input.Description == "" {
+		return nil, fmt.Errorf("%w: date and description are required", ErrInvalidActivity)
+	}
+	if input.Minutes <= 0 || input.Minutes > maxActivityMinutes {
+		return nil, fmt.Errorf("%w: minutes must be between 1 and %d", ErrInvalidActivity, maxActivityMinutes)
+	}
+	if input.Category == "" {
+		input.Category = "other"
+	}
+	if !validCategories[input.Category] {
+		return nil, fmt.Errorf("%w: category must be planning, event, training or other", ErrInvalidActivity)
+	}
+	if input.Date.After(s.now()) {
+		return nil, fmt.Errorf("%w: activities can only be logged after they happen", ErrInvalidActivity)
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.Teacher{}).Where("id = ?", teacherID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrTeacherNotFound
+	}
+
+	activity := &models.VolunteerActivity{
+		TeacherID:   teacherID,
+		Date:        input.Date,
+		Minutes:     input.Minutes,
+		Category:    input.Category,
+		Description: input.Description,
+		Status:      StatusPending,
+		CreatedByID: userID,
+	}
+	if approved {
+		now := s.now()
+		activity.Status = StatusApproved
+		activity.ReviewedByID = &userID
+		activity.ReviewedAt = &now
+	}
+	if err := s.db.WithContext(ctx).Create(activity).Error; err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
+
+// DeleteActivity removes a pending activity of the teacher
+func (s *service) DeleteActivity(ctx context.Context, teacherID uint, id uint) error {
+	var activity models.VolunteerActivity
+	if err := s.db.WithContext(ctx).Where("id = ? AND teacher_id = ?", id, teacherID).First(&activity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrActivityNotFound
+		}
+		return err
+	}
+	if activity.Status != StatusPending {
+		return ErrNotPending
+	}
+	return s.db.WithContext(ctx).Delete(&activity).Error
+}
+
+// ReviewActivity approves or rejects a pending activity
+func (s *service) ReviewActivity(ctx context.Context, id uint, approve bool, notes string, userID uint) (*models.VolunteerActivity, error) {
+	var activity models.VolunteerActivity
+	if err := s.db.WithContext(ctx).First(&activity, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrActivityNotFound
+		}
+		return nil, err
+	}
+	if activity.Status != StatusPending {
+		return nil, ErrNotPending
+	}
+
+	now := s.now()
+	activity.Status = StatusRejected
+	if approve {
+		activity.Status = StatusApproved
+	}
+	activity.ReviewNotes = strings.TrimSpace(notes)
+	activity.ReviewedByID = &userID
+	activity.ReviewedAt = &now
+	if err := s.db.WithContext(ctx).Save(&activity).Error; err != nil {
+		return nil, err
+	}
+	return &activity, nil
+}
+
+// IssueDeclaration registers a signed declaration of the hours in the period and renders it as PDF
+func (s *service) IssueDeclaration(ctx context.Context, teacherID uint, period Period, userID uint) (*models.VolunteerDeclaration, []byte, error) {
+	statement, err := s.Statement(ctx, teacherID, period)
+	if err != nil {
+		return nil, nil, err
+	}
+	code, err := generateCode()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	declaration := &models.VolunteerDeclaration{
+		Code:            code,
+		TeacherID:       teacherID,
+		StartDate:       period.StartDate,
+		EndDate:         period.EndDate,
+		SessionMinutes:  statement.SessionMinutes,
+		ActivityMinutes: statement.ActivityMinutes,
+		TotalMinutes:    statement.TotalMinutes,
+		IssuedByID:      userID,
+		IssuedAt:        s.now().UTC().Truncate(time.Second),
+	}
+	declaration.Signature = s.sign(declaration)
+	if err := s.db.WithContext(ctx).Create(declaration).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return declaration, s.renderDeclaration(statement, declaration), nil
+}
+
+// VerifyDeclaration checks an issued declaration by its verification code
+func (s *service) VerifyDeclaration(ctx context.Context, code string) (*Verification, error) {
+	var declaration models.VolunteerDeclaration
+	if err := s.db.WithContext(ctx).Where("code = ?", strings.ToUpper(strings.TrimSpace(code))).First(&declaration).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeclarationNotFound
+		}
+		return nil, err
+	}
+
+	var teacherName string
+	if err := s.db.WithContext(ctx).Raw(`
+		SELECT u.name FROM teachers t INNER JOIN users u ON u.id = t.user_id WHERE t.id = ?
+	`, declaration.TeacherID).Scan(&teacherName).Error; err != nil {
+		return nil, err
+	}
+
+	return &Verification{
+		Code:            declaration.Code,
+		Organization:    s.organization,
+		TeacherName:     teacherName,
+		StartDate:       declaration.StartDate,
+		EndDate:         declaration.EndDate,
+		SessionMinutes:  declaration.SessionMinutes,
+		ActivityMinutes: declaration.ActivityMinutes,
+		TotalMinutes:    declaration.TotalMinutes,
+		IssuedAt:        declaration.IssuedAt,
+		Valid:           hmac.Equal([]byte(declaration.Signature), []byte(s.sign(&declaration))),
+	}, nil
+}
+
+func (s *service) TeacherIDForUser(ctx context.Context, userID uint) (uint, error) {
+	var teacher models.Teacher
+	if err := s.db.WithContext(ctx).Where("user_id = ?", userID).First(&teacher).Error; err != nil {
+		return 0, ErrNotTeacher
+	}
+	return teacher.ID, nil
+}
+
+// sign is the HMAC-SHA256 of the declared data
+func (s *service) sign(declaration *models.VolunteerDeclaration) string {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s|%d|%s|%s|%d|%d|%d|%s",
+		declaration.Code,
+		declaration.TeacherID,
+		declaration.StartDate.UTC().Format("2006-01-02"),
+		declaration.EndDate.UTC().Format("2006-01-02"),
+		declaration.SessionMinutes,
+		declaration.ActivityMinutes,
+		declaration.TotalMinutes,
+		declaration.IssuedAt.UTC().Format(time.RFC3339),
+	)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// renderDeclaration writes the declaration under the Volunteer Law with the detailed hours
+func (s *service) renderDeclaration(statement *Statement, declaration *models.VolunteerDeclaration) []byte {
+	doc := newPDFDocument()
+	doc.Centered(s.organization, 13, true)
+	doc.Space(18)
+	doc.Centered("DECLARAÇÃO DE SERVIÇO VOLUNTÁRIO", 16, true)
+	doc.Space(18)
+
+	identification := statement.TeacherName
+	if statement.teacherCPF != "" {
+		identification += ", inscrito(a) no CPF sob o nº " + statement.teacherCPF + ","
+	}
+	doc.Text(fmt.Sprintf(
+		"Declaramos, para os fins da Lei nº 9.608, de 18 de fevereiro de 1998, que %s prestou serviço voluntário, "+
+			"sem remuneração e sem vínculo empregatício, nesta instituição no período de %s a %s, totalizando %s, assim distribuídas:",
+		identification,
+		statement.StartDate.Format("02/01/2006"),
+		statement.EndDate.Format("02/01/2006"),
+		formatDuration(statement.TotalMinutes),
+	), 11, false)
+	doc.Space(6)
+	doc.Text(fmt.Sprintf("- Aulas ministradas (%d): %s", len(statement.Sessions), formatDuration(statement.SessionMinutes)), 11, false)
+	doc.Text(fmt.Sprintf("- Atividades complementares (%d): %s", len(statement.Activities), formatDuration(statement.ActivityMinutes)), 11, false)
+
+	if len(statement.Sessions) > 0 {
+		doc.Space(12)
+		doc.Text("Aulas ministradas", 11, true)
+		for _, session := range statement.Sessions {
+			line := fmt.Sprintf("%s  %s-%s  %s", session.Date.Format("02/01/2006"), session.StartTime, session.EndTime, session.CourseName)
+			if session.ClassName != "" {
+				line += " - " + session.ClassName
+			}
+			if session.Substitution {
+				line += " (substituição)"
+			}
+			doc.Text(line+"  "+formatClock(session.Minutes), 9, false)
+		}
+	}
+	if len(statement.Activities) > 0 {
+		doc.Space(12)
+		doc.Text("Atividades complementares", 11, true)
+		for _, activity := range statement.Activities {
+			doc.Text(fmt.Sprintf("%s  %s  %s", activity.Date.Format("02/01/2006"), activity.Description, formatClock(activity.Minutes)), 9, false)
+		}
+	}
+
+	doc.Space(18)
+	doc.Text("Emitida em "+declaration.IssuedAt.Local().Format("02/01/2006 às 15:04")+".", 10, false)
+	doc.Text("Código de verificação: "+declaration.Code, 10, true)
+	doc.Text("Confira a autenticidade em "+s.publicURL+"/api/v1/volunteer-declarations/"+declaration.Code, 9, false)
+	doc.Text("Assinatura eletrônica (HMAC-SHA256): "+declaration.Signature, 8, false)
+	return doc.Bytes()
+}
+
+func validatePeriod(period Period) error {
+	if period.StartDate.IsZero() || period.EndDate.IsZero() {
+		return fmt.Errorf("%w: startDate and endDate are required", ErrInvalidPeriod)
+	}
+	if period.EndDate.Before(period.StartDate) {
+		return fmt.Errorf("%w: endDate before startDate", ErrInvalidPeriod)
+	}
+	return nil
+}
+
+// sessionMinutes is the duration of a session from its HH:MM times (0 when unknown)
+func sessionMinutes(start, end string) int {
+	startTime, err := time.Parse("15:04", start)
+	if err != nil {
+		return 0
+	}
+	endTime, err := time.Parse("15:04", end)
+	if err != nil || !endTime.After(startTime) {
+		return 0
+	}
+	return int(endTime.Sub(startTime).Minutes())
+}
+
+// formatDuration writes the minutes in full, e.g. "12 horas e 30 minutos"
+func formatDuration(minutes int) string {
+	hours, rest := minutes/60, minutes%60
+	text := fmt.Sprintf("%d hora", hours)
+	if hours != 1 {
+		text += "s"
+	}
+	if rest > 0 {
+		text += fmt.Sprintf(" e %d minuto", rest)
+		if rest != 1 {
+			text += "s"
+		}
+	}
+	return text
+}
+
+// formatClock writes the minutes as hours, e.g. "1h30"
+func formatClock(minutes int) string {
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dh%02d", minutes/60, minutes%60)
+}
+
+// generateCode returns a random verification code such as "4F9A-C21B-77D0"
+func generateCode() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToUpper(hex.EncodeToString(b))
+	return code[0:4] + "-" + code[4:8] + "-" + code[8:12], nil
+}
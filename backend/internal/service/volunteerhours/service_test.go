@@ -0,0 +1,60 @@
+package volunteerhours
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devdavidalonso/cecor/backend/internal/models"
+)
+
+func TestSessionMinutes(t *testing.T) {
+	if got := sessionMinutes("14:00", "15:30"); got != 90 {
+		t.Errorf("Expected 90 minutes, got %d", got)
+	}
+	if got := sessionMinutes("", "15:30"); got != 0 {
+		t.Errorf("Expected 0 minutes without start time, got %d", got)
+	}
+	if got := sessionMinutes("16:00", "15:00"); got != 0 {
+		t.Errorf("Expected 0 minutes for an inverted range, got %d", got)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	cases := map[int]string{60: "1 hora", 750: "12 horas e 30 minutos", 61: "1 hora e 1 minuto", 0: "0 horas"}
+	for minutes, expected := range cases {
+		if got := formatDuration(minutes); got != expected {
+			t.Errorf("Expected %q for %d minutes, got %q", expected, minutes, got)
+		}
+	}
+	if got := formatClock(95); got != "1h35" {
+		t.Errorf("Expected 1h35, got %s", got)
+	}
+}
+
+func TestSign(t *testing.T) {
+	s := &service{key: []byte("secret")}
+	declaration := &models.VolunteerDeclaration{
+		Code:         "4F9A-C21B-77D0",
+		TeacherID:    3,
+		StartDate:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:      time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+		TotalMinutes: 1200,
+		IssuedAt:     time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC),
+	}
+	signature := s.sign(declaration)
+
+	reloaded := *declaration
+	reloaded.StartDate = reloaded.StartDate.In(time.FixedZone("BRT", -3*3600)).Add(3 * time.Hour)
+	if s.sign(&reloaded) != signature {
+		t.Error("Expected the signature to survive a time zone change")
+	}
+
+	tampered := *declaration
+	tampered.TotalMinutes = 1260
+	if s.sign(&tampered) == signature {
+		t.Error("Expected a different signature for tampered hours")
+	}
+	if (&service{key: []byte("other")}).sign(declaration) == signature {
+		t.Error("Expected a different signature with another key")
+	}
+}
@@ -0,0 +1,66 @@
+// backend/pkg/cpf/cpf.go
+
+// Package cpf validates and formats the Brazilian individual taxpayer number (CPF).
+// CPFs are stored as 11 digits and shown as 000.000.000-00.
+package cpf
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalid is returned for values that are not a valid CPF
+var ErrInvalid = errors.New("invalid CPF")
+
+// Normalize keeps only the digits, so any formatting ("123.456.789-09", "123 456 789 09") matches
+func Normalize(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// IsValid checks the length and both check digits. Sequences of a single repeated digit pass the
+// check-digit calculation but are not issued, so they are rejected.
+func IsValid(value string) bool {
+	digits := Normalize(value)
+	if len(digits) != 11 || strings.Count(digits, digits[:1]) == 11 {
+		return false
+	}
+	return checkDigit(digits[:9]) == digits[9] && checkDigit(digits[:10]) == digits[10]
+}
+
+// Parse returns the canonical form (digits only) of a valid CPF
+func Parse(value string) (string, error) {
+	if !IsValid(value) {
+		return "", ErrInvalid
+	}
+	return Normalize(value), nil
+}
+
+// Format returns 000.000.000-00 for a valid CPF and the value unchanged otherwise
+func Format(value string) string {
+	if !IsValid(value) {
+		return value
+	}
+	d := Normalize(value)
+	return d[:3] + "." + d[3:6] + "." + d[6:9] + "-" + d[9:]
+}
+
+// checkDigit computes the next check digit of the given digits (weights from len+1 down to 2)
+func checkDigit(digits string) byte {
+	sum := 0
+	weight := len(digits) + 1
+	for i := 0; i < len(digits); i++ {
+		sum += int(digits[i]-'0') * weight
+		weight--
+	}
+	rest := sum * 10 % 11
+	if rest == 10 {
+		rest = 0
+	}
+	return byte('0' + rest)
+}
@@ -0,0 +1,43 @@
+package cpf
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsValid(t *testing.T) {
+	for _, value := range []string{"529.982.247-25", "52998224725", " 529 982 247 25 ", "111.444.777-35", "123.456.789-09"} {
+		if !IsValid(value) {
+			t.Errorf("Expected %q to be valid", value)
+		}
+	}
+	for _, value := range []string{"", "529.982.247-24", "5299822472", "529982247250", "000.000.000-00", "111.111.111-11", "abc"} {
+		if IsValid(value) {
+			t.Errorf("Expected %q to be invalid", value)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	got, err := Parse("529.982.247-25")
+	if err != nil || got != "52998224725" {
+		t.Errorf("Expected the digits only, got %q (%v)", got, err)
+	}
+	if _, err := Parse("529.982.247-24"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Expected ErrInvalid, got %v", err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cases := map[string]string{
+		"52998224725":    "529.982.247-25",
+		"529.982.247-25": "529.982.247-25",
+		"123":            "123",
+		"":               "",
+	}
+	for value, want := range cases {
+		if got := Format(value); got != want {
+			t.Errorf("Format(%q) = %q, expected %q", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+// backend/pkg/fieldcrypt/fieldcrypt.go
+
+// Package fieldcrypt encrypts sensitive fields (health data, CPF) before they are stored.
+//
+// Encrypted values look like enc:<key id>:<base64 of nonce + ciphertext> (AES-256-GCM), so each
+// value names the key that encrypted it: new values use the current key and the previous keys are
+// still accepted on reads until the rotation finishes. Values without the prefix are legacy clear
+// text and are returned unchanged.
+//
+// Encrypted fields cannot be searched by equality; the blind index (HMAC-SHA256 with a separate
+// key) finds an exact value without revealing it.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// prefix marks the encrypted values
+const prefix = "enc:"
+
+var (
+	// ErrNotConfigured is returned when no keyring was configured
+	ErrNotConfigured = errors.New("field encryption keys are not configured")
+	// ErrInvalidKeys is returned for an inconsistent key configuration
+	ErrInvalidKeys = errors.New("invalid field encryption keys")
+	// ErrUnknownKey is returned for values encrypted with a key that is not configured
+	ErrUnknownKey = errors.New("value encrypted with an unknown key")
+	// ErrCorrupted is returned for encrypted values that cannot be opened
+	ErrCorrupted = errors.New("encrypted value is corrupted")
+)
+
+// Keyring holds the encryption keys by id and the blind index key
+type Keyring struct {
+	current string
+	aeads   map[string]cipher.AEAD
+	index   []byte
+}
+
+// NewKeyring creates a keyring. current encrypts the new values; every key in keys (secrets by
+// id, including current) decrypts. The secrets are hashed to the 256-bit AES keys, so they
+// must be long random strings.
+func NewKeyring(current string, keys map[string]string, indexKey string) (*Keyring, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("%w: current key %q is not in the key list", ErrInvalidKeys, current)
+	}
+	if indexKey == "" {
+		return nil, fmt.Errorf("%w: the blind index key is empty", ErrInvalidKeys)
+	}
+
+	keyring := &Keyring{current: current, aeads: map[string]cipher.AEAD{}}
+	for id, secret := range keys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("%w: key id %q must be non-empty and without ':'", ErrInvalidKeys, id)
+		}
+		if secret == "" {
+			return nil, fmt.Errorf("%w: key %q is empty", ErrInvalidKeys, id)
+		}
+		key := sha256.Sum256([]byte(secret))
+		block, err := aes.NewCipher(key[:])
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		keyring.aeads[id] = aead
+	}
+	index := sha256.Sum256([]byte(indexKey))
+	keyring.index = index[:]
+	return keyring, nil
+}
+
+// Current returns the id of the key that encrypts new values
+func (k *Keyring) Current() string {
+	return k.current
+}
+
+// Has reports whether the key id is configured
+func (k *Keyring) Has(id string) bool {
+	_, ok := k.aeads[id]
+	return ok
+}
+
+// Encrypt encrypts a value with the current key. Empty values stay empty.
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	aead := k.aeads[k.current]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	// The key id is authenticated data: changing the prefix invalidates the value
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(k.current))
+	return prefix + k.current + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens an encrypted value with the key it names. Legacy clear text is returned unchanged.
+func (k *Keyring) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	id, encoded, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !ok {
+		return "", ErrCorrupted
+	}
+	aead, ok := k.aeads[id]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownKey, id)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrCorrupted
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(id))
+	if err != nil {
+		return "", ErrCorrupted
+	}
+	return string(plaintext), nil
+}
+
+// BlindIndex returns the searchable index of a value (hex HMAC-SHA256). Empty values have no index.
+func (k *Keyring) BlindIndex(value string) string {
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, k.index)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsEncrypted reports whether a stored value is encrypted
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// KeyID returns the id of the key that encrypted a stored value ("" for clear text)
+func KeyID(value string) string {
+	if !IsEncrypted(value) {
+		return ""
+	}
+	id, _, _ := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	return id
+}
+
+// The default keyring is used by the GORM serializer, which has no other way to receive it.
+var (
+	mu             sync.RWMutex
+	defaultKeyring *Keyring
+)
+
+// SetDefault configures the keyring used by the package functions
+func SetDefault(keyring *Keyring) {
+	mu.Lock()
+	defer mu.Unlock()
+	defaultKeyring = keyring
+}
+
+// Default returns the configured keyring
+func Default() (*Keyring, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if defaultKeyring == nil {
+		return nil, ErrNotConfigured
+	}
+	return defaultKeyring, nil
+}
+
+// Encrypt encrypts a value with the default keyring
+func Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	keyring, err := Default()
+	if err != nil {
+		return "", err
+	}
+	return keyring.Encrypt(plaintext)
+}
+
+// Decrypt opens a value with the default keyring
+func Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	keyring, err := Default()
+	if err != nil {
+		return "", err
+	}
+	return keyring.Decrypt(value)
+}
+
+// BlindIndex returns the index of a value with the default keyring
+func BlindIndex(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	keyring, err := Default()
+	if err != nil {
+		return "", err
+	}
+	return keyring.BlindIndex(value), nil
+}
@@ -0,0 +1,108 @@
+package fieldcrypt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newKeyring(t *testing.T, current string) *Keyring {
+	t.Helper()
+	keyring, err := NewKeyring(current, map[string]string{"2025": "old secret", "2026": "new secret"}, "index secret")
+	if err != nil {
+		t.Fatalf("Expected keyring, got %v", err)
+	}
+	return keyring
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	keyring := newKeyring(t, "2026")
+
+	encrypted, err := keyring.Encrypt("Asma, usa bombinha")
+	if err != nil {
+		t.Fatalf("Expected encryption, got %v", err)
+	}
+	if !strings.HasPrefix(encrypted, "enc:2026:") || strings.Contains(encrypted, "Asma") {
+		t.Errorf("Unexpected encrypted value %q", encrypted)
+	}
+	again, _ := keyring.Encrypt("Asma, usa bombinha")
+	if again == encrypted {
+		t.Error("Expected a new nonce for each encryption")
+	}
+	if plaintext, err := keyring.Decrypt(encrypted); err != nil || plaintext != "Asma, usa bombinha" {
+		t.Errorf("Decrypt = %q, %v", plaintext, err)
+	}
+
+	if empty, _ := keyring.Encrypt(""); empty != "" {
+		t.Errorf("Expected empty value to stay empty, got %q", empty)
+	}
+	if legacy, err := keyring.Decrypt("52998224725"); err != nil || legacy != "52998224725" {
+		t.Errorf("Expected clear text unchanged, got %q, %v", legacy, err)
+	}
+}
+
+func TestRotation(t *testing.T) {
+	old := newKeyring(t, "2025")
+	encrypted, _ := old.Encrypt("52998224725")
+
+	current := newKeyring(t, "2026")
+	if KeyID(encrypted) != "2025" || current.Current() != "2026" {
+		t.Errorf("Expected value under the old key, got %q", KeyID(encrypted))
+	}
+	if plaintext, err := current.Decrypt(encrypted); err != nil || plaintext != "52998224725" {
+		t.Errorf("Expected old key to still decrypt, got %q, %v", plaintext, err)
+	}
+
+	retired, err := NewKeyring("2026", map[string]string{"2026": "new secret"}, "index secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := retired.Decrypt(encrypted); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Expected ErrUnknownKey after removing the old key, got %v", err)
+	}
+}
+
+func TestTampering(t *testing.T) {
+	keyring := newKeyring(t, "2026")
+	encrypted, _ := keyring.Encrypt("52998224725")
+
+	relabeled := strings.Replace(encrypted, "enc:2026:", "enc:2025:", 1)
+	if _, err := keyring.Decrypt(relabeled); !errors.Is(err, ErrCorrupted) {
+		t.Errorf("Expected ErrCorrupted for a changed key id, got %v", err)
+	}
+	if _, err := keyring.Decrypt("enc:2026:????"); !errors.Is(err, ErrCorrupted) {
+		t.Errorf("Expected ErrCorrupted for invalid base64, got %v", err)
+	}
+}
+
+func TestBlindIndex(t *testing.T) {
+	keyring := newKeyring(t, "2025")
+	index := keyring.BlindIndex("52998224725")
+	if len(index) != 64 || index != newKeyring(t, "2026").BlindIndex("52998224725") {
+		t.Errorf("Expected a stable index independent of the encryption key, got %q", index)
+	}
+	if index == keyring.BlindIndex("11144477735") {
+		t.Error("Expected different values to have different indexes")
+	}
+	if keyring.BlindIndex("") != "" {
+		t.Error("Expected no index for empty values")
+	}
+}
+
+func TestNewKeyring(t *testing.T) {
+	invalid := []struct {
+		current string
+		keys    map[string]string
+		index   string
+	}{
+		{"2027", map[string]string{"2026": "secret"}, "index"},
+		{"2026", map[string]string{"2026": ""}, "index"},
+		{"a:b", map[string]string{"a:b": "secret"}, "index"},
+		{"2026", map[string]string{"2026": "secret"}, ""},
+	}
+	for _, tt := range invalid {
+		if _, err := NewKeyring(tt.current, tt.keys, tt.index); !errors.Is(err, ErrInvalidKeys) {
+			t.Errorf("Expected ErrInvalidKeys for %+v, got %v", tt, err)
+		}
+	}
+}
@@ -22,7 +22,8 @@ export interface UserContact {
 }
 
 export interface Teacher {
-  id?: number;
+  id?: number; // teachers.id
+  userId?: number;
   name: string;
   email: string;
   cpf?: string;